@@ -27,12 +27,16 @@ import (
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	egressipcontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/egressip"
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/ipaddressallocation"
+	sharedvpccontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/sharedvpc"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
 	namespacecontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/namespace"
+	natrulecontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/natrule"
 	networkinfocontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/networkinfo"
 	networkpolicycontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/networkpolicy"
+	networkquotacontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/networkquota"
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/node"
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/pod"
 	securitypolicycontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/securitypolicy"
@@ -42,12 +46,16 @@ import (
 	subnetbindingcontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/subnetbinding"
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/subnetport"
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/subnetset"
+	vpcpeeringcontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/vpcpeering"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/ipblocksinfo"
+	natruleservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/natrule"
 	nodeservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/node"
+	securitypolicyservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/staticroute"
 	subnetservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
 	subnetbindingservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetbinding"
 	subnetportservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetport"
+	vpcpeeringservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpcpeering"
 
 	commonctl "github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
 	nsxserviceaccountcontroller "github.com/vmware-tanzu/nsx-operator/pkg/controllers/nsxserviceaccount"
@@ -127,7 +135,7 @@ func StartNSXServiceAccountController(mgr ctrl.Manager, commonService common.Ser
 	go commonctl.GenericGarbageCollector(make(chan bool), common.GCInterval, nsxServiceAccountReconcile.CollectGarbage)
 }
 
-func StartNetworkInfoController(mgr ctrl.Manager, vpcService *vpc.VPCService, ipblocksInfoService *ipblocksinfo.IPBlocksInfoService) {
+func StartNetworkInfoController(mgr ctrl.Manager, vpcService *vpc.VPCService, ipblocksInfoService *ipblocksinfo.IPBlocksInfoService, hookServer webhook.Server) {
 	networkInfoReconciler := &networkinfocontroller.NetworkInfoReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -136,19 +144,21 @@ func StartNetworkInfoController(mgr ctrl.Manager, vpcService *vpc.VPCService, ip
 	networkInfoReconciler.Service = vpcService
 	networkInfoReconciler.IPBlocksInfoService = ipblocksInfoService
 	networkInfoReconciler.StatusUpdater = commonctl.NewStatusUpdater(networkInfoReconciler.Client, networkInfoReconciler.Service.NSXConfig, networkInfoReconciler.Recorder, commonctl.MetricResTypeNetworkInfo, "VPC", "NetworkInfo")
-	if err := networkInfoReconciler.Start(mgr); err != nil {
+	if err := networkInfoReconciler.Start(mgr, hookServer); err != nil {
 		log.Error(err, "Failed to create networkinfo controller", "controller", "NetworkInfo")
 		os.Exit(1)
 	}
 	go commonctl.GenericGarbageCollector(make(chan bool), common.GCInterval, networkInfoReconciler.CollectGarbage)
 }
 
-func StartNamespaceController(mgr ctrl.Manager, cf *config.NSXOperatorConfig, vpcService common.VPCServiceProvider) {
+func StartNamespaceController(mgr ctrl.Manager, cf *config.NSXOperatorConfig, vpcService common.VPCServiceProvider, subnetService *subnetservice.SubnetService, securityPolicyService *securitypolicyservice.SecurityPolicyService) {
 	nsReconciler := &namespacecontroller.NamespaceReconciler{
-		Client:     mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		NSXConfig:  cf,
-		VPCService: vpcService,
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		NSXConfig:             cf,
+		VPCService:            vpcService,
+		SubnetService:         subnetService,
+		SecurityPolicyService: securityPolicyService,
 	}
 
 	if err := nsReconciler.Start(mgr); err != nil {
@@ -157,7 +167,7 @@ func StartNamespaceController(mgr ctrl.Manager, cf *config.NSXOperatorConfig, vp
 	}
 }
 
-func StartIPAddressAllocationController(mgr ctrl.Manager, ipAddressAllocationService *ipaddressallocationservice.IPAddressAllocationService, vpcService common.VPCServiceProvider) {
+func StartIPAddressAllocationController(mgr ctrl.Manager, ipAddressAllocationService *ipaddressallocationservice.IPAddressAllocationService, vpcService common.VPCServiceProvider, hookServer webhook.Server) {
 	ipAddressAllocationReconciler := &ipaddressallocation.IPAddressAllocationReconciler{
 		Client:     mgr.GetClient(),
 		Scheme:     mgr.GetScheme(),
@@ -167,23 +177,38 @@ func StartIPAddressAllocationController(mgr ctrl.Manager, ipAddressAllocationSer
 	}
 	ipAddressAllocationReconciler.StatusUpdater = commonctl.NewStatusUpdater(ipAddressAllocationReconciler.Client, ipAddressAllocationReconciler.Service.NSXConfig, ipAddressAllocationReconciler.Recorder, commonctl.MetricResTypeNetworkInfo, "IPAddressAllocation", "IPAddressAllocation")
 
-	if err := ipAddressAllocationReconciler.SetupWithManager(mgr); err != nil {
+	if err := ipAddressAllocationReconciler.Start(mgr, hookServer); err != nil {
 		log.Error(err, "Failed to create ipaddressallocation controller")
 		os.Exit(1)
 	}
 	go commonctl.GenericGarbageCollector(make(chan bool), common.GCInterval, ipAddressAllocationReconciler.CollectGarbage)
 }
 
+func StartIPUtilizationCollector(mgr ctrl.Manager, subnetService *subnetservice.SubnetService, subnetPortService *subnetportservice.SubnetPortService) {
+	interval := subnetService.NSXConfig.SubnetIPUtilizationInterval
+	if interval <= 0 {
+		return
+	}
+	metrics.InitializeSubnetIPUtilizationMetrics()
+	ipUtilizationCollector := &commonctl.IPUtilizationCollector{
+		Client:            mgr.GetClient(),
+		SubnetService:     subnetService,
+		SubnetPortService: subnetPortService,
+		Recorder:          mgr.GetEventRecorderFor("iputilization-collector"),
+		Threshold:         subnetService.NSXConfig.SubnetIPUtilizationThreshold,
+	}
+	go commonctl.GenericGarbageCollector(make(chan bool), time.Duration(interval)*time.Second, ipUtilizationCollector.CollectIPUtilization)
+}
+
 func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
-	// Generate webhook certificates, and start refreshing webhook certificates periodically
-	if cf.CoeConfig.EnableVPCNetwork {
-		if err := pkgutil.GenerateWebhookCerts(); err != nil {
-			log.Error(err, "Failed to generate webhook certificates")
-		} else {
-			log.Info("Successfully generated webhook certificates")
-		}
-		go refreshCertPeriodically()
+	// Generate webhook certificates, and start refreshing webhook certificates periodically. The webhook server
+	// runs in both VPC and T1 mode, as the legacy SecurityPolicy webhook is served in T1 mode.
+	if err := pkgutil.GenerateWebhookCerts(); err != nil {
+		log.Error(err, "Failed to generate webhook certificates")
+	} else {
+		log.Info("Successfully generated webhook certificates")
 	}
+	go refreshCertPeriodically()
 
 	//  Embed the common commonService to sub-services.
 	commonService := common.Service{
@@ -195,6 +220,7 @@ func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
 	checkLicense(nsxClient, cf.LicenseValidationInterval)
 
 	var vpcService *vpc.VPCService
+	hookServer := startWebhookServer(mgr)
 
 	if cf.CoeConfig.EnableVPCNetwork {
 		// Check NSX version for VPC networking mode
@@ -224,6 +250,7 @@ func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
 			log.Error(err, "Failed to initialize subnetport commonService", "controller", "SubnetPort")
 			os.Exit(1)
 		}
+		subnetService.SubnetPortService = subnetPortService
 		nodeService, err := nodeservice.InitializeNode(commonService)
 		if err != nil {
 			log.Error(err, "Failed to initialize node commonService", "controller", "Node")
@@ -234,6 +261,16 @@ func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
 			log.Error(err, "Failed to initialize staticroute commonService", "controller", "StaticRoute")
 			os.Exit(1)
 		}
+		natRuleService, err := natruleservice.InitializeNATRule(commonService, vpcService)
+		if err != nil {
+			log.Error(err, "Failed to initialize natrule commonService", "controller", "NATRule")
+			os.Exit(1)
+		}
+		vpcPeeringService, err := vpcpeeringservice.InitializeVPCPeering(commonService, vpcService)
+		if err != nil {
+			log.Error(err, "Failed to initialize vpcpeering commonService", "controller", "VPCPeering")
+			os.Exit(1)
+		}
 		ipblocksInfoService := ipblocksinfo.InitializeIPBlocksInfoService(commonService)
 
 		subnetBindingService, err := subnetbindingservice.InitializeService(commonService)
@@ -243,28 +280,9 @@ func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
 		}
 
 		// Start controllers which only supports VPC
-		StartNetworkInfoController(mgr, vpcService, ipblocksInfoService)
-		StartNamespaceController(mgr, cf, vpcService)
+		StartNetworkInfoController(mgr, vpcService, ipblocksInfoService, hookServer)
+		StartNamespaceController(mgr, cf, vpcService, subnetService, securitypolicyservice.GetSecurityService(commonService, vpcService))
 
-		var hookServer webhook.Server
-		if _, err := os.Stat(config.WebhookCertDir); errors.Is(err, os.ErrNotExist) {
-			log.Error(err, "Server cert not found, disabling webhook server", "cert", config.WebhookCertDir)
-		} else {
-			hookServer = webhook.NewServer(webhook.Options{
-				Port:    config.WebhookServerPort,
-				CertDir: config.WebhookCertDir,
-				TLSOpts: []func(*tls.Config){
-					func(cfg *tls.Config) {
-						cfg.MinVersion = tls.VersionTLS12
-						cfg.CipherSuites = []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA}
-					},
-				},
-			})
-			if err := mgr.Add(hookServer); err != nil {
-				log.Error(err, "Failed to add hook server")
-				os.Exit(1)
-			}
-		}
 		// Start Subnet/SubnetSet controller.
 		if err := subnet.StartSubnetController(mgr, subnetService, subnetPortService, vpcService, subnetBindingService, hookServer); err != nil {
 			os.Exit(1)
@@ -274,16 +292,22 @@ func startServiceController(mgr manager.Manager, nsxClient *nsx.Client) {
 		}
 
 		node.StartNodeController(mgr, nodeService)
-		staticroutecontroller.StartStaticRouteController(mgr, staticRouteService)
+		staticroutecontroller.StartStaticRouteController(mgr, staticRouteService, hookServer)
+		natrulecontroller.StartNATRuleController(mgr, natRuleService)
+		vpcpeeringcontroller.StartVPCPeeringController(mgr, vpcPeeringService)
+		egressipcontroller.StartEgressIPController(mgr, cf)
+		sharedvpccontroller.StartSharedVPCController(mgr, vpcService, hookServer, cf)
+		networkquotacontroller.StartNetworkQuotaController(mgr, vpcService, cf)
 		subnetport.StartSubnetPortController(mgr, subnetPortService, subnetService, vpcService, hookServer)
 		pod.StartPodController(mgr, subnetPortService, subnetService, vpcService, nodeService)
-		StartIPAddressAllocationController(mgr, ipAddressAllocationService, vpcService)
+		StartIPAddressAllocationController(mgr, ipAddressAllocationService, vpcService, hookServer)
 		networkpolicycontroller.StartNetworkPolicyController(mgr, commonService, vpcService)
 		service.StartServiceLbController(mgr, commonService)
 		subnetbindingcontroller.StartSubnetBindingController(mgr, subnetService, subnetBindingService)
+		StartIPUtilizationCollector(mgr, subnetService, subnetPortService)
 	}
 	// Start controllers which can run in non-VPC mode
-	securitypolicycontroller.StartSecurityPolicyController(mgr, commonService, vpcService)
+	securitypolicycontroller.StartSecurityPolicyController(mgr, commonService, vpcService, hookServer)
 
 	// Start the NSXServiceAccount controller.
 	if cf.EnableAntreaNSXInterworking {
@@ -414,6 +438,30 @@ func updateLicensePeriodically(nsxClient *nsx.Client, interval time.Duration) {
 	}
 }
 
+// startWebhookServer adds the webhook server to the manager, the webhook server is disabled if the server
+// cert is not found.
+func startWebhookServer(mgr manager.Manager) webhook.Server {
+	if _, err := os.Stat(config.WebhookCertDir); errors.Is(err, os.ErrNotExist) {
+		log.Error(err, "Server cert not found, disabling webhook server", "cert", config.WebhookCertDir)
+		return nil
+	}
+	hookServer := webhook.NewServer(webhook.Options{
+		Port:    config.WebhookServerPort,
+		CertDir: config.WebhookCertDir,
+		TLSOpts: []func(*tls.Config){
+			func(cfg *tls.Config) {
+				cfg.MinVersion = tls.VersionTLS12
+				cfg.CipherSuites = []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA}
+			},
+		},
+	})
+	if err := mgr.Add(hookServer); err != nil {
+		log.Error(err, "Failed to add hook server")
+		os.Exit(1)
+	}
+	return hookServer
+}
+
 func refreshCertPeriodically() {
 	ticker := time.NewTicker(30 * 24 * time.Hour) // 30 days
 	defer ticker.Stop()
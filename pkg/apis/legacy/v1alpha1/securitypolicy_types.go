@@ -37,12 +37,32 @@ const (
 	RuleDirectionEgress RuleDirection = "Egress"
 )
 
+// SecurityPolicyTier is the tier of SecurityPolicy, which is mapped to the NSX distributed firewall category.
+// +kubebuilder:validation:Enum=Emergency;Infrastructure;Environment;Application
+type SecurityPolicyTier string
+
+const (
+	// SecurityPolicyTierEmergency is for the quarantine and emergency rules, it's enforced first.
+	SecurityPolicyTierEmergency SecurityPolicyTier = "Emergency"
+	// SecurityPolicyTierInfrastructure is for the rules of the shared services, e.g. DNS and NTP.
+	SecurityPolicyTierInfrastructure SecurityPolicyTier = "Infrastructure"
+	// SecurityPolicyTierEnvironment is for the rules between the security zones, e.g. production and testing.
+	SecurityPolicyTierEnvironment SecurityPolicyTier = "Environment"
+	// SecurityPolicyTierApplication is for the rules between the applications, it's enforced last.
+	SecurityPolicyTierApplication SecurityPolicyTier = "Application"
+)
+
 // SecurityPolicySpec defines the desired state of SecurityPolicy.
 type SecurityPolicySpec struct {
-	// Priority defines the order of policy enforcement.
+	// Priority defines the order of policy enforcement within the tier.
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=1000
 	Priority int `json:"priority,omitempty"`
+	// Tier defines the NSX distributed firewall category of the policy. The tiers are enforced in the
+	// order Emergency, Infrastructure, Environment and Application. The tiers other than Application
+	// are privileged, they are allowed only in the Namespaces selected by a SecurityPolicyTierBinding.
+	// +kubebuilder:default=Application
+	Tier SecurityPolicyTier `json:"tier,omitempty"`
 	// AppliedTo is a list of policy targets to apply rules.
 	// Policy level 'Applied To' will take precedence over rule level.
 	AppliedTo []SecurityPolicyTarget `json:"appliedTo,omitempty"`
@@ -112,6 +132,25 @@ type SecurityPolicyPort struct {
 type SecurityPolicyStatus struct {
 	// Conditions describes current state of security policy.
 	Conditions []Condition `json:"conditions"`
+	// RuleStatistics summarizes the statistics of the NSX rules realized for the security policy.
+	// It is only reported when the rule statistics collector is enabled.
+	RuleStatistics *RuleStatisticsSummary `json:"ruleStatistics,omitempty"`
+}
+
+// RuleStatisticsSummary summarizes the NSX rule statistics of a security policy.
+type RuleStatisticsSummary struct {
+	// LastUpdateTime is the time when the statistics were changed.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+	// HitCount is the total number of hits of the rules.
+	HitCount int64 `json:"hitCount"`
+	// PacketCount is the total number of packets matching the rules.
+	PacketCount int64 `json:"packetCount"`
+	// ByteCount is the total number of bytes matching the rules.
+	ByteCount int64 `json:"byteCount"`
+	// SessionCount is the total number of sessions matching the rules.
+	SessionCount int64 `json:"sessionCount"`
+	// UnusedRules lists the rules which have never been hit.
+	UnusedRules []string `json:"unusedRules,omitempty"`
 }
 
 // +genclient
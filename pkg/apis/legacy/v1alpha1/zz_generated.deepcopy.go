@@ -220,6 +220,27 @@ func (in *NSXServiceAccountStatus) DeepCopy() *NSXServiceAccountStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleStatisticsSummary) DeepCopyInto(out *RuleStatisticsSummary) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.UnusedRules != nil {
+		in, out := &in.UnusedRules, &out.UnusedRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleStatisticsSummary.
+func (in *RuleStatisticsSummary) DeepCopy() *RuleStatisticsSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleStatisticsSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityPolicy) DeepCopyInto(out *SecurityPolicy) {
 	*out = *in
@@ -420,6 +441,11 @@ func (in *SecurityPolicyStatus) DeepCopyInto(out *SecurityPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RuleStatistics != nil {
+		in, out := &in.RuleStatistics, &out.RuleStatistics
+		*out = new(RuleStatisticsSummary)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicyStatus.
@@ -9,10 +9,13 @@ type ConditionType string
 
 const (
 	Ready                      ConditionType = "Ready"
+	VPCReady                   ConditionType = "VPCReady"
+	LBReady                    ConditionType = "LBReady"
 	GatewayConnectionReady     ConditionType = "GatewayConnectionReady"
 	AutoSnatEnabled            ConditionType = "AutoSnatEnabled"
 	ExternalIPBlocksConfigured ConditionType = "ExternalIPBlocksConfigured"
 	DeleteFailure              ConditionType = "DeletionFailed"
+	IPUtilizationHigh          ConditionType = "IPUtilizationHigh"
 )
 
 // Condition defines condition of custom resource.
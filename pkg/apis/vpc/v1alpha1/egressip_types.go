@@ -0,0 +1,59 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EgressIPSpec defines the desired state of EgressIP.
+// +kubebuilder:validation:XValidation:rule="has(self.podSelector) || has(self.vmSelector)",message="At least one of podSelector or vmSelector must be specified"
+type EgressIPSpec struct {
+	// PodSelector uses label selector to select the Pods in the Namespace which egress from the EgressIP.
+	// +kubebuilder:validation:Optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// VMSelector uses label selector to select the VMs in the Namespace which egress from the EgressIP.
+	// +kubebuilder:validation:Optional
+	VMSelector *metav1.LabelSelector `json:"vmSelector,omitempty"`
+}
+
+// EgressIPStatus defines the observed state of EgressIP.
+type EgressIPStatus struct {
+	// Conditions described if the EgressIP is configured on NSX or not.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// EgressIP is the external IP allocated for the selected workloads to egress from.
+	EgressIP string `json:"egressIP,omitempty"`
+	// IPAddresses is the IP addresses of the selected workloads translated to the EgressIP.
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope="Namespaced",path=egressips
+
+// EgressIP is the Schema for the egressips API.
+// +kubebuilder:printcolumn:name="EgressIP",type=string,JSONPath=`.status.egressIP`,description="External IP which the selected workloads egress from"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Whether the EgressIP is realized on NSX"
+type EgressIP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EgressIPSpec   `json:"spec,omitempty"`
+	Status EgressIPStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EgressIPList contains a list of EgressIP.
+type EgressIPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EgressIP `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EgressIP{}, &EgressIPList{})
+}
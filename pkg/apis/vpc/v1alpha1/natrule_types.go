@@ -0,0 +1,124 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NATRuleAction is the action of the NAT rule.
+// +kubebuilder:validation:Enum=SNAT;DNAT;NO_SNAT;REFLEXIVE
+type NATRuleAction string
+
+// NATRuleFirewallMatch indicates how the firewall matches the address of the traffic translated by the NAT rule.
+// +kubebuilder:validation:Enum=MATCH_EXTERNAL_ADDRESS;MATCH_INTERNAL_ADDRESS;BYPASS
+type NATRuleFirewallMatch string
+
+const (
+	NATRuleActionSNAT      NATRuleAction = "SNAT"
+	NATRuleActionDNAT      NATRuleAction = "DNAT"
+	NATRuleActionNoSNAT    NATRuleAction = "NO_SNAT"
+	NATRuleActionReflexive NATRuleAction = "REFLEXIVE"
+
+	NATRuleFirewallMatchExternalAddress NATRuleFirewallMatch = "MATCH_EXTERNAL_ADDRESS"
+	NATRuleFirewallMatchInternalAddress NATRuleFirewallMatch = "MATCH_INTERNAL_ADDRESS"
+	NATRuleFirewallMatchBypass          NATRuleFirewallMatch = "BYPASS"
+)
+
+// NATRuleNetwork specifies the source or destination network of the NAT rule with the CIDRs, or
+// the addresses of a Subnet or a SubnetPort in the same Namespace.
+// +kubebuilder:validation:XValidation:rule="[has(self.cidrs), has(self.subnetName), has(self.subnetPortName)].filter(x, x).size() == 1",message="Only one of cidrs, subnetName or subnetPortName can be specified"
+type NATRuleNetwork struct {
+	// CIDRs is the list of IP addresses or CIDRs.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinItems=1
+	CIDRs []string `json:"cidrs,omitempty"`
+	// SubnetName is the name of the Subnet whose network addresses are used.
+	// +kubebuilder:validation:Optional
+	SubnetName string `json:"subnetName,omitempty"`
+	// SubnetPortName is the name of the SubnetPort whose IP addresses are used.
+	// +kubebuilder:validation:Optional
+	SubnetPortName string `json:"subnetPortName,omitempty"`
+}
+
+// NATRuleTranslatedNetwork specifies the translated network of the NAT rule with the IP allocated by an
+// IPAddressAllocation in the same Namespace or an explicit IP address.
+// +kubebuilder:validation:XValidation:rule="has(self.ipAddressAllocationName) && !has(self.ipAddress) || !has(self.ipAddressAllocationName) && has(self.ipAddress)",message="Only one of ipAddressAllocationName or ipAddress can be specified"
+type NATRuleTranslatedNetwork struct {
+	// IPAddressAllocationName is the name of the IPAddressAllocation whose allocated IPs are used.
+	// +kubebuilder:validation:Optional
+	IPAddressAllocationName string `json:"ipAddressAllocationName,omitempty"`
+	// IPAddress is the translated IP address.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Format=ip
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// NATRuleSpec defines the desired state of NATRule.
+// +kubebuilder:validation:XValidation:rule="self.action == 'NO_SNAT' ? !has(self.translatedNetwork) : has(self.translatedNetwork)",message="translatedNetwork must be specified unless action is NO_SNAT"
+// +kubebuilder:validation:XValidation:rule="self.action == 'DNAT' || has(self.sourceNetwork)",message="sourceNetwork must be specified for SNAT, NO_SNAT and REFLEXIVE"
+// +kubebuilder:validation:XValidation:rule="self.action != 'DNAT' || has(self.destinationNetwork)",message="destinationNetwork must be specified for DNAT"
+// +kubebuilder:validation:XValidation:rule="self.action != 'REFLEXIVE' || !has(self.destinationNetwork)",message="destinationNetwork is not supported for REFLEXIVE"
+type NATRuleSpec struct {
+	// Action is the action of the NAT rule.
+	// +kubebuilder:validation:Required
+	Action NATRuleAction `json:"action"`
+	// SourceNetwork is the source network of the traffic matched by the NAT rule.
+	// +kubebuilder:validation:Optional
+	SourceNetwork *NATRuleNetwork `json:"sourceNetwork,omitempty"`
+	// DestinationNetwork is the destination network of the traffic matched by the NAT rule.
+	// +kubebuilder:validation:Optional
+	DestinationNetwork *NATRuleNetwork `json:"destinationNetwork,omitempty"`
+	// TranslatedNetwork is the network which the source or the destination of the traffic is translated to.
+	// +kubebuilder:validation:Optional
+	TranslatedNetwork *NATRuleTranslatedNetwork `json:"translatedNetwork,omitempty"`
+	// FirewallMatch indicates how the firewall matches the address of the traffic translated by the NAT rule.
+	// +kubebuilder:default=MATCH_INTERNAL_ADDRESS
+	// +kubebuilder:validation:Optional
+	FirewallMatch NATRuleFirewallMatch `json:"firewallMatch,omitempty"`
+	// SequenceNumber is used to resolve the conflicts between the NAT rules, the rule with the lower
+	// sequence number is evaluated first.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	SequenceNumber int64 `json:"sequenceNumber,omitempty"`
+}
+
+// NATRuleStatus defines the observed state of NATRule.
+type NATRuleStatus struct {
+	// Conditions described if the NATRule is configured on NSX or not.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// TranslatedNetwork is the translated network realized on NSX.
+	TranslatedNetwork string `json:"translatedNetwork,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope="Namespaced",path=natrules,shortName=nat
+
+// NATRule is the Schema for the natrules API.
+// +kubebuilder:printcolumn:name="Action",type=string,JSONPath=`.spec.action`,description="Action of the NAT rule"
+// +kubebuilder:printcolumn:name="TranslatedNetwork",type=string,JSONPath=`.status.translatedNetwork`,description="Translated network of the NAT rule"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Whether the NAT rule is realized on NSX"
+type NATRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NATRuleSpec   `json:"spec,omitempty"`
+	Status NATRuleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NATRuleList contains a list of NATRule.
+type NATRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NATRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NATRule{}, &NATRuleList{})
+}
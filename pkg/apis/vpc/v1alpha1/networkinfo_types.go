@@ -18,6 +18,8 @@ type NetworkInfo struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	VPCs []VPCState `json:"vpcs"`
+	// Conditions describe the readiness of the VPC, its gateway connection, SNAT and load balancer.
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -39,6 +41,30 @@ type VPCState struct {
 	LoadBalancerIPAddresses string `json:"loadBalancerIPAddresses,omitempty"`
 	// Private CIDRs used for the VPC.
 	PrivateIPs []string `json:"privateIPs,omitempty"`
+	// IP utilization of all the Subnets and SubnetSets in the Namespace.
+	IPUtilization *IPUtilization `json:"ipUtilization,omitempty"`
+	// NSX Policy path for the VPC.
+	VPCPath string `json:"vpcPath,omitempty"`
+	// VPC connectivity profile used by the VPC.
+	VPCConnectivityProfile string `json:"vpcConnectivityProfile,omitempty"`
+	// NSX Policy paths of the external IP blocks in the VPC connectivity profile.
+	ExternalIPBlocks []string `json:"externalIPBlocks,omitempty"`
+	// NSX Policy paths of the private transit gateway IP blocks in the VPC connectivity profile.
+	PrivateTGWIPBlocks []string `json:"privateTGWIPBlocks,omitempty"`
+	// Subnets and SubnetSets in the Namespace.
+	Subnets []SubnetState `json:"subnets,omitempty"`
+}
+
+// SubnetState defines information for a Subnet or SubnetSet in the VPC.
+type SubnetState struct {
+	// Name of the Subnet or SubnetSet.
+	Name string `json:"name"`
+	// Kind is either Subnet or SubnetSet.
+	Kind string `json:"kind"`
+	// Access mode of the Subnet or SubnetSet.
+	AccessMode AccessMode `json:"accessMode,omitempty"`
+	// Network addresses (CIDRs) of the Subnet or the Subnets of the SubnetSet.
+	NetworkAddresses []string `json:"networkAddresses,omitempty"`
 }
 
 func init() {
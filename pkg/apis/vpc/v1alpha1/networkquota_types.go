@@ -0,0 +1,87 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkQuotaResources is the amount of the network resources in a Namespace. A nil value means unlimited
+// in the spec of NetworkQuota.
+type NetworkQuotaResources struct {
+	// Subnets is the number of Subnets, including the Subnets created by the SubnetSets. A SubnetSet
+	// without any Subnet realized counts as one Subnet.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Subnets *int64 `json:"subnets,omitempty"`
+	// SubnetIPs is the total number of the IPv4 addresses of the Subnets, including the Subnets created
+	// by the SubnetSets.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	SubnetIPs *int64 `json:"subnetIPs,omitempty"`
+	// SubnetPorts is the number of SubnetPorts.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	SubnetPorts *int64 `json:"subnetPorts,omitempty"`
+	// ExternalIPs is the total number of the IP addresses allocated by the IPAddressAllocations with
+	// the External visibility.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ExternalIPs *int64 `json:"externalIPs,omitempty"`
+	// StaticRoutes is the number of StaticRoutes.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	StaticRoutes *int64 `json:"staticRoutes,omitempty"`
+}
+
+// NetworkQuotaSpec defines the desired state of NetworkQuota.
+type NetworkQuotaSpec struct {
+	// Hard is the maximum amount of the network resources in the Namespace. The creation of a resource
+	// exceeding the limit is denied.
+	// +kubebuilder:validation:Required
+	Hard NetworkQuotaResources `json:"hard"`
+}
+
+// NetworkQuotaStatus defines the observed state of NetworkQuota.
+type NetworkQuotaStatus struct {
+	// Conditions described if the usage of the network resources is calculated or not.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Used is the current usage of the network resources limited by the NetworkQuota in the Namespace.
+	Used NetworkQuotaResources `json:"used,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope="Namespaced",path=networkquotas,shortName=nquota
+
+// NetworkQuota is the Schema for the networkquotas API. It limits the network resources in the Namespace,
+// which is enforced when the Subnets, SubnetSets, SubnetPorts, IPAddressAllocations and StaticRoutes are
+// created, and when a SubnetSet creates a new Subnet. All the NetworkQuotas in the Namespace are enforced.
+// The enforcement is best-effort: the usage is computed when the resource is created, so the resources created
+// concurrently may exceed the limit, and the default SubnetSets are never denied.
+// +kubebuilder:printcolumn:name="Subnets",type=string,JSONPath=`.status.used.subnets`,description="Number of Subnets used"
+// +kubebuilder:printcolumn:name="SubnetIPs",type=string,JSONPath=`.status.used.subnetIPs`,description="Number of Subnet IPs used"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Whether the usage is calculated"
+type NetworkQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetworkQuotaSpec   `json:"spec,omitempty"`
+	Status NetworkQuotaStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NetworkQuotaList contains a list of NetworkQuota.
+type NetworkQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NetworkQuota{}, &NetworkQuotaList{})
+}
@@ -0,0 +1,46 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecurityPolicyTierBindingSpec defines the desired state of SecurityPolicyTierBinding.
+type SecurityPolicyTierBindingSpec struct {
+	// Tiers is the list of the privileged tiers which the SecurityPolicies are allowed to use.
+	// +kubebuilder:validation:MinItems=1
+	Tiers []SecurityPolicyTier `json:"tiers"`
+	// NamespaceSelector selects the Namespaces where the SecurityPolicies are allowed to use the tiers.
+	// An empty selector selects all the Namespaces.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope="Cluster"
+//+kubebuilder:storageversion
+
+// SecurityPolicyTierBinding allows the SecurityPolicies in the selected Namespaces to use the privileged tiers.
+type SecurityPolicyTierBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SecurityPolicyTierBindingSpec `json:"spec"`
+}
+
+//+kubebuilder:object:root=true
+
+// SecurityPolicyTierBindingList contains a list of SecurityPolicyTierBinding.
+type SecurityPolicyTierBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecurityPolicyTierBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SecurityPolicyTierBinding{}, &SecurityPolicyTierBindingList{})
+}
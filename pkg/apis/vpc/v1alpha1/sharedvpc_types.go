@@ -0,0 +1,65 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SharedVPCSpec defines the desired state of SharedVPC.
+// +kubebuilder:validation:XValidation:rule="!has(self.consumerNamespaces) || !(self.ownerNamespace in self.consumerNamespaces)",message="ownerNamespace cannot be a consumer of its own VPC"
+type SharedVPCSpec struct {
+	// OwnerNamespace is the Namespace which owns the VPC shared with the consumer Namespaces.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="ownerNamespace is immutable"
+	OwnerNamespace string `json:"ownerNamespace"`
+	// ConsumerNamespaces is the list of the Namespaces which use the VPC of the owner Namespace
+	// instead of creating their own VPCs.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	ConsumerNamespaces []string `json:"consumerNamespaces,omitempty"`
+}
+
+// SharedVPCStatus defines the observed state of SharedVPC.
+type SharedVPCStatus struct {
+	// Conditions described if the VPC of the owner Namespace is shared or not.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Consumers is the list of the existing consumer Namespaces which are using the shared VPC.
+	Consumers []string `json:"consumers,omitempty"`
+	// VPCPath is the NSX path of the shared VPC.
+	VPCPath string `json:"vpcPath,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope="Cluster",path=sharedvpcs
+
+// SharedVPC declares the VPC of the owner Namespace is shared with the consumer Namespaces.
+// +kubebuilder:printcolumn:name="OwnerNamespace",type=string,JSONPath=`.spec.ownerNamespace`,description="Namespace which owns the shared VPC"
+// +kubebuilder:printcolumn:name="Consumers",type=string,JSONPath=`.status.consumers[*]`,description="Namespaces which are using the shared VPC"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Whether the VPC is shared"
+type SharedVPC struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SharedVPCSpec   `json:"spec,omitempty"`
+	Status SharedVPCStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SharedVPCList contains a list of SharedVPC.
+type SharedVPCList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SharedVPC `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SharedVPC{}, &SharedVPCList{})
+}
@@ -10,6 +10,10 @@ import (
 type AccessMode string
 type DHCPConfigMode string
 
+// IPFamily is the IP family of a Subnet.
+// +kubebuilder:validation:Enum=IPv4;IPv6
+type IPFamily string
+
 const (
 	AccessModePublic          string = "Public"
 	AccessModePrivate         string = "Private"
@@ -17,6 +21,9 @@ const (
 	DHCPConfigModeDeactivated string = "DHCPDeactivated"
 	DHCPConfigModeServer      string = "DHCPServer"
 	DHCPConfigModeRelay       string = "DHCPRelay"
+
+	IPFamilyIPv4 IPFamily = "IPv4"
+	IPFamilyIPv6 IPFamily = "IPv6"
 )
 
 // SubnetSpec defines the desired state of Subnet.
@@ -24,21 +31,42 @@ const (
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.ipv4SubnetSize) || has(self.ipv4SubnetSize)", message="ipv4SubnetSize is required once set"
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.accessMode) || has(self.accessMode)", message="accessMode is required once set"
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.ipAddresses) || has(self.ipAddresses)", message="ipAddresses is required once set"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.ipFamilies) || has(self.ipFamilies)", message="ipFamilies is required once set"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.ipv6SubnetSize) || has(self.ipv6SubnetSize)", message="ipv6SubnetSize is required once set"
+// +kubebuilder:validation:XValidation:rule="has(oldSelf.nsxSubnetPath) == has(self.nsxSubnetPath)", message="nsxSubnetPath is immutable"
 type SubnetSpec struct {
 	// Size of Subnet based upon estimated workload count.
 	// +kubebuilder:validation:Maximum:=65536
 	// +kubebuilder:validation:Minimum:=16
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	IPv4SubnetSize int `json:"ipv4SubnetSize,omitempty"`
+	// IP families of Subnet, IPv4 will be used if it is not defined.
+	// Set both IPv4 and IPv6 for a dual-stack Subnet.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	IPFamilies []IPFamily `json:"ipFamilies,omitempty"`
+	// Prefix length of the IPv6 Subnet, only 64 is supported. 64 will be used if it is not
+	// defined for an IPv6 Subnet.
+	// +kubebuilder:validation:Maximum:=64
+	// +kubebuilder:validation:Minimum:=64
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	IPv6SubnetSize int `json:"ipv6SubnetSize,omitempty"`
 	// Access mode of Subnet, accessible only from within VPC or from outside VPC.
+	// The access mode can only be changed when no SubnetPort is attached to the Subnet,
+	// the NSX Subnet is re-created with the CIDR allocated from the IP blocks of the new access mode.
 	// +kubebuilder:validation:Enum=Private;Public;PrivateTGW
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	AccessMode AccessMode `json:"accessMode,omitempty"`
 	// Subnet CIDRS.
 	// +kubebuilder:validation:MinItems=0
 	// +kubebuilder:validation:MaxItems=2
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// IPv4 ranges reserved in the Subnet, in the format of a single IP or startIP-endIP.
+	// The reserved IPs are skipped by the static IP allocation of the SubnetPorts, and
+	// cannot be requested by the SubnetPorts.
+	// +kubebuilder:validation:MaxItems=16
+	ReservedIPRanges []string `json:"reservedIPRanges,omitempty"`
 
 	// DHCP mode of a Subnet can only switch between DHCPServer or DHCPRelay.
 	// If subnetDHCPConfig is not set, the DHCP mode is DHCPDeactivated by default.
@@ -54,6 +82,11 @@ type SubnetSpec struct {
 
 	// DHCP configuration for Subnet.
 	SubnetDHCPConfig SubnetDHCPConfig `json:"subnetDHCPConfig,omitempty"`
+	// Path of the existing NSX Subnet to import, only supported in the Namespace using a pre-created VPC.
+	// The NSX Subnet is adopted without being re-created or deleted with the Subnet, and the
+	// other fields of the spec are not applied to it.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	NSXSubnetPath string `json:"nsxSubnetPath,omitempty"`
 }
 
 // SubnetStatus defines the observed state of Subnet.
@@ -63,8 +96,20 @@ type SubnetStatus struct {
 	// Gateway address of the Subnet.
 	GatewayAddresses []string `json:"gatewayAddresses,omitempty"`
 	// DHCP server IP address.
-	DHCPServerAddresses []string    `json:"DHCPServerAddresses,omitempty"`
-	Conditions          []Condition `json:"conditions,omitempty"`
+	DHCPServerAddresses []string `json:"DHCPServerAddresses,omitempty"`
+	// IP utilization of the Subnet.
+	IPUtilization *IPUtilization `json:"ipUtilization,omitempty"`
+	Conditions    []Condition    `json:"conditions,omitempty"`
+}
+
+// IPUtilization shows the usage of the IPs which can be allocated to the SubnetPorts.
+type IPUtilization struct {
+	// Number of the IPs which can be allocated to the SubnetPorts.
+	Total int `json:"total"`
+	// Number of the IPs allocated to the SubnetPorts, including the SubnetPorts under creation.
+	Allocated int `json:"allocated"`
+	// Number of the IPs available for the new SubnetPorts.
+	Available int `json:"available"`
 }
 
 // +genclient
@@ -102,6 +147,49 @@ type SubnetDHCPConfig struct {
 	// +kubebuilder:validation:Enum=DHCPServer;DHCPRelay;DHCPDeactivated
 	// +kubebuilder:validation:XValidation:rule="oldSelf!='DHCPDeactivated' && self!='DHCPDeactivated' || oldSelf==self", message="subnetDHCPConfig mode can only switch between DHCPServer and DHCPRelay"
 	Mode DHCPConfigMode `json:"mode,omitempty"`
+	// Additional DHCP server configuration, it is only applicable for the DHCPServer mode.
+	DHCPServerAdditionalConfig *DHCPServerAdditionalConfig `json:"dhcpServerAdditionalConfig,omitempty"`
+	// DHCP relay configuration, it is only applicable for the DHCPRelay mode.
+	DHCPRelayConfig *DHCPRelayConfig `json:"dhcpRelayConfig,omitempty"`
+}
+
+// DHCPServerAdditionalConfig is the additional DHCP server configuration for Subnet.
+type DHCPServerAdditionalConfig struct {
+	// IPs of the DNS servers offered to the DHCP clients.
+	// +kubebuilder:validation:MaxItems=8
+	DNSServers []string `json:"dnsServers,omitempty"`
+	// Domain search list offered to the DHCP clients.
+	// +kubebuilder:validation:MaxItems=8
+	SearchDomains []string `json:"searchDomains,omitempty"`
+	// Lease time in seconds of the IPs allocated by the DHCP server.
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:validation:Maximum=4294967295
+	LeaseTime int64 `json:"leaseTime,omitempty"`
+	// IPs of the NTP servers offered to the DHCP clients.
+	// +kubebuilder:validation:MaxItems=8
+	NTPServers []string `json:"ntpServers,omitempty"`
+	// Static bindings of the MAC addresses to the IPs.
+	StaticBindings []DHCPStaticBinding `json:"staticBindings,omitempty"`
+	// IP ranges excluded from the DHCP allocation, e.g. 192.168.1.1 or 192.168.1.3-192.168.1.100.
+	ExcludedRanges []string `json:"excludedRanges,omitempty"`
+}
+
+// DHCPStaticBinding binds a MAC address to an IP of the Subnet.
+type DHCPStaticBinding struct {
+	// MAC address of the DHCP client.
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`
+	MACAddress string `json:"macAddress"`
+	// IP allocated to the DHCP client.
+	IPAddress string `json:"ipAddress"`
+	// Host name offered to the DHCP client.
+	HostName string `json:"hostName,omitempty"`
+}
+
+// DHCPRelayConfig is the DHCP relay configuration for Subnet.
+type DHCPRelayConfig struct {
+	// IPs of the DHCP servers which the DHCP requests are relayed to.
+	// +kubebuilder:validation:MinItems=1
+	ServerAddresses []string `json:"serverAddresses"`
 }
 
 func init() {
@@ -14,6 +14,12 @@ type SubnetPortSpec struct {
 	Subnet string `json:"subnet,omitempty"`
 	// SubnetSet defines the parent SubnetSet name of the SubnetPort.
 	SubnetSet string `json:"subnetSet,omitempty"`
+	// Static IPv4 address requested for the SubnetPort, which must be inside the parent Subnet.
+	// It can only be requested when the parent Subnet is specified.
+	IPAddress string `json:"ipAddress,omitempty"`
+	// Static MAC address requested for the SubnetPort.
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`
+	MACAddress string `json:"macAddress,omitempty"`
 }
 
 // SubnetPortStatus defines the observed state of SubnetPort.
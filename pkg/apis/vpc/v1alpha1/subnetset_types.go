@@ -7,19 +7,45 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// SubnetSetAllocationStrategy defines how the SubnetPorts are allocated to the Subnets of a SubnetSet.
+// +kubebuilder:validation:Enum=Pack;Spread
+type SubnetSetAllocationStrategy string
+
+const (
+	// AllocationStrategyPack allocates the SubnetPorts to the first Subnet with free IPs.
+	AllocationStrategyPack SubnetSetAllocationStrategy = "Pack"
+	// AllocationStrategySpread allocates the SubnetPorts to the Subnet with the most free IPs.
+	AllocationStrategySpread SubnetSetAllocationStrategy = "Spread"
+)
+
 // SubnetSetSpec defines the desired state of SubnetSet.
 // +kubebuilder:validation:XValidation:rule="has(oldSelf.subnetDHCPConfig)==has(self.subnetDHCPConfig) || (has(oldSelf.subnetDHCPConfig) && !has(self.subnetDHCPConfig) && (!has(oldSelf.subnetDHCPConfig.mode) || oldSelf.subnetDHCPConfig.mode=='DHCPDeactivated')) || (!has(oldSelf.subnetDHCPConfig) && has(self.subnetDHCPConfig) && (!has(self.subnetDHCPConfig.mode) || self.subnetDHCPConfig.mode=='DHCPDeactivated'))", message="subnetDHCPConfig mode can only switch between DHCPServer and DHCPRelay"
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.accessMode) || has(self.accessMode)", message="accessMode is required once set"
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.ipv4SubnetSize) || has(self.ipv4SubnetSize)", message="ipv4SubnetSize is required once set"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.ipFamilies) || has(self.ipFamilies)", message="ipFamilies is required once set"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.ipv6SubnetSize) || has(self.ipv6SubnetSize)", message="ipv6SubnetSize is required once set"
 type SubnetSetSpec struct {
 	// Size of Subnet based upon estimated workload count.
 	// +kubebuilder:validation:Maximum:=65536
 	// +kubebuilder:validation:Minimum:=16
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	IPv4SubnetSize int `json:"ipv4SubnetSize,omitempty"`
+	// IP families of the Subnets, IPv4 will be used if it is not defined.
+	// Set both IPv4 and IPv6 for dual-stack Subnets.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	IPFamilies []IPFamily `json:"ipFamilies,omitempty"`
+	// Prefix length of the IPv6 Subnets, only 64 is supported. 64 will be used if it is not
+	// defined for IPv6 Subnets.
+	// +kubebuilder:validation:Maximum:=64
+	// +kubebuilder:validation:Minimum:=64
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	IPv6SubnetSize int `json:"ipv6SubnetSize,omitempty"`
 	// Access mode of Subnet, accessible only from within VPC or from outside VPC.
+	// The access mode can only be changed when no SubnetPort is attached to the SubnetSet,
+	// the NSX Subnets are re-created with the new access mode on demand.
 	// +kubebuilder:validation:Enum=Private;Public;PrivateTGW
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	AccessMode AccessMode `json:"accessMode,omitempty"`
 	// DHCP mode of a Subnet can only switch between DHCPServer or DHCPRelay.
 	// If subnetDHCPConfig is not set, the DHCP mode is DHCPDeactivated by default.
@@ -35,6 +61,30 @@ type SubnetSetSpec struct {
 
 	// Subnet DHCP configuration.
 	SubnetDHCPConfig SubnetDHCPConfig `json:"subnetDHCPConfig,omitempty"`
+	// Strategy to allocate the SubnetPorts to the Subnets, Pack will be used if it is not defined.
+	// +kubebuilder:default=Pack
+	AllocationStrategy SubnetSetAllocationStrategy `json:"allocationStrategy,omitempty"`
+	// Growth policy of the size of the new Subnets, all the Subnets have the same size
+	// ipv4SubnetSize if it is not defined.
+	GrowthPolicy *SubnetSetGrowthPolicy `json:"growthPolicy,omitempty"`
+	// Maximum number of the Subnets in the SubnetSet, no limit if it is not defined.
+	// +kubebuilder:validation:Minimum:=1
+	MaxSubnets int `json:"maxSubnets,omitempty"`
+	// Minimum number of the free IPs in the SubnetSet, a new Subnet is created in advance
+	// when the free IPs in the existing Subnets are less than this number.
+	// +kubebuilder:validation:Minimum:=0
+	MinFreeIPs int `json:"minFreeIPs,omitempty"`
+}
+
+// SubnetSetGrowthPolicy defines the size of the new Subnets of a SubnetSet.
+type SubnetSetGrowthPolicy struct {
+	// Each new Subnet is Factor times the size of the largest existing Subnet.
+	// +kubebuilder:validation:Enum=1;2;4
+	Factor int `json:"factor"`
+	// Maximum IPv4 size of the new Subnets.
+	// +kubebuilder:validation:Maximum:=65536
+	// +kubebuilder:validation:Minimum:=16
+	MaxSubnetSize int `json:"maxSubnetSize,omitempty"`
 }
 
 // SubnetInfo defines the observed state of a single Subnet of a SubnetSet.
@@ -45,12 +95,16 @@ type SubnetInfo struct {
 	GatewayAddresses []string `json:"gatewayAddresses,omitempty"`
 	// Dhcp server IP address.
 	DHCPServerAddresses []string `json:"DHCPServerAddresses,omitempty"`
+	// IP utilization of the Subnet.
+	IPUtilization *IPUtilization `json:"ipUtilization,omitempty"`
 }
 
 // SubnetSetStatus defines the observed state of SubnetSet.
 type SubnetSetStatus struct {
 	Conditions []Condition  `json:"conditions,omitempty"`
 	Subnets    []SubnetInfo `json:"subnets,omitempty"`
+	// IP utilization of all the Subnets of the SubnetSet.
+	IPUtilization *IPUtilization `json:"ipUtilization,omitempty"`
 }
 
 // +genclient
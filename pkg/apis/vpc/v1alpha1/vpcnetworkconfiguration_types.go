@@ -44,6 +44,21 @@ type VPCNetworkConfigurationStatus struct {
 	VPCs []VPCInfo `json:"vpcs,omitempty"`
 	// Conditions describe current state of VPCNetworkConfiguration.
 	Conditions []Condition `json:"conditions,omitempty"`
+	// Namespaces describes the progress of applying the VPCNetworkConfiguration to the VPCs
+	// of the Namespaces using it.
+	Namespaces []NamespaceRolloutStatus `json:"namespaces,omitempty"`
+}
+
+// NamespaceRolloutStatus describes the progress of applying the VPCNetworkConfiguration to the
+// VPC of a Namespace.
+type NamespaceRolloutStatus struct {
+	// Namespace name.
+	Name string `json:"name"`
+	// ObservedGeneration is the generation of the VPCNetworkConfiguration last applied to the VPC
+	// of the Namespace.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Message describes the error if the latest generation failed to be applied.
+	Message string `json:"message,omitempty"`
 }
 
 // VPCInfo defines VPC info needed by tenant admin.
@@ -0,0 +1,102 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VPCPeeringPeer specifies a Namespace whose VPC is peered, and the networks of the VPC exchanged with
+// the other peer. Only the specified networks are reachable from the other peer.
+// +kubebuilder:validation:XValidation:rule="has(self.subnets) || has(self.cidrs)",message="At least one of subnets or cidrs must be specified"
+type VPCPeeringPeer struct {
+	// Namespace is the name of the Namespace whose VPC is peered.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// Subnets is the names of the Subnets in the Namespace whose network addresses are exchanged.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Subnets []string `json:"subnets,omitempty"`
+	// CIDRs is the list of CIDRs of the VPC which are exchanged. The CIDRs must be in the private IPs or the
+	// Subnets of the VPC.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	CIDRs []string `json:"cidrs,omitempty"`
+}
+
+// VPCPeeringSpec defines the desired state of VPCPeering.
+// +kubebuilder:validation:XValidation:rule="self.peers[0].namespace != self.peers[1].namespace",message="The peers must be different Namespaces"
+type VPCPeeringSpec struct {
+	// Peers is the two Namespaces whose VPCs are peered.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=2
+	// +kubebuilder:validation:MaxItems=2
+	Peers []VPCPeeringPeer `json:"peers"`
+}
+
+// VPCPeeringRoute is a route realized on NSX to reach a network of a peer.
+type VPCPeeringRoute struct {
+	// Network is the destination network of the route.
+	Network string `json:"network"`
+	// NextHop is the next hop IP address of the route.
+	NextHop string `json:"nextHop"`
+	// Path is the NSX policy path of the static route.
+	Path string `json:"path,omitempty"`
+}
+
+// VPCPeeringPeerStatus is the realized state of a peer.
+type VPCPeeringPeerStatus struct {
+	// Namespace is the name of the peered Namespace.
+	Namespace string `json:"namespace"`
+	// VPCPath is the NSX policy path of the VPC of the Namespace.
+	VPCPath string `json:"vpcPath,omitempty"`
+	// TransitGatewayPath is the NSX policy path of the transit gateway the VPC is attached to.
+	TransitGatewayPath string `json:"transitGatewayPath,omitempty"`
+	// Routes is the routes realized in the VPC to reach the networks of the other peer.
+	Routes []VPCPeeringRoute `json:"routes,omitempty"`
+	// TransitGatewayRoutes is the routes realized in the transit gateway to reach the networks of the peer.
+	TransitGatewayRoutes []VPCPeeringRoute `json:"transitGatewayRoutes,omitempty"`
+}
+
+// VPCPeeringStatus defines the observed state of VPCPeering.
+type VPCPeeringStatus struct {
+	// Conditions described if the VPCPeering is configured on NSX or not.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Peers is the realized state of both peers.
+	Peers []VPCPeeringPeerStatus `json:"peers,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope="Cluster",path=vpcpeerings
+
+// VPCPeering is the Schema for the vpcpeerings API. It connects the VPCs of two Namespaces in the same
+// project through their transit gateway, exchanging only the specified networks.
+// +kubebuilder:printcolumn:name="Peer1",type=string,JSONPath=`.spec.peers[0].namespace`,description="Namespace of the first peer"
+// +kubebuilder:printcolumn:name="Peer2",type=string,JSONPath=`.spec.peers[1].namespace`,description="Namespace of the second peer"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Whether the VPC peering is realized on NSX"
+type VPCPeering struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VPCPeeringSpec   `json:"spec,omitempty"`
+	Status VPCPeeringStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VPCPeeringList contains a list of VPCPeering.
+type VPCPeeringList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VPCPeering `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VPCPeering{}, &VPCPeeringList{})
+}
@@ -124,6 +124,192 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPRelayConfig) DeepCopyInto(out *DHCPRelayConfig) {
+	*out = *in
+	if in.ServerAddresses != nil {
+		in, out := &in.ServerAddresses, &out.ServerAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPRelayConfig.
+func (in *DHCPRelayConfig) DeepCopy() *DHCPRelayConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPRelayConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPServerAdditionalConfig) DeepCopyInto(out *DHCPServerAdditionalConfig) {
+	*out = *in
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SearchDomains != nil {
+		in, out := &in.SearchDomains, &out.SearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StaticBindings != nil {
+		in, out := &in.StaticBindings, &out.StaticBindings
+		*out = make([]DHCPStaticBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedRanges != nil {
+		in, out := &in.ExcludedRanges, &out.ExcludedRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPServerAdditionalConfig.
+func (in *DHCPServerAdditionalConfig) DeepCopy() *DHCPServerAdditionalConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPServerAdditionalConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPStaticBinding) DeepCopyInto(out *DHCPStaticBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPStaticBinding.
+func (in *DHCPStaticBinding) DeepCopy() *DHCPStaticBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPStaticBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIP) DeepCopyInto(out *EgressIP) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIP.
+func (in *EgressIP) DeepCopy() *EgressIP {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EgressIP) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPList) DeepCopyInto(out *EgressIPList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EgressIP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPList.
+func (in *EgressIPList) DeepCopy() *EgressIPList {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EgressIPList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPSpec) DeepCopyInto(out *EgressIPSpec) {
+	*out = *in
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMSelector != nil {
+		in, out := &in.VMSelector, &out.VMSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPSpec.
+func (in *EgressIPSpec) DeepCopy() *EgressIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPStatus) DeepCopyInto(out *EgressIPStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPStatus.
+func (in *EgressIPStatus) DeepCopy() *EgressIPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAddressAllocation) DeepCopyInto(out *IPAddressAllocation) {
 	*out = *in
@@ -303,31 +489,41 @@ func (in *IPBlocksInfoList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkInfo) DeepCopyInto(out *NetworkInfo) {
+func (in *IPUtilization) DeepCopyInto(out *IPUtilization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPUtilization.
+func (in *IPUtilization) DeepCopy() *IPUtilization {
+	if in == nil {
+		return nil
+	}
+	out := new(IPUtilization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATRule) DeepCopyInto(out *NATRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	if in.VPCs != nil {
-		in, out := &in.VPCs, &out.VPCs
-		*out = make([]VPCState, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInfo.
-func (in *NetworkInfo) DeepCopy() *NetworkInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATRule.
+func (in *NATRule) DeepCopy() *NATRule {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkInfo)
+	out := new(NATRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NetworkInfo) DeepCopyObject() runtime.Object {
+func (in *NATRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -335,31 +531,31 @@ func (in *NetworkInfo) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkInfoList) DeepCopyInto(out *NetworkInfoList) {
+func (in *NATRuleList) DeepCopyInto(out *NATRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NetworkInfo, len(*in))
+		*out = make([]NATRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInfoList.
-func (in *NetworkInfoList) DeepCopy() *NetworkInfoList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATRuleList.
+func (in *NATRuleList) DeepCopy() *NATRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkInfoList)
+	out := new(NATRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NetworkInfoList) DeepCopyObject() runtime.Object {
+func (in *NATRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -367,91 +563,140 @@ func (in *NetworkInfoList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkInterfaceConfig) DeepCopyInto(out *NetworkInterfaceConfig) {
+func (in *NATRuleNetwork) DeepCopyInto(out *NATRuleNetwork) {
 	*out = *in
-	if in.IPAddresses != nil {
-		in, out := &in.IPAddresses, &out.IPAddresses
-		*out = make([]NetworkInterfaceIPAddress, len(*in))
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceConfig.
-func (in *NetworkInterfaceConfig) DeepCopy() *NetworkInterfaceConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATRuleNetwork.
+func (in *NATRuleNetwork) DeepCopy() *NATRuleNetwork {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkInterfaceConfig)
+	out := new(NATRuleNetwork)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkInterfaceIPAddress) DeepCopyInto(out *NetworkInterfaceIPAddress) {
+func (in *NATRuleSpec) DeepCopyInto(out *NATRuleSpec) {
 	*out = *in
+	if in.SourceNetwork != nil {
+		in, out := &in.SourceNetwork, &out.SourceNetwork
+		*out = new(NATRuleNetwork)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DestinationNetwork != nil {
+		in, out := &in.DestinationNetwork, &out.DestinationNetwork
+		*out = new(NATRuleNetwork)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TranslatedNetwork != nil {
+		in, out := &in.TranslatedNetwork, &out.TranslatedNetwork
+		*out = new(NATRuleTranslatedNetwork)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceIPAddress.
-func (in *NetworkInterfaceIPAddress) DeepCopy() *NetworkInterfaceIPAddress {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATRuleSpec.
+func (in *NATRuleSpec) DeepCopy() *NATRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkInterfaceIPAddress)
+	out := new(NATRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NextHop) DeepCopyInto(out *NextHop) {
+func (in *NATRuleStatus) DeepCopyInto(out *NATRuleStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NextHop.
-func (in *NextHop) DeepCopy() *NextHop {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATRuleStatus.
+func (in *NATRuleStatus) DeepCopy() *NATRuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NextHop)
+	out := new(NATRuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortAttachment) DeepCopyInto(out *PortAttachment) {
+func (in *NATRuleTranslatedNetwork) DeepCopyInto(out *NATRuleTranslatedNetwork) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortAttachment.
-func (in *PortAttachment) DeepCopy() *PortAttachment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATRuleTranslatedNetwork.
+func (in *NATRuleTranslatedNetwork) DeepCopy() *NATRuleTranslatedNetwork {
 	if in == nil {
 		return nil
 	}
-	out := new(PortAttachment)
+	out := new(NATRuleTranslatedNetwork)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityPolicy) DeepCopyInto(out *SecurityPolicy) {
+func (in *NamespaceRolloutStatus) DeepCopyInto(out *NamespaceRolloutStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRolloutStatus.
+func (in *NamespaceRolloutStatus) DeepCopy() *NamespaceRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInfo) DeepCopyInto(out *NetworkInfo) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.VPCs != nil {
+		in, out := &in.VPCs, &out.VPCs
+		*out = make([]VPCState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicy.
-func (in *SecurityPolicy) DeepCopy() *SecurityPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInfo.
+func (in *NetworkInfo) DeepCopy() *NetworkInfo {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityPolicy)
+	out := new(NetworkInfo)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecurityPolicy) DeepCopyObject() runtime.Object {
+func (in *NetworkInfo) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -459,31 +704,31 @@ func (in *SecurityPolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityPolicyList) DeepCopyInto(out *SecurityPolicyList) {
+func (in *NetworkInfoList) DeepCopyInto(out *NetworkInfoList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SecurityPolicy, len(*in))
+		*out = make([]NetworkInfo, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicyList.
-func (in *SecurityPolicyList) DeepCopy() *SecurityPolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInfoList.
+func (in *NetworkInfoList) DeepCopy() *NetworkInfoList {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityPolicyList)
+	out := new(NetworkInfoList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecurityPolicyList) DeepCopyObject() runtime.Object {
+func (in *NetworkInfoList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -491,7 +736,290 @@ func (in *SecurityPolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityPolicyPeer) DeepCopyInto(out *SecurityPolicyPeer) {
+func (in *NetworkInterfaceConfig) DeepCopyInto(out *NetworkInterfaceConfig) {
+	*out = *in
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]NetworkInterfaceIPAddress, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceConfig.
+func (in *NetworkInterfaceConfig) DeepCopy() *NetworkInterfaceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterfaceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterfaceIPAddress) DeepCopyInto(out *NetworkInterfaceIPAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceIPAddress.
+func (in *NetworkInterfaceIPAddress) DeepCopy() *NetworkInterfaceIPAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterfaceIPAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkQuota) DeepCopyInto(out *NetworkQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkQuota.
+func (in *NetworkQuota) DeepCopy() *NetworkQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkQuotaList) DeepCopyInto(out *NetworkQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NetworkQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkQuotaList.
+func (in *NetworkQuotaList) DeepCopy() *NetworkQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkQuotaResources) DeepCopyInto(out *NetworkQuotaResources) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SubnetIPs != nil {
+		in, out := &in.SubnetIPs, &out.SubnetIPs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SubnetPorts != nil {
+		in, out := &in.SubnetPorts, &out.SubnetPorts
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ExternalIPs != nil {
+		in, out := &in.ExternalIPs, &out.ExternalIPs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StaticRoutes != nil {
+		in, out := &in.StaticRoutes, &out.StaticRoutes
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkQuotaResources.
+func (in *NetworkQuotaResources) DeepCopy() *NetworkQuotaResources {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkQuotaResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkQuotaSpec) DeepCopyInto(out *NetworkQuotaSpec) {
+	*out = *in
+	in.Hard.DeepCopyInto(&out.Hard)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkQuotaSpec.
+func (in *NetworkQuotaSpec) DeepCopy() *NetworkQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkQuotaStatus) DeepCopyInto(out *NetworkQuotaStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Used.DeepCopyInto(&out.Used)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkQuotaStatus.
+func (in *NetworkQuotaStatus) DeepCopy() *NetworkQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NextHop) DeepCopyInto(out *NextHop) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NextHop.
+func (in *NextHop) DeepCopy() *NextHop {
+	if in == nil {
+		return nil
+	}
+	out := new(NextHop)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortAttachment) DeepCopyInto(out *PortAttachment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortAttachment.
+func (in *PortAttachment) DeepCopy() *PortAttachment {
+	if in == nil {
+		return nil
+	}
+	out := new(PortAttachment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleStatisticsSummary) DeepCopyInto(out *RuleStatisticsSummary) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.UnusedRules != nil {
+		in, out := &in.UnusedRules, &out.UnusedRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleStatisticsSummary.
+func (in *RuleStatisticsSummary) DeepCopy() *RuleStatisticsSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleStatisticsSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityPolicy) DeepCopyInto(out *SecurityPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicy.
+func (in *SecurityPolicy) DeepCopy() *SecurityPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityPolicyList) DeepCopyInto(out *SecurityPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecurityPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicyList.
+func (in *SecurityPolicyList) DeepCopy() *SecurityPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityPolicyPeer) DeepCopyInto(out *SecurityPolicyPeer) {
 	*out = *in
 	if in.VMSelector != nil {
 		in, out := &in.VMSelector, &out.VMSelector
@@ -587,83 +1115,277 @@ func (in *SecurityPolicyRule) DeepCopy() *SecurityPolicyRule {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityPolicyRule)
-	in.DeepCopyInto(out)
-	return out
+	out := new(SecurityPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityPolicySpec) DeepCopyInto(out *SecurityPolicySpec) {
+	*out = *in
+	if in.AppliedTo != nil {
+		in, out := &in.AppliedTo, &out.AppliedTo
+		*out = make([]SecurityPolicyTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]SecurityPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicySpec.
+func (in *SecurityPolicySpec) DeepCopy() *SecurityPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityPolicyStatus) DeepCopyInto(out *SecurityPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuleStatistics != nil {
+		in, out := &in.RuleStatistics, &out.RuleStatistics
+		*out = new(RuleStatisticsSummary)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicyStatus.
+func (in *SecurityPolicyStatus) DeepCopy() *SecurityPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityPolicyTarget) DeepCopyInto(out *SecurityPolicyTarget) {
+	*out = *in
+	if in.VMSelector != nil {
+		in, out := &in.VMSelector, &out.VMSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicyTarget.
+func (in *SecurityPolicyTarget) DeepCopy() *SecurityPolicyTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityPolicyTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityPolicyTierBinding) DeepCopyInto(out *SecurityPolicyTierBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicyTierBinding.
+func (in *SecurityPolicyTierBinding) DeepCopy() *SecurityPolicyTierBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityPolicyTierBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityPolicyTierBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityPolicyTierBindingList) DeepCopyInto(out *SecurityPolicyTierBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecurityPolicyTierBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicyTierBindingList.
+func (in *SecurityPolicyTierBindingList) DeepCopy() *SecurityPolicyTierBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityPolicyTierBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityPolicyTierBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityPolicyTierBindingSpec) DeepCopyInto(out *SecurityPolicyTierBindingSpec) {
+	*out = *in
+	if in.Tiers != nil {
+		in, out := &in.Tiers, &out.Tiers
+		*out = make([]SecurityPolicyTier, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicyTierBindingSpec.
+func (in *SecurityPolicyTierBindingSpec) DeepCopy() *SecurityPolicyTierBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityPolicyTierBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedVPC) DeepCopyInto(out *SharedVPC) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedVPC.
+func (in *SharedVPC) DeepCopy() *SharedVPC {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedVPC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SharedVPC) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityPolicySpec) DeepCopyInto(out *SecurityPolicySpec) {
+func (in *SharedVPCList) DeepCopyInto(out *SharedVPCList) {
 	*out = *in
-	if in.AppliedTo != nil {
-		in, out := &in.AppliedTo, &out.AppliedTo
-		*out = make([]SecurityPolicyTarget, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Rules != nil {
-		in, out := &in.Rules, &out.Rules
-		*out = make([]SecurityPolicyRule, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SharedVPC, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicySpec.
-func (in *SecurityPolicySpec) DeepCopy() *SecurityPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedVPCList.
+func (in *SharedVPCList) DeepCopy() *SharedVPCList {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityPolicySpec)
+	out := new(SharedVPCList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SharedVPCList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityPolicyStatus) DeepCopyInto(out *SecurityPolicyStatus) {
+func (in *SharedVPCSpec) DeepCopyInto(out *SharedVPCSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ConsumerNamespaces != nil {
+		in, out := &in.ConsumerNamespaces, &out.ConsumerNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicyStatus.
-func (in *SecurityPolicyStatus) DeepCopy() *SecurityPolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedVPCSpec.
+func (in *SharedVPCSpec) DeepCopy() *SharedVPCSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityPolicyStatus)
+	out := new(SharedVPCSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityPolicyTarget) DeepCopyInto(out *SecurityPolicyTarget) {
+func (in *SharedVPCStatus) DeepCopyInto(out *SharedVPCStatus) {
 	*out = *in
-	if in.VMSelector != nil {
-		in, out := &in.VMSelector, &out.VMSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.PodSelector != nil {
-		in, out := &in.PodSelector, &out.PodSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicyTarget.
-func (in *SecurityPolicyTarget) DeepCopy() *SecurityPolicyTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedVPCStatus.
+func (in *SharedVPCStatus) DeepCopy() *SharedVPCStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityPolicyTarget)
+	out := new(SharedVPCStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -911,6 +1633,16 @@ func (in *SubnetConnectionBindingMapStatus) DeepCopy() *SubnetConnectionBindingM
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetDHCPConfig) DeepCopyInto(out *SubnetDHCPConfig) {
 	*out = *in
+	if in.DHCPServerAdditionalConfig != nil {
+		in, out := &in.DHCPServerAdditionalConfig, &out.DHCPServerAdditionalConfig
+		*out = new(DHCPServerAdditionalConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DHCPRelayConfig != nil {
+		in, out := &in.DHCPRelayConfig, &out.DHCPRelayConfig
+		*out = new(DHCPRelayConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetDHCPConfig.
@@ -941,6 +1673,11 @@ func (in *SubnetInfo) DeepCopyInto(out *SubnetInfo) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPUtilization != nil {
+		in, out := &in.IPUtilization, &out.IPUtilization
+		*out = new(IPUtilization)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetInfo.
@@ -1088,7 +1825,7 @@ func (in *SubnetSet) DeepCopyInto(out *SubnetSet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1110,6 +1847,21 @@ func (in *SubnetSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetSetGrowthPolicy) DeepCopyInto(out *SubnetSetGrowthPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSetGrowthPolicy.
+func (in *SubnetSetGrowthPolicy) DeepCopy() *SubnetSetGrowthPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetSetGrowthPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSetList) DeepCopyInto(out *SubnetSetList) {
 	*out = *in
@@ -1145,7 +1897,17 @@ func (in *SubnetSetList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSetSpec) DeepCopyInto(out *SubnetSetSpec) {
 	*out = *in
-	out.SubnetDHCPConfig = in.SubnetDHCPConfig
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]IPFamily, len(*in))
+		copy(*out, *in)
+	}
+	in.SubnetDHCPConfig.DeepCopyInto(&out.SubnetDHCPConfig)
+	if in.GrowthPolicy != nil {
+		in, out := &in.GrowthPolicy, &out.GrowthPolicy
+		*out = new(SubnetSetGrowthPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSetSpec.
@@ -1175,6 +1937,11 @@ func (in *SubnetSetStatus) DeepCopyInto(out *SubnetSetStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.IPUtilization != nil {
+		in, out := &in.IPUtilization, &out.IPUtilization
+		*out = new(IPUtilization)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSetStatus.
@@ -1190,12 +1957,22 @@ func (in *SubnetSetStatus) DeepCopy() *SubnetSetStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]IPFamily, len(*in))
+		copy(*out, *in)
+	}
 	if in.IPAddresses != nil {
 		in, out := &in.IPAddresses, &out.IPAddresses
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	out.SubnetDHCPConfig = in.SubnetDHCPConfig
+	if in.ReservedIPRanges != nil {
+		in, out := &in.ReservedIPRanges, &out.ReservedIPRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.SubnetDHCPConfig.DeepCopyInto(&out.SubnetDHCPConfig)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
@@ -1208,6 +1985,26 @@ func (in *SubnetSpec) DeepCopy() *SubnetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetState) DeepCopyInto(out *SubnetState) {
+	*out = *in
+	if in.NetworkAddresses != nil {
+		in, out := &in.NetworkAddresses, &out.NetworkAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetState.
+func (in *SubnetState) DeepCopy() *SubnetState {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
 	*out = *in
@@ -1226,6 +2023,11 @@ func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPUtilization != nil {
+		in, out := &in.IPUtilization, &out.IPUtilization
+		*out = new(IPUtilization)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]Condition, len(*in))
@@ -1354,6 +2156,11 @@ func (in *VPCNetworkConfigurationStatus) DeepCopyInto(out *VPCNetworkConfigurati
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceRolloutStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCNetworkConfigurationStatus.
@@ -1366,6 +2173,181 @@ func (in *VPCNetworkConfigurationStatus) DeepCopy() *VPCNetworkConfigurationStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeering) DeepCopyInto(out *VPCPeering) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeering.
+func (in *VPCPeering) DeepCopy() *VPCPeering {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VPCPeering) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringList) DeepCopyInto(out *VPCPeeringList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VPCPeering, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringList.
+func (in *VPCPeeringList) DeepCopy() *VPCPeeringList {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VPCPeeringList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringPeer) DeepCopyInto(out *VPCPeeringPeer) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringPeer.
+func (in *VPCPeeringPeer) DeepCopy() *VPCPeeringPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringPeerStatus) DeepCopyInto(out *VPCPeeringPeerStatus) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]VPCPeeringRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.TransitGatewayRoutes != nil {
+		in, out := &in.TransitGatewayRoutes, &out.TransitGatewayRoutes
+		*out = make([]VPCPeeringRoute, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringPeerStatus.
+func (in *VPCPeeringPeerStatus) DeepCopy() *VPCPeeringPeerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringPeerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringRoute) DeepCopyInto(out *VPCPeeringRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringRoute.
+func (in *VPCPeeringRoute) DeepCopy() *VPCPeeringRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringSpec) DeepCopyInto(out *VPCPeeringSpec) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]VPCPeeringPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringSpec.
+func (in *VPCPeeringSpec) DeepCopy() *VPCPeeringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringStatus) DeepCopyInto(out *VPCPeeringStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]VPCPeeringPeerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringStatus.
+func (in *VPCPeeringStatus) DeepCopy() *VPCPeeringStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VPCState) DeepCopyInto(out *VPCState) {
 	*out = *in
@@ -1374,6 +2356,28 @@ func (in *VPCState) DeepCopyInto(out *VPCState) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPUtilization != nil {
+		in, out := &in.IPUtilization, &out.IPUtilization
+		*out = new(IPUtilization)
+		**out = **in
+	}
+	if in.ExternalIPBlocks != nil {
+		in, out := &in.ExternalIPBlocks, &out.ExternalIPBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateTGWIPBlocks != nil {
+		in, out := &in.PrivateTGWIPBlocks, &out.PrivateTGWIPBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]SubnetState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCState.
@@ -14,12 +14,14 @@ import (
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/ipaddressallocation"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/natrule"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
 	sr "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/staticroute"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetbinding"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetport"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpcpeering"
 	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 
 	"github.com/go-logr/logr"
@@ -159,6 +161,18 @@ func InitializeCleanupService(cf *config.NSXOperatorConfig, nsxClient *nsx.Clien
 		}
 	}
 
+	wrapInitializeNATRule := func(service common.Service) cleanupFunc {
+		return func() (cleanup, error) {
+			return natrule.InitializeNATRule(service, vpcService)
+		}
+	}
+
+	wrapInitializeVPCPeering := func(service common.Service) cleanupFunc {
+		return func() (cleanup, error) {
+			return vpcpeering.InitializeVPCPeering(service, vpcService)
+		}
+	}
+
 	wrapInitializeSubnetPort := func(service common.Service) cleanupFunc {
 		return func() (cleanup, error) {
 			return subnetport.InitializeSubnetPort(service)
@@ -181,6 +195,8 @@ func InitializeCleanupService(cf *config.NSXOperatorConfig, nsxClient *nsx.Clien
 		AddCleanupService(wrapInitializeSubnetService(commonService)).
 		AddCleanupService(wrapInitializeSecurityPolicy(commonService)).
 		AddCleanupService(wrapInitializeStaticRoute(commonService)).
+		AddCleanupService(wrapInitializeNATRule(commonService)).
+		AddCleanupService(wrapInitializeVPCPeering(commonService)).
 		AddCleanupService(wrapInitializeVPC(commonService)).
 		AddCleanupService(wrapInitializeIPAddressAllocation(commonService))
 
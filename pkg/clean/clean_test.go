@@ -15,12 +15,14 @@ import (
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/ipaddressallocation"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/natrule"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
 	sr "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/staticroute"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetbinding"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetport"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpcpeering"
 )
 
 var (
@@ -175,6 +177,12 @@ func TestInitializeCleanupService_Success(t *testing.T) {
 	patches.ApplyFunc(sr.InitializeStaticRoute, func(service common.Service, vpcService common.VPCServiceProvider) (*sr.StaticRouteService, error) {
 		return &sr.StaticRouteService{}, nil
 	})
+	patches.ApplyFunc(natrule.InitializeNATRule, func(service common.Service, vpcService common.VPCServiceProvider) (*natrule.NATRuleService, error) {
+		return &natrule.NATRuleService{}, nil
+	})
+	patches.ApplyFunc(vpcpeering.InitializeVPCPeering, func(service common.Service, vpcService common.VPCServiceProvider) (*vpcpeering.VPCPeeringService, error) {
+		return &vpcpeering.VPCPeeringService{}, nil
+	})
 	patches.ApplyFunc(subnetport.InitializeSubnetPort, func(service common.Service) (*subnetport.SubnetPortService, error) {
 		return &subnetport.SubnetPortService{}, nil
 	})
@@ -188,7 +196,7 @@ func TestInitializeCleanupService_Success(t *testing.T) {
 	cleanupService, err := InitializeCleanupService(cf, nsxClient)
 	assert.NoError(t, err)
 	assert.NotNil(t, cleanupService)
-	assert.Len(t, cleanupService.cleans, 7)
+	assert.Len(t, cleanupService.cleans, 9)
 }
 
 func TestInitializeCleanupService_VPCError(t *testing.T) {
@@ -212,6 +220,12 @@ func TestInitializeCleanupService_VPCError(t *testing.T) {
 	patches.ApplyFunc(sr.InitializeStaticRoute, func(service common.Service, vpcService common.VPCServiceProvider) (*sr.StaticRouteService, error) {
 		return &sr.StaticRouteService{}, nil
 	})
+	patches.ApplyFunc(natrule.InitializeNATRule, func(service common.Service, vpcService common.VPCServiceProvider) (*natrule.NATRuleService, error) {
+		return &natrule.NATRuleService{}, nil
+	})
+	patches.ApplyFunc(vpcpeering.InitializeVPCPeering, func(service common.Service, vpcService common.VPCServiceProvider) (*vpcpeering.VPCPeeringService, error) {
+		return &vpcpeering.VPCPeeringService{}, nil
+	})
 	patches.ApplyFunc(subnetport.InitializeSubnetPort, func(service common.Service) (*subnetport.SubnetPortService, error) {
 		return &subnetport.SubnetPortService{}, nil
 	})
@@ -225,6 +239,6 @@ func TestInitializeCleanupService_VPCError(t *testing.T) {
 	cleanupService, err := InitializeCleanupService(cf, nsxClient)
 	assert.NoError(t, err)
 	assert.NotNil(t, cleanupService)
-	assert.Len(t, cleanupService.cleans, 5)
+	assert.Len(t, cleanupService.cleans, 7)
 	assert.Equal(t, expectedError, cleanupService.err)
 }
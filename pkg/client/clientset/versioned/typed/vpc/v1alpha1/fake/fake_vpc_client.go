@@ -19,6 +19,10 @@ func (c *FakeCrdV1alpha1) AddressBindings(namespace string) v1alpha1.AddressBind
 	return &FakeAddressBindings{c, namespace}
 }
 
+func (c *FakeCrdV1alpha1) EgressIPs(namespace string) v1alpha1.EgressIPInterface {
+	return &FakeEgressIPs{c, namespace}
+}
+
 func (c *FakeCrdV1alpha1) IPAddressAllocations(namespace string) v1alpha1.IPAddressAllocationInterface {
 	return &FakeIPAddressAllocations{c, namespace}
 }
@@ -27,14 +31,30 @@ func (c *FakeCrdV1alpha1) IPBlocksInfos() v1alpha1.IPBlocksInfoInterface {
 	return &FakeIPBlocksInfos{c}
 }
 
+func (c *FakeCrdV1alpha1) NATRules(namespace string) v1alpha1.NATRuleInterface {
+	return &FakeNATRules{c, namespace}
+}
+
 func (c *FakeCrdV1alpha1) NetworkInfos(namespace string) v1alpha1.NetworkInfoInterface {
 	return &FakeNetworkInfos{c, namespace}
 }
 
+func (c *FakeCrdV1alpha1) NetworkQuotas(namespace string) v1alpha1.NetworkQuotaInterface {
+	return &FakeNetworkQuotas{c, namespace}
+}
+
 func (c *FakeCrdV1alpha1) SecurityPolicies(namespace string) v1alpha1.SecurityPolicyInterface {
 	return &FakeSecurityPolicies{c, namespace}
 }
 
+func (c *FakeCrdV1alpha1) SecurityPolicyTierBindings() v1alpha1.SecurityPolicyTierBindingInterface {
+	return &FakeSecurityPolicyTierBindings{c}
+}
+
+func (c *FakeCrdV1alpha1) SharedVPCs() v1alpha1.SharedVPCInterface {
+	return &FakeSharedVPCs{c}
+}
+
 func (c *FakeCrdV1alpha1) StaticRoutes(namespace string) v1alpha1.StaticRouteInterface {
 	return &FakeStaticRoutes{c, namespace}
 }
@@ -59,6 +79,10 @@ func (c *FakeCrdV1alpha1) VPCNetworkConfigurations() v1alpha1.VPCNetworkConfigur
 	return &FakeVPCNetworkConfigurations{c}
 }
 
+func (c *FakeCrdV1alpha1) VPCPeerings() v1alpha1.VPCPeeringInterface {
+	return &FakeVPCPeerings{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeCrdV1alpha1) RESTClient() rest.Interface {
@@ -7,14 +7,24 @@ package v1alpha1
 
 type AddressBindingExpansion interface{}
 
+type EgressIPExpansion interface{}
+
 type IPAddressAllocationExpansion interface{}
 
 type IPBlocksInfoExpansion interface{}
 
+type NATRuleExpansion interface{}
+
 type NetworkInfoExpansion interface{}
 
+type NetworkQuotaExpansion interface{}
+
 type SecurityPolicyExpansion interface{}
 
+type SecurityPolicyTierBindingExpansion interface{}
+
+type SharedVPCExpansion interface{}
+
 type StaticRouteExpansion interface{}
 
 type SubnetExpansion interface{}
@@ -26,3 +36,5 @@ type SubnetPortExpansion interface{}
 type SubnetSetExpansion interface{}
 
 type VPCNetworkConfigurationExpansion interface{}
+
+type VPCPeeringExpansion interface{}
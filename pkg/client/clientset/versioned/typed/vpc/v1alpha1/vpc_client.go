@@ -16,16 +16,22 @@ import (
 type CrdV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	AddressBindingsGetter
+	EgressIPsGetter
 	IPAddressAllocationsGetter
 	IPBlocksInfosGetter
+	NATRulesGetter
 	NetworkInfosGetter
+	NetworkQuotasGetter
 	SecurityPoliciesGetter
+	SecurityPolicyTierBindingsGetter
+	SharedVPCsGetter
 	StaticRoutesGetter
 	SubnetsGetter
 	SubnetConnectionBindingMapsGetter
 	SubnetPortsGetter
 	SubnetSetsGetter
 	VPCNetworkConfigurationsGetter
+	VPCPeeringsGetter
 }
 
 // CrdV1alpha1Client is used to interact with features provided by the crd.nsx.vmware.com group.
@@ -37,6 +43,10 @@ func (c *CrdV1alpha1Client) AddressBindings(namespace string) AddressBindingInte
 	return newAddressBindings(c, namespace)
 }
 
+func (c *CrdV1alpha1Client) EgressIPs(namespace string) EgressIPInterface {
+	return newEgressIPs(c, namespace)
+}
+
 func (c *CrdV1alpha1Client) IPAddressAllocations(namespace string) IPAddressAllocationInterface {
 	return newIPAddressAllocations(c, namespace)
 }
@@ -45,14 +55,30 @@ func (c *CrdV1alpha1Client) IPBlocksInfos() IPBlocksInfoInterface {
 	return newIPBlocksInfos(c)
 }
 
+func (c *CrdV1alpha1Client) NATRules(namespace string) NATRuleInterface {
+	return newNATRules(c, namespace)
+}
+
 func (c *CrdV1alpha1Client) NetworkInfos(namespace string) NetworkInfoInterface {
 	return newNetworkInfos(c, namespace)
 }
 
+func (c *CrdV1alpha1Client) NetworkQuotas(namespace string) NetworkQuotaInterface {
+	return newNetworkQuotas(c, namespace)
+}
+
 func (c *CrdV1alpha1Client) SecurityPolicies(namespace string) SecurityPolicyInterface {
 	return newSecurityPolicies(c, namespace)
 }
 
+func (c *CrdV1alpha1Client) SecurityPolicyTierBindings() SecurityPolicyTierBindingInterface {
+	return newSecurityPolicyTierBindings(c)
+}
+
+func (c *CrdV1alpha1Client) SharedVPCs() SharedVPCInterface {
+	return newSharedVPCs(c)
+}
+
 func (c *CrdV1alpha1Client) StaticRoutes(namespace string) StaticRouteInterface {
 	return newStaticRoutes(c, namespace)
 }
@@ -77,6 +103,10 @@ func (c *CrdV1alpha1Client) VPCNetworkConfigurations() VPCNetworkConfigurationIn
 	return newVPCNetworkConfigurations(c)
 }
 
+func (c *CrdV1alpha1Client) VPCPeerings() VPCPeeringInterface {
+	return newVPCPeerings(c)
+}
+
 // NewForConfig creates a new CrdV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -42,14 +42,24 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=crd.nsx.vmware.com, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("addressbindings"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().AddressBindings().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("egressips"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().EgressIPs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("ipaddressallocations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().IPAddressAllocations().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("ipblocksinfos"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().IPBlocksInfos().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("natrules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().NATRules().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("networkinfos"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().NetworkInfos().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("networkquotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().NetworkQuotas().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("securitypolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().SecurityPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("securitypolicytierbindings"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().SecurityPolicyTierBindings().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("sharedvpcs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().SharedVPCs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("staticroutes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().StaticRoutes().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("subnets"):
@@ -62,6 +72,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().SubnetSets().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("vpcnetworkconfigurations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().VPCNetworkConfigurations().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("vpcpeerings"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().VPCPeerings().Informer()}, nil
 
 	}
 
@@ -13,14 +13,24 @@ import (
 type Interface interface {
 	// AddressBindings returns a AddressBindingInformer.
 	AddressBindings() AddressBindingInformer
+	// EgressIPs returns a EgressIPInformer.
+	EgressIPs() EgressIPInformer
 	// IPAddressAllocations returns a IPAddressAllocationInformer.
 	IPAddressAllocations() IPAddressAllocationInformer
 	// IPBlocksInfos returns a IPBlocksInfoInformer.
 	IPBlocksInfos() IPBlocksInfoInformer
+	// NATRules returns a NATRuleInformer.
+	NATRules() NATRuleInformer
 	// NetworkInfos returns a NetworkInfoInformer.
 	NetworkInfos() NetworkInfoInformer
+	// NetworkQuotas returns a NetworkQuotaInformer.
+	NetworkQuotas() NetworkQuotaInformer
 	// SecurityPolicies returns a SecurityPolicyInformer.
 	SecurityPolicies() SecurityPolicyInformer
+	// SecurityPolicyTierBindings returns a SecurityPolicyTierBindingInformer.
+	SecurityPolicyTierBindings() SecurityPolicyTierBindingInformer
+	// SharedVPCs returns a SharedVPCInformer.
+	SharedVPCs() SharedVPCInformer
 	// StaticRoutes returns a StaticRouteInformer.
 	StaticRoutes() StaticRouteInformer
 	// Subnets returns a SubnetInformer.
@@ -33,6 +43,8 @@ type Interface interface {
 	SubnetSets() SubnetSetInformer
 	// VPCNetworkConfigurations returns a VPCNetworkConfigurationInformer.
 	VPCNetworkConfigurations() VPCNetworkConfigurationInformer
+	// VPCPeerings returns a VPCPeeringInformer.
+	VPCPeerings() VPCPeeringInformer
 }
 
 type version struct {
@@ -51,6 +63,11 @@ func (v *version) AddressBindings() AddressBindingInformer {
 	return &addressBindingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// EgressIPs returns a EgressIPInformer.
+func (v *version) EgressIPs() EgressIPInformer {
+	return &egressIPInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // IPAddressAllocations returns a IPAddressAllocationInformer.
 func (v *version) IPAddressAllocations() IPAddressAllocationInformer {
 	return &iPAddressAllocationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -61,16 +78,36 @@ func (v *version) IPBlocksInfos() IPBlocksInfoInformer {
 	return &iPBlocksInfoInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// NATRules returns a NATRuleInformer.
+func (v *version) NATRules() NATRuleInformer {
+	return &nATRuleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // NetworkInfos returns a NetworkInfoInformer.
 func (v *version) NetworkInfos() NetworkInfoInformer {
 	return &networkInfoInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// NetworkQuotas returns a NetworkQuotaInformer.
+func (v *version) NetworkQuotas() NetworkQuotaInformer {
+	return &networkQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SecurityPolicies returns a SecurityPolicyInformer.
 func (v *version) SecurityPolicies() SecurityPolicyInformer {
 	return &securityPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// SecurityPolicyTierBindings returns a SecurityPolicyTierBindingInformer.
+func (v *version) SecurityPolicyTierBindings() SecurityPolicyTierBindingInformer {
+	return &securityPolicyTierBindingInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// SharedVPCs returns a SharedVPCInformer.
+func (v *version) SharedVPCs() SharedVPCInformer {
+	return &sharedVPCInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // StaticRoutes returns a StaticRouteInformer.
 func (v *version) StaticRoutes() StaticRouteInformer {
 	return &staticRouteInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -100,3 +137,8 @@ func (v *version) SubnetSets() SubnetSetInformer {
 func (v *version) VPCNetworkConfigurations() VPCNetworkConfigurationInformer {
 	return &vPCNetworkConfigurationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// VPCPeerings returns a VPCPeeringInformer.
+func (v *version) VPCPeerings() VPCPeeringInformer {
+	return &vPCPeeringInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
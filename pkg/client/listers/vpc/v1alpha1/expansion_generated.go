@@ -13,6 +13,14 @@ type AddressBindingListerExpansion interface{}
 // AddressBindingNamespaceLister.
 type AddressBindingNamespaceListerExpansion interface{}
 
+// EgressIPListerExpansion allows custom methods to be added to
+// EgressIPLister.
+type EgressIPListerExpansion interface{}
+
+// EgressIPNamespaceListerExpansion allows custom methods to be added to
+// EgressIPNamespaceLister.
+type EgressIPNamespaceListerExpansion interface{}
+
 // IPAddressAllocationListerExpansion allows custom methods to be added to
 // IPAddressAllocationLister.
 type IPAddressAllocationListerExpansion interface{}
@@ -25,6 +33,14 @@ type IPAddressAllocationNamespaceListerExpansion interface{}
 // IPBlocksInfoLister.
 type IPBlocksInfoListerExpansion interface{}
 
+// NATRuleListerExpansion allows custom methods to be added to
+// NATRuleLister.
+type NATRuleListerExpansion interface{}
+
+// NATRuleNamespaceListerExpansion allows custom methods to be added to
+// NATRuleNamespaceLister.
+type NATRuleNamespaceListerExpansion interface{}
+
 // NetworkInfoListerExpansion allows custom methods to be added to
 // NetworkInfoLister.
 type NetworkInfoListerExpansion interface{}
@@ -33,6 +49,14 @@ type NetworkInfoListerExpansion interface{}
 // NetworkInfoNamespaceLister.
 type NetworkInfoNamespaceListerExpansion interface{}
 
+// NetworkQuotaListerExpansion allows custom methods to be added to
+// NetworkQuotaLister.
+type NetworkQuotaListerExpansion interface{}
+
+// NetworkQuotaNamespaceListerExpansion allows custom methods to be added to
+// NetworkQuotaNamespaceLister.
+type NetworkQuotaNamespaceListerExpansion interface{}
+
 // SecurityPolicyListerExpansion allows custom methods to be added to
 // SecurityPolicyLister.
 type SecurityPolicyListerExpansion interface{}
@@ -41,6 +65,14 @@ type SecurityPolicyListerExpansion interface{}
 // SecurityPolicyNamespaceLister.
 type SecurityPolicyNamespaceListerExpansion interface{}
 
+// SecurityPolicyTierBindingListerExpansion allows custom methods to be added to
+// SecurityPolicyTierBindingLister.
+type SecurityPolicyTierBindingListerExpansion interface{}
+
+// SharedVPCListerExpansion allows custom methods to be added to
+// SharedVPCLister.
+type SharedVPCListerExpansion interface{}
+
 // StaticRouteListerExpansion allows custom methods to be added to
 // StaticRouteLister.
 type StaticRouteListerExpansion interface{}
@@ -84,3 +116,7 @@ type SubnetSetNamespaceListerExpansion interface{}
 // VPCNetworkConfigurationListerExpansion allows custom methods to be added to
 // VPCNetworkConfigurationLister.
 type VPCNetworkConfigurationListerExpansion interface{}
+
+// VPCPeeringListerExpansion allows custom methods to be added to
+// VPCPeeringLister.
+type VPCPeeringListerExpansion interface{}
@@ -86,6 +86,14 @@ func (operatorConfig *NSXOperatorConfig) GetCACert() []byte {
 	return ca
 }
 
+// GetLabelTagKeys returns the keys of the labels mirrored to NSX tags, or nil if the label propagation is not enabled.
+func (operatorConfig *NSXOperatorConfig) GetLabelTagKeys() []string {
+	if operatorConfig == nil || operatorConfig.K8sConfig == nil {
+		return nil
+	}
+	return removeEmptyItem(operatorConfig.LabelTagKeys)
+}
+
 type configCache struct {
 	// nsxCA stores all file contents of NsxConfig.CaFile in a byte slice
 	nsxCA []byte
@@ -122,6 +130,16 @@ type NsxConfig struct {
 	UseNSXLoadBalancer        *bool    `ini:"use_native_loadbalancer"`
 	RelaxNSXLBScaleValication bool     `ini:"relax_scale_validation"`
 	NSXLBSize                 string   `ini:"service_size"`
+	// Seconds an empty Subnet of a SubnetSet is kept before it's deleted, 0 deletes it at the next garbage collection
+	SubnetSetCompactionGracePeriod int `ini:"subnetset_compaction_grace_period"`
+	// Minimum number of the Subnets kept in a SubnetSet when the empty Subnets are deleted
+	SubnetSetMinSubnets int `ini:"subnetset_min_subnets"`
+	// Interval in seconds to report the IP utilization of Subnets, SubnetSets and NetworkInfos, 0 disables the report
+	SubnetIPUtilizationInterval int `ini:"subnet_ip_utilization_interval"`
+	// Percentage of the allocated IPs above which the IPUtilizationHigh condition is set, 80 will be used if it is not defined
+	SubnetIPUtilizationThreshold int `ini:"subnet_ip_utilization_threshold"`
+	// Number of the realized NSX SubnetPorts kept ready per node and SubnetSet for the new Pods, 0 disables the pool
+	SubnetPortPoolSize int `ini:"subnetport_pool_size"`
 }
 
 type K8sConfig struct {
@@ -131,6 +149,12 @@ type K8sConfig struct {
 	EnableRestore      bool   `ini:"enable_restore"`
 	EnablePromMetrics  bool   `ini:"enable_prometheus_metrics"`
 	KubeConfigFile     string `ini:"kubeconfig"`
+	// Reject the SecurityPolicy with shadowed or conflicting rules at admission instead of warning
+	RejectSecurityPolicyConflict bool `ini:"reject_security_policy_conflict"`
+	// Interval in seconds to collect the NSX rule statistics of SecurityPolicy and NetworkPolicy, 0 disables the collection
+	RuleStatisticsInterval int `ini:"rule_statistics_interval"`
+	// Keys of the Namespace and object labels mirrored to the NSX tags of VPCs, Subnets, SubnetPorts and security groups
+	LabelTagKeys []string `ini:"label_tag_keys"`
 	// Controlled by FSS
 	EnableAntreaNSXInterworking bool `ini:"enable_antrea_nsx_interworking"`
 }
@@ -0,0 +1,189 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
+)
+
+// DefaultIPUtilizationThreshold is the percentage of the allocated IPs above which the IPUtilizationHigh
+// condition is set if the threshold is not configured.
+const DefaultIPUtilizationThreshold = 80
+
+const (
+	ReasonIPUtilizationHigh   = "IPUtilizationHigh"
+	ReasonIPUtilizationNormal = "IPUtilizationNormal"
+)
+
+// IPUtilizationCollector reports the IP utilization of the Subnets, SubnetSets and NetworkInfos. The IP
+// utilization is published as metrics and updated in the status, and the IPUtilizationHigh condition is
+// set on the Subnets and SubnetSets whose IP utilization is above the threshold.
+type IPUtilizationCollector struct {
+	Client            k8sclient.Client
+	SubnetService     *subnet.SubnetService
+	SubnetPortService servicecommon.SubnetPortServiceProvider
+	Recorder          record.EventRecorder
+	Threshold         int
+}
+
+func (c *IPUtilizationCollector) CollectIPUtilization(ctx context.Context) {
+	log.V(1).Info("Subnet IP utilization collector started")
+	// Reset the metrics to remove the deleted Subnets.
+	metrics.SubnetIPTotal.Reset()
+	metrics.SubnetIPAllocated.Reset()
+	metrics.SubnetIPAvailable.Reset()
+	namespaceIPUtilization := map[string]*v1alpha1.IPUtilization{}
+
+	subnetList := &v1alpha1.SubnetList{}
+	if err := c.Client.List(ctx, subnetList); err != nil {
+		log.Error(err, "Failed to list Subnets")
+	}
+	for i := range subnetList.Items {
+		obj := &subnetList.Items[i]
+		nsxSubnets := c.SubnetService.SubnetStore.GetByIndex(servicecommon.TagScopeSubnetCRUID, string(obj.UID))
+		if len(nsxSubnets) == 0 {
+			continue
+		}
+		ipUtilization := c.SubnetPortService.GetIPUtilization(nsxSubnets[0])
+		setSubnetIPUtilizationMetrics(obj.Namespace, MetricResTypeSubnet, obj.Name, *nsxSubnets[0].Id, ipUtilization)
+		namespaceIPUtilization[obj.Namespace] = subnet.AddIPUtilization(namespaceIPUtilization[obj.Namespace], ipUtilization)
+		if err := c.updateSubnetIPUtilization(ctx, obj, ipUtilization); err != nil {
+			log.Error(err, "Failed to update Subnet IP utilization", "Subnet", obj.Namespace+"/"+obj.Name)
+		}
+	}
+
+	subnetSetList := &v1alpha1.SubnetSetList{}
+	if err := c.Client.List(ctx, subnetSetList); err != nil {
+		log.Error(err, "Failed to list SubnetSets")
+	}
+	for i := range subnetSetList.Items {
+		obj := &subnetSetList.Items[i]
+		nsxSubnets := c.SubnetService.SubnetStore.GetByIndex(servicecommon.TagScopeSubnetSetCRUID, string(obj.UID))
+		if len(nsxSubnets) == 0 {
+			continue
+		}
+		var ipUtilization *v1alpha1.IPUtilization
+		for _, nsxSubnet := range nsxSubnets {
+			subnetIPUtilization := c.SubnetPortService.GetIPUtilization(nsxSubnet)
+			setSubnetIPUtilizationMetrics(obj.Namespace, MetricResTypeSubnetSet, obj.Name, *nsxSubnet.Id, subnetIPUtilization)
+			ipUtilization = subnet.AddIPUtilization(ipUtilization, subnetIPUtilization)
+		}
+		namespaceIPUtilization[obj.Namespace] = subnet.AddIPUtilization(namespaceIPUtilization[obj.Namespace], *ipUtilization)
+		if err := c.updateSubnetSetIPUtilization(ctx, obj, *ipUtilization); err != nil {
+			log.Error(err, "Failed to update SubnetSet IP utilization", "SubnetSet", obj.Namespace+"/"+obj.Name)
+		}
+	}
+
+	networkInfoList := &v1alpha1.NetworkInfoList{}
+	if err := c.Client.List(ctx, networkInfoList); err != nil {
+		log.Error(err, "Failed to list NetworkInfos")
+	}
+	for i := range networkInfoList.Items {
+		obj := &networkInfoList.Items[i]
+		if err := c.updateNetworkInfoIPUtilization(ctx, obj, namespaceIPUtilization[obj.Namespace]); err != nil {
+			log.Error(err, "Failed to update NetworkInfo IP utilization", "NetworkInfo", obj.Namespace+"/"+obj.Name)
+		}
+	}
+}
+
+func setSubnetIPUtilizationMetrics(namespace, kind, name, nsxSubnetID string, ipUtilization v1alpha1.IPUtilization) {
+	labels := []string{namespace, kind, name, nsxSubnetID}
+	metrics.SubnetIPTotal.WithLabelValues(labels...).Set(float64(ipUtilization.Total))
+	metrics.SubnetIPAllocated.WithLabelValues(labels...).Set(float64(ipUtilization.Allocated))
+	metrics.SubnetIPAvailable.WithLabelValues(labels...).Set(float64(ipUtilization.Available))
+}
+
+func (c *IPUtilizationCollector) updateSubnetIPUtilization(ctx context.Context, obj *v1alpha1.Subnet, ipUtilization v1alpha1.IPUtilization) error {
+	utilizationUpdated := obj.Status.IPUtilization == nil || *obj.Status.IPUtilization != ipUtilization
+	obj.Status.IPUtilization = &ipUtilization
+	conditionUpdated := c.mergeIPUtilizationCondition(obj, &obj.Status.Conditions, ipUtilization)
+	if !utilizationUpdated && !conditionUpdated {
+		return nil
+	}
+	return c.Client.Status().Update(ctx, obj)
+}
+
+func (c *IPUtilizationCollector) updateSubnetSetIPUtilization(ctx context.Context, obj *v1alpha1.SubnetSet, ipUtilization v1alpha1.IPUtilization) error {
+	utilizationUpdated := obj.Status.IPUtilization == nil || *obj.Status.IPUtilization != ipUtilization
+	conditionUpdated := c.mergeIPUtilizationCondition(obj, &obj.Status.Conditions, ipUtilization)
+	if utilizationUpdated {
+		// Rebuild the status of all the Subnets of the SubnetSet, the condition is updated as well.
+		return c.SubnetService.UpdateSubnetSetStatus(obj)
+	}
+	if conditionUpdated {
+		return c.Client.Status().Update(ctx, obj)
+	}
+	return nil
+}
+
+func (c *IPUtilizationCollector) updateNetworkInfoIPUtilization(ctx context.Context, obj *v1alpha1.NetworkInfo, ipUtilization *v1alpha1.IPUtilization) error {
+	// The NetworkInfo without VPC is not ready yet.
+	if len(obj.VPCs) == 0 {
+		return nil
+	}
+	existing := obj.VPCs[0].IPUtilization
+	if existing == ipUtilization || (existing != nil && ipUtilization != nil && *existing == *ipUtilization) {
+		return nil
+	}
+	obj.VPCs[0].IPUtilization = ipUtilization
+	return c.Client.Update(ctx, obj)
+}
+
+// mergeIPUtilizationCondition sets the IPUtilizationHigh condition according to the IP utilization, and
+// records a warning event when the IP utilization goes above the threshold. It returns true if the
+// conditions are updated.
+func (c *IPUtilizationCollector) mergeIPUtilizationCondition(obj k8sclient.Object, conditions *[]v1alpha1.Condition, ipUtilization v1alpha1.IPUtilization) bool {
+	threshold := c.Threshold
+	if threshold <= 0 {
+		threshold = DefaultIPUtilizationThreshold
+	}
+	newCondition := v1alpha1.Condition{
+		Type:    v1alpha1.IPUtilizationHigh,
+		Status:  v1.ConditionFalse,
+		Reason:  ReasonIPUtilizationNormal,
+		Message: fmt.Sprintf("%d of %d IPs are allocated", ipUtilization.Allocated, ipUtilization.Total),
+	}
+	if ipUtilization.Total > 0 && ipUtilization.Allocated*100 >= ipUtilization.Total*threshold {
+		newCondition.Status = v1.ConditionTrue
+		newCondition.Reason = ReasonIPUtilizationHigh
+		newCondition.Message = fmt.Sprintf("%d of %d IPs are allocated, the IP utilization is above %d%%", ipUtilization.Allocated, ipUtilization.Total, threshold)
+	}
+
+	for i := range *conditions {
+		existing := &(*conditions)[i]
+		if existing.Type != newCondition.Type {
+			continue
+		}
+		if existing.Status == newCondition.Status && existing.Reason == newCondition.Reason && existing.Message == newCondition.Message {
+			return false
+		}
+		if existing.Status != newCondition.Status {
+			existing.LastTransitionTime = metav1.Now()
+			if newCondition.Status == v1.ConditionTrue {
+				c.Recorder.Event(obj, v1.EventTypeWarning, ReasonIPUtilizationHigh, newCondition.Message)
+			}
+		}
+		existing.Status = newCondition.Status
+		existing.Reason = newCondition.Reason
+		existing.Message = newCondition.Message
+		return true
+	}
+	newCondition.LastTransitionTime = metav1.Now()
+	if newCondition.Status == v1.ConditionTrue {
+		c.Recorder.Event(obj, v1.EventTypeWarning, ReasonIPUtilizationHigh, newCondition.Message)
+	}
+	*conditions = append(*conditions, newCondition)
+	return true
+}
@@ -0,0 +1,102 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	pkg_mock "github.com/vmware-tanzu/nsx-operator/pkg/mock"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
+)
+
+func TestIPUtilizationCollector_CollectIPUtilization(t *testing.T) {
+	scheme := runtime.NewScheme()
+	v1alpha1.AddToScheme(scheme)
+	subnetCR := &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet-1", Namespace: "ns-1", UID: "subnet-uid-1"}}
+	subnetSetCR := &v1alpha1.SubnetSet{ObjectMeta: metav1.ObjectMeta{Name: "subnetset-1", Namespace: "ns-1", UID: "subnetset-uid-1"}}
+	networkInfo := &v1alpha1.NetworkInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns-1", Namespace: "ns-1"},
+		VPCs:       []v1alpha1.VPCState{{Name: "vpc-1"}},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(subnetCR, subnetSetCR, networkInfo).WithStatusSubresource(subnetCR, subnetSetCR).Build()
+
+	nsxSubnet := &model.VpcSubnet{Id: servicecommon.String("subnet-1"), Path: servicecommon.String("subnet-path-1")}
+	nsxSubnetSetSubnets := []*model.VpcSubnet{
+		{Id: servicecommon.String("subnetset-subnet-1"), Path: servicecommon.String("subnet-path-2")},
+		{Id: servicecommon.String("subnetset-subnet-2"), Path: servicecommon.String("subnet-path-3")},
+	}
+	subnetService := &subnet.SubnetService{SubnetStore: &subnet.SubnetStore{}}
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(subnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+		if key == servicecommon.TagScopeSubnetCRUID {
+			return []*model.VpcSubnet{nsxSubnet}
+		}
+		return nsxSubnetSetSubnets
+	})
+	defer patches.Reset()
+	updateSubnetSetStatusCalled := 0
+	patches.ApplyMethod(reflect.TypeOf(subnetService), "UpdateSubnetSetStatus", func(_ *subnet.SubnetService, obj *v1alpha1.SubnetSet) error {
+		updateSubnetSetStatusCalled++
+		return fakeClient.Status().Update(context.TODO(), obj)
+	})
+
+	spsp := &pkg_mock.MockSubnetPortServiceProvider{}
+	spsp.On("GetIPUtilization", nsxSubnet).Return(v1alpha1.IPUtilization{Total: 12, Allocated: 11, Available: 1})
+	spsp.On("GetIPUtilization", nsxSubnetSetSubnets[0]).Return(v1alpha1.IPUtilization{Total: 12, Allocated: 2, Available: 10})
+	spsp.On("GetIPUtilization", nsxSubnetSetSubnets[1]).Return(v1alpha1.IPUtilization{Total: 28, Allocated: 3, Available: 25})
+	recorder := record.NewFakeRecorder(10)
+	collector := &IPUtilizationCollector{
+		Client:            fakeClient,
+		SubnetService:     subnetService,
+		SubnetPortService: spsp,
+		Recorder:          recorder,
+	}
+	collector.CollectIPUtilization(context.TODO())
+
+	// The Subnet is above the default threshold.
+	updatedSubnet := &v1alpha1.Subnet{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "ns-1", Name: "subnet-1"}, updatedSubnet))
+	assert.Equal(t, &v1alpha1.IPUtilization{Total: 12, Allocated: 11, Available: 1}, updatedSubnet.Status.IPUtilization)
+	assert.Equal(t, 1, len(updatedSubnet.Status.Conditions))
+	assert.Equal(t, v1alpha1.IPUtilizationHigh, updatedSubnet.Status.Conditions[0].Type)
+	assert.Equal(t, v1.ConditionTrue, updatedSubnet.Status.Conditions[0].Status)
+	assert.Equal(t, "Warning IPUtilizationHigh 11 of 12 IPs are allocated, the IP utilization is above 80%", <-recorder.Events)
+
+	// The IP utilization of the SubnetSet is aggregated.
+	updatedSubnetSet := &v1alpha1.SubnetSet{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "ns-1", Name: "subnetset-1"}, updatedSubnetSet))
+	assert.Equal(t, 1, updateSubnetSetStatusCalled)
+	assert.Equal(t, 1, len(updatedSubnetSet.Status.Conditions))
+	assert.Equal(t, v1.ConditionFalse, updatedSubnetSet.Status.Conditions[0].Status)
+	assert.Equal(t, ReasonIPUtilizationNormal, updatedSubnetSet.Status.Conditions[0].Reason)
+
+	// The IP utilization of the Namespace is reported in the NetworkInfo.
+	updatedNetworkInfo := &v1alpha1.NetworkInfo{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "ns-1", Name: "ns-1"}, updatedNetworkInfo))
+	assert.Equal(t, &v1alpha1.IPUtilization{Total: 52, Allocated: 16, Available: 36}, updatedNetworkInfo.VPCs[0].IPUtilization)
+
+	// The SubnetSet status is not rebuilt if the IP utilization is not changed.
+	updatedSubnetSet.Status.IPUtilization = &v1alpha1.IPUtilization{Total: 40, Allocated: 5, Available: 35}
+	assert.NoError(t, collector.updateSubnetSetIPUtilization(context.TODO(), updatedSubnetSet, v1alpha1.IPUtilization{Total: 40, Allocated: 5, Available: 35}))
+	assert.Equal(t, 1, updateSubnetSetStatusCalled)
+
+	// The condition is reset when the IP utilization goes down.
+	collector.Threshold = 95
+	assert.True(t, collector.mergeIPUtilizationCondition(updatedSubnet, &updatedSubnet.Status.Conditions, *updatedSubnet.Status.IPUtilization))
+	assert.Equal(t, v1.ConditionFalse, updatedSubnet.Status.Conditions[0].Status)
+	assert.False(t, collector.mergeIPUtilizationCondition(updatedSubnet, &updatedSubnet.Status.Conditions, *updatedSubnet.Status.IPUtilization))
+}
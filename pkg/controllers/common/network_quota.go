@@ -0,0 +1,206 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+// NetworkQuotaUsage is the amount of the network resources limited by the NetworkQuotas, either used in a
+// Namespace or requested by a resource being created.
+type NetworkQuotaUsage struct {
+	Subnets      int64
+	SubnetIPs    int64
+	SubnetPorts  int64
+	ExternalIPs  int64
+	StaticRoutes int64
+}
+
+func (u *NetworkQuotaUsage) Add(other NetworkQuotaUsage) {
+	u.Subnets += other.Subnets
+	u.SubnetIPs += other.SubnetIPs
+	u.SubnetPorts += other.SubnetPorts
+	u.ExternalIPs += other.ExternalIPs
+	u.StaticRoutes += other.StaticRoutes
+}
+
+// ToResources converts the usage to the status of NetworkQuota.
+func (u NetworkQuotaUsage) ToResources() v1alpha1.NetworkQuotaResources {
+	return v1alpha1.NetworkQuotaResources{
+		Subnets:      servicecommon.Int64(u.Subnets),
+		SubnetIPs:    servicecommon.Int64(u.SubnetIPs),
+		SubnetPorts:  servicecommon.Int64(u.SubnetPorts),
+		ExternalIPs:  servicecommon.Int64(u.ExternalIPs),
+		StaticRoutes: servicecommon.Int64(u.StaticRoutes),
+	}
+}
+
+// exceeded returns the names of the resources whose usage plus the requested amount is above the hard limit.
+func (u NetworkQuotaUsage) exceeded(requested NetworkQuotaUsage, hard v1alpha1.NetworkQuotaResources) []string {
+	var names []string
+	check := func(name string, used, req int64, limit *int64) {
+		if limit != nil && req > 0 && used+req > *limit {
+			names = append(names, fmt.Sprintf("%s (used %d, requested %d, limit %d)", name, used, req, *limit))
+		}
+	}
+	check("subnets", u.Subnets, requested.Subnets, hard.Subnets)
+	check("subnetIPs", u.SubnetIPs, requested.SubnetIPs, hard.SubnetIPs)
+	check("subnetPorts", u.SubnetPorts, requested.SubnetPorts, hard.SubnetPorts)
+	check("externalIPs", u.ExternalIPs, requested.ExternalIPs, hard.ExternalIPs)
+	check("staticRoutes", u.StaticRoutes, requested.StaticRoutes, hard.StaticRoutes)
+	return names
+}
+
+// DefaultSubnetSizeOfNamespace returns the default IPv4 Subnet size of the VPCNetworkConfiguration used by the
+// Namespace, which is used by the Subnets and SubnetSets without ipv4SubnetSize.
+func DefaultSubnetSizeOfNamespace(vpcService servicecommon.VPCServiceProvider, ns string) int64 {
+	if vpcService == nil {
+		return 0
+	}
+	vpcNetworkConfig := vpcService.GetVPCNetworkConfigByNamespace(ns)
+	if vpcNetworkConfig == nil {
+		return 0
+	}
+	return int64(vpcNetworkConfig.DefaultSubnetSize)
+}
+
+// countIPv4Addresses returns the count of the IPv4 addresses in the CIDRs, the invalid and IPv6 CIDRs are ignored.
+func countIPv4Addresses(cidrs []string) int64 {
+	var count int64
+	for _, cidr := range cidrs {
+		if ipFamily, err := util.GetIPFamily(cidr); err != nil || ipFamily != v1alpha1.IPFamilyIPv4 {
+			continue
+		}
+		if prefix, err := util.GetIPPrefix(cidr); err == nil && prefix >= 0 && prefix <= 32 {
+			count += util.CalculateSubnetSize(prefix)
+		}
+	}
+	return count
+}
+
+// SubnetQuotaUsage returns the network resources used by a Subnet. The IPv4 addresses are counted from the
+// realized CIDRs, or from the spec if the Subnet is not realized yet.
+func SubnetQuotaUsage(subnet *v1alpha1.Subnet, defaultSubnetSize int64) NetworkQuotaUsage {
+	usage := NetworkQuotaUsage{Subnets: 1}
+	switch {
+	case len(subnet.Status.NetworkAddresses) > 0:
+		usage.SubnetIPs = countIPv4Addresses(subnet.Status.NetworkAddresses)
+	case subnet.Spec.NSXSubnetPath != "":
+		// The size of an imported NSX Subnet is unknown until it is realized.
+	case len(subnet.Spec.IPAddresses) > 0:
+		usage.SubnetIPs = countIPv4Addresses(subnet.Spec.IPAddresses)
+	case subnet.Spec.IPv4SubnetSize != 0:
+		usage.SubnetIPs = int64(subnet.Spec.IPv4SubnetSize)
+	case util.HasIPFamily(subnet.Spec.IPFamilies, v1alpha1.IPFamilyIPv4):
+		usage.SubnetIPs = defaultSubnetSize
+	}
+	return usage
+}
+
+// SubnetSetQuotaUsage returns the network resources used by a SubnetSet. A SubnetSet without any realized
+// Subnet is counted as one Subnet with the size in the spec, as its first Subnet will be created on demand.
+func SubnetSetQuotaUsage(subnetSet *v1alpha1.SubnetSet, defaultSubnetSize int64) NetworkQuotaUsage {
+	if len(subnetSet.Status.Subnets) > 0 {
+		usage := NetworkQuotaUsage{Subnets: int64(len(subnetSet.Status.Subnets))}
+		for _, subnetInfo := range subnetSet.Status.Subnets {
+			usage.SubnetIPs += countIPv4Addresses(subnetInfo.NetworkAddresses)
+		}
+		return usage
+	}
+	usage := NetworkQuotaUsage{Subnets: 1}
+	if subnetSet.Spec.IPv4SubnetSize != 0 {
+		usage.SubnetIPs = int64(subnetSet.Spec.IPv4SubnetSize)
+	} else if util.HasIPFamily(subnetSet.Spec.IPFamilies, v1alpha1.IPFamilyIPv4) {
+		usage.SubnetIPs = defaultSubnetSize
+	}
+	return usage
+}
+
+// IPAddressAllocationQuotaUsage returns the network resources used by an IPAddressAllocation, only the IP
+// addresses allocated from the external IPBlocks are limited.
+func IPAddressAllocationQuotaUsage(allocation *v1alpha1.IPAddressAllocation) NetworkQuotaUsage {
+	if allocation.Spec.IPAddressBlockVisibility != v1alpha1.IPAddressVisibilityExternal {
+		return NetworkQuotaUsage{}
+	}
+	return NetworkQuotaUsage{ExternalIPs: int64(allocation.Spec.AllocationSize)}
+}
+
+// ComputeNetworkQuotaUsage returns the network resources used in the Namespace.
+func ComputeNetworkQuotaUsage(ctx context.Context, client k8sclient.Client, vpcService servicecommon.VPCServiceProvider, ns string) (*NetworkQuotaUsage, error) {
+	usage := &NetworkQuotaUsage{}
+	defaultSubnetSize := DefaultSubnetSizeOfNamespace(vpcService, ns)
+
+	subnetList := &v1alpha1.SubnetList{}
+	if err := client.List(ctx, subnetList, k8sclient.InNamespace(ns)); err != nil {
+		return nil, fmt.Errorf("failed to list Subnets: %w", err)
+	}
+	for i := range subnetList.Items {
+		usage.Add(SubnetQuotaUsage(&subnetList.Items[i], defaultSubnetSize))
+	}
+
+	subnetSetList := &v1alpha1.SubnetSetList{}
+	if err := client.List(ctx, subnetSetList, k8sclient.InNamespace(ns)); err != nil {
+		return nil, fmt.Errorf("failed to list SubnetSets: %w", err)
+	}
+	for i := range subnetSetList.Items {
+		usage.Add(SubnetSetQuotaUsage(&subnetSetList.Items[i], defaultSubnetSize))
+	}
+
+	subnetPortList := &v1alpha1.SubnetPortList{}
+	if err := client.List(ctx, subnetPortList, k8sclient.InNamespace(ns)); err != nil {
+		return nil, fmt.Errorf("failed to list SubnetPorts: %w", err)
+	}
+	usage.SubnetPorts = int64(len(subnetPortList.Items))
+
+	allocationList := &v1alpha1.IPAddressAllocationList{}
+	if err := client.List(ctx, allocationList, k8sclient.InNamespace(ns)); err != nil {
+		return nil, fmt.Errorf("failed to list IPAddressAllocations: %w", err)
+	}
+	for i := range allocationList.Items {
+		usage.Add(IPAddressAllocationQuotaUsage(&allocationList.Items[i]))
+	}
+
+	staticRouteList := &v1alpha1.StaticRouteList{}
+	if err := client.List(ctx, staticRouteList, k8sclient.InNamespace(ns)); err != nil {
+		return nil, fmt.Errorf("failed to list StaticRoutes: %w", err)
+	}
+	usage.StaticRoutes = int64(len(staticRouteList.Items))
+	return usage, nil
+}
+
+// CheckNetworkQuota checks if the requested network resources are allowed by all the NetworkQuotas in the
+// Namespace. It returns a message describing the exceeded NetworkQuotas, or an empty string if the request
+// is allowed. The vpcService is only used to get the default size of the Subnets, it can be nil if no Subnet IP
+// is requested.
+func CheckNetworkQuota(ctx context.Context, client k8sclient.Client, vpcService servicecommon.VPCServiceProvider, ns string, requested NetworkQuotaUsage) (string, error) {
+	if requested == (NetworkQuotaUsage{}) {
+		return "", nil
+	}
+	quotaList := &v1alpha1.NetworkQuotaList{}
+	if err := client.List(ctx, quotaList, k8sclient.InNamespace(ns)); err != nil {
+		return "", fmt.Errorf("failed to list NetworkQuotas: %w", err)
+	}
+	if len(quotaList.Items) == 0 {
+		return "", nil
+	}
+	usage, err := ComputeNetworkQuotaUsage(ctx, client, vpcService, ns)
+	if err != nil {
+		return "", err
+	}
+	var messages []string
+	for _, quota := range quotaList.Items {
+		if exceeded := usage.exceeded(requested, quota.Spec.Hard); len(exceeded) > 0 {
+			messages = append(messages, fmt.Sprintf("NetworkQuota %s exceeded: %s", quota.Name, strings.Join(exceeded, ", ")))
+		}
+	}
+	return strings.Join(messages, "; "), nil
+}
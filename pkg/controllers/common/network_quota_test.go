@@ -0,0 +1,161 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+type fakeQuotaVPCService struct {
+	servicecommon.VPCServiceProvider
+	defaultSubnetSize int
+}
+
+func (s *fakeQuotaVPCService) GetVPCNetworkConfigByNamespace(ns string) *servicecommon.VPCNetworkConfigInfo {
+	return &servicecommon.VPCNetworkConfigInfo{DefaultSubnetSize: s.defaultSubnetSize}
+}
+
+func TestSubnetQuotaUsage(t *testing.T) {
+	tests := []struct {
+		name   string
+		subnet *v1alpha1.Subnet
+		want   NetworkQuotaUsage
+	}{
+		{
+			name:   "realized",
+			subnet: &v1alpha1.Subnet{Spec: v1alpha1.SubnetSpec{IPv4SubnetSize: 16}, Status: v1alpha1.SubnetStatus{NetworkAddresses: []string{"10.0.0.0/26", "2001:db8::/64"}}},
+			want:   NetworkQuotaUsage{Subnets: 1, SubnetIPs: 64},
+		},
+		{
+			name:   "size in spec",
+			subnet: &v1alpha1.Subnet{Spec: v1alpha1.SubnetSpec{IPv4SubnetSize: 32}},
+			want:   NetworkQuotaUsage{Subnets: 1, SubnetIPs: 32},
+		},
+		{
+			name:   "CIDR in spec",
+			subnet: &v1alpha1.Subnet{Spec: v1alpha1.SubnetSpec{IPAddresses: []string{"10.0.0.0/28"}}},
+			want:   NetworkQuotaUsage{Subnets: 1, SubnetIPs: 16},
+		},
+		{
+			name:   "default size",
+			subnet: &v1alpha1.Subnet{},
+			want:   NetworkQuotaUsage{Subnets: 1, SubnetIPs: 128},
+		},
+		{
+			name:   "IPv6 only",
+			subnet: &v1alpha1.Subnet{Spec: v1alpha1.SubnetSpec{IPFamilies: []v1alpha1.IPFamily{v1alpha1.IPFamilyIPv6}}},
+			want:   NetworkQuotaUsage{Subnets: 1},
+		},
+		{
+			name:   "imported",
+			subnet: &v1alpha1.Subnet{Spec: v1alpha1.SubnetSpec{NSXSubnetPath: "/orgs/default/projects/p1/vpcs/vpc1/subnets/s1"}},
+			want:   NetworkQuotaUsage{Subnets: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SubnetQuotaUsage(tt.subnet, 128))
+		})
+	}
+}
+
+func TestSubnetSetQuotaUsage(t *testing.T) {
+	subnetSet := &v1alpha1.SubnetSet{}
+	assert.Equal(t, NetworkQuotaUsage{Subnets: 1, SubnetIPs: 64}, SubnetSetQuotaUsage(subnetSet, 64))
+
+	subnetSet.Spec.IPv4SubnetSize = 32
+	assert.Equal(t, NetworkQuotaUsage{Subnets: 1, SubnetIPs: 32}, SubnetSetQuotaUsage(subnetSet, 64))
+
+	subnetSet.Status.Subnets = []v1alpha1.SubnetInfo{
+		{NetworkAddresses: []string{"10.0.0.0/27"}},
+		{NetworkAddresses: []string{"10.0.0.32/27"}},
+	}
+	assert.Equal(t, NetworkQuotaUsage{Subnets: 2, SubnetIPs: 64}, SubnetSetQuotaUsage(subnetSet, 64))
+}
+
+func TestCheckNetworkQuota(t *testing.T) {
+	scheme := clientgoscheme.Scheme
+	v1alpha1.AddToScheme(scheme)
+	ctx := context.TODO()
+	vpcService := &fakeQuotaVPCService{defaultSubnetSize: 32}
+	objs := func(quotas ...*v1alpha1.NetworkQuota) *fake.ClientBuilder {
+		builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "subnet1"}},
+			&v1alpha1.SubnetSet{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "subnetset1"}, Spec: v1alpha1.SubnetSetSpec{IPv4SubnetSize: 64}},
+			&v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "subnet2"}},
+			&v1alpha1.SubnetPort{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "port1"}},
+			&v1alpha1.IPAddressAllocation{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "ipa1"}, Spec: v1alpha1.IPAddressAllocationSpec{IPAddressBlockVisibility: v1alpha1.IPAddressVisibilityExternal, AllocationSize: 4}},
+			&v1alpha1.IPAddressAllocation{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "ipa2"}, Spec: v1alpha1.IPAddressAllocationSpec{IPAddressBlockVisibility: v1alpha1.IPAddressVisibilityPrivate, AllocationSize: 8}},
+			&v1alpha1.StaticRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "route1"}},
+		)
+		for _, quota := range quotas {
+			builder.WithObjects(quota)
+		}
+		return builder
+	}
+	newQuota := func(name string, hard v1alpha1.NetworkQuotaResources) *v1alpha1.NetworkQuota {
+		return &v1alpha1.NetworkQuota{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name}, Spec: v1alpha1.NetworkQuotaSpec{Hard: hard}}
+	}
+
+	usage, err := ComputeNetworkQuotaUsage(ctx, objs().Build(), vpcService, "ns1")
+	require.NoError(t, err)
+	assert.Equal(t, &NetworkQuotaUsage{Subnets: 2, SubnetIPs: 96, SubnetPorts: 1, ExternalIPs: 4, StaticRoutes: 1}, usage)
+
+	tests := []struct {
+		name      string
+		quotas    []*v1alpha1.NetworkQuota
+		requested NetworkQuotaUsage
+		want      string
+	}{
+		{
+			name:      "no NetworkQuota",
+			requested: NetworkQuotaUsage{Subnets: 100},
+		},
+		{
+			name:      "within limit",
+			quotas:    []*v1alpha1.NetworkQuota{newQuota("quota1", v1alpha1.NetworkQuotaResources{Subnets: servicecommon.Int64(3), SubnetIPs: servicecommon.Int64(128)})},
+			requested: NetworkQuotaUsage{Subnets: 1, SubnetIPs: 32},
+		},
+		{
+			name:      "unlimited resource",
+			quotas:    []*v1alpha1.NetworkQuota{newQuota("quota1", v1alpha1.NetworkQuotaResources{Subnets: servicecommon.Int64(2)})},
+			requested: NetworkQuotaUsage{StaticRoutes: 10},
+		},
+		{
+			name: "exceeded",
+			quotas: []*v1alpha1.NetworkQuota{
+				newQuota("quota1", v1alpha1.NetworkQuotaResources{Subnets: servicecommon.Int64(3), SubnetIPs: servicecommon.Int64(100)}),
+				newQuota("quota2", v1alpha1.NetworkQuotaResources{Subnets: servicecommon.Int64(10)}),
+			},
+			requested: NetworkQuotaUsage{Subnets: 1, SubnetIPs: 32},
+			want:      "NetworkQuota quota1 exceeded: subnetIPs (used 96, requested 32, limit 100)",
+		},
+		{
+			name: "exceeded by multiple NetworkQuotas",
+			quotas: []*v1alpha1.NetworkQuota{
+				newQuota("quota1", v1alpha1.NetworkQuotaResources{ExternalIPs: servicecommon.Int64(6)}),
+				newQuota("quota2", v1alpha1.NetworkQuotaResources{ExternalIPs: servicecommon.Int64(4)}),
+			},
+			requested: NetworkQuotaUsage{ExternalIPs: 4},
+			want:      "NetworkQuota quota1 exceeded: externalIPs (used 4, requested 4, limit 6); NetworkQuota quota2 exceeded: externalIPs (used 4, requested 4, limit 4)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := CheckNetworkQuota(ctx, objs(tt.quotas...).Build(), vpcService, "ns1", tt.requested)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, msg)
+		})
+	}
+}
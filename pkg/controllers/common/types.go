@@ -15,6 +15,11 @@ const (
 	MetricResTypeNSXServiceAccount          = "nsxserviceaccount"
 	MetricResTypeSubnetPort                 = "subnetport"
 	MetricResTypeStaticRoute                = "staticroute"
+	MetricResTypeNATRule                    = "natrule"
+	MetricResTypeVPCPeering                 = "vpcpeering"
+	MetricResTypeEgressIP                   = "egressip"
+	MetricResTypeSharedVPC                  = "sharedvpc"
+	MetricResTypeNetworkQuota               = "networkquota"
 	MetricResTypeSubnet                     = "subnet"
 	MetricResTypeSubnetSet                  = "subnetset"
 	MetricResTypeSubnetConnectionBindingMap = "subnetconnectionbindingmap"
@@ -47,6 +52,7 @@ const (
 	ReasonSuccessfulUpdate = "SuccessfulUpdate"
 	ReasonFailDelete       = "FailDelete"
 	ReasonFailUpdate       = "FailUpdate"
+	ReasonSubnetCompacted  = "SubnetCompacted"
 )
 
 // GarbageCollector interface with collectGarbage method
@@ -4,55 +4,185 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
 	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
 	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
 	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
 var (
 	log            = &logger.Log
 	SubnetSetLocks sync.Map
+	// subnetSetPreCreating records the SubnetSets which are creating the Subnet in advance.
+	subnetSetPreCreating sync.Map
 )
 
-func AllocateSubnetFromSubnetSet(subnetSet *v1alpha1.SubnetSet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider, subnetPortService servicecommon.SubnetPortServiceProvider) (string, error) {
+func AllocateSubnetFromSubnetSet(client k8sclient.Client, subnetSet *v1alpha1.SubnetSet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider, subnetPortService servicecommon.SubnetPortServiceProvider) (string, error) {
 	// Use SubnetSet uuid lock to make sure when multiple ports are created on the same SubnetSet, only one Subnet will be created
 	subnetSetLock := LockSubnetSet(subnetSet.GetUID())
 	defer UnlockSubnetSet(subnetSet.GetUID(), subnetSetLock)
-	subnetList := subnetService.GetSubnetsByIndex(servicecommon.TagScopeSubnetSetCRUID, string(subnetSet.GetUID()))
+	subnetList := getSubnetsOfSubnetSet(subnetSet, subnetService)
+	if subnetSet.Spec.AllocationStrategy == v1alpha1.AllocationStrategySpread {
+		freeIPs := make(map[*model.VpcSubnet]int, len(subnetList))
+		for _, nsxSubnet := range subnetList {
+			freeIPs[nsxSubnet] = subnetPortService.GetFreeIPCount(nsxSubnet)
+		}
+		subnetList = slices.Clone(subnetList)
+		sort.SliceStable(subnetList, func(i, j int) bool {
+			return freeIPs[subnetList[i]] > freeIPs[subnetList[j]]
+		})
+	}
 	for _, nsxSubnet := range subnetList {
 		if subnetPortService.AllocatePortFromSubnet(nsxSubnet) {
+			if needPreCreateSubnet(subnetSet, subnetList, subnetPortService) {
+				go preCreateSubnet(client, subnetSet.DeepCopy(), vpcService, subnetService, subnetPortService)
+			}
 			return *nsxSubnet.Path, nil
 		}
 	}
+	log.Info("The existing subnets are not available, creating new subnet", "subnetList", subnetList, "subnetSet.Name", subnetSet.Name, "subnetSet.Namespace", subnetSet.Namespace)
+	nsxSubnet, err := createSubnetForSubnetSet(client, subnetSet, subnetList, vpcService, subnetService)
+	if err != nil {
+		return "", err
+	}
+	subnetPortService.AllocatePortFromSubnet(nsxSubnet)
+	return *nsxSubnet.Path, nil
+}
+
+// getSubnetsOfSubnetSet returns the NSX Subnets of the SubnetSet to allocate the SubnetPorts, the NSX Subnets
+// whose access mode is changed are skipped as they are deleted by the SubnetSet controller.
+func getSubnetsOfSubnetSet(subnetSet *v1alpha1.SubnetSet, subnetService servicecommon.SubnetServiceProvider) []*model.VpcSubnet {
+	subnetList := subnetService.GetSubnetsByIndex(servicecommon.TagScopeSubnetSetCRUID, string(subnetSet.GetUID()))
+	subnets := make([]*model.VpcSubnet, 0, len(subnetList))
+	for _, nsxSubnet := range subnetList {
+		if !subnet.IsAccessModeChanged(nsxSubnet, subnetSet.Spec.AccessMode) {
+			subnets = append(subnets, nsxSubnet)
+		}
+	}
+	return subnets
+}
+
+func createSubnetForSubnetSet(client k8sclient.Client, subnetSet *v1alpha1.SubnetSet, subnetList []*model.VpcSubnet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider) (*model.VpcSubnet, error) {
+	if subnetSet.Spec.MaxSubnets > 0 && len(subnetList) >= subnetSet.Spec.MaxSubnets {
+		err := fmt.Errorf("SubnetSet %s/%s has reached the maximum number of Subnets %d", subnetSet.Namespace, subnetSet.Name, subnetSet.Spec.MaxSubnets)
+		log.Error(err, "Failed to allocate Subnet")
+		return nil, err
+	}
 	tags := subnetService.GenerateSubnetNSTags(subnetSet)
 	if tags == nil {
-		return "", errors.New("failed to generate subnet tags")
+		return nil, errors.New("failed to generate subnet tags")
 	}
-	log.Info("The existing subnets are not available, creating new subnet", "subnetList", subnetList, "subnetSet.Name", subnetSet.Name, "subnetSet.Namespace", subnetSet.Namespace)
 	vpcInfoList := vpcService.ListVPCInfo(subnetSet.Namespace)
 	if len(vpcInfoList) == 0 {
 		err := errors.New("no VPC found")
 		log.Error(err, "Failed to allocate Subnet")
-		return "", err
+		return nil, err
+	}
+	obj := subnetSet
+	if size := getNextSubnetSize(subnetSet, subnetList); size != subnetSet.Spec.IPv4SubnetSize {
+		// The NSX Subnet is built with the size in the SubnetSet spec.
+		obj = subnetSet.DeepCopy()
+		obj.Spec.IPv4SubnetSize = size
+	}
+	if err := checkSubnetSetQuota(client, obj, subnetList, vpcService); err != nil {
+		log.Error(err, "Failed to allocate Subnet")
+		return nil, err
+	}
+	return subnetService.CreateOrUpdateSubnet(obj, vpcInfoList[0], tags)
+}
+
+// checkSubnetSetQuota checks if the NetworkQuotas in the Namespace allow a new Subnet for the SubnetSet. The
+// first Subnet is already counted in the usage of the SubnetSet, so only the additional Subnets are checked.
+func checkSubnetSetQuota(client k8sclient.Client, subnetSet *v1alpha1.SubnetSet, subnetList []*model.VpcSubnet, vpcService servicecommon.VPCServiceProvider) error {
+	if len(subnetList) == 0 && len(subnetSet.Status.Subnets) == 0 {
+		return nil
+	}
+	requested := NetworkQuotaUsage{Subnets: 1}
+	if subnetSet.Spec.IPv4SubnetSize != 0 {
+		requested.SubnetIPs = int64(subnetSet.Spec.IPv4SubnetSize)
+	} else if util.HasIPFamily(subnetSet.Spec.IPFamilies, v1alpha1.IPFamilyIPv4) {
+		requested.SubnetIPs = DefaultSubnetSizeOfNamespace(vpcService, subnetSet.Namespace)
 	}
-	nsxSubnet, err := subnetService.CreateOrUpdateSubnet(subnetSet, vpcInfoList[0], tags)
+	msg, err := CheckNetworkQuota(context.Background(), client, vpcService, subnetSet.Namespace, requested)
 	if err != nil {
-		return "", err
+		return err
+	}
+	if msg != "" {
+		return fmt.Errorf("failed to create Subnet for SubnetSet %s/%s: %s", subnetSet.Namespace, subnetSet.Name, msg)
+	}
+	return nil
+}
+
+// getNextSubnetSize returns the IPv4 size of the next Subnet created for the SubnetSet. With the growth
+// policy, the new Subnet is Factor times the size of the largest existing Subnet, up to MaxSubnetSize.
+func getNextSubnetSize(subnetSet *v1alpha1.SubnetSet, subnetList []*model.VpcSubnet) int {
+	size := subnetSet.Spec.IPv4SubnetSize
+	policy := subnetSet.Spec.GrowthPolicy
+	if size == 0 || policy == nil || policy.Factor <= 1 {
+		return size
+	}
+	for _, nsxSubnet := range subnetList {
+		if nsxSubnet.Ipv4SubnetSize != nil {
+			size = max(size, int(*nsxSubnet.Ipv4SubnetSize)*policy.Factor)
+		}
+	}
+	if policy.MaxSubnetSize > 0 {
+		size = min(size, max(policy.MaxSubnetSize, subnetSet.Spec.IPv4SubnetSize))
+	}
+	return size
+}
+
+// needPreCreateSubnet checks if the free IPs in the Subnets of the SubnetSet are less than MinFreeIPs.
+func needPreCreateSubnet(subnetSet *v1alpha1.SubnetSet, subnetList []*model.VpcSubnet, subnetPortService servicecommon.SubnetPortServiceProvider) bool {
+	if subnetSet.Spec.MinFreeIPs <= 0 {
+		return false
+	}
+	if subnetSet.Spec.MaxSubnets > 0 && len(subnetList) >= subnetSet.Spec.MaxSubnets {
+		return false
+	}
+	freeIPs := 0
+	for _, nsxSubnet := range subnetList {
+		freeIPs += subnetPortService.GetFreeIPCount(nsxSubnet)
+	}
+	return freeIPs < subnetSet.Spec.MinFreeIPs
+}
+
+// preCreateSubnet creates the next Subnet of the SubnetSet in the background, so that the SubnetPorts
+// don't wait for the Subnet realization when the existing Subnets are exhausted.
+func preCreateSubnet(client k8sclient.Client, subnetSet *v1alpha1.SubnetSet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider, subnetPortService servicecommon.SubnetPortServiceProvider) {
+	if _, loaded := subnetSetPreCreating.LoadOrStore(subnetSet.GetUID(), struct{}{}); loaded {
+		return
+	}
+	defer subnetSetPreCreating.Delete(subnetSet.GetUID())
+	subnetSetLock := LockSubnetSet(subnetSet.GetUID())
+	defer UnlockSubnetSet(subnetSet.GetUID(), subnetSetLock)
+	// Check again as the Subnet may have been created by the SubnetPort allocation.
+	subnetList := getSubnetsOfSubnetSet(subnetSet, subnetService)
+	if !needPreCreateSubnet(subnetSet, subnetList, subnetPortService) {
+		return
+	}
+	log.Info("Free IPs in SubnetSet are less than the minimum, creating new subnet", "subnetSet.Name", subnetSet.Name, "subnetSet.Namespace", subnetSet.Namespace, "minFreeIPs", subnetSet.Spec.MinFreeIPs)
+	if _, err := createSubnetForSubnetSet(client, subnetSet, subnetList, vpcService, subnetService); err != nil {
+		log.Error(err, "Failed to pre-create Subnet for SubnetSet", "subnetSet.Name", subnetSet.Name, "subnetSet.Namespace", subnetSet.Namespace)
 	}
-	subnetPortService.AllocatePortFromSubnet(nsxSubnet)
-	return *nsxSubnet.Path, nil
 }
 
 func getSharedNamespaceForNamespace(client k8sclient.Client, ctx context.Context, namespaceName string) (string, error) {
@@ -62,8 +192,12 @@ func getSharedNamespaceForNamespace(client k8sclient.Client, ctx context.Context
 		log.Error(err, "Failed to get target namespace during getting VPC for namespace")
 		return "", err
 	}
-	sharedNamespaceName, exists := namespace.Annotations[servicecommon.AnnotationSharedVPCNamespace]
-	if !exists {
+	sharedNamespaceName, err := util.GetSharedVPCNamespace(client, ctx, namespace)
+	if err != nil {
+		log.Error(err, "Failed to get shared VPC namespace", "namespace", namespaceName)
+		return "", err
+	}
+	if sharedNamespaceName == "" || sharedNamespaceName == namespaceName {
 		return "", nil
 	}
 	log.Info("Got shared VPC namespace", "current namespace", namespaceName, "shared namespace", sharedNamespaceName)
@@ -113,6 +247,44 @@ func getDefaultSubnetSetByNamespace(client k8sclient.Client, namespace string, r
 
 }
 
+// IsNamespaceVPCSwitched checks if the Namespace is switched to the VPC of another VPCNetworkConfiguration,
+// the resources in the VPC of the Namespace need to be reconciled again after the switch.
+func IsNamespaceVPCSwitched(oldObj, newObj *v1.Namespace) bool {
+	return oldObj.Annotations[servicecommon.AnnotationAppliedVPCNetworkConfig] != newObj.Annotations[servicecommon.AnnotationAppliedVPCNetworkConfig]
+}
+
+// IsLabelTagsChanged checks if the values of the labels mirrored to NSX tags are changed, the NSX resources
+// tagged with the labels need to be updated after the change.
+func IsLabelTagsChanged(labelKeys []string, oldLabels, newLabels map[string]string) bool {
+	for _, key := range labelKeys {
+		oldValue, oldOK := oldLabels[key]
+		newValue, newOK := newLabels[key]
+		if oldOK != newOK || oldValue != newValue {
+			return true
+		}
+	}
+	return false
+}
+
+// NamespaceLabelTagsChangedPredicate filters the Namespace events which change the labels mirrored to the NSX
+// tags, the NSX resources in the Namespace need to be updated after the change.
+func NamespaceLabelTagsChangedPredicate(labelKeys []string) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return IsLabelTagsChanged(labelKeys, e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
 func NodeIsMaster(node *v1.Node) bool {
 	for k := range node.Labels {
 		if k == LabelK8sMasterRole || k == LabelK8sControlRole {
@@ -18,6 +18,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
@@ -131,7 +132,7 @@ func TestAllocateSubnetFromSubnetSet(t *testing.T) {
 			ssp := &pkg_mock.MockSubnetServiceProvider{}
 			spsp := &pkg_mock.MockSubnetPortServiceProvider{}
 			tt.prepareFunc(t, vps, ssp, spsp)
-			subnetPath, err := AllocateSubnetFromSubnetSet(&v1alpha1.SubnetSet{
+			subnetPath, err := AllocateSubnetFromSubnetSet(fake.NewClientBuilder().Build(), &v1alpha1.SubnetSet{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "subnetset-1",
 					Namespace: "ns-1",
@@ -147,6 +148,96 @@ func TestAllocateSubnetFromSubnetSet(t *testing.T) {
 	}
 }
 
+func TestAllocateSubnetFromSubnetSetWithStrategy(t *testing.T) {
+	subnetSize := int64(32)
+	subnet1 := &model.VpcSubnet{Id: servicecommon.String("id-1"), Path: servicecommon.String("subnet-path-1"), Ipv4SubnetSize: &subnetSize}
+	subnet2 := &model.VpcSubnet{Id: servicecommon.String("id-2"), Path: servicecommon.String("subnet-path-2"), Ipv4SubnetSize: &subnetSize}
+	subnetSet := &v1alpha1.SubnetSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "subnetset-1",
+			Namespace: "ns-1",
+			UID:       "subnetset-uid-1",
+		},
+		Spec: v1alpha1.SubnetSetSpec{
+			IPv4SubnetSize:     32,
+			AllocationStrategy: v1alpha1.AllocationStrategySpread,
+		},
+	}
+
+	// Spread allocates the SubnetPort to the Subnet with the most free IPs.
+	ssp := &pkg_mock.MockSubnetServiceProvider{}
+	spsp := &pkg_mock.MockSubnetPortServiceProvider{}
+	ssp.On("GetSubnetsByIndex", mock.Anything, mock.Anything).Return([]*model.VpcSubnet{subnet1, subnet2})
+	spsp.On("GetFreeIPCount", subnet1).Return(5)
+	spsp.On("GetFreeIPCount", subnet2).Return(20)
+	subnetPath, err := AllocateSubnetFromSubnetSet(fake.NewClientBuilder().Build(), subnetSet, &pkg_mock.MockVPCServiceProvider{}, ssp, spsp)
+	assert.Nil(t, err)
+	assert.Equal(t, "subnet-path-2", subnetPath)
+
+	// The free IPs are less than MinFreeIPs.
+	subnetSet.Spec.MinFreeIPs = 30
+	assert.True(t, needPreCreateSubnet(subnetSet, []*model.VpcSubnet{subnet1, subnet2}, spsp))
+	subnetSet.Spec.MaxSubnets = 2
+	assert.False(t, needPreCreateSubnet(subnetSet, []*model.VpcSubnet{subnet1, subnet2}, spsp))
+
+	// No more Subnet can be created.
+	_, err = createSubnetForSubnetSet(fake.NewClientBuilder().Build(), subnetSet, []*model.VpcSubnet{subnet1, subnet2}, &pkg_mock.MockVPCServiceProvider{}, ssp)
+	assert.ErrorContains(t, err, "SubnetSet ns-1/subnetset-1 has reached the maximum number of Subnets 2")
+}
+
+func TestCreateSubnetForSubnetSetWithNetworkQuota(t *testing.T) {
+	scheme := runtime.NewScheme()
+	v1alpha1.AddToScheme(scheme)
+	subnetSize := int64(32)
+	subnet1 := &model.VpcSubnet{Id: servicecommon.String("id-1"), Path: servicecommon.String("subnet-path-1"), Ipv4SubnetSize: &subnetSize}
+	subnetSet := &v1alpha1.SubnetSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnetset-1", Namespace: "ns-1", UID: "subnetset-uid-1"},
+		Spec:       v1alpha1.SubnetSetSpec{IPv4SubnetSize: 32},
+		Status:     v1alpha1.SubnetSetStatus{Subnets: []v1alpha1.SubnetInfo{{NetworkAddresses: []string{"10.0.0.0/27"}}}},
+	}
+	quota := &v1alpha1.NetworkQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota-1", Namespace: "ns-1"},
+		Spec:       v1alpha1.NetworkQuotaSpec{Hard: v1alpha1.NetworkQuotaResources{Subnets: servicecommon.Int64(1)}},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(subnetSet, quota).Build()
+	vps := &pkg_mock.MockVPCServiceProvider{}
+	vps.On("ListVPCInfo", "ns-1").Return([]servicecommon.VPCResourceInfo{{}})
+	vps.On("GetVPCNetworkConfigByNamespace")
+	ssp := &pkg_mock.MockSubnetServiceProvider{}
+	ssp.On("GenerateSubnetNSTags", mock.Anything)
+	ssp.On("CreateOrUpdateSubnet", mock.Anything, mock.Anything, mock.Anything).Return(&model.VpcSubnet{Path: servicecommon.String("subnet-path-2")}, nil)
+
+	// The first Subnet of the SubnetSet is already counted in the NetworkQuota usage.
+	nsxSubnet, err := createSubnetForSubnetSet(k8sClient, &v1alpha1.SubnetSet{ObjectMeta: subnetSet.ObjectMeta, Spec: subnetSet.Spec}, nil, vps, ssp)
+	assert.Nil(t, err)
+	assert.Equal(t, "subnet-path-2", *nsxSubnet.Path)
+
+	// No more Subnet is allowed by the NetworkQuota.
+	_, err = createSubnetForSubnetSet(k8sClient, subnetSet, []*model.VpcSubnet{subnet1}, vps, ssp)
+	assert.ErrorContains(t, err, "failed to create Subnet for SubnetSet ns-1/subnetset-1: NetworkQuota quota-1 exceeded: subnets (used 1, requested 1, limit 1)")
+	ssp.AssertNumberOfCalls(t, "CreateOrUpdateSubnet", 1)
+
+	// The Subnet is created once the hard limit is raised.
+	quota.Spec.Hard.Subnets = servicecommon.Int64(2)
+	assert.Nil(t, k8sClient.Update(context.TODO(), quota))
+	_, err = createSubnetForSubnetSet(k8sClient, subnetSet, []*model.VpcSubnet{subnet1}, vps, ssp)
+	assert.Nil(t, err)
+	ssp.AssertNumberOfCalls(t, "CreateOrUpdateSubnet", 2)
+}
+
+func TestGetNextSubnetSize(t *testing.T) {
+	size32, size128 := int64(32), int64(128)
+	subnetSet := &v1alpha1.SubnetSet{Spec: v1alpha1.SubnetSetSpec{IPv4SubnetSize: 32}}
+	subnetList := []*model.VpcSubnet{{Ipv4SubnetSize: &size32}, {Ipv4SubnetSize: &size128}}
+	assert.Equal(t, 32, getNextSubnetSize(subnetSet, subnetList))
+
+	subnetSet.Spec.GrowthPolicy = &v1alpha1.SubnetSetGrowthPolicy{Factor: 2}
+	assert.Equal(t, 32, getNextSubnetSize(subnetSet, nil))
+	assert.Equal(t, 256, getNextSubnetSize(subnetSet, subnetList))
+	subnetSet.Spec.GrowthPolicy.MaxSubnetSize = 128
+	assert.Equal(t, 128, getNextSubnetSize(subnetSet, subnetList))
+}
+
 func TestGetDefaultSubnetSet(t *testing.T) {
 	mockCtl := gomock.NewController(t)
 	k8sClient := mock_client.NewMockClient(mockCtl)
@@ -187,10 +278,32 @@ func TestGetDefaultSubnetSet(t *testing.T) {
 			},
 			expectedResult: &subnetSet,
 		},
+		{
+			name: "SharedNamespaceFromSharedVPC",
+			prepareFunc: func(t *testing.T) {
+				k8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Do(func(_ context.Context, _ client.ObjectKey, obj client.Object, option ...client.GetOption) error {
+					obj.SetName("ns-1")
+					return nil
+				})
+				k8sClient.EXPECT().List(gomock.Any(), gomock.AssignableToTypeOf(&v1alpha1.SharedVPCList{})).Return(nil).Do(func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					a := list.(*v1alpha1.SharedVPCList)
+					a.Items = append(a.Items, v1alpha1.SharedVPC{Spec: v1alpha1.SharedVPCSpec{OwnerNamespace: "sharedNamespace", ConsumerNamespaces: []string{"ns-1"}}})
+					return nil
+				})
+				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Do(func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+					assert.Equal(t, "sharedNamespace", opts[0].(*client.ListOptions).Namespace)
+					a := list.(*v1alpha1.SubnetSetList)
+					a.Items = append(a.Items, subnetSet)
+					return nil
+				})
+			},
+			expectedResult: &subnetSet,
+		},
 		{
 			name: "ListSubnetSetFailure",
 			prepareFunc: func(t *testing.T) {
 				k8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				k8sClient.EXPECT().List(gomock.Any(), gomock.AssignableToTypeOf(&v1alpha1.SharedVPCList{})).Return(nil)
 				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed to list SubnetSet"))
 			},
 			expectedErr: "failed to list SubnetSet",
@@ -199,6 +312,7 @@ func TestGetDefaultSubnetSet(t *testing.T) {
 			name: "NoDefaultSubnetSet",
 			prepareFunc: func(t *testing.T) {
 				k8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				k8sClient.EXPECT().List(gomock.Any(), gomock.AssignableToTypeOf(&v1alpha1.SharedVPCList{})).Return(nil)
 				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			expectedErr: "default subnetset not found",
@@ -207,6 +321,7 @@ func TestGetDefaultSubnetSet(t *testing.T) {
 			name: "MultipleDefaultSubnetSet",
 			prepareFunc: func(t *testing.T) {
 				k8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				k8sClient.EXPECT().List(gomock.Any(), gomock.AssignableToTypeOf(&v1alpha1.SharedVPCList{})).Return(nil)
 				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Do(func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
 					a := list.(*v1alpha1.SubnetSetList)
 					a.Items = append(a.Items, subnetSet)
@@ -303,3 +418,16 @@ func TestStatusUpdater_DeleteFail(t *testing.T) {
 
 	statusUpdater.DeleteFail(types.NamespacedName{Name: "name", Namespace: "ns"}, &v1alpha1.Subnet{}, fmt.Errorf("mock error"))
 }
+
+func TestNamespaceLabelTagsChangedPredicate(t *testing.T) {
+	oldNs := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"team": "net", "env": "dev"}}}
+	newNs := oldNs.DeepCopy()
+	newNs.Labels["env"] = "prod"
+	assert.False(t, NamespaceLabelTagsChangedPredicate(nil).Update(event.UpdateEvent{ObjectOld: oldNs, ObjectNew: newNs}))
+	assert.False(t, NamespaceLabelTagsChangedPredicate([]string{"team"}).Update(event.UpdateEvent{ObjectOld: oldNs, ObjectNew: newNs}))
+	assert.True(t, NamespaceLabelTagsChangedPredicate([]string{"team", "env"}).Update(event.UpdateEvent{ObjectOld: oldNs, ObjectNew: newNs}))
+
+	delete(newNs.Labels, "team")
+	assert.True(t, IsLabelTagsChanged([]string{"team"}, oldNs.Labels, newNs.Labels))
+	assert.False(t, NamespaceLabelTagsChangedPredicate([]string{"team"}).Create(event.CreateEvent{Object: newNs}))
+}
@@ -0,0 +1,373 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package egressip
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	vmv1alpha1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+var (
+	log                   = &logger.Log
+	ResultNormal          = common.ResultNormal
+	ResultRequeue         = common.ResultRequeue
+	MetricResTypeEgressIP = common.MetricResTypeEgressIP
+)
+
+// natRuleSequenceNumber is the sequence number of the SNAT rules of the EgressIPs, which is lower than the
+// default SNAT rules of the VPC so that the selected workloads egress from the EgressIP.
+const natRuleSequenceNumber = int64(10)
+
+// EgressIPReconciler reconciles an EgressIP object. The external IP of the EgressIP is allocated by an
+// IPAddressAllocation with External visibility, and the IPs of the selected workloads are translated to it
+// by a SNAT NATRule. Both of them are owned by the EgressIP and removed together with it.
+type EgressIPReconciler struct {
+	Client        client.Client
+	Scheme        *apimachineryruntime.Scheme
+	Recorder      record.EventRecorder
+	StatusUpdater common.StatusUpdater
+}
+
+func setEgressIPReadyStatusTrue(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, args ...interface{}) {
+	egressIP := obj.(*v1alpha1.EgressIP)
+	updateEgressIPStatus(client, ctx, egressIP, v1alpha1.Condition{
+		Type:               v1alpha1.Ready,
+		Status:             v1.ConditionTrue,
+		Message:            "EgressIP has been successfully created/updated",
+		Reason:             "EgressIPReady",
+		LastTransitionTime: transitionTime,
+	}, args...)
+}
+
+func setEgressIPReadyStatusFalse(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, err error, args ...interface{}) {
+	egressIP := obj.(*v1alpha1.EgressIP)
+	updateEgressIPStatus(client, ctx, egressIP, v1alpha1.Condition{
+		Type:               v1alpha1.Ready,
+		Status:             v1.ConditionFalse,
+		Message:            fmt.Sprintf("Error occurred while processing the EgressIP CR. Error: %v", err),
+		Reason:             "EgressIPNotReady",
+		LastTransitionTime: transitionTime,
+	}, args...)
+}
+
+// updateEgressIPStatus updates the status of the EgressIP only if it is changed. The egress IP and the IPs of
+// the selected workloads are passed in args if they are resolved.
+func updateEgressIPStatus(client client.Client, ctx context.Context, egressIP *v1alpha1.EgressIP, newCondition v1alpha1.Condition, args ...interface{}) {
+	updated := mergeEgressIPStatusCondition(egressIP, &newCondition)
+	if len(args) == 2 {
+		ip, ipAddresses := args[0].(string), args[1].([]string)
+		if egressIP.Status.EgressIP != ip || !slices.Equal(egressIP.Status.IPAddresses, ipAddresses) {
+			egressIP.Status.EgressIP = ip
+			egressIP.Status.IPAddresses = ipAddresses
+			updated = true
+		}
+	}
+	if !updated {
+		return
+	}
+	if err := client.Status().Update(ctx, egressIP); err != nil {
+		log.Error(err, "Failed to update EgressIP status", "Namespace", egressIP.Namespace, "Name", egressIP.Name)
+		return
+	}
+	log.V(1).Info("Updated EgressIP status", "Namespace", egressIP.Namespace, "Name", egressIP.Name, "New Condition", newCondition)
+}
+
+func mergeEgressIPStatusCondition(egressIP *v1alpha1.EgressIP, newCondition *v1alpha1.Condition) bool {
+	for i := range egressIP.Status.Conditions {
+		matchedCondition := &egressIP.Status.Conditions[i]
+		if matchedCondition.Type != newCondition.Type {
+			continue
+		}
+		if matchedCondition.Status == newCondition.Status && matchedCondition.Reason == newCondition.Reason && matchedCondition.Message == newCondition.Message {
+			return false
+		}
+		if matchedCondition.Status != newCondition.Status {
+			matchedCondition.LastTransitionTime = newCondition.LastTransitionTime
+		}
+		matchedCondition.Reason = newCondition.Reason
+		matchedCondition.Message = newCondition.Message
+		matchedCondition.Status = newCondition.Status
+		return true
+	}
+	egressIP.Status.Conditions = append(egressIP.Status.Conditions, *newCondition)
+	return true
+}
+
+func (r *EgressIPReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := &v1alpha1.EgressIP{}
+	log.Info("Reconciling EgressIP CR", "EgressIP", req.NamespacedName)
+	r.StatusUpdater.IncreaseSyncTotal()
+
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The IPAddressAllocation and NATRule are deleted with the EgressIP by the owner references.
+			r.StatusUpdater.DeleteSuccess(req.NamespacedName, nil)
+			return ResultNormal, nil
+		}
+		log.Error(err, "Unable to fetch EgressIP CR", "req", req.NamespacedName)
+		return ResultRequeue, err
+	}
+	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ResultNormal, nil
+	}
+
+	r.StatusUpdater.IncreaseUpdateTotal()
+	ipAddressAllocation, err := r.createOrUpdateIPAddressAllocation(ctx, obj)
+	if err != nil {
+		r.StatusUpdater.UpdateFail(ctx, obj, err, "failed to allocate the external IP", setEgressIPReadyStatusFalse)
+		return ResultRequeue, err
+	}
+	// The EgressIP is reconciled again once the IP is allocated since the IPAddressAllocation is owned by it.
+	egressIP := strings.TrimSuffix(ipAddressAllocation.Status.AllocationIPs, "/32")
+	if egressIP == "" {
+		setEgressIPReadyStatusFalse(r.Client, ctx, obj, metav1.Now(), fmt.Errorf("waiting for IPAddressAllocation %s to allocate the external IP", ipAddressAllocation.Name))
+		return ResultNormal, nil
+	}
+
+	ipAddresses, err := r.listWorkloadIPAddresses(ctx, obj)
+	if err != nil {
+		r.StatusUpdater.UpdateFail(ctx, obj, err, "failed to list the selected workloads", setEgressIPReadyStatusFalse)
+		return ResultRequeue, err
+	}
+	natRule, err := r.createOrUpdateNATRule(ctx, obj, ipAddressAllocation.Name, ipAddresses)
+	if err != nil {
+		r.StatusUpdater.UpdateFail(ctx, obj, err, "failed to update the SNAT rule", setEgressIPReadyStatusFalse)
+		return ResultRequeue, err
+	}
+	if natRule != nil && !isNATRuleReady(natRule) {
+		// The EgressIP is reconciled again once the NATRule is realized since the NATRule is owned by it.
+		setEgressIPReadyStatusFalse(r.Client, ctx, obj, metav1.Now(), fmt.Errorf("waiting for NATRule %s to be realized", natRule.Name), egressIP, ipAddresses)
+		return ResultNormal, nil
+	}
+	r.StatusUpdater.UpdateSuccess(ctx, obj, setEgressIPReadyStatusTrue, egressIP, ipAddresses)
+	return ResultNormal, nil
+}
+
+func isNATRuleReady(natRule *v1alpha1.NATRule) bool {
+	for _, condition := range natRule.Status.Conditions {
+		if condition.Type == v1alpha1.Ready {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// childName returns the name of the IPAddressAllocation and NATRule created for the EgressIP.
+func childName(obj *v1alpha1.EgressIP) string {
+	return "egressip-" + obj.Name
+}
+
+// checkOwner returns an error if the existing child resource is not created for the EgressIP.
+func checkOwner(obj *v1alpha1.EgressIP, child client.Object) error {
+	if owner := metav1.GetControllerOf(child); owner == nil || owner.UID != obj.UID {
+		return fmt.Errorf("%s %s/%s already exists and is not owned by EgressIP %s", child.GetObjectKind().GroupVersionKind().Kind, child.GetNamespace(), child.GetName(), obj.Name)
+	}
+	return nil
+}
+
+func (r *EgressIPReconciler) createOrUpdateIPAddressAllocation(ctx context.Context, obj *v1alpha1.EgressIP) (*v1alpha1.IPAddressAllocation, error) {
+	ipAddressAllocation := &v1alpha1.IPAddressAllocation{}
+	key := types.NamespacedName{Namespace: obj.Namespace, Name: childName(obj)}
+	err := r.Client.Get(ctx, key, ipAddressAllocation)
+	if err == nil {
+		return ipAddressAllocation, checkOwner(obj, ipAddressAllocation)
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	ipAddressAllocation = &v1alpha1.IPAddressAllocation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec: v1alpha1.IPAddressAllocationSpec{
+			IPAddressBlockVisibility: v1alpha1.IPAddressVisibilityExternal,
+			AllocationSize:           1,
+		},
+	}
+	if err := controllerutil.SetControllerReference(obj, ipAddressAllocation, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Client.Create(ctx, ipAddressAllocation); err != nil {
+		return nil, err
+	}
+	log.Info("Created IPAddressAllocation for EgressIP", "Namespace", obj.Namespace, "EgressIP", obj.Name, "IPAddressAllocation", key.Name)
+	return ipAddressAllocation, nil
+}
+
+// createOrUpdateNATRule keeps the SNAT NATRule of the EgressIP in sync with the IPs of the selected workloads,
+// the NATRule is deleted if no workload is selected. The NATRule is returned if it exists.
+func (r *EgressIPReconciler) createOrUpdateNATRule(ctx context.Context, obj *v1alpha1.EgressIP, ipAddressAllocationName string, ipAddresses []string) (*v1alpha1.NATRule, error) {
+	natRule := &v1alpha1.NATRule{}
+	key := types.NamespacedName{Namespace: obj.Namespace, Name: childName(obj)}
+	err := r.Client.Get(ctx, key, natRule)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	exists := err == nil
+	if exists {
+		if err := checkOwner(obj, natRule); err != nil {
+			return nil, err
+		}
+	}
+	if len(ipAddresses) == 0 {
+		if exists {
+			log.Info("Deleting NATRule of EgressIP without selected workloads", "Namespace", obj.Namespace, "EgressIP", obj.Name)
+			return nil, client.IgnoreNotFound(r.Client.Delete(ctx, natRule))
+		}
+		return nil, nil
+	}
+	spec := v1alpha1.NATRuleSpec{
+		Action:            v1alpha1.NATRuleActionSNAT,
+		SourceNetwork:     &v1alpha1.NATRuleNetwork{CIDRs: ipAddresses},
+		TranslatedNetwork: &v1alpha1.NATRuleTranslatedNetwork{IPAddressAllocationName: ipAddressAllocationName},
+		FirewallMatch:     v1alpha1.NATRuleFirewallMatchInternalAddress,
+		SequenceNumber:    natRuleSequenceNumber,
+	}
+	if !exists {
+		natRule = &v1alpha1.NATRule{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}, Spec: spec}
+		if err := controllerutil.SetControllerReference(obj, natRule, r.Scheme); err != nil {
+			return nil, err
+		}
+		if err := r.Client.Create(ctx, natRule); err != nil {
+			return nil, err
+		}
+		log.Info("Created NATRule for EgressIP", "Namespace", obj.Namespace, "EgressIP", obj.Name, "IPAddresses", ipAddresses)
+		return natRule, nil
+	}
+	// The NATRule without the source network is drifted from the desired spec, and repaired by the update.
+	if natRule.Spec.SourceNetwork != nil && sets.New(natRule.Spec.SourceNetwork.CIDRs...).Equal(sets.New(ipAddresses...)) {
+		return natRule, nil
+	}
+	natRule.Spec = spec
+	if err := r.Client.Update(ctx, natRule); err != nil {
+		return nil, err
+	}
+	log.Info("Updated NATRule for EgressIP", "Namespace", obj.Namespace, "EgressIP", obj.Name, "IPAddresses", ipAddresses)
+	return natRule, nil
+}
+
+// listWorkloadIPAddresses returns the sorted IPs of the Pods and VMs selected by the EgressIP.
+func (r *EgressIPReconciler) listWorkloadIPAddresses(ctx context.Context, obj *v1alpha1.EgressIP) ([]string, error) {
+	ipAddresses := sets.New[string]()
+	if obj.Spec.PodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(obj.Spec.PodSelector)
+		if err != nil {
+			return nil, err
+		}
+		podList := &v1.PodList{}
+		if err := r.Client.List(ctx, podList, client.InNamespace(obj.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for _, pod := range podList.Items {
+			// The host network Pods use the IP of the node, and the terminated Pods have released their IPs.
+			if pod.Spec.HostNetwork || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+				continue
+			}
+			for _, podIP := range pod.Status.PodIPs {
+				ipAddresses.Insert(podIP.IP)
+			}
+		}
+	}
+	if obj.Spec.VMSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(obj.Spec.VMSelector)
+		if err != nil {
+			return nil, err
+		}
+		vmList := &vmv1alpha1.VirtualMachineList{}
+		if err := r.Client.List(ctx, vmList, client.InNamespace(obj.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		vmNames := sets.New[string]()
+		for _, vm := range vmList.Items {
+			vmNames.Insert(vm.Name)
+		}
+		subnetPortList := &v1alpha1.SubnetPortList{}
+		if vmNames.Len() > 0 {
+			if err := r.Client.List(ctx, subnetPortList, client.InNamespace(obj.Namespace)); err != nil {
+				return nil, err
+			}
+		}
+		for i := range subnetPortList.Items {
+			vmName, _, _ := common.GetVirtualMachineNameForSubnetPort(&subnetPortList.Items[i])
+			if !vmNames.Has(vmName) {
+				continue
+			}
+			for _, address := range subnetPortList.Items[i].Status.NetworkInterfaceConfig.IPAddresses {
+				// The IP address of the SubnetPort is in the format of "IP/prefix".
+				if ip := strings.Split(address.IPAddress, "/")[0]; ip != "" {
+					ipAddresses.Insert(ip)
+				}
+			}
+		}
+	}
+	result := ipAddresses.UnsortedList()
+	sort.Strings(result)
+	return result, nil
+}
+
+// namespaceMapFunc enqueues all the EgressIPs in the Namespace of the workload, so that the SNAT rules are
+// updated when the selected workloads come and go or change their labels and IPs.
+func (r *EgressIPReconciler) namespaceMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	egressIPList := &v1alpha1.EgressIPList{}
+	if err := r.Client.List(ctx, egressIPList, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.Error(err, "Failed to list EgressIP CR", "Namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, egressIP := range egressIPList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: egressIP.Namespace, Name: egressIP.Name}})
+	}
+	return requests
+}
+
+func (r *EgressIPReconciler) setupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.EgressIP{}).
+		WithOptions(
+			controller.Options{
+				MaxConcurrentReconciles: common.NumReconcile(),
+			}).
+		Owns(&v1alpha1.IPAddressAllocation{}).
+		Owns(&v1alpha1.NATRule{}).
+		Watches(&v1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.namespaceMapFunc)).
+		Watches(&vmv1alpha1.VirtualMachine{}, handler.EnqueueRequestsFromMapFunc(r.namespaceMapFunc)).
+		Watches(&v1alpha1.SubnetPort{}, handler.EnqueueRequestsFromMapFunc(r.namespaceMapFunc)).
+		Complete(r)
+}
+
+func StartEgressIPController(mgr ctrl.Manager, cf *config.NSXOperatorConfig) {
+	egressIPReconciler := EgressIPReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("egressip-controller"),
+	}
+	egressIPReconciler.StatusUpdater = common.NewStatusUpdater(egressIPReconciler.Client, cf, egressIPReconciler.Recorder, MetricResTypeEgressIP, servicecommon.ResourceTypeNATRule, "EgressIP")
+	if err := egressIPReconciler.setupWithManager(mgr); err != nil {
+		log.Error(err, "Failed to create controller", "controller", "EgressIP")
+		os.Exit(1)
+	}
+}
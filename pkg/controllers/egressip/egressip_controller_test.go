@@ -0,0 +1,167 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package egressip
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vmv1alpha1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+func createEgressIPReconciler(objs ...client.Object) *EgressIPReconciler {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(newScheme))
+	utilruntime.Must(vmv1alpha1.AddToScheme(newScheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.EgressIP{}, &v1alpha1.IPAddressAllocation{}, &v1alpha1.NATRule{}).Build()
+	r := &EgressIPReconciler{
+		Client:   fakeClient,
+		Scheme:   newScheme,
+		Recorder: &record.FakeRecorder{},
+	}
+	r.StatusUpdater = common.NewStatusUpdater(r.Client, &config.NSXOperatorConfig{NsxConfig: &config.NsxConfig{}}, r.Recorder, MetricResTypeEgressIP, servicecommon.ResourceTypeNATRule, "EgressIP")
+	return r
+}
+
+func newPod(name string, labels map[string]string, ip string, phase v1.PodPhase) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns-1", Labels: labels},
+		Status:     v1.PodStatus{Phase: phase, PodIPs: []v1.PodIP{{IP: ip}}},
+	}
+}
+
+func TestEgressIPReconciler_Reconcile(t *testing.T) {
+	egressIP := &v1alpha1.EgressIP{
+		ObjectMeta: metav1.ObjectMeta{Name: "egress-1", Namespace: "ns-1", UID: "egress-uid-1"},
+		Spec: v1alpha1.EgressIPSpec{
+			PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "payment"}},
+			VMSelector:  &metav1.LabelSelector{MatchLabels: map[string]string{"app": "payment-db"}},
+		},
+	}
+	vm := &vmv1alpha1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Name: "vm-1", Namespace: "ns-1", Labels: map[string]string{"app": "payment-db"}}}
+	vmSubnetPort := &v1alpha1.SubnetPort{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "vm-1-port",
+			Namespace:   "ns-1",
+			Annotations: map[string]string{servicecommon.AnnotationAttachmentRef: "virtualmachine/vm-1/port1"},
+		},
+		Status: v1alpha1.SubnetPortStatus{NetworkInterfaceConfig: v1alpha1.NetworkInterfaceConfig{
+			IPAddresses: []v1alpha1.NetworkInterfaceIPAddress{{IPAddress: "10.0.1.5/28"}},
+		}},
+	}
+	r := createEgressIPReconciler(egressIP, vm, vmSubnetPort,
+		newPod("pod-1", map[string]string{"app": "payment"}, "10.0.0.3", v1.PodRunning),
+		newPod("pod-2", map[string]string{"app": "payment"}, "10.0.0.4", v1.PodSucceeded),
+		newPod("pod-3", map[string]string{"app": "web"}, "10.0.0.5", v1.PodRunning),
+	)
+	ctx := context.TODO()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns-1", Name: "egress-1"}}
+	childKey := types.NamespacedName{Namespace: "ns-1", Name: "egressip-egress-1"}
+
+	// An External IPAddressAllocation is created for the EgressIP, which waits for the IP to be allocated.
+	result, err := r.Reconcile(ctx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, ResultNormal, result)
+	ipAddressAllocation := &v1alpha1.IPAddressAllocation{}
+	assert.Nil(t, r.Client.Get(ctx, childKey, ipAddressAllocation))
+	assert.Equal(t, v1alpha1.IPAddressVisibilityExternal, ipAddressAllocation.Spec.IPAddressBlockVisibility)
+	assert.Equal(t, 1, ipAddressAllocation.Spec.AllocationSize)
+	assert.Equal(t, types.UID("egress-uid-1"), metav1.GetControllerOf(ipAddressAllocation).UID)
+	obj := &v1alpha1.EgressIP{}
+	assert.Nil(t, r.Client.Get(ctx, req.NamespacedName, obj))
+	assert.Equal(t, v1.ConditionFalse, obj.Status.Conditions[0].Status)
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(ctx, childKey, &v1alpha1.NATRule{})))
+
+	// A SNAT NATRule is created for the selected Pods and VMs once the IP is allocated.
+	ipAddressAllocation.Status.AllocationIPs = "192.168.0.10/32"
+	assert.Nil(t, r.Client.Status().Update(ctx, ipAddressAllocation))
+	_, err = r.Reconcile(ctx, req)
+	assert.Nil(t, err)
+	natRule := &v1alpha1.NATRule{}
+	assert.Nil(t, r.Client.Get(ctx, childKey, natRule))
+	assert.Equal(t, v1alpha1.NATRuleActionSNAT, natRule.Spec.Action)
+	assert.Equal(t, []string{"10.0.0.3", "10.0.1.5"}, natRule.Spec.SourceNetwork.CIDRs)
+	assert.Equal(t, "egressip-egress-1", natRule.Spec.TranslatedNetwork.IPAddressAllocationName)
+	assert.Equal(t, natRuleSequenceNumber, natRule.Spec.SequenceNumber)
+	assert.Nil(t, r.Client.Get(ctx, req.NamespacedName, obj))
+	assert.Equal(t, v1.ConditionFalse, obj.Status.Conditions[0].Status)
+	assert.Equal(t, "192.168.0.10", obj.Status.EgressIP)
+
+	// The EgressIP is ready once the NATRule is realized.
+	natRule.Status.Conditions = []v1alpha1.Condition{{Type: v1alpha1.Ready, Status: v1.ConditionTrue}}
+	assert.Nil(t, r.Client.Status().Update(ctx, natRule))
+	_, err = r.Reconcile(ctx, req)
+	assert.Nil(t, err)
+	assert.Nil(t, r.Client.Get(ctx, req.NamespacedName, obj))
+	assert.Equal(t, v1.ConditionTrue, obj.Status.Conditions[0].Status)
+	assert.Equal(t, []string{"10.0.0.3", "10.0.1.5"}, obj.Status.IPAddresses)
+
+	// The NATRule follows the selected workloads.
+	assert.Nil(t, r.Client.Create(ctx, newPod("pod-4", map[string]string{"app": "payment"}, "10.0.0.6", v1.PodRunning)))
+	_, err = r.Reconcile(ctx, req)
+	assert.Nil(t, err)
+	assert.Nil(t, r.Client.Get(ctx, childKey, natRule))
+	assert.Equal(t, []string{"10.0.0.3", "10.0.0.6", "10.0.1.5"}, natRule.Spec.SourceNetwork.CIDRs)
+
+	// The NATRule whose source network is removed is repaired.
+	natRule.Spec.SourceNetwork = nil
+	assert.Nil(t, r.Client.Update(ctx, natRule))
+	_, err = r.Reconcile(ctx, req)
+	assert.Nil(t, err)
+	assert.Nil(t, r.Client.Get(ctx, childKey, natRule))
+	assert.Equal(t, []string{"10.0.0.3", "10.0.0.6", "10.0.1.5"}, natRule.Spec.SourceNetwork.CIDRs)
+
+	// The NATRule is deleted if no workload is selected.
+	assert.Nil(t, r.Client.Get(ctx, req.NamespacedName, obj))
+	obj.Spec.PodSelector.MatchLabels = map[string]string{"app": "none"}
+	obj.Spec.VMSelector = nil
+	assert.Nil(t, r.Client.Update(ctx, obj))
+	_, err = r.Reconcile(ctx, req)
+	assert.Nil(t, err)
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(ctx, childKey, natRule)))
+	assert.Nil(t, r.Client.Get(ctx, req.NamespacedName, obj))
+	assert.Equal(t, v1.ConditionTrue, obj.Status.Conditions[0].Status)
+	assert.Empty(t, obj.Status.IPAddresses)
+}
+
+func TestEgressIPReconciler_ChildNotOwned(t *testing.T) {
+	egressIP := &v1alpha1.EgressIP{
+		ObjectMeta: metav1.ObjectMeta{Name: "egress-1", Namespace: "ns-1", UID: "egress-uid-1"},
+		Spec:       v1alpha1.EgressIPSpec{PodSelector: &metav1.LabelSelector{}},
+	}
+	ipAddressAllocation := &v1alpha1.IPAddressAllocation{ObjectMeta: metav1.ObjectMeta{Name: "egressip-egress-1", Namespace: "ns-1"}}
+	r := createEgressIPReconciler(egressIP, ipAddressAllocation)
+
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns-1", Name: "egress-1"}})
+	assert.ErrorContains(t, err, "is not owned by EgressIP egress-1")
+}
+
+func TestEgressIPReconciler_NamespaceMapFunc(t *testing.T) {
+	r := createEgressIPReconciler(
+		&v1alpha1.EgressIP{ObjectMeta: metav1.ObjectMeta{Name: "egress-1", Namespace: "ns-1"}},
+		&v1alpha1.EgressIP{ObjectMeta: metav1.ObjectMeta{Name: "egress-2", Namespace: "ns-2"}},
+	)
+	requests := r.namespaceMapFunc(context.TODO(), newPod("pod-1", nil, "10.0.0.3", v1.PodRunning))
+	assert.Equal(t, 1, len(requests))
+	assert.Equal(t, "egress-1", requests[0].Name)
+}
@@ -17,6 +17,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
@@ -126,6 +128,24 @@ func (r *IPAddressAllocationReconciler) handleDeletion(req ctrl.Request, obj *v1
 	return resultNormal, nil
 }
 
+// Start setups the manager and registers the webhook of IPAddressAllocation.
+func (r *IPAddressAllocationReconciler) Start(mgr ctrl.Manager, hookServer webhook.Server) error {
+	if err := r.SetupWithManager(mgr); err != nil {
+		return err
+	}
+	if hookServer != nil {
+		hookServer.Register("/validate-crd-nsx-vmware-com-v1alpha1-ipaddressallocation",
+			&webhook.Admission{
+				Handler: &IPAddressAllocationValidator{
+					Client:     mgr.GetClient(),
+					decoder:    admission.NewDecoder(mgr.GetScheme()),
+					vpcService: r.VPCService,
+				},
+			})
+	}
+	return nil
+}
+
 func (r *IPAddressAllocationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.IPAddressAllocation{}).
@@ -0,0 +1,49 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package ipaddressallocation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+// +kubebuilder:webhook:path=/validate-crd-nsx-vmware-com-v1alpha1-ipaddressallocation,mutating=false,failurePolicy=fail,sideEffects=None,groups=crd.nsx.vmware.com,resources=ipaddressallocations,verbs=create,versions=v1alpha1,name=ipaddressallocation.validating.crd.nsx.vmware.com,admissionReviewVersions=v1
+
+// IPAddressAllocationValidator denies the IPAddressAllocation allocating more external IP addresses than
+// the NetworkQuotas in the Namespace allow.
+type IPAddressAllocationValidator struct {
+	Client     client.Client
+	decoder    admission.Decoder
+	vpcService servicecommon.VPCServiceProvider
+}
+
+// Handle handles admission requests.
+func (v *IPAddressAllocationValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+	ipAddressAllocation := &v1alpha1.IPAddressAllocation{}
+	if err := v.decoder.Decode(req, ipAddressAllocation); err != nil {
+		log.Error(err, "error while decoding IPAddressAllocation", "IPAddressAllocation", req.Namespace+"/"+req.Name)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	requested := common.IPAddressAllocationQuotaUsage(ipAddressAllocation)
+	msg, err := common.CheckNetworkQuota(ctx, v.Client, v.vpcService, ipAddressAllocation.Namespace, requested)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if msg != "" {
+		return admission.Denied(fmt.Sprintf("IPAddressAllocation %s/%s is denied: %s", ipAddressAllocation.Namespace, ipAddressAllocation.Name, msg))
+	}
+	return admission.Allowed("")
+}
@@ -0,0 +1,81 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package ipaddressallocation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+func TestIPAddressAllocationValidator_Handle(t *testing.T) {
+	newIPAddressAllocation := func(name string, visibility v1alpha1.IPAddressVisibility, size int) []byte {
+		raw, _ := json.Marshal(&v1alpha1.IPAddressAllocation{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name},
+			Spec:       v1alpha1.IPAddressAllocationSpec{IPAddressBlockVisibility: visibility, AllocationSize: size},
+		})
+		return raw
+	}
+	createRequest := func(raw []byte) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: runtime.RawExtension{Raw: raw}}}
+	}
+	tests := []struct {
+		name string
+		req  admission.Request
+		want admission.Response
+	}{
+		{
+			name: "delete",
+			req:  admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Delete}},
+			want: admission.Allowed(""),
+		},
+		{
+			name: "create private",
+			req:  createRequest(newIPAddressAllocation("ipa2", v1alpha1.IPAddressVisibilityPrivate, 64)),
+			want: admission.Allowed(""),
+		},
+		{
+			name: "create external within quota",
+			req:  createRequest(newIPAddressAllocation("ipa2", v1alpha1.IPAddressVisibilityExternal, 4)),
+			want: admission.Allowed(""),
+		},
+		{
+			name: "create external exceeding quota",
+			req:  createRequest(newIPAddressAllocation("ipa2", v1alpha1.IPAddressVisibilityExternal, 8)),
+			want: admission.Denied("IPAddressAllocation ns1/ipa2 is denied: NetworkQuota quota1 exceeded: externalIPs (used 4, requested 8, limit 8)"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := clientgoscheme.Scheme
+			v1alpha1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&v1alpha1.IPAddressAllocation{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "ipa1"},
+					Spec:       v1alpha1.IPAddressAllocationSpec{IPAddressBlockVisibility: v1alpha1.IPAddressVisibilityExternal, AllocationSize: 4},
+				},
+				&v1alpha1.NetworkQuota{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "quota1"},
+					Spec:       v1alpha1.NetworkQuotaSpec{Hard: v1alpha1.NetworkQuotaResources{ExternalIPs: servicecommon.Int64(8)}},
+				},
+			).Build()
+			v := &IPAddressAllocationValidator{
+				Client:  client,
+				decoder: admission.NewDecoder(scheme),
+			}
+			assert.Equal(t, tt.want, v.Handle(context.TODO(), tt.req))
+		})
+	}
+}
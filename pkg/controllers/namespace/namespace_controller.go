@@ -24,6 +24,8 @@ import (
 	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
 	_ "github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
 	types "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
@@ -40,6 +42,10 @@ type NamespaceReconciler struct {
 	Scheme     *apimachineryruntime.Scheme
 	NSXConfig  *config.NSXOperatorConfig
 	VPCService types.VPCServiceProvider
+	// SubnetService and SecurityPolicyService are used to move the Subnets and security policies
+	// when the Namespace is moved to another VPCNetworkConfiguration.
+	SubnetService         *subnet.SubnetService
+	SecurityPolicyService *securitypolicy.SecurityPolicyService
 }
 
 func (r *NamespaceReconciler) getDefaultNetworkConfigName() (string, error) {
@@ -170,7 +176,11 @@ func (r *NamespaceReconciler) insertNamespaceNetworkconfigBinding(ns string, ann
 		log.V(2).Info("Empty annotation for Namespace, using default network config", "Namespace", ns)
 		useDefault = true
 	} else {
-		annoNC, ncExist := anno[types.AnnotationVPCNetworkConfig]
+		// The applied network config is kept until the Namespace is moved to the new one.
+		annoNC, ncExist := anno[types.AnnotationAppliedVPCNetworkConfig]
+		if !ncExist {
+			annoNC, ncExist = anno[types.AnnotationVPCNetworkConfig]
+		}
 		if !ncExist {
 			useDefault = true
 		} else {
@@ -198,11 +208,15 @@ We suppose Namespace should have following annotations:
     If the Namespace contains this annotation, first check if the Namespace in annotation is the same as
     the one in Namespace event, if yes, create an infra VPC for it. if	not, skip the whole Namespace event as the infra
     VPC will be created its corresponding Namespace creation event.
+  - A SharedVPC CR declares the same relationship as the annotation above for its owner and consumer Namespaces,
+    the annotation takes precedence if both are present.
   - "nsx.vmware.com/vpc_network_config":"<Supervisor ID>"
     If Namespace does not contain "nsx.vmware.com/shared_vpc_namespace" annotation. Use this annotation to handle VPC creation.
     VPC will locate the network config with the CR name, and create VPC using its config.
   - If the Namespace do not have either of the annotation above, then we believe it is using default VPC, try to search
     default VPC in network config CR store. The default VPC network config CR's name is "default".
+  - If "nsx.vmware.com/vpc_network_config" is changed after the VPC is created, the Namespace is moved to the new
+    network config, see syncNetworkConfigMigration.
 */
 func (r *NamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	startTime := time.Now()
@@ -261,7 +275,7 @@ func (r *NamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		if err := r.createDefaultSubnetSet(ctx, ns, nc.DefaultSubnetSize); err != nil {
 			return common.ResultRequeueAfter10sec, err
 		}
-		return common.ResultNormal, nil
+		return r.syncNetworkConfigMigration(ctx, obj, ncName)
 	} else {
 		metrics.CounterInc(r.NSXConfig, metrics.ControllerDeleteTotal, common.MetricResTypeNamespace)
 		r.VPCService.UnRegisterNamespaceNetworkconfigBinding(obj.GetNamespace())
@@ -255,8 +255,7 @@ func TestNamespaceReconciler_Reconcile(t *testing.T) {
 			}
 			r := createNameSpaceReconciler(objs)
 
-			if tc.patches(r) != nil {
-				patches := tc.patches(r)
+			if patches := tc.patches(r); patches != nil {
 				defer patches.Reset()
 			}
 
@@ -0,0 +1,480 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	types "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+/*
+	Namespace migration between VPCNetworkConfigurations:
+
+The network config in annotation "nsx.vmware.com/applied_vpc_network_config" is the one used by the VPC of the
+Namespace, it is kept until the Namespace has been moved to the network config in annotation
+"nsx.vmware.com/vpc_network_config". The phases of the migration are tracked in Namespace condition
+"NamespaceVPCMigration". A Namespace sharing the VPC of another Namespace, or whose VPC is shared with other
+Namespaces, can not be moved:
+  - If the new network config is in the same NSX project as the applied one, the applied network config is
+    switched directly, the new private IPs and connectivity profile are propagated to the existing VPC.
+  - Draining: wait until the Pods and SubnetPorts are removed from the Namespace, the workloads are expected
+    to be stopped or scaled down. The StaticRoutes, NATRules and IPAddressAllocations realized in the VPC
+    are not moved to the new VPC, they must be removed as well and can be created again after the migration.
+    Reverting annotation "nsx.vmware.com/vpc_network_config" in this phase aborts the migration.
+  - Cleaning: the NSX Subnets of the Subnets/SubnetSets and the NSX security policies of the SecurityPolicies/
+    NetworkPolicies are deleted from the VPC, the imported NSX Subnets are released instead of deleted, then
+    the applied network config is switched. The migration
+    can not be aborted once it starts cleaning, the target is recorded in annotation
+    "nsx.vmware.com/vpc_migration_target" until the switch is done.
+  - CreatingVPC: the NetworkInfo controller deletes the old VPC and creates the VPC under the new network config.
+    The VPCs out of the applied network config are only deleted in this phase.
+  - MovingSubnets: the Subnets/SubnetSets are realized in the new VPC, and the security policies are created
+    again in the new VPC. The workloads can be started again after the migration is completed.
+*/
+
+const (
+	NamespaceVPCMigration = v1.NamespaceConditionType(types.NamespaceConditionVPCMigration)
+
+	MigrationReasonDraining      = "Draining"
+	MigrationReasonCleaning      = "Cleaning"
+	MigrationReasonCreatingVPC   = types.VPCMigrationReasonCreatingVPC
+	MigrationReasonMovingSubnets = "MovingSubnets"
+	MigrationReasonCompleted     = "Completed"
+	MigrationReasonAborted       = "Aborted"
+	MigrationReasonFailed        = "Failed"
+)
+
+// syncNetworkConfigMigration moves the Namespace to the VPCNetworkConfiguration ncName if it is not applied yet,
+// or continues the ongoing migration of the Namespace.
+func (r *NamespaceReconciler) syncNetworkConfigMigration(ctx context.Context, obj *v1.Namespace, ncName string) (ctrl.Result, error) {
+	annotations := obj.GetAnnotations()
+	appliedNCName, ncApplied := annotations[types.AnnotationAppliedVPCNetworkConfig]
+	if !ncApplied {
+		changes := map[string]string{types.AnnotationAppliedVPCNetworkConfig: ncName}
+		if err := util.UpdateK8sResourceAnnotation(r.Client, ctx, obj, changes); err != nil {
+			log.Error(err, "Failed to record the applied network config", "Namespace", obj.Name)
+			return common.ResultRequeue, err
+		}
+		return common.ResultNormal, nil
+	}
+
+	if targetNCName := annotations[types.AnnotationVPCMigrationTarget]; targetNCName != "" {
+		return r.switchNetworkConfig(ctx, obj, appliedNCName, targetNCName)
+	}
+	if appliedNCName != ncName {
+		return r.startMigration(ctx, obj, appliedNCName, ncName)
+	}
+
+	cond := getMigrationCondition(obj)
+	if cond == nil {
+		return common.ResultNormal, nil
+	}
+	switch cond.Reason {
+	case MigrationReasonDraining, MigrationReasonFailed:
+		log.Info("Namespace migration is aborted", "Namespace", obj.Name, "NetworkConfig", appliedNCName)
+		msg := fmt.Sprintf("Namespace stays with VPCNetworkConfiguration %s", appliedNCName)
+		return common.ResultNormal, r.setMigrationCondition(ctx, obj.Name, v1.ConditionFalse, MigrationReasonAborted, msg)
+	case MigrationReasonCreatingVPC, MigrationReasonMovingSubnets:
+		return r.completeMigration(ctx, obj, appliedNCName)
+	}
+	return common.ResultNormal, nil
+}
+
+func (r *NamespaceReconciler) startMigration(ctx context.Context, obj *v1.Namespace, fromNCName, toNCName string) (ctrl.Result, error) {
+	ns := obj.Name
+	log.Info("Start moving Namespace to another network config", "Namespace", ns, "from", fromNCName, "to", toNCName)
+	sharedNS, err := util.GetSharedVPCNamespace(r.Client, ctx, obj)
+	if err != nil {
+		return common.ResultRequeue, err
+	}
+	if sharedNS != "" && sharedNS != ns {
+		msg := fmt.Sprintf("Namespace shares the VPC of Namespace %s and cannot be moved to VPCNetworkConfiguration %s", sharedNS, toNCName)
+		return common.ResultNormal, r.setMigrationCondition(ctx, ns, v1.ConditionFalse, MigrationReasonFailed, msg)
+	}
+	// The VPC of the owner Namespace is deleted by the migration, it can't be moved while the VPC is shared.
+	consumers, err := r.listSharedVPCConsumers(ctx, ns)
+	if err != nil {
+		return common.ResultRequeue, err
+	}
+	if len(consumers) > 0 {
+		msg := fmt.Sprintf("Namespace shares its VPC with Namespace(s) %s and cannot be moved to VPCNetworkConfiguration %s", strings.Join(consumers, ", "), toNCName)
+		return common.ResultNormal, r.setMigrationCondition(ctx, ns, v1.ConditionFalse, MigrationReasonFailed, msg)
+	}
+
+	fromNC, fromExist := r.VPCService.GetVPCNetworkConfig(fromNCName)
+	toNC, _ := r.VPCService.GetVPCNetworkConfig(toNCName)
+	// The VPC is kept if the new network config is in the same NSX project, the changes of the private IPs and
+	// connectivity profile are propagated to it by the NetworkInfo controller.
+	if len(r.VPCService.ListVPCInfo(ns)) == 0 || (fromExist && isInPlaceMigration(fromNC, toNC)) {
+		if err := r.updateNetworkConfigAnnotations(ctx, obj, toNCName); err != nil {
+			return common.ResultRequeue, err
+		}
+		r.VPCService.RegisterNamespaceNetworkconfigBinding(ns, toNCName)
+		return common.ResultNormal, r.setMigrationCondition(ctx, ns, v1.ConditionTrue, MigrationReasonCompleted, migrationCompletedMessage(toNCName))
+	}
+
+	pending, err := r.countDrainingResources(ctx, ns)
+	if err != nil {
+		return common.ResultRequeue, err
+	}
+	if len(pending) > 0 {
+		msg := fmt.Sprintf("Waiting for %s to be removed before moving to VPCNetworkConfiguration %s", strings.Join(pending, ", "), toNCName)
+		if err := r.setMigrationCondition(ctx, ns, v1.ConditionFalse, MigrationReasonDraining, msg); err != nil {
+			return common.ResultRequeue, err
+		}
+		return common.ResultRequeueAfter60sec, nil
+	}
+
+	changes := map[string]string{types.AnnotationVPCMigrationTarget: toNCName}
+	if err := util.UpdateK8sResourceAnnotation(r.Client, ctx, obj, changes); err != nil {
+		log.Error(err, "Failed to record the migration target", "Namespace", ns)
+		return common.ResultRequeue, err
+	}
+	return r.switchNetworkConfig(ctx, obj, fromNCName, toNCName)
+}
+
+// switchNetworkConfig deletes the Subnets and security policies of the Namespace from the VPC of the applied
+// network config, then switches the Namespace to the target network config.
+func (r *NamespaceReconciler) switchNetworkConfig(ctx context.Context, obj *v1.Namespace, fromNCName, toNCName string) (ctrl.Result, error) {
+	ns := obj.Name
+	msg := fmt.Sprintf("Deleting Subnets and security policies from the VPC of VPCNetworkConfiguration %s", fromNCName)
+	if err := r.setMigrationCondition(ctx, ns, v1.ConditionFalse, MigrationReasonCleaning, msg); err != nil {
+		return common.ResultRequeue, err
+	}
+	if err := r.cleanupVPCResources(ctx, ns); err != nil {
+		log.Error(err, "Failed to delete resources from VPC", "Namespace", ns)
+		msg = fmt.Sprintf("Failed to delete resources from the VPC of VPCNetworkConfiguration %s: %v", fromNCName, err)
+		if err := r.setMigrationCondition(ctx, ns, v1.ConditionFalse, MigrationReasonCleaning, msg); err != nil {
+			log.Error(err, "Failed to update Namespace condition", "Namespace", ns)
+		}
+		return common.ResultRequeueAfter10sec, err
+	}
+
+	// The condition is set before switching the network config, the NetworkInfo controller deletes the old VPC
+	// only when the Namespace is being migrated.
+	msg = fmt.Sprintf("Creating VPC under VPCNetworkConfiguration %s", toNCName)
+	if err := r.setMigrationCondition(ctx, ns, v1.ConditionFalse, MigrationReasonCreatingVPC, msg); err != nil {
+		return common.ResultRequeue, err
+	}
+	if err := r.updateNetworkConfigAnnotations(ctx, obj, toNCName); err != nil {
+		return common.ResultRequeue, err
+	}
+	r.VPCService.RegisterNamespaceNetworkconfigBinding(ns, toNCName)
+	log.Info("Switched Namespace to network config", "Namespace", ns, "NetworkConfig", toNCName)
+	return common.ResultRequeueAfter10sec, nil
+}
+
+// completeMigration waits until the VPC is created under the network config ncName and the Subnets are realized
+// in it, the security policies are created in the new VPC as well.
+func (r *NamespaceReconciler) completeMigration(ctx context.Context, obj *v1.Namespace, ncName string) (ctrl.Result, error) {
+	ns := obj.Name
+	vpcInfoList := r.VPCService.ListVPCInfo(ns)
+	if len(vpcInfoList) == 0 {
+		log.Info("VPC is not created under network config yet, requeueing", "Namespace", ns, "NetworkConfig", ncName)
+		return common.ResultRequeueAfter10sec, nil
+	}
+
+	msg := fmt.Sprintf("Moving Subnets and security policies to the VPC of VPCNetworkConfiguration %s", ncName)
+	if err := r.setMigrationCondition(ctx, ns, v1.ConditionFalse, MigrationReasonMovingSubnets, msg); err != nil {
+		return common.ResultRequeue, err
+	}
+	if err := r.restoreSecurityPolicies(ctx, ns); err != nil {
+		log.Error(err, "Failed to create security policies in the new VPC", "Namespace", ns)
+		msg = fmt.Sprintf("Failed to create security policies in the VPC of VPCNetworkConfiguration %s: %v", ncName, err)
+		if err := r.setMigrationCondition(ctx, ns, v1.ConditionFalse, MigrationReasonMovingSubnets, msg); err != nil {
+			log.Error(err, "Failed to update Namespace condition", "Namespace", ns)
+		}
+		return common.ResultRequeueAfter10sec, err
+	}
+
+	vpcInfo := vpcInfoList[0]
+	pending, err := r.countPendingSubnets(ctx, ns, fmt.Sprintf(vpc.VPCKey, vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID))
+	if err != nil {
+		return common.ResultRequeue, err
+	}
+	if pending > 0 {
+		log.Info("Subnets are not realized in the new VPC yet, requeueing", "Namespace", ns, "Count", pending)
+		return common.ResultRequeueAfter10sec, nil
+	}
+	log.Info("Moved Namespace to network config", "Namespace", ns, "NetworkConfig", ncName)
+	return common.ResultNormal, r.setMigrationCondition(ctx, ns, v1.ConditionTrue, MigrationReasonCompleted, migrationCompletedMessage(ncName))
+}
+
+// isInPlaceMigration checks if the VPC of the Namespace can be kept when it is moved between the network configs.
+func isInPlaceMigration(fromNC, toNC types.VPCNetworkConfigInfo) bool {
+	return !vpc.IsPreCreatedVPC(fromNC) && !vpc.IsPreCreatedVPC(toNC) && fromNC.Org == toNC.Org && fromNC.NSXProject == toNC.NSXProject
+}
+
+func migrationCompletedMessage(ncName string) string {
+	return fmt.Sprintf("Namespace is moved to VPCNetworkConfiguration %s", ncName)
+}
+
+// countDrainingResources counts the resources in the Namespace which must be removed before the VPC is moved:
+// the running Pods and SubnetPorts connected to the Subnets of the VPC, and the StaticRoutes, NATRules and
+// IPAddressAllocations realized in the VPC. The counts of the resource kinds still present are returned.
+func (r *NamespaceReconciler) countDrainingResources(ctx context.Context, ns string) ([]string, error) {
+	podList := &v1.PodList{}
+	if err := r.Client.List(ctx, podList, client.InNamespace(ns)); err != nil {
+		log.Error(err, "Failed to list Pods", "Namespace", ns)
+		return nil, err
+	}
+	pods := 0
+	for _, pod := range podList.Items {
+		if pod.Spec.HostNetwork || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		pods++
+	}
+	var pending []string
+	if pods > 0 {
+		pending = append(pending, fmt.Sprintf("%d Pod(s)", pods))
+	}
+	for _, item := range []struct {
+		kind string
+		list client.ObjectList
+	}{
+		{kind: "SubnetPort", list: &v1alpha1.SubnetPortList{}},
+		{kind: "StaticRoute", list: &v1alpha1.StaticRouteList{}},
+		{kind: "NATRule", list: &v1alpha1.NATRuleList{}},
+		{kind: "IPAddressAllocation", list: &v1alpha1.IPAddressAllocationList{}},
+	} {
+		if err := r.Client.List(ctx, item.list, client.InNamespace(ns)); err != nil {
+			log.Error(err, "Failed to list resources", "Namespace", ns, "Kind", item.kind)
+			return nil, err
+		}
+		if count := meta.LenList(item.list); count > 0 {
+			pending = append(pending, fmt.Sprintf("%d %s(s)", count, item.kind))
+		}
+	}
+	return pending, nil
+}
+
+// listSharedVPCConsumers returns the other Namespaces using the VPC of Namespace ns, either from the SharedVPCs
+// owned by ns or from the shared VPC annotation of the Namespaces.
+func (r *NamespaceReconciler) listSharedVPCConsumers(ctx context.Context, ns string) ([]string, error) {
+	consumers := sets.New[string]()
+	sharedVPCList := &v1alpha1.SharedVPCList{}
+	if err := r.Client.List(ctx, sharedVPCList); err != nil {
+		log.Error(err, "Failed to list SharedVPCs", "Namespace", ns)
+		return nil, err
+	}
+	for _, sharedVPC := range sharedVPCList.Items {
+		if sharedVPC.Spec.OwnerNamespace == ns {
+			consumers.Insert(sharedVPC.Spec.ConsumerNamespaces...)
+		}
+	}
+	nsList := &v1.NamespaceList{}
+	if err := r.Client.List(ctx, nsList); err != nil {
+		log.Error(err, "Failed to list Namespaces", "Namespace", ns)
+		return nil, err
+	}
+	for _, item := range nsList.Items {
+		if item.Annotations[types.AnnotationSharedVPCNamespace] == ns {
+			consumers.Insert(item.Name)
+		}
+	}
+	consumers.Delete(ns)
+	return sets.List(consumers), nil
+}
+
+// cleanupVPCResources deletes the NSX Subnets and security policies of the Namespace from its VPC,
+// the resources are created again by their controllers or restoreSecurityPolicies in the new VPC. The
+// imported NSX Subnets are owned by the pre-created VPC, they are only released.
+func (r *NamespaceReconciler) cleanupVPCResources(ctx context.Context, ns string) error {
+	subnetList := &v1alpha1.SubnetList{}
+	if err := r.Client.List(ctx, subnetList, client.InNamespace(ns)); err != nil {
+		return err
+	}
+	for _, subnetCR := range subnetList.Items {
+		for _, nsxSubnet := range r.SubnetService.ListSubnetCreatedBySubnet(string(subnetCR.UID)) {
+			if subnetCR.Spec.NSXSubnetPath != "" {
+				if err := r.SubnetService.ReleaseImportedSubnet(*nsxSubnet); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := r.SubnetService.DeleteSubnet(*nsxSubnet); err != nil {
+				return err
+			}
+		}
+	}
+	subnetSetList := &v1alpha1.SubnetSetList{}
+	if err := r.Client.List(ctx, subnetSetList, client.InNamespace(ns)); err != nil {
+		return err
+	}
+	for i := range subnetSetList.Items {
+		subnetSet := &subnetSetList.Items[i]
+		nsxSubnets := r.SubnetService.ListSubnetCreatedBySubnetSet(string(subnetSet.UID))
+		if len(nsxSubnets) == 0 {
+			continue
+		}
+		for _, nsxSubnet := range nsxSubnets {
+			if err := r.SubnetService.DeleteSubnet(*nsxSubnet); err != nil {
+				return err
+			}
+		}
+		if err := r.SubnetService.UpdateSubnetSetStatus(subnetSet); err != nil {
+			return err
+		}
+	}
+
+	securityPolicyList := &v1alpha1.SecurityPolicyList{}
+	if err := r.Client.List(ctx, securityPolicyList, client.InNamespace(ns)); err != nil {
+		return err
+	}
+	for _, sp := range securityPolicyList.Items {
+		if err := r.SecurityPolicyService.DeleteSecurityPolicy(sp.UID, false, false, types.ResourceTypeSecurityPolicy); err != nil {
+			return err
+		}
+	}
+	networkPolicyList := &networkingv1.NetworkPolicyList{}
+	if err := r.Client.List(ctx, networkPolicyList, client.InNamespace(ns)); err != nil {
+		return err
+	}
+	for i := range networkPolicyList.Items {
+		if err := r.SecurityPolicyService.DeleteSecurityPolicy(&networkPolicyList.Items[i], false, false, types.ResourceTypeNetworkPolicy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreSecurityPolicies creates the NSX security policies of the SecurityPolicies and NetworkPolicies in the
+// Namespace, they are created in the current VPC of the Namespace.
+func (r *NamespaceReconciler) restoreSecurityPolicies(ctx context.Context, ns string) error {
+	securityPolicyList := &v1alpha1.SecurityPolicyList{}
+	if err := r.Client.List(ctx, securityPolicyList, client.InNamespace(ns)); err != nil {
+		return err
+	}
+	for i := range securityPolicyList.Items {
+		sp := &securityPolicyList.Items[i]
+		if !sp.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.SecurityPolicyService.CreateOrUpdateSecurityPolicy(securitypolicy.VPCToT1(sp)); err != nil {
+			return err
+		}
+	}
+	networkPolicyList := &networkingv1.NetworkPolicyList{}
+	if err := r.Client.List(ctx, networkPolicyList, client.InNamespace(ns)); err != nil {
+		return err
+	}
+	for i := range networkPolicyList.Items {
+		np := &networkPolicyList.Items[i]
+		if !np.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.SecurityPolicyService.CreateOrUpdateSecurityPolicy(np); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countPendingSubnets counts the Subnets in the Namespace which are not realized in the VPC vpcPath yet. The
+// imported Subnets are not counted as their NSX Subnets are not moved to the new VPC.
+func (r *NamespaceReconciler) countPendingSubnets(ctx context.Context, ns string, vpcPath string) (int, error) {
+	subnetList := &v1alpha1.SubnetList{}
+	if err := r.Client.List(ctx, subnetList, client.InNamespace(ns)); err != nil {
+		log.Error(err, "Failed to list Subnets", "Namespace", ns)
+		return 0, err
+	}
+	pending := 0
+	for _, subnetCR := range subnetList.Items {
+		if subnetCR.Spec.NSXSubnetPath != "" {
+			continue
+		}
+		realized := false
+		for _, nsxSubnet := range r.SubnetService.ListSubnetCreatedBySubnet(string(subnetCR.UID)) {
+			if nsxSubnet.Path != nil && strings.HasPrefix(*nsxSubnet.Path, vpcPath+"/") {
+				realized = true
+				break
+			}
+		}
+		if !realized {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// updateNetworkConfigAnnotations records ncName as the applied network config of the Namespace and
+// clears the migration target.
+func (r *NamespaceReconciler) updateNetworkConfigAnnotations(ctx context.Context, obj *v1.Namespace, ncName string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &v1.Namespace{}
+		if err := r.Client.Get(ctx, apitypes.NamespacedName{Name: obj.Name}, latest); err != nil {
+			return err
+		}
+		if latest.Annotations == nil {
+			latest.Annotations = map[string]string{}
+		}
+		latest.Annotations[types.AnnotationAppliedVPCNetworkConfig] = ncName
+		delete(latest.Annotations, types.AnnotationVPCMigrationTarget)
+		if err := r.Client.Update(ctx, latest); err != nil {
+			return err
+		}
+		latest.DeepCopyInto(obj)
+		return nil
+	})
+}
+
+func getMigrationCondition(obj *v1.Namespace) *v1.NamespaceCondition {
+	for i := range obj.Status.Conditions {
+		if obj.Status.Conditions[i].Type == NamespaceVPCMigration {
+			return &obj.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// setMigrationCondition updates the migration condition of the Namespace, the Namespace is not updated if
+// the condition is not changed.
+func (r *NamespaceReconciler) setMigrationCondition(ctx context.Context, nsName string, status v1.ConditionStatus, reason, msg string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj := &v1.Namespace{}
+		if err := r.Client.Get(ctx, apitypes.NamespacedName{Name: nsName}, obj); err != nil {
+			return err
+		}
+		if cond := getMigrationCondition(obj); cond != nil && cond.Status == status && cond.Reason == reason && cond.Message == msg {
+			return nil
+		}
+		conditions := make([]v1.NamespaceCondition, 0, len(obj.Status.Conditions)+1)
+		for _, cond := range obj.Status.Conditions {
+			if cond.Type != NamespaceVPCMigration {
+				conditions = append(conditions, cond)
+			}
+		}
+		obj.Status.Conditions = append(conditions, v1.NamespaceCondition{
+			Type:               NamespaceVPCMigration,
+			Status:             status,
+			Reason:             reason,
+			Message:            msg,
+			LastTransitionTime: metav1.Now(),
+		})
+		if err := r.Client.Status().Update(ctx, obj); err != nil {
+			return err
+		}
+		log.Info("Updated Namespace migration condition", "Namespace", nsName, "reason", reason, "message", msg)
+		return nil
+	})
+}
@@ -0,0 +1,274 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package namespace
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+)
+
+const (
+	migrationNS       = "ns1"
+	fromNCName        = "nc1"
+	toNCName          = "nc2"
+	newVPCPath        = "/orgs/default/projects/p2/vpcs/vpc1"
+	subnetCRUID       = "subnet-uid"
+	subnetSetUID      = "subnetset-uid"
+	securityPolicyUID = "sp-uid"
+)
+
+func createMigrationReconciler(objs ...client.Object) *NamespaceReconciler {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(vpcv1alpha1.AddToScheme(newScheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(objs...).WithStatusSubresource(&v1.Namespace{}).Build()
+	r := createNameSpaceReconciler(nil)
+	r.Client = fakeClient
+	r.SubnetService = &subnet.SubnetService{}
+	r.SecurityPolicyService = &securitypolicy.SecurityPolicyService{}
+	r.VPCService.RegisterVPCNetworkConfig(fromNCName, servicecommon.VPCNetworkConfigInfo{Name: fromNCName, Org: "default", NSXProject: "p1"})
+	r.VPCService.RegisterVPCNetworkConfig(toNCName, servicecommon.VPCNetworkConfigInfo{Name: toNCName, Org: "default", NSXProject: "p2"})
+	return r
+}
+
+func newMigrationNamespace(ncName, appliedNCName string) *v1.Namespace {
+	return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name: migrationNS,
+		Annotations: map[string]string{
+			servicecommon.AnnotationVPCNetworkConfig:        ncName,
+			servicecommon.AnnotationAppliedVPCNetworkConfig: appliedNCName,
+		},
+	}}
+}
+
+func getNamespace(t *testing.T, r *NamespaceReconciler) *v1.Namespace {
+	ns := &v1.Namespace{}
+	require.NoError(t, r.Client.Get(context.TODO(), apitypes.NamespacedName{Name: migrationNS}, ns))
+	return ns
+}
+
+func syncMigration(t *testing.T, r *NamespaceReconciler) (*v1.Namespace, error) {
+	ns := getNamespace(t, r)
+	_, err := r.syncNetworkConfigMigration(context.TODO(), ns, ns.Annotations[servicecommon.AnnotationVPCNetworkConfig])
+	return getNamespace(t, r), err
+}
+
+func patchListVPCInfo(vpcInfos []servicecommon.VPCResourceInfo) *gomonkey.Patches {
+	return gomonkey.ApplyMethod(reflect.TypeOf(&vpc.VPCService{}), "ListVPCInfo", func(_ *vpc.VPCService, ns string) []servicecommon.VPCResourceInfo {
+		return vpcInfos
+	})
+}
+
+func TestSyncNetworkConfigMigration_RecordAppliedNetworkConfig(t *testing.T) {
+	ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: migrationNS}}
+	r := createMigrationReconciler(ns)
+	res, err := r.syncNetworkConfigMigration(context.TODO(), ns, fromNCName)
+	require.NoError(t, err)
+	assert.Equal(t, common.ResultNormal, res)
+	ns = getNamespace(t, r)
+	assert.Equal(t, fromNCName, ns.Annotations[servicecommon.AnnotationAppliedVPCNetworkConfig])
+	assert.Nil(t, getMigrationCondition(ns))
+}
+
+func TestSyncNetworkConfigMigration_InPlace(t *testing.T) {
+	r := createMigrationReconciler(newMigrationNamespace(toNCName, fromNCName))
+	r.VPCService.RegisterVPCNetworkConfig(toNCName, servicecommon.VPCNetworkConfigInfo{Name: toNCName, Org: "default", NSXProject: "p1"})
+	patches := patchListVPCInfo([]servicecommon.VPCResourceInfo{{OrgID: "default", ProjectID: "p1", VPCID: "vpc1"}})
+	defer patches.Reset()
+
+	ns, err := syncMigration(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, toNCName, ns.Annotations[servicecommon.AnnotationAppliedVPCNetworkConfig])
+	assert.Equal(t, toNCName, r.VPCService.GetVPCNetworkConfigByNamespace(migrationNS).Name)
+	cond := getMigrationCondition(ns)
+	require.NotNil(t, cond)
+	assert.Equal(t, v1.ConditionTrue, cond.Status)
+	assert.Equal(t, MigrationReasonCompleted, cond.Reason)
+}
+
+func TestSyncNetworkConfigMigration_SharedVPCNamespace(t *testing.T) {
+	ns := newMigrationNamespace(toNCName, fromNCName)
+	ns.Annotations[servicecommon.AnnotationSharedVPCNamespace] = "ns-owner"
+	r := createMigrationReconciler(ns)
+
+	ns, err := syncMigration(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, fromNCName, ns.Annotations[servicecommon.AnnotationAppliedVPCNetworkConfig])
+	assert.Equal(t, MigrationReasonFailed, getMigrationCondition(ns).Reason)
+}
+
+func TestSyncNetworkConfigMigration_SharedVPCOwner(t *testing.T) {
+	consumerNS := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-consumer1", Annotations: map[string]string{servicecommon.AnnotationSharedVPCNamespace: migrationNS}}}
+	sharedVPC := &vpcv1alpha1.SharedVPC{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-vpc", Namespace: migrationNS},
+		Spec:       vpcv1alpha1.SharedVPCSpec{OwnerNamespace: migrationNS, ConsumerNamespaces: []string{"ns-consumer2"}},
+	}
+	r := createMigrationReconciler(newMigrationNamespace(toNCName, fromNCName), consumerNS, sharedVPC)
+
+	ns, err := syncMigration(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, fromNCName, ns.Annotations[servicecommon.AnnotationAppliedVPCNetworkConfig])
+	cond := getMigrationCondition(ns)
+	assert.Equal(t, MigrationReasonFailed, cond.Reason)
+	assert.Equal(t, "Namespace shares its VPC with Namespace(s) ns-consumer1, ns-consumer2 and cannot be moved to VPCNetworkConfiguration nc2", cond.Message)
+}
+
+func TestSyncNetworkConfigMigration_DrainAndAbort(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: migrationNS}}
+	completedPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: migrationNS}, Status: v1.PodStatus{Phase: v1.PodSucceeded}}
+	staticRoute := &vpcv1alpha1.StaticRoute{ObjectMeta: metav1.ObjectMeta{Name: "route1", Namespace: migrationNS}}
+	natRule := &vpcv1alpha1.NATRule{ObjectMeta: metav1.ObjectMeta{Name: "natrule1", Namespace: migrationNS}}
+	ipAddressAllocation := &vpcv1alpha1.IPAddressAllocation{ObjectMeta: metav1.ObjectMeta{Name: "ipalloc1", Namespace: migrationNS}}
+	r := createMigrationReconciler(newMigrationNamespace(toNCName, fromNCName), pod, completedPod, staticRoute, natRule, ipAddressAllocation)
+	patches := patchListVPCInfo([]servicecommon.VPCResourceInfo{{OrgID: "default", ProjectID: "p1", VPCID: "vpc1"}})
+	defer patches.Reset()
+
+	ns := getNamespace(t, r)
+	res, err := r.syncNetworkConfigMigration(context.TODO(), ns, toNCName)
+	require.NoError(t, err)
+	assert.Equal(t, common.ResultRequeueAfter60sec, res)
+	ns = getNamespace(t, r)
+	cond := getMigrationCondition(ns)
+	require.NotNil(t, cond)
+	assert.Equal(t, MigrationReasonDraining, cond.Reason)
+	assert.Contains(t, cond.Message, "Waiting for 1 Pod(s), 1 StaticRoute(s), 1 NATRule(s), 1 IPAddressAllocation(s) to be removed")
+	assert.Equal(t, fromNCName, ns.Annotations[servicecommon.AnnotationAppliedVPCNetworkConfig])
+
+	// Revert the network config to abort the migration.
+	ns.Annotations[servicecommon.AnnotationVPCNetworkConfig] = fromNCName
+	require.NoError(t, r.Client.Update(context.TODO(), ns))
+	ns, err = syncMigration(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, MigrationReasonAborted, getMigrationCondition(ns).Reason)
+	assert.Equal(t, fromNCName, ns.Annotations[servicecommon.AnnotationAppliedVPCNetworkConfig])
+}
+
+func TestSyncNetworkConfigMigration_MoveVPC(t *testing.T) {
+	subnetCR := &vpcv1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet1", Namespace: migrationNS, UID: subnetCRUID}}
+	importedSubnetCR := &vpcv1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet3", Namespace: migrationNS, UID: "imported-subnet-uid"},
+		Spec:       vpcv1alpha1.SubnetSpec{NSXSubnetPath: "/orgs/default/projects/p1/vpcs/vpc1/subnets/subnet3"},
+	}
+	subnetSet := &vpcv1alpha1.SubnetSet{ObjectMeta: metav1.ObjectMeta{Name: "subnetset1", Namespace: migrationNS, UID: subnetSetUID}}
+	sp := &vpcv1alpha1.SecurityPolicy{ObjectMeta: metav1.ObjectMeta{Name: "sp1", Namespace: migrationNS, UID: securityPolicyUID}}
+	np := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "np1", Namespace: migrationNS}}
+	r := createMigrationReconciler(newMigrationNamespace(toNCName, fromNCName), subnetCR, importedSubnetCR, subnetSet, sp, np)
+
+	vpcInfos := []servicecommon.VPCResourceInfo{{OrgID: "default", ProjectID: "p1", VPCID: "vpc1"}}
+	nsxSubnets := map[string][]*model.VpcSubnet{
+		subnetCRUID:           {{Id: servicecommon.String("subnet1"), Path: servicecommon.String("/orgs/default/projects/p1/vpcs/vpc1/subnets/subnet1")}},
+		subnetSetUID:          {{Id: servicecommon.String("subnet2"), Path: servicecommon.String("/orgs/default/projects/p1/vpcs/vpc1/subnets/subnet2")}},
+		"imported-subnet-uid": {{Id: servicecommon.String("subnet3"), Path: servicecommon.String("/orgs/default/projects/p1/vpcs/vpc1/subnets/subnet3")}},
+	}
+	var deletedSubnets, releasedSubnets, deletedPolicies, createdPolicies []string
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(&vpc.VPCService{}), "ListVPCInfo", func(_ *vpc.VPCService, ns string) []servicecommon.VPCResourceInfo {
+		return vpcInfos
+	})
+	defer patches.Reset()
+	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "ListSubnetCreatedBySubnet", func(_ *subnet.SubnetService, id string) []*model.VpcSubnet {
+		return nsxSubnets[id]
+	})
+	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "ListSubnetCreatedBySubnetSet", func(_ *subnet.SubnetService, id string) []*model.VpcSubnet {
+		return nsxSubnets[id]
+	})
+	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, nsxSubnet model.VpcSubnet) error {
+		deletedSubnets = append(deletedSubnets, *nsxSubnet.Id)
+		return nil
+	})
+	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "ReleaseImportedSubnet", func(_ *subnet.SubnetService, nsxSubnet model.VpcSubnet) error {
+		releasedSubnets = append(releasedSubnets, *nsxSubnet.Id)
+		return nil
+	})
+	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "UpdateSubnetSetStatus", func(_ *subnet.SubnetService, obj *vpcv1alpha1.SubnetSet) error {
+		return nil
+	})
+	patches.ApplyMethod(reflect.TypeOf(r.SecurityPolicyService), "DeleteSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, obj interface{}, isGC, isVPCCleanup bool, createdFor string) error {
+		deletedPolicies = append(deletedPolicies, createdFor)
+		return nil
+	})
+	patches.ApplyMethod(reflect.TypeOf(r.SecurityPolicyService), "CreateOrUpdateSecurityPolicy", func(_ *securitypolicy.SecurityPolicyService, obj interface{}) error {
+		switch o := obj.(type) {
+		case *v1alpha1.SecurityPolicy:
+			createdPolicies = append(createdPolicies, o.Name)
+		case *networkingv1.NetworkPolicy:
+			createdPolicies = append(createdPolicies, o.Name)
+		}
+		return nil
+	})
+
+	// The Subnets and security policies are deleted from the old VPC, then the network config is switched.
+	ns, err := syncMigration(t, r)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"subnet1", "subnet2"}, deletedSubnets)
+	// The imported NSX Subnet is released instead of deleted.
+	assert.Equal(t, []string{"subnet3"}, releasedSubnets)
+	assert.ElementsMatch(t, []string{servicecommon.ResourceTypeSecurityPolicy, servicecommon.ResourceTypeNetworkPolicy}, deletedPolicies)
+	assert.Equal(t, toNCName, ns.Annotations[servicecommon.AnnotationAppliedVPCNetworkConfig])
+	assert.NotContains(t, ns.Annotations, servicecommon.AnnotationVPCMigrationTarget)
+	assert.Equal(t, toNCName, r.VPCService.GetVPCNetworkConfigByNamespace(migrationNS).Name)
+	assert.Equal(t, MigrationReasonCreatingVPC, getMigrationCondition(ns).Reason)
+
+	// Wait for the new VPC.
+	vpcInfos = nil
+	ns, err = syncMigration(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, MigrationReasonCreatingVPC, getMigrationCondition(ns).Reason)
+
+	// Wait for the Subnet to be realized in the new VPC, the security policies are created again.
+	vpcInfos = []servicecommon.VPCResourceInfo{{OrgID: "default", ProjectID: "p2", VPCID: "vpc1"}}
+	nsxSubnets = map[string][]*model.VpcSubnet{}
+	ns, err = syncMigration(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, MigrationReasonMovingSubnets, getMigrationCondition(ns).Reason)
+	assert.ElementsMatch(t, []string{"sp1", "np1"}, createdPolicies)
+
+	nsxSubnets[subnetCRUID] = []*model.VpcSubnet{{Id: servicecommon.String("subnet1"), Path: servicecommon.String(newVPCPath + "/subnets/subnet1")}}
+	ns, err = syncMigration(t, r)
+	require.NoError(t, err)
+	cond := getMigrationCondition(ns)
+	assert.Equal(t, v1.ConditionTrue, cond.Status)
+	assert.Equal(t, MigrationReasonCompleted, cond.Reason)
+}
+
+func TestSyncNetworkConfigMigration_ContinueAfterRevert(t *testing.T) {
+	// The migration target is kept once the migration starts cleaning the VPC.
+	ns := newMigrationNamespace(fromNCName, fromNCName)
+	ns.Annotations[servicecommon.AnnotationVPCMigrationTarget] = toNCName
+	r := createMigrationReconciler(ns)
+
+	ns, err := syncMigration(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, toNCName, ns.Annotations[servicecommon.AnnotationAppliedVPCNetworkConfig])
+	assert.Equal(t, MigrationReasonCreatingVPC, getMigrationCondition(ns).Reason)
+}
+
+func TestIsInPlaceMigration(t *testing.T) {
+	nc1 := servicecommon.VPCNetworkConfigInfo{Org: "default", NSXProject: "p1"}
+	assert.True(t, isInPlaceMigration(nc1, servicecommon.VPCNetworkConfigInfo{Org: "default", NSXProject: "p1"}))
+	assert.False(t, isInPlaceMigration(nc1, servicecommon.VPCNetworkConfigInfo{Org: "default", NSXProject: "p2"}))
+	assert.False(t, isInPlaceMigration(nc1, servicecommon.VPCNetworkConfigInfo{Org: "default", NSXProject: "p1", VPCPath: "/orgs/default/projects/p1/vpcs/vpc2"}))
+}
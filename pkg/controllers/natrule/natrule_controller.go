@@ -0,0 +1,346 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package natrule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	commonservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/natrule"
+)
+
+var (
+	log                  = &logger.Log
+	ResultNormal         = common.ResultNormal
+	ResultRequeue        = common.ResultRequeue
+	MetricResTypeNATRule = common.MetricResTypeNATRule
+)
+
+// NATRuleReconciler reconciles a NATRule object
+type NATRuleReconciler struct {
+	Client        client.Client
+	Scheme        *apimachineryruntime.Scheme
+	Service       *natrule.NATRuleService
+	Recorder      record.EventRecorder
+	StatusUpdater common.StatusUpdater
+}
+
+func setNATRuleReadyStatusTrue(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, args ...interface{}) {
+	natRule := obj.(*v1alpha1.NATRule)
+	translatedNetwork := natRule.Status.TranslatedNetwork
+	if len(args) == 1 {
+		translatedNetwork = args[0].(string)
+	}
+	updateNATRuleStatus(client, ctx, natRule, v1alpha1.Condition{
+		Type:               v1alpha1.Ready,
+		Status:             v1.ConditionTrue,
+		Message:            "NSX NAT rule has been successfully created/updated",
+		Reason:             "NATRuleReady",
+		LastTransitionTime: transitionTime,
+	}, translatedNetwork)
+}
+
+func setNATRuleReadyStatusFalse(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, err error, _ ...interface{}) {
+	natRule := obj.(*v1alpha1.NATRule)
+	updateNATRuleStatus(client, ctx, natRule, v1alpha1.Condition{
+		Type:               v1alpha1.Ready,
+		Status:             v1.ConditionFalse,
+		Message:            fmt.Sprintf("Error occurred while processing the NATRule CR. Error: %v", err),
+		Reason:             "NATRuleNotReady",
+		LastTransitionTime: transitionTime,
+	}, natRule.Status.TranslatedNetwork)
+}
+
+// updateNATRuleStatus updates the status of the NATRule only if it is changed, so that the status update
+// doesn't trigger the reconciliation of the NATRule again.
+func updateNATRuleStatus(client client.Client, ctx context.Context, natRule *v1alpha1.NATRule, newCondition v1alpha1.Condition, translatedNetwork string) {
+	conditionUpdated := mergeNATRuleStatusCondition(natRule, &newCondition)
+	if !conditionUpdated && natRule.Status.TranslatedNetwork == translatedNetwork {
+		return
+	}
+	natRule.Status.TranslatedNetwork = translatedNetwork
+	if err := client.Status().Update(ctx, natRule); err != nil {
+		log.Error(err, "Failed to update NATRule status", "Namespace", natRule.Namespace, "Name", natRule.Name)
+		return
+	}
+	log.V(1).Info("Updated NATRule status", "Namespace", natRule.Namespace, "Name", natRule.Name, "New Condition", newCondition)
+}
+
+func mergeNATRuleStatusCondition(natRule *v1alpha1.NATRule, newCondition *v1alpha1.Condition) bool {
+	for i := range natRule.Status.Conditions {
+		matchedCondition := &natRule.Status.Conditions[i]
+		if matchedCondition.Type != newCondition.Type {
+			continue
+		}
+		if matchedCondition.Status == newCondition.Status && matchedCondition.Reason == newCondition.Reason && matchedCondition.Message == newCondition.Message {
+			return false
+		}
+		if matchedCondition.Status != newCondition.Status {
+			matchedCondition.LastTransitionTime = newCondition.LastTransitionTime
+		}
+		matchedCondition.Reason = newCondition.Reason
+		matchedCondition.Message = newCondition.Message
+		matchedCondition.Status = newCondition.Status
+		return true
+	}
+	natRule.Status.Conditions = append(natRule.Status.Conditions, *newCondition)
+	return true
+}
+
+func (r *NATRuleReconciler) deleteNATRuleByName(ns, name string) error {
+	for _, item := range r.Service.ListNATRuleByName(ns, name) {
+		log.Info("Deleting NAT rule", "Namespace", ns, "Name", name, "nsxNATRuleId", *item.Id)
+		if err := r.Service.DeleteNATRule(item); err != nil {
+			log.Error(err, "Failed to delete NAT rule", "nsxNATRuleId", *item.Id)
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *NATRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := &v1alpha1.NATRule{}
+	log.Info("Reconciling NATRule CR", "NATRule", req.NamespacedName)
+	r.StatusUpdater.IncreaseSyncTotal()
+
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			if err := r.deleteNATRuleByName(req.Namespace, req.Name); err != nil {
+				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
+				return ResultRequeue, err
+			}
+			r.StatusUpdater.DeleteSuccess(req.NamespacedName, nil)
+			return ResultNormal, nil
+		}
+		log.Error(err, "Unable to fetch NATRule CR", "req", req.NamespacedName)
+		return ResultRequeue, err
+	}
+
+	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
+		r.StatusUpdater.IncreaseDeleteTotal()
+		if err := r.Service.DeleteNATRuleByCR(obj); err != nil {
+			r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
+			return ResultRequeue, err
+		}
+		r.StatusUpdater.DeleteSuccess(req.NamespacedName, nil)
+		return ResultNormal, nil
+	}
+
+	r.StatusUpdater.IncreaseUpdateTotal()
+	networks, err := r.resolveNetworks(ctx, obj)
+	if err != nil {
+		r.StatusUpdater.UpdateFail(ctx, obj, err, "failed to resolve the networks", setNATRuleReadyStatusFalse)
+		return ResultRequeue, err
+	}
+	nsxNATRule, err := r.Service.CreateOrUpdateNATRule(obj, networks)
+	if err != nil {
+		r.StatusUpdater.UpdateFail(ctx, obj, err, "", setNATRuleReadyStatusFalse)
+		return ResultRequeue, err
+	}
+	translatedNetwork := ""
+	if nsxNATRule.TranslatedNetwork != nil {
+		translatedNetwork = *nsxNATRule.TranslatedNetwork
+	}
+	r.StatusUpdater.UpdateSuccess(ctx, obj, setNATRuleReadyStatusTrue, translatedNetwork)
+	return ResultNormal, nil
+}
+
+// resolveNetworks resolves the source, destination and translated networks of the NATRule from the
+// CIDRs or the referenced Subnet, SubnetPort and IPAddressAllocation in the same Namespace.
+func (r *NATRuleReconciler) resolveNetworks(ctx context.Context, obj *v1alpha1.NATRule) (*natrule.NATRuleNetworks, error) {
+	networks := &natrule.NATRuleNetworks{}
+	var err error
+	if networks.SourceNetwork, err = r.resolveNetwork(ctx, obj.Namespace, obj.Spec.SourceNetwork); err != nil {
+		return nil, err
+	}
+	if networks.DestinationNetwork, err = r.resolveNetwork(ctx, obj.Namespace, obj.Spec.DestinationNetwork); err != nil {
+		return nil, err
+	}
+	translated := obj.Spec.TranslatedNetwork
+	if translated == nil {
+		return networks, nil
+	}
+	if translated.IPAddress != "" {
+		networks.TranslatedNetwork = translated.IPAddress
+		return networks, nil
+	}
+	ipAddressAllocation := &v1alpha1.IPAddressAllocation{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: obj.Namespace, Name: translated.IPAddressAllocationName}, ipAddressAllocation); err != nil {
+		return nil, err
+	}
+	if ipAddressAllocation.Status.AllocationIPs == "" {
+		return nil, fmt.Errorf("IPAddressAllocation %s/%s has no allocated IPs", obj.Namespace, translated.IPAddressAllocationName)
+	}
+	networks.TranslatedNetwork = ipAddressAllocation.Status.AllocationIPs
+	return networks, nil
+}
+
+func (r *NATRuleReconciler) resolveNetwork(ctx context.Context, namespace string, network *v1alpha1.NATRuleNetwork) ([]string, error) {
+	switch {
+	case network == nil:
+		return nil, nil
+	case len(network.CIDRs) > 0:
+		return network.CIDRs, nil
+	case network.SubnetName != "":
+		subnet := &v1alpha1.Subnet{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: network.SubnetName}, subnet); err != nil {
+			return nil, err
+		}
+		if len(subnet.Status.NetworkAddresses) == 0 {
+			return nil, fmt.Errorf("Subnet %s/%s has no network addresses", namespace, network.SubnetName)
+		}
+		return subnet.Status.NetworkAddresses, nil
+	case network.SubnetPortName != "":
+		subnetPort := &v1alpha1.SubnetPort{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: network.SubnetPortName}, subnetPort); err != nil {
+			return nil, err
+		}
+		var ips []string
+		for _, address := range subnetPort.Status.NetworkInterfaceConfig.IPAddresses {
+			// The IP address of the SubnetPort is in the format of "IP/prefix".
+			if ip := strings.Split(address.IPAddress, "/")[0]; ip != "" {
+				ips = append(ips, ip)
+			}
+		}
+		if len(ips) == 0 {
+			return nil, fmt.Errorf("SubnetPort %s/%s has no IP addresses", namespace, network.SubnetPortName)
+		}
+		return ips, nil
+	}
+	return nil, nil
+}
+
+// referenceMapFunc returns a map function which enqueues the NATRules referencing the object by the names
+// returned from refNames.
+func (r *NATRuleReconciler) referenceMapFunc(refNames func(*v1alpha1.NATRule) []string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		natRuleList := &v1alpha1.NATRuleList{}
+		if err := r.Client.List(ctx, natRuleList, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.Error(err, "Failed to list NATRule CR", "Namespace", obj.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range natRuleList.Items {
+			natRule := &natRuleList.Items[i]
+			if sets.New[string](refNames(natRule)...).Has(obj.GetName()) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: natRule.Namespace, Name: natRule.Name}})
+			}
+		}
+		return requests
+	}
+}
+
+func natRuleNetworks(natRule *v1alpha1.NATRule) []*v1alpha1.NATRuleNetwork {
+	var networks []*v1alpha1.NATRuleNetwork
+	for _, network := range []*v1alpha1.NATRuleNetwork{natRule.Spec.SourceNetwork, natRule.Spec.DestinationNetwork} {
+		if network != nil {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
+func subnetRefNames(natRule *v1alpha1.NATRule) []string {
+	var names []string
+	for _, network := range natRuleNetworks(natRule) {
+		names = append(names, network.SubnetName)
+	}
+	return names
+}
+
+func subnetPortRefNames(natRule *v1alpha1.NATRule) []string {
+	var names []string
+	for _, network := range natRuleNetworks(natRule) {
+		names = append(names, network.SubnetPortName)
+	}
+	return names
+}
+
+func ipAddressAllocationRefNames(natRule *v1alpha1.NATRule) []string {
+	if natRule.Spec.TranslatedNetwork == nil {
+		return nil
+	}
+	return []string{natRule.Spec.TranslatedNetwork.IPAddressAllocationName}
+}
+
+func (r *NATRuleReconciler) setupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.NATRule{}).
+		WithOptions(
+			controller.Options{
+				MaxConcurrentReconciles: common.NumReconcile(),
+			}).
+		Watches(&v1alpha1.Subnet{}, handler.EnqueueRequestsFromMapFunc(r.referenceMapFunc(subnetRefNames))).
+		Watches(&v1alpha1.SubnetPort{}, handler.EnqueueRequestsFromMapFunc(r.referenceMapFunc(subnetPortRefNames))).
+		Watches(&v1alpha1.IPAddressAllocation{}, handler.EnqueueRequestsFromMapFunc(r.referenceMapFunc(ipAddressAllocationRefNames))).
+		Complete(r)
+}
+
+// CollectGarbage collects the NSX NAT rules whose NATRule CRs have been removed.
+// It implements the interface GarbageCollector method.
+func (r *NATRuleReconciler) CollectGarbage(ctx context.Context) {
+	log.Info("NAT rule garbage collector started")
+	nsxNATRuleList := r.Service.ListNATRule()
+	if len(nsxNATRuleList) == 0 {
+		return
+	}
+
+	crNATRuleList := &v1alpha1.NATRuleList{}
+	if err := r.Client.List(ctx, crNATRuleList); err != nil {
+		log.Error(err, "Failed to list NATRule CR")
+		return
+	}
+	crNATRuleSet := sets.New[string]()
+	for _, natRule := range crNATRuleList.Items {
+		crNATRuleSet.Insert(string(natRule.UID))
+	}
+
+	for _, nsxNATRule := range nsxNATRuleList {
+		UID := r.Service.GetUID(nsxNATRule)
+		if UID == nil || crNATRuleSet.Has(*UID) {
+			continue
+		}
+		log.V(1).Info("GC collected NAT rule", "UID", *UID)
+		r.StatusUpdater.IncreaseDeleteTotal()
+		if err := r.Service.DeleteNATRule(nsxNATRule); err != nil {
+			r.StatusUpdater.IncreaseDeleteFailTotal()
+		} else {
+			r.StatusUpdater.IncreaseDeleteSuccessTotal()
+		}
+	}
+}
+
+func StartNATRuleController(mgr ctrl.Manager, natRuleService *natrule.NATRuleService) {
+	natRuleReconciler := NATRuleReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Service:  natRuleService,
+		Recorder: mgr.GetEventRecorderFor("natrule-controller"),
+	}
+	natRuleReconciler.StatusUpdater = common.NewStatusUpdater(natRuleReconciler.Client, natRuleReconciler.Service.NSXConfig, natRuleReconciler.Recorder, MetricResTypeNATRule, commonservice.ResourceTypeNATRule, "NATRule")
+	if err := natRuleReconciler.setupWithManager(mgr); err != nil {
+		log.Error(err, "Failed to create controller", "controller", "NATRule")
+		os.Exit(1)
+	}
+	go common.GenericGarbageCollector(make(chan bool), commonservice.GCInterval, natRuleReconciler.CollectGarbage)
+}
@@ -0,0 +1,235 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package natrule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/natrule"
+)
+
+func createNATRuleReconciler(objs ...client.Object) *NATRuleReconciler {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(newScheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(objs...).WithStatusSubresource(&v1alpha1.NATRule{}).Build()
+	service := &natrule.NATRuleService{
+		Service: servicecommon.Service{
+			NSXConfig: &config.NSXOperatorConfig{
+				NsxConfig: &config.NsxConfig{},
+				CoeConfig: &config.CoeConfig{Cluster: "k8scl-one:test"},
+			},
+		},
+	}
+	r := &NATRuleReconciler{
+		Client:   fakeClient,
+		Scheme:   newScheme,
+		Service:  service,
+		Recorder: &record.FakeRecorder{},
+	}
+	r.StatusUpdater = common.NewStatusUpdater(r.Client, r.Service.NSXConfig, r.Recorder, MetricResTypeNATRule, servicecommon.ResourceTypeNATRule, "NATRule")
+	return r
+}
+
+func TestNATRuleReconciler_ResolveNetworks(t *testing.T) {
+	subnet := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet-1", Namespace: "ns-1"},
+		Status:     v1alpha1.SubnetStatus{NetworkAddresses: []string{"10.0.0.0/28"}},
+	}
+	subnetPort := &v1alpha1.SubnetPort{
+		ObjectMeta: metav1.ObjectMeta{Name: "port-1", Namespace: "ns-1"},
+		Status: v1alpha1.SubnetPortStatus{NetworkInterfaceConfig: v1alpha1.NetworkInterfaceConfig{
+			IPAddresses: []v1alpha1.NetworkInterfaceIPAddress{{IPAddress: "10.0.0.5/28"}},
+		}},
+	}
+	ipAddressAllocation := &v1alpha1.IPAddressAllocation{
+		ObjectMeta: metav1.ObjectMeta{Name: "ipa-1", Namespace: "ns-1"},
+		Status:     v1alpha1.IPAddressAllocationStatus{AllocationIPs: "192.168.0.10/32"},
+	}
+	pendingIPAddressAllocation := &v1alpha1.IPAddressAllocation{ObjectMeta: metav1.ObjectMeta{Name: "ipa-2", Namespace: "ns-1"}}
+	r := createNATRuleReconciler(subnet, subnetPort, ipAddressAllocation, pendingIPAddressAllocation)
+	ctx := context.TODO()
+
+	obj := &v1alpha1.NATRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "snat-1", Namespace: "ns-1"},
+		Spec: v1alpha1.NATRuleSpec{
+			Action:            v1alpha1.NATRuleActionSNAT,
+			SourceNetwork:     &v1alpha1.NATRuleNetwork{SubnetName: "subnet-1"},
+			TranslatedNetwork: &v1alpha1.NATRuleTranslatedNetwork{IPAddressAllocationName: "ipa-1"},
+		},
+	}
+	networks, err := r.resolveNetworks(ctx, obj)
+	assert.Nil(t, err)
+	assert.Equal(t, &natrule.NATRuleNetworks{SourceNetwork: []string{"10.0.0.0/28"}, TranslatedNetwork: "192.168.0.10/32"}, networks)
+
+	obj.Spec = v1alpha1.NATRuleSpec{
+		Action:             v1alpha1.NATRuleActionDNAT,
+		DestinationNetwork: &v1alpha1.NATRuleNetwork{CIDRs: []string{"192.168.0.20"}},
+		TranslatedNetwork:  &v1alpha1.NATRuleTranslatedNetwork{IPAddress: "10.0.0.20"},
+	}
+	networks, err = r.resolveNetworks(ctx, obj)
+	assert.Nil(t, err)
+	assert.Equal(t, &natrule.NATRuleNetworks{DestinationNetwork: []string{"192.168.0.20"}, TranslatedNetwork: "10.0.0.20"}, networks)
+
+	obj.Spec = v1alpha1.NATRuleSpec{
+		Action:        v1alpha1.NATRuleActionNoSNAT,
+		SourceNetwork: &v1alpha1.NATRuleNetwork{SubnetPortName: "port-1"},
+	}
+	networks, err = r.resolveNetworks(ctx, obj)
+	assert.Nil(t, err)
+	assert.Equal(t, &natrule.NATRuleNetworks{SourceNetwork: []string{"10.0.0.5"}}, networks)
+
+	// The referenced resources are not found or not realized.
+	obj.Spec.SourceNetwork = &v1alpha1.NATRuleNetwork{SubnetName: "subnet-2"}
+	_, err = r.resolveNetworks(ctx, obj)
+	assert.ErrorContains(t, err, "not found")
+	obj.Spec = v1alpha1.NATRuleSpec{
+		Action:            v1alpha1.NATRuleActionSNAT,
+		SourceNetwork:     &v1alpha1.NATRuleNetwork{CIDRs: []string{"10.0.0.0/24"}},
+		TranslatedNetwork: &v1alpha1.NATRuleTranslatedNetwork{IPAddressAllocationName: "ipa-2"},
+	}
+	_, err = r.resolveNetworks(ctx, obj)
+	assert.ErrorContains(t, err, "IPAddressAllocation ns-1/ipa-2 has no allocated IPs")
+}
+
+func TestNATRuleReconciler_Reconcile(t *testing.T) {
+	natRuleCR := &v1alpha1.NATRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "snat-1", Namespace: "ns-1", UID: "uid-1"},
+		Spec: v1alpha1.NATRuleSpec{
+			Action:            v1alpha1.NATRuleActionSNAT,
+			SourceNetwork:     &v1alpha1.NATRuleNetwork{CIDRs: []string{"10.0.0.0/24"}},
+			TranslatedNetwork: &v1alpha1.NATRuleTranslatedNetwork{IPAddress: "192.168.0.10"},
+		},
+	}
+	r := createNATRuleReconciler(natRuleCR)
+	ctx := context.TODO()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns-1", Name: "snat-1"}}
+
+	// Failed to create the NSX NAT rule.
+	patches := gomonkey.ApplyMethod(r.Service, "CreateOrUpdateNATRule", func(_ *natrule.NATRuleService, obj *v1alpha1.NATRule, networks *natrule.NATRuleNetworks) (*model.PolicyVpcNatRule, error) {
+		return nil, errors.New("patch failed")
+	})
+	_, err := r.Reconcile(ctx, req)
+	assert.ErrorContains(t, err, "patch failed")
+	obj := &v1alpha1.NATRule{}
+	assert.Nil(t, r.Client.Get(ctx, req.NamespacedName, obj))
+	assert.Equal(t, v1.ConditionFalse, obj.Status.Conditions[0].Status)
+	patches.Reset()
+
+	patches = gomonkey.ApplyMethod(r.Service, "CreateOrUpdateNATRule", func(_ *natrule.NATRuleService, obj *v1alpha1.NATRule, networks *natrule.NATRuleNetworks) (*model.PolicyVpcNatRule, error) {
+		assert.Equal(t, []string{"10.0.0.0/24"}, networks.SourceNetwork)
+		return &model.PolicyVpcNatRule{TranslatedNetwork: servicecommon.String(networks.TranslatedNetwork)}, nil
+	})
+	result, err := r.Reconcile(ctx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, ResultNormal, result)
+	assert.Nil(t, r.Client.Get(ctx, req.NamespacedName, obj))
+	assert.Equal(t, v1.ConditionTrue, obj.Status.Conditions[0].Status)
+	assert.Equal(t, "192.168.0.10", obj.Status.TranslatedNetwork)
+	patches.Reset()
+
+	// The NSX NAT rules are deleted by name if the CR is not found.
+	assert.Nil(t, r.Client.Delete(ctx, obj))
+	deleted := 0
+	patches = gomonkey.ApplyMethod(r.Service, "ListNATRuleByName", func(_ *natrule.NATRuleService, ns, name string) []*model.PolicyVpcNatRule {
+		return []*model.PolicyVpcNatRule{{Id: servicecommon.String("snat-1_uid-1")}}
+	})
+	defer patches.Reset()
+	patches.ApplyMethod(r.Service, "DeleteNATRule", func(_ *natrule.NATRuleService, nsxNATRule *model.PolicyVpcNatRule) error {
+		deleted++
+		return nil
+	})
+	result, err = r.Reconcile(ctx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, ResultNormal, result)
+	assert.Equal(t, 1, deleted)
+}
+
+func TestNATRuleReconciler_MapFunc(t *testing.T) {
+	natRule1 := &v1alpha1.NATRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "snat-1", Namespace: "ns-1"},
+		Spec: v1alpha1.NATRuleSpec{
+			Action:            v1alpha1.NATRuleActionSNAT,
+			SourceNetwork:     &v1alpha1.NATRuleNetwork{SubnetName: "subnet-1"},
+			TranslatedNetwork: &v1alpha1.NATRuleTranslatedNetwork{IPAddressAllocationName: "ipa-1"},
+		},
+	}
+	natRule2 := &v1alpha1.NATRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "dnat-1", Namespace: "ns-1"},
+		Spec: v1alpha1.NATRuleSpec{
+			Action:             v1alpha1.NATRuleActionDNAT,
+			DestinationNetwork: &v1alpha1.NATRuleNetwork{CIDRs: []string{"192.168.0.10"}},
+			TranslatedNetwork:  &v1alpha1.NATRuleTranslatedNetwork{IPAddressAllocationName: "ipa-1"},
+		},
+	}
+	r := createNATRuleReconciler(natRule1, natRule2)
+	ctx := context.TODO()
+
+	requests := r.referenceMapFunc(subnetRefNames)(ctx, &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet-1", Namespace: "ns-1"}})
+	assert.Equal(t, 1, len(requests))
+	assert.Equal(t, "snat-1", requests[0].Name)
+	requests = r.referenceMapFunc(ipAddressAllocationRefNames)(ctx, &v1alpha1.IPAddressAllocation{ObjectMeta: metav1.ObjectMeta{Name: "ipa-1", Namespace: "ns-1"}})
+	assert.Equal(t, 2, len(requests))
+	requests = r.referenceMapFunc(subnetPortRefNames)(ctx, &v1alpha1.SubnetPort{ObjectMeta: metav1.ObjectMeta{Name: "port-1", Namespace: "ns-1"}})
+	assert.Empty(t, requests)
+	requests = r.referenceMapFunc(subnetRefNames)(ctx, &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet-1", Namespace: "ns-2"}})
+	assert.Empty(t, requests)
+}
+
+func TestNATRuleReconciler_CollectGarbage(t *testing.T) {
+	natRuleCR := &v1alpha1.NATRule{ObjectMeta: metav1.ObjectMeta{Name: "snat-1", Namespace: "ns-1", UID: "uid-1"}}
+	r := createNATRuleReconciler(natRuleCR)
+
+	patches := gomonkey.ApplyMethod(r.Service, "ListNATRule", func(_ *natrule.NATRuleService) []*model.PolicyVpcNatRule {
+		return []*model.PolicyVpcNatRule{
+			{Id: servicecommon.String("snat-1_uid-1"), Tags: []model.Tag{{Scope: servicecommon.String(servicecommon.TagScopeNATRuleCRUID), Tag: servicecommon.String("uid-1")}}},
+			{Id: servicecommon.String("snat-2_uid-2"), Tags: []model.Tag{{Scope: servicecommon.String(servicecommon.TagScopeNATRuleCRUID), Tag: servicecommon.String("uid-2")}}},
+		}
+	})
+	defer patches.Reset()
+	var deleted []string
+	patches.ApplyMethod(r.Service, "DeleteNATRule", func(_ *natrule.NATRuleService, nsxNATRule *model.PolicyVpcNatRule) error {
+		deleted = append(deleted, *nsxNATRule.Id)
+		return nil
+	})
+	r.CollectGarbage(context.TODO())
+	assert.Equal(t, []string{"snat-2_uid-2"}, deleted)
+}
+
+func TestMergeNATRuleStatusCondition(t *testing.T) {
+	transitionTime := metav1.Now()
+	natRule := &v1alpha1.NATRule{}
+	readyCondition := v1alpha1.Condition{Type: v1alpha1.Ready, Status: v1.ConditionTrue, Reason: "NATRuleReady", LastTransitionTime: transitionTime}
+	assert.True(t, mergeNATRuleStatusCondition(natRule, &readyCondition))
+
+	// The condition is not updated if only the transition time is changed.
+	readyCondition.LastTransitionTime = metav1.NewTime(transitionTime.Add(time.Minute))
+	assert.False(t, mergeNATRuleStatusCondition(natRule, &readyCondition))
+
+	notReadyCondition := v1alpha1.Condition{Type: v1alpha1.Ready, Status: v1.ConditionFalse, Reason: "NATRuleNotReady", LastTransitionTime: readyCondition.LastTransitionTime}
+	assert.True(t, mergeNATRuleStatusCondition(natRule, &notReadyCondition))
+	assert.Equal(t, 1, len(natRule.Status.Conditions))
+	assert.Equal(t, notReadyCondition, natRule.Status.Conditions[0])
+}
@@ -6,8 +6,11 @@ package networkinfo
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
+	vapierrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -21,7 +24,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
@@ -50,31 +58,41 @@ const (
 	NSReasonVPCSnatNotReady      string = "VPCSnatNotReady"
 )
 
+const (
+	NIReasonAutoSnatDisabled       string = "AutoSnatDisabled"
+	NIReasonLoadBalancerNotEnabled string = "LoadBalancerNotEnabled"
+)
+
 var (
-	nsMsgVPCNetCfgGetError        = newNsUnreadyMessage("Error happened to get VPC network configuration: %v", NSReasonVPCNetConfigNotReady)
-	nsMsgSystemVPCNetCfgNotFound  = newNsUnreadyMessage("Error happened to get system VPC network configuration: %v", NSReasonVPCNetConfigNotReady)
-	nsMsgVPCGwConnectionGetError  = newNsUnreadyMessage("Error happened to validate system VPC gateway connection readiness: %v", NSReasonVPCNetConfigNotReady)
-	nsMsgVPCGwConnectionNotReady  = newNsUnreadyMessage("System VPC gateway connection is not ready", NSReasonVPCNetConfigNotReady)
-	nsMsgVPCCreateUpdateError     = newNsUnreadyMessage("Error happened to create or update VPC: %v", NSReasonVPCNotReady)
-	nsMsgVPCNsxLBSNotReady        = newNsUnreadyMessage("Error happened to get NSX LBS path in VPC: %v", NSReasonVPCNotReady)
-	nsMsgVPCAviSubnetError        = newNsUnreadyMessage("Error happened to get Avi Load balancer Subnet info: %v", NSReasonVPCNotReady)
-	nsMsgVPCNSXLBSNATIPError      = newNsUnreadyMessage("Error happened to get NSX Load balancer SNAT IP info: %v", NSReasonVPCNotReady)
-	nsMsgVPCGetExtIPBlockError    = newNsUnreadyMessage("Error happened to get external IP blocks: %v", NSReasonVPCNotReady)
-	nsMsgVPCNoExternalIPBlock     = newNsUnreadyMessage("System VPC has no external IP blocks", NSReasonVPCNotReady)
-	nsMsgVPCAutoSNATDisabled      = newNsUnreadyMessage("SNAT is not enabled in System VPC", NSReasonVPCSnatNotReady)
-	nsMsgVPCDefaultSNATIPGetError = newNsUnreadyMessage("Default SNAT IP is not allocated in VPC: %v", NSReasonVPCSnatNotReady)
-	nsMsgVPCIsReady               = newNsUnreadyMessage("", "")
+	nsMsgVPCNetCfgGetError        = newNsUnreadyMessage("Error happened to get VPC network configuration: %v", NSReasonVPCNetConfigNotReady, v1alpha1.VPCReady)
+	nsMsgSystemVPCNetCfgNotFound  = newNsUnreadyMessage("Error happened to get system VPC network configuration: %v", NSReasonVPCNetConfigNotReady, v1alpha1.VPCReady)
+	nsMsgVPCNetCfgNSXError        = newNsUnreadyMessage("Error happened to validate VPC network configuration: %v", NSReasonVPCNetConfigNotReady, v1alpha1.VPCReady)
+	nsMsgVPCGwConnectionGetError  = newNsUnreadyMessage("Error happened to validate system VPC gateway connection readiness: %v", NSReasonVPCNetConfigNotReady, v1alpha1.GatewayConnectionReady)
+	nsMsgVPCGwConnectionNotReady  = newNsUnreadyMessage("System VPC gateway connection is not ready", NSReasonVPCNetConfigNotReady, v1alpha1.GatewayConnectionReady)
+	nsMsgVPCCreateUpdateError     = newNsUnreadyMessage("Error happened to create or update VPC: %v", NSReasonVPCNotReady, v1alpha1.VPCReady)
+	nsMsgVPCNsxLBSNotReady        = newNsUnreadyMessage("Error happened to get NSX LBS path in VPC: %v", NSReasonVPCNotReady, v1alpha1.LBReady)
+	nsMsgVPCAviSubnetError        = newNsUnreadyMessage("Error happened to get Avi Load balancer Subnet info: %v", NSReasonVPCNotReady, v1alpha1.LBReady)
+	nsMsgVPCNSXLBSNATIPError      = newNsUnreadyMessage("Error happened to get NSX Load balancer SNAT IP info: %v", NSReasonVPCNotReady, v1alpha1.LBReady)
+	nsMsgVPCGetExtIPBlockError    = newNsUnreadyMessage("Error happened to get external IP blocks: %v", NSReasonVPCNotReady, v1alpha1.VPCReady)
+	nsMsgVPCNoExternalIPBlock     = newNsUnreadyMessage("System VPC has no external IP blocks", NSReasonVPCNotReady, v1alpha1.VPCReady)
+	nsMsgVPCAutoSNATDisabled      = newNsUnreadyMessage("SNAT is not enabled in System VPC", NSReasonVPCSnatNotReady, v1alpha1.AutoSnatEnabled)
+	nsMsgVPCDefaultSNATIPGetError = newNsUnreadyMessage("Default SNAT IP is not allocated in VPC: %v", NSReasonVPCSnatNotReady, v1alpha1.AutoSnatEnabled)
+	nsMsgVPCIsReady               = newNsUnreadyMessage("", "", v1alpha1.VPCReady)
 )
 
+// nsUnreadyMessage describes the Namespace network condition, and the NetworkInfo condition of the
+// conditionType affected by the same error.
 type nsUnreadyMessage struct {
-	reason string
-	msg    string
+	reason        string
+	msg           string
+	conditionType v1alpha1.ConditionType
 }
 
-func newNsUnreadyMessage(msg string, reason string) *nsUnreadyMessage {
+func newNsUnreadyMessage(msg string, reason string, conditionType v1alpha1.ConditionType) *nsUnreadyMessage {
 	return &nsUnreadyMessage{
-		msg:    msg,
-		reason: reason,
+		msg:           msg,
+		reason:        reason,
+		conditionType: conditionType,
 	}
 }
 
@@ -91,6 +109,28 @@ func (m *nsUnreadyMessage) getNSNetworkCondition(options ...interface{}) *corev1
 	return cond
 }
 
+func (m *nsUnreadyMessage) getNetworkInfoCondition(options ...interface{}) *v1alpha1.Condition {
+	cond := &v1alpha1.Condition{
+		Type:   m.conditionType,
+		Status: corev1.ConditionTrue,
+	}
+	if m.reason != "" {
+		cond.Status = corev1.ConditionFalse
+		cond.Reason = m.reason
+		cond.Message = fmt.Sprintf(m.msg, options...)
+	}
+	return cond
+}
+
+// newNetworkInfoCondition returns the NetworkInfo condition with status True if ready, otherwise with status
+// False and the reason.
+func newNetworkInfoCondition(conditionType v1alpha1.ConditionType, ready bool, reason string) *v1alpha1.Condition {
+	if ready {
+		return &v1alpha1.Condition{Type: conditionType, Status: corev1.ConditionTrue}
+	}
+	return &v1alpha1.Condition{Type: conditionType, Status: corev1.ConditionFalse, Reason: reason}
+}
+
 // NetworkInfoReconciler NetworkInfoReconcile reconciles a NetworkInfo object
 // Actually it is more like a shell, which is used to manage nsx VPC
 type NetworkInfoReconciler struct {
@@ -127,6 +167,50 @@ func (r *NetworkInfoReconciler) GetVpcConnectivityProfilePathByVpcPath(vpcPath s
 	}
 }
 
+// validateNSXResources checks the NSX project, VPC connectivity profile and pre-created VPC exist on NSX.
+// The check is skipped if NSX is unreachable, creating or updating the VPC reports the error in that case.
+func (r *NetworkInfoReconciler) validateNSXResources(nc *v1alpha1.VPCNetworkConfiguration) error {
+	nsxClient := r.Service.NSXClient
+	org, project, err := nsxProjectPathToId(nc.Spec.NSXProject)
+	if err != nil {
+		return err
+	}
+	checkNotFound := func(kind, path string, err error) error {
+		if err == nil {
+			return nil
+		}
+		if _, notFound := err.(vapierrors.NotFound); notFound {
+			return fmt.Errorf("%s %s does not exist on NSX", kind, path)
+		}
+		log.Info("Skip checking the NSX resource as NSX is not available", kind, path, "error", util.TransNSXApiError(err))
+		return nil
+	}
+	if _, err := nsxClient.ProjectClient.Get(org, project, nil); err != nil {
+		return checkNotFound("nsxProject", nc.Spec.NSXProject, err)
+	}
+	if nc.Spec.VPC != "" {
+		vpcInfo, err := commonservice.ParseVPCResourcePath(nc.Spec.VPC)
+		if err != nil {
+			return err
+		}
+		_, err = nsxClient.VPCClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID)
+		return checkNotFound("vpc", nc.Spec.VPC, err)
+	}
+	if nc.Spec.VPCConnectivityProfile != "" {
+		parts := strings.Split(nc.Spec.VPCConnectivityProfile, "/")
+		_, err = nsxClient.VPCConnectivityProfilesClient.Get(org, project, parts[len(parts)-1])
+		return checkNotFound("vpcConnectivityProfile", nc.Spec.VPCConnectivityProfile, err)
+	}
+	return nil
+}
+
+// setNetworkUnreadyConditions reports the error on both the Namespace network condition and the NetworkInfo
+// condition.
+func (r *NetworkInfoReconciler) setNetworkUnreadyConditions(ctx context.Context, networkInfo *v1alpha1.NetworkInfo, msg *nsUnreadyMessage, options ...interface{}) {
+	setNSNetworkReadyCondition(ctx, r.Client, networkInfo.Namespace, msg.getNSNetworkCondition(options...))
+	setNetworkInfoConditions(ctx, r.Client, networkInfo, msg.getNetworkInfoCondition(options...))
+}
+
 func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	startTime := time.Now()
 	defer func() {
@@ -167,7 +251,7 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	nc, err := r.getNetworkConfigInfo(ctx, networkInfoCR)
 	if err != nil {
 		r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, "", setNetworkInfoVPCStatusWithError, nil)
-		setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCNetCfgGetError.getNSNetworkCondition(err))
+		r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCNetCfgGetError, err)
 		return common.ResultRequeueAfter10sec, err
 	}
 
@@ -178,12 +262,22 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	err = r.Client.Get(ctx, types.NamespacedName{Name: commonservice.SystemVPCNetworkConfigurationName}, systemVpcNetCfg)
 	if err != nil {
 		r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, "Failed to get system VPCNetworkConfiguration", setNetworkInfoVPCStatusWithError, nil)
-		setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgSystemVPCNetCfgNotFound.getNSNetworkCondition(err))
+		r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgSystemVPCNetCfgNotFound, err)
 		return common.ResultRequeueAfter10sec, err
 	}
 
+	// The VPCNetworkConfiguration is used to track the rollout of its changes to the VPC of the Namespace.
+	vpcNetCfg := systemVpcNetCfg
+	if ncName != commonservice.SystemVPCNetworkConfigurationName {
+		vpcNetCfg = &v1alpha1.VPCNetworkConfiguration{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: ncName}, vpcNetCfg); err != nil {
+			log.Error(err, "Failed to get VPCNetworkConfiguration", "Name", ncName)
+			vpcNetCfg = nil
+		}
+	}
+
 	retryWithSystemVPC := false
-	var systemNSCondition *corev1.NamespaceCondition
+	var systemNSMsg *nsUnreadyMessage
 
 	gatewayConnectionReady, _ := getGatewayConnectionStatus(ctx, systemVpcNetCfg)
 	gatewayConnectionReason := ""
@@ -191,7 +285,7 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// Retry after 60s if the gateway connection is not ready in system VPC.
 		if ncName != commonservice.SystemVPCNetworkConfigurationName {
 			log.Info("Skipping reconciliation due to unready system gateway connection", "NetworkInfo", req.NamespacedName)
-			setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCGwConnectionNotReady.getNSNetworkCondition())
+			r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCGwConnectionNotReady)
 			return common.ResultRequeueAfter60sec, nil
 		}
 
@@ -200,7 +294,7 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		log.Info("Got the gateway connection status", "gatewayConnectionReady", gatewayConnectionReady, "gatewayConnectionReason", gatewayConnectionReason)
 		if err != nil {
 			r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, fmt.Sprintf("Failed to validate the edge and gateway connection, Org: %s, Porject: %s", nc.Org, nc.NSXProject), setNetworkInfoVPCStatusWithError, nil)
-			setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCGwConnectionGetError.getNSNetworkCondition(err))
+			r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCGwConnectionGetError, err)
 			return common.ResultRequeueAfter10sec, err
 		}
 		setVPCNetworkConfigurationStatusWithGatewayConnection(ctx, r.Client, systemVpcNetCfg, gatewayConnectionReady, gatewayConnectionReason)
@@ -209,7 +303,18 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if !gatewayConnectionReady {
 			log.Info("Requeue NetworkInfo CR because VPCNetworkConfiguration system is not ready", "gatewayConnectionReason", gatewayConnectionReason, "req", req)
 			retryWithSystemVPC = true
-			systemNSCondition = nsMsgVPCGwConnectionNotReady.getNSNetworkCondition()
+			systemNSMsg = nsMsgVPCGwConnectionNotReady
+		}
+	}
+
+	// The NSX resources referred by the VPCNetworkConfiguration are checked when its new generation is applied,
+	// the error is reported in the rollout status of the Namespace.
+	if vpcNetCfg != nil && isNamespaceRolloutPending(vpcNetCfg, req.Namespace) {
+		if err := r.validateNSXResources(vpcNetCfg); err != nil {
+			r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, "Invalid VPCNetworkConfiguration", setNetworkInfoVPCStatusWithError, nil)
+			r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCNetCfgNSXError, err)
+			setVPCNetworkConfigurationStatusWithRollout(ctx, r.Client, vpcNetCfg, req.Namespace, err)
+			return common.ResultRequeueAfter60sec, nil
 		}
 	}
 
@@ -217,7 +322,8 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	createdVpc, err := r.Service.CreateOrUpdateVPC(ctx, networkInfoCR, &nc, lbProvider)
 	if err != nil {
 		r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, "Failed to create or update VPC", setNetworkInfoVPCStatusWithError, nil)
-		setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCCreateUpdateError.getNSNetworkCondition(err))
+		r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCCreateUpdateError, err)
+		setVPCNetworkConfigurationStatusWithRollout(ctx, r.Client, vpcNetCfg, req.Namespace, err)
 		return common.ResultRequeueAfter10sec, err
 	}
 
@@ -238,17 +344,25 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			nsxLBSPath, err = r.Service.GetLBSsFromNSXByVPC(vpcPath)
 			if err != nil {
 				r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, fmt.Sprintf("Failed to get NSX LBS path with pre-created VPC %s", vpcPath), setNetworkInfoVPCStatusWithError, nil)
-				setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCNsxLBSNotReady.getNSNetworkCondition(err))
+				r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCNsxLBSNotReady, err)
 				return common.ResultRequeueAfter10sec, err
 			}
 			if nsxLBSPath == "" {
 				log.Error(nil, "NSX LB path is not set with pre-created VPC", "VPC", vpcPath)
 				err = fmt.Errorf("NSX LB does not exist")
-				setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCNsxLBSNotReady.getNSNetworkCondition(err))
+				r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCNsxLBSNotReady, err)
 				return common.ResultRequeueAfter10sec, err
 			}
 		}
 	} else {
+		if isNamespaceRolloutPending(vpcNetCfg, req.Namespace) {
+			if err := r.updateVPCConnectivityProfile(ctx, req.Namespace, &nc, createdVpc); err != nil {
+				r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, "Failed to update VPC connectivity profile", setNetworkInfoVPCStatusWithError, nil)
+				r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCCreateUpdateError, err)
+				setVPCNetworkConfigurationStatusWithRollout(ctx, r.Client, vpcNetCfg, req.Namespace, err)
+				return common.ResultRequeueAfter10sec, err
+			}
+		}
 		privateIPs = nc.PrivateIPs
 		vpcConnectivityProfilePath = nc.VPCConnectivityProfile
 		nsxLBSPath = r.Service.GetDefaultNSXLBSPathByVPC(*createdVpc.Id)
@@ -259,7 +373,7 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	vpcConnectivityProfile, err := r.Service.GetVpcConnectivityProfile(&nc, vpcConnectivityProfilePath)
 	if err != nil {
 		r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, "Failed to get VPC connectivity profile", setNetworkInfoVPCStatusWithError, nil)
-		setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCGetExtIPBlockError.getNSNetworkCondition(err))
+		r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCGetExtIPBlockError, err)
 		return common.ResultRequeueAfter10sec, err
 	}
 	// Check external IP blocks on system VPC network config.
@@ -269,7 +383,7 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if !hasExternalIPs && !retryWithSystemVPC {
 			log.Error(err, "There is no ExternalIPBlock in VPC ConnectivityProfile", "NetworkInfo", req.NamespacedName)
 			retryWithSystemVPC = true
-			systemNSCondition = nsMsgVPCNoExternalIPBlock.getNSNetworkCondition()
+			systemNSMsg = nsMsgVPCNoExternalIPBlock
 		}
 	}
 
@@ -286,7 +400,7 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				PrivateIPs:              privateIPs,
 			}
 			r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, fmt.Sprintf("Failed to read default SNAT IP from VPC: %s", *createdVpc.Id), setNetworkInfoVPCStatusWithError, state)
-			setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCDefaultSNATIPGetError.getNSNetworkCondition(err))
+			r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCDefaultSNATIPGetError, err)
 			return common.ResultRequeueAfter10sec, err
 		}
 	}
@@ -296,7 +410,7 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if !autoSnatEnabled && !retryWithSystemVPC {
 			log.Info("Requeue NetworkInfo CR because VPCNetworkConfiguration system is not ready", "autoSnatEnabled", autoSnatEnabled, "req", req)
 			retryWithSystemVPC = true
-			systemNSCondition = nsMsgVPCAutoSNATDisabled.getNSNetworkCondition()
+			systemNSMsg = nsMsgVPCAutoSNATDisabled
 		}
 	}
 
@@ -314,7 +428,7 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				PrivateIPs:              privateIPs,
 			}
 			r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, fmt.Sprintf("Failed to read AVI LB Subnet path and CIDR, VPC: %s", *createdVpc.Id), setNetworkInfoVPCStatusWithError, state)
-			setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCAviSubnetError.getNSNetworkCondition(err))
+			r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCAviSubnetError, err)
 			return common.ResultRequeueAfter10sec, err
 		}
 		lbIP = aviSECIDR
@@ -329,35 +443,152 @@ func (r *NetworkInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				PrivateIPs:              privateIPs,
 			}
 			r.StatusUpdater.UpdateFail(ctx, networkInfoCR, err, fmt.Sprintf("Failed to read NSX LB Subnet path and CIDR, VPC: %s", *createdVpc.Id), setNetworkInfoVPCStatusWithError, state)
-			setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCNSXLBSNATIPError.getNSNetworkCondition(err))
+			r.setNetworkUnreadyConditions(ctx, networkInfoCR, nsMsgVPCNSXLBSNATIPError, err)
 			return common.ResultRequeueAfter10sec, err
 		}
 		lbIP = nsxLBSNATIP
 	}
 
+	subnets, err := listSubnetStates(ctx, r.Client, req.Namespace)
+	if err != nil {
+		log.Error(err, "Failed to list Subnets and SubnetSets", "Namespace", req.Namespace)
+		if len(networkInfoCR.VPCs) > 0 {
+			subnets = networkInfoCR.VPCs[0].Subnets
+		}
+	}
 	state := &v1alpha1.VPCState{
 		Name:                    *createdVpc.DisplayName,
 		DefaultSNATIP:           snatIP,
 		LoadBalancerIPAddresses: lbIP,
 		PrivateIPs:              privateIPs,
+		VPCPath:                 *createdVpc.Path,
+		VPCConnectivityProfile:  vpcConnectivityProfilePath,
+		ExternalIPBlocks:        vpcConnectivityProfile.ExternalIpBlocks,
+		PrivateTGWIPBlocks:      vpcConnectivityProfile.PrivateTgwIpBlocks,
+		Subnets:                 subnets,
 	}
 
 	// AKO needs to know the AVI subnet path created by NSX
 	setVPCNetworkConfigurationStatusWithLBS(ctx, r.Client, ncName, state.Name, aviSubnetPath, nsxLBSPath, *createdVpc.Path)
 	r.StatusUpdater.UpdateSuccess(ctx, networkInfoCR, setNetworkInfoVPCStatus, state)
+	setVPCNetworkConfigurationStatusWithRollout(ctx, r.Client, vpcNetCfg, req.Namespace, nil)
 
+	networkInfoConditions := []*v1alpha1.Condition{
+		nsMsgVPCIsReady.getNetworkInfoCondition(),
+		newNetworkInfoCondition(v1alpha1.GatewayConnectionReady, gatewayConnectionReady, gatewayConnectionReason),
+		newNetworkInfoCondition(v1alpha1.AutoSnatEnabled, autoSnatEnabled, NIReasonAutoSnatDisabled),
+		newNetworkInfoCondition(v1alpha1.LBReady, lbIP != "", NIReasonLoadBalancerNotEnabled),
+	}
 	if retryWithSystemVPC {
-		setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, systemNSCondition)
+		// The unready system VPC overrides the NetworkInfo condition it affects.
+		systemCondition := systemNSMsg.getNetworkInfoCondition()
+		for i := range networkInfoConditions {
+			if networkInfoConditions[i].Type == systemCondition.Type {
+				networkInfoConditions[i] = systemCondition
+			}
+		}
+		setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, systemNSMsg.getNSNetworkCondition())
+		setNetworkInfoConditions(ctx, r.Client, networkInfoCR, networkInfoConditions...)
 		return common.ResultRequeueAfter60sec, nil
 	}
 
 	setNSNetworkReadyCondition(ctx, r.Client, req.Namespace, nsMsgVPCIsReady.getNSNetworkCondition())
+	setNetworkInfoConditions(ctx, r.Client, networkInfoCR, networkInfoConditions...)
 	return common.ResultNormal, nil
 }
 
+// updateVPCConnectivityProfile switches the VPC owned by the Namespace to the connectivity profile in the
+// VPCNetworkConfiguration, the shared VPC is only updated with its owner Namespace.
+func (r *NetworkInfoReconciler) updateVPCConnectivityProfile(ctx context.Context, ns string, nc *commonservice.VPCNetworkConfigInfo, nsxVPC *model.Vpc) error {
+	isShared, err := r.Service.IsSharedVPCNamespaceByNS(ctx, ns)
+	if err != nil || isShared {
+		return err
+	}
+	return r.Service.UpdateVPCConnectivityProfile(nc, nsxVPC)
+}
+
+// namespaceMapFunc enqueues the NetworkInfo CRs in the Namespace, so that the VPC is created under the
+// VPCNetworkConfiguration which the Namespace is switched to.
+func (r *NetworkInfoReconciler) namespaceMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.listNetworkInfoRequests(ctx, obj.GetName())
+}
+
+// subnetMapFunc enqueues the NetworkInfo CRs in the Namespace of the Subnet or SubnetSet, so that the Subnets in
+// the VPC state are refreshed by the reconciler instead of blocking the informer.
+func (r *NetworkInfoReconciler) subnetMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.listNetworkInfoRequests(ctx, obj.GetNamespace())
+}
+
+func (r *NetworkInfoReconciler) listNetworkInfoRequests(ctx context.Context, ns string) []reconcile.Request {
+	networkInfoList := &v1alpha1.NetworkInfoList{}
+	if err := r.Client.List(ctx, networkInfoList, client.InNamespace(ns)); err != nil {
+		log.Error(err, "Failed to list NetworkInfo CR", "Namespace", ns)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, networkInfo := range networkInfoList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: networkInfo.Namespace, Name: networkInfo.Name}})
+	}
+	return requests
+}
+
+// namespaceChangedPredicate filters the Namespace events which switch the VPC of the Namespace or change the
+// labels mirrored to the NSX tags of the VPC.
+func namespaceChangedPredicate(labelKeys []string) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNs, newNs := e.ObjectOld.(*corev1.Namespace), e.ObjectNew.(*corev1.Namespace)
+			return common.IsNamespaceVPCSwitched(oldNs, newNs) || common.IsLabelTagsChanged(labelKeys, oldNs.Labels, newNs.Labels)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// networkInfoChangedPredicate filters out the NetworkInfo updates which only change the VPC state and conditions,
+// they are written by the reconciler itself.
+var networkInfoChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNetworkInfo := e.ObjectOld.(*v1alpha1.NetworkInfo).DeepCopy()
+		newNetworkInfo := e.ObjectNew.(*v1alpha1.NetworkInfo).DeepCopy()
+		for _, networkInfo := range []*v1alpha1.NetworkInfo{oldNetworkInfo, newNetworkInfo} {
+			networkInfo.VPCs = nil
+			networkInfo.Conditions = nil
+			networkInfo.ResourceVersion = ""
+			networkInfo.Generation = 0
+			networkInfo.ManagedFields = nil
+		}
+		return !reflect.DeepEqual(oldNetworkInfo, newNetworkInfo)
+	},
+}
+
+// subnetStateChangedPredicate filters the Subnet and SubnetSet events which change the Subnets in the VPC state.
+var subnetStateChangedPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		// The network addresses are not allocated yet when the Subnet or SubnetSet is created.
+		return false
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !reflect.DeepEqual(getSubnetState(e.ObjectOld), getSubnetState(e.ObjectNew))
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return true
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
+
 func (r *NetworkInfoReconciler) setupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.NetworkInfo{}).
+		For(&v1alpha1.NetworkInfo{}, builder.WithPredicates(networkInfoChangedPredicate)).
 		WithOptions(
 			controller.Options{
 				MaxConcurrentReconciles: common.NumReconcile(),
@@ -366,8 +597,8 @@ func (r *NetworkInfoReconciler) setupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			// For created/removed network config, add/remove from VPC network config cache,
 			// and update IPBlocksInfo.
-			// For modified network config, currently only support appending ips to public ip blocks,
-			// update network config in cache and update nsx VPC object.
+			// For modified network config, update network config in cache and requeue the NetworkInfo CRs
+			// to append the new private ips and switch the connectivity profile of the nsx VPC object.
 			&v1alpha1.VPCNetworkConfiguration{},
 			&VPCNetworkConfigurationHandler{
 				Client:              mgr.GetClient(),
@@ -375,11 +606,29 @@ func (r *NetworkInfoReconciler) setupWithManager(mgr ctrl.Manager) error {
 				ipBlocksInfoService: r.IPBlocksInfoService,
 			},
 			builder.WithPredicates(VPCNetworkConfigurationPredicate)).
+		Watches(
+			// For the Namespace moved to another VPCNetworkConfiguration, requeue the NetworkInfo CR
+			// to delete the stale VPC and create the VPC under the new network config.
+			// For the Namespace labels mirrored to NSX tags changed, requeue the NetworkInfo CR to update
+			// the tags of the VPC.
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.namespaceMapFunc),
+			builder.WithPredicates(namespaceChangedPredicate(r.Service.NSXConfig.GetLabelTagKeys()))).
+		Watches(
+			// For the Subnet or SubnetSet deleted or allocated with new network addresses, requeue the
+			// NetworkInfo CR to update the Subnets in the VPC state.
+			&v1alpha1.Subnet{},
+			handler.EnqueueRequestsFromMapFunc(r.subnetMapFunc),
+			builder.WithPredicates(subnetStateChangedPredicate)).
+		Watches(
+			&v1alpha1.SubnetSet{},
+			handler.EnqueueRequestsFromMapFunc(r.subnetMapFunc),
+			builder.WithPredicates(subnetStateChangedPredicate)).
 		Complete(r)
 }
 
 // Start setup manager and launch GC
-func (r *NetworkInfoReconciler) Start(mgr ctrl.Manager) error {
+func (r *NetworkInfoReconciler) Start(mgr ctrl.Manager, hookServer webhook.Server) error {
 	err := r.RegisterAllNetworkInfo(context.Background())
 	if err != nil {
 		return err
@@ -388,6 +637,16 @@ func (r *NetworkInfoReconciler) Start(mgr ctrl.Manager) error {
 	if err != nil {
 		return err
 	}
+	if hookServer != nil {
+		hookServer.Register("/validate-crd-nsx-vmware-com-v1alpha1-vpcnetworkconfiguration",
+			&webhook.Admission{
+				Handler: &VPCNetworkConfigurationValidator{
+					Client:     mgr.GetClient(),
+					decoder:    admission.NewDecoder(mgr.GetScheme()),
+					vpcService: r.Service,
+				},
+			})
+	}
 
 	// Start a goroutine to periodically sync the pre-created VPC's private IPs to NetworkInfo CR if used.
 	go wait.UntilWithContext(context.Background(), r.syncPreCreatedVpcIPs, preVPCSyncInterval)
@@ -435,11 +694,44 @@ func (r *NetworkInfoReconciler) listNamespaceCRsNameIDSet(ctx context.Context) (
 	return nsSet, idSet, nil
 }
 
+// listSharedVPCOwnersInUse returns the owner Namespaces whose shared VPCs are still used by the existing consumer
+// Namespaces, the VPC of such an owner Namespace is kept after the owner Namespace is deleted.
+func (r *NetworkInfoReconciler) listSharedVPCOwnersInUse(ctx context.Context) (sets.Set[string], error) {
+	namespaces := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, namespaces); err != nil {
+		return nil, err
+	}
+	nsSet := sets.Set[string]{}
+	owners := sets.Set[string]{}
+	for _, ns := range namespaces.Items {
+		if !ns.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+		nsSet.Insert(ns.Name)
+		if sharedNS := ns.Annotations[commonservice.AnnotationSharedVPCNamespace]; sharedNS != "" && sharedNS != ns.Name {
+			owners.Insert(sharedNS)
+		}
+	}
+	sharedVPCList := &v1alpha1.SharedVPCList{}
+	if err := r.Client.List(ctx, sharedVPCList); err != nil {
+		return nil, err
+	}
+	for _, sharedVPC := range sharedVPCList.Items {
+		for _, consumer := range sharedVPC.Spec.ConsumerNamespaces {
+			if nsSet.Has(consumer) {
+				owners.Insert(sharedVPC.Spec.OwnerNamespace)
+				break
+			}
+		}
+	}
+	return owners, nil
+}
+
 // CollectGarbage logic for NSX VPC is that:
 // 1. list all current existing namespace in kubernetes
 // 2. list all the NSX VPC in vpcStore
 // 3. loop all the NSX VPC to get its namespace, check if the namespace still exist
-// 4. if ns do not exist anymore, delete the NSX VPC resource
+// 4. if ns do not exist anymore, delete the NSX VPC resource unless it is still shared with other Namespaces
 // it implements the interface GarbageCollector method.
 func (r *NetworkInfoReconciler) CollectGarbage(ctx context.Context) {
 	startTime := time.Now()
@@ -459,6 +751,11 @@ func (r *NetworkInfoReconciler) CollectGarbage(ctx context.Context) {
 		log.Error(err, "Failed to list Kubernetes Namespaces for VPC garbage collection")
 		return
 	}
+	sharedVPCOwners, err := r.listSharedVPCOwnersInUse(ctx)
+	if err != nil {
+		log.Error(err, "Failed to list shared VPCs for VPC garbage collection")
+		return
+	}
 
 	for i, nsxVPC := range nsxVPCList {
 		nsxVPCNamespaceName := filterTagFromNSXVPC(&nsxVPCList[i], commonservice.TagScopeNamespace)
@@ -466,6 +763,10 @@ func (r *NetworkInfoReconciler) CollectGarbage(ctx context.Context) {
 		if idSet.Has(nsxVPCNamespaceID) {
 			continue
 		}
+		if sharedVPCOwners.Has(nsxVPCNamespaceName) {
+			log.V(1).Info("Skipping garbage collection, VPC is still shared with consumer Namespaces", "VPC", nsxVPC.Id, "Namespace", nsxVPCNamespaceName)
+			continue
+		}
 		log.Info("Garbage collecting NSX VPC object", "VPC", nsxVPC.Id, "Namespace", nsxVPCNamespaceName)
 		r.StatusUpdater.IncreaseDeleteTotal()
 
@@ -491,6 +792,15 @@ func (r *NetworkInfoReconciler) deleteVPCsByNamespace(ctx context.Context, ns st
 		log.Error(err, "Failed to list Kubernetes Namespaces")
 		return fmt.Errorf("failed to list Kubernetes Namespaces while deleting VPCs: %v", err)
 	}
+	sharedVPCOwners, err := r.listSharedVPCOwnersInUse(ctx)
+	if err != nil {
+		log.Error(err, "Failed to list shared VPCs")
+		return fmt.Errorf("failed to list shared VPCs while deleting VPCs: %v", err)
+	}
+	if sharedVPCOwners.Has(ns) {
+		log.Info("Skipping deletion, VPC is still shared with consumer Namespaces", "Namespace", ns)
+		return nil
+	}
 
 	var vpcToDelete []*model.Vpc
 	for _, nsxVPC := range staleVPCs {
@@ -528,6 +838,7 @@ func (r *NetworkInfoReconciler) deleteVPCs(ctx context.Context, staleVPCs []*mod
 	vpcNetConfig := r.Service.GetVPCNetworkConfigByNamespace(ns)
 	if vpcNetConfig != nil {
 		updateVPCNetworkConfigurationStatusWithAliveVPCs(ctx, r.Client, vpcNetConfig.Name, r.listVPCsByNetworkConfigName)
+		deleteVPCNetworkConfigurationStatusRollout(ctx, r.Client, vpcNetConfig.Name, ns)
 	}
 	return nil
 }
@@ -15,7 +15,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	vapierrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/orgs"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/orgs/projects"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -27,6 +30,7 @@ import (
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
@@ -149,6 +153,7 @@ func TestNetworkInfoReconciler_Reconcile(t *testing.T) {
 	tests := []struct {
 		name        string
 		prepareFunc func(*testing.T, *NetworkInfoReconciler, context.Context) *gomonkey.Patches
+		checkFunc   func(*testing.T, *NetworkInfoReconciler, context.Context)
 		args        args
 		want        controllerruntime.Result
 		wantErr     bool
@@ -228,6 +233,15 @@ func TestNetworkInfoReconciler_Reconcile(t *testing.T) {
 					})
 				return patches
 			},
+			checkFunc: func(t *testing.T, r *NetworkInfoReconciler, ctx context.Context) {
+				networkInfo := &v1alpha1.NetworkInfo{}
+				require.NoError(t, r.Client.Get(ctx, requestArgs.req.NamespacedName, networkInfo))
+				autoSnatCondition := getExistingConditionOfType(v1alpha1.AutoSnatEnabled, networkInfo.Conditions)
+				require.NotNil(t, autoSnatCondition)
+				assert.Equal(t, corev1.ConditionFalse, autoSnatCondition.Status)
+				assert.Equal(t, NSReasonVPCSnatNotReady, autoSnatCondition.Reason)
+				assert.Equal(t, corev1.ConditionTrue, getExistingConditionOfType(v1alpha1.VPCReady, networkInfo.Conditions).Status)
+			},
 			args:    requestArgs,
 			want:    common.ResultRequeueAfter60sec,
 			wantErr: false,
@@ -246,6 +260,19 @@ func TestNetworkInfoReconciler_Reconcile(t *testing.T) {
 						Name: "system",
 					},
 				}))
+				assert.NoError(t, r.Client.Create(ctx, &v1alpha1.Subnet{
+					ObjectMeta: metav1.ObjectMeta{Namespace: requestArgs.req.Namespace, Name: "subnet1"},
+					Spec:       v1alpha1.SubnetSpec{AccessMode: v1alpha1.AccessMode(v1alpha1.AccessModePrivate)},
+					Status:     v1alpha1.SubnetStatus{NetworkAddresses: []string{"172.26.0.0/28"}},
+				}))
+				assert.NoError(t, r.Client.Create(ctx, &v1alpha1.SubnetSet{
+					ObjectMeta: metav1.ObjectMeta{Namespace: requestArgs.req.Namespace, Name: "pod-default"},
+					Spec:       v1alpha1.SubnetSetSpec{AccessMode: v1alpha1.AccessMode(v1alpha1.AccessModePrivate)},
+					Status: v1alpha1.SubnetSetStatus{Subnets: []v1alpha1.SubnetInfo{
+						{NetworkAddresses: []string{"172.26.0.16/28"}},
+						{NetworkAddresses: []string{"172.26.0.32/28"}},
+					}},
+				}))
 				patches = gomonkey.ApplyMethod(reflect.TypeOf(r.Service), "GetNetworkconfigNameFromNS", func(_ *vpc.VPCService, _ context.Context, _ string) (string, error) {
 					return "non-system", nil
 				})
@@ -293,6 +320,29 @@ func TestNetworkInfoReconciler_Reconcile(t *testing.T) {
 					})
 				return patches
 			},
+			checkFunc: func(t *testing.T, r *NetworkInfoReconciler, ctx context.Context) {
+				networkInfo := &v1alpha1.NetworkInfo{}
+				require.NoError(t, r.Client.Get(ctx, requestArgs.req.NamespacedName, networkInfo))
+				require.Len(t, networkInfo.VPCs, 1)
+				vpcState := networkInfo.VPCs[0]
+				assert.Equal(t, "/orgs/default/projects/project-quality/vpcs/fake-vpc", vpcState.VPCPath)
+				assert.Equal(t, "/orgs/default/projects/nsx_operator_e2e_test/vpc-connectivity-profiles/default", vpcState.VPCConnectivityProfile)
+				assert.Equal(t, []string{"fake-ip-block"}, vpcState.ExternalIPBlocks)
+				assert.Equal(t, []v1alpha1.SubnetState{
+					{Name: "subnet1", Kind: "Subnet", AccessMode: "Private", NetworkAddresses: []string{"172.26.0.0/28"}},
+					{Name: "pod-default", Kind: "SubnetSet", AccessMode: "Private", NetworkAddresses: []string{"172.26.0.16/28", "172.26.0.32/28"}},
+				}, vpcState.Subnets)
+				expectedConditions := map[v1alpha1.ConditionType]corev1.ConditionStatus{
+					v1alpha1.VPCReady:               corev1.ConditionTrue,
+					v1alpha1.GatewayConnectionReady: corev1.ConditionTrue,
+					v1alpha1.AutoSnatEnabled:        corev1.ConditionFalse,
+					v1alpha1.LBReady:                corev1.ConditionTrue,
+				}
+				require.Len(t, networkInfo.Conditions, len(expectedConditions))
+				for _, condition := range networkInfo.Conditions {
+					assert.Equal(t, expectedConditions[condition.Type], condition.Status, "condition %s", condition.Type)
+				}
+			},
 			args:    requestArgs,
 			want:    common.ResultNormal,
 			wantErr: false,
@@ -817,6 +867,13 @@ func TestNetworkInfoReconciler_Reconcile(t *testing.T) {
 			r := createNetworkInfoReconciler(nil)
 			v1alpha1.AddToScheme(r.Scheme)
 			ctx := context.TODO()
+			profilePatches := gomonkey.ApplyMethod(reflect.TypeOf(r.Service), "UpdateVPCConnectivityProfile", func(_ *vpc.VPCService, _ *servicecommon.VPCNetworkConfigInfo, _ *model.Vpc) error {
+				return nil
+			})
+			profilePatches.ApplyPrivateMethod(reflect.TypeOf(r), "validateNSXResources", func(_ *NetworkInfoReconciler, _ *v1alpha1.VPCNetworkConfiguration) error {
+				return nil
+			})
+			defer profilePatches.Reset()
 			if tt.prepareFunc != nil {
 				patches := tt.prepareFunc(t, r, ctx)
 				defer patches.Reset()
@@ -829,6 +886,9 @@ func TestNetworkInfoReconciler_Reconcile(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Reconcile() got = %v, want %v", got, tt.want)
 			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, r, ctx)
+			}
 		})
 	}
 }
@@ -1254,6 +1314,36 @@ func TestNetworkInfoReconciler_CollectGarbage(t *testing.T) {
 		defer patches.Reset()
 		r.CollectGarbage(ctx)
 	})
+
+	t.Run("skip the VPC shared with consumer Namespaces", func(t *testing.T) {
+		r := createNetworkInfoReconciler([]client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-consumer", UID: "ns-consumer-uid"}},
+			&v1alpha1.SharedVPC{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-1"},
+				Spec:       v1alpha1.SharedVPCSpec{OwnerNamespace: "ns-owner", ConsumerNamespaces: []string{"ns-consumer"}},
+			},
+		})
+		patches := gomonkey.ApplyMethod(reflect.TypeOf(r.Service), "ListVPC", func(_ *vpc.VPCService) []model.Vpc {
+			return []model.Vpc{
+				{
+					Path: servicecommon.String("/vpc/1"),
+					Tags: []model.Tag{{Scope: servicecommon.String(servicecommon.TagScopeNamespace), Tag: servicecommon.String("ns-owner")}},
+				},
+				{
+					Path: servicecommon.String("/vpc/2"),
+					Tags: []model.Tag{{Scope: servicecommon.String(servicecommon.TagScopeNamespace), Tag: servicecommon.String("ns-2")}},
+				},
+			}
+		})
+		var deleted []string
+		patches.ApplyMethod(reflect.TypeOf(r.Service), "DeleteVPC", func(_ *vpc.VPCService, path string) error {
+			deleted = append(deleted, path)
+			return nil
+		})
+		defer patches.Reset()
+		r.CollectGarbage(ctx)
+		assert.Equal(t, []string{"/vpc/2"}, deleted)
+	})
 }
 
 func TestNetworkInfoReconciler_GetVpcConnectivityProfilePathByVpcPath(t *testing.T) {
@@ -1525,3 +1615,145 @@ func TestListVPCsByNetworkConfigName(t *testing.T) {
 	actVPCs := r.listVPCsByNetworkConfigName("nc1")
 	assert.ElementsMatch(t, expVPCs, actVPCs)
 }
+
+func TestNetworkInfoReconciler_NamespaceMapFunc(t *testing.T) {
+	r := createNetworkInfoReconciler([]client.Object{
+		&v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Namespace: "ns1"}},
+		&v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Name: "ns2", Namespace: "ns2"}},
+	})
+	requests := r.namespaceMapFunc(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}})
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "ns1"}}}, requests)
+
+	oldNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Annotations: map[string]string{servicecommon.AnnotationAppliedVPCNetworkConfig: "nc1"}}}
+	newNs := oldNs.DeepCopy()
+	newNs.Labels = map[string]string{"env": "test"}
+	assert.False(t, namespaceChangedPredicate(nil).Update(event.UpdateEvent{ObjectOld: oldNs, ObjectNew: newNs}))
+	assert.False(t, namespaceChangedPredicate([]string{"team"}).Update(event.UpdateEvent{ObjectOld: oldNs, ObjectNew: newNs}))
+	assert.True(t, namespaceChangedPredicate([]string{"team", "env"}).Update(event.UpdateEvent{ObjectOld: oldNs, ObjectNew: newNs}))
+	newNs.Annotations[servicecommon.AnnotationAppliedVPCNetworkConfig] = "nc2"
+	assert.True(t, namespaceChangedPredicate(nil).Update(event.UpdateEvent{ObjectOld: oldNs, ObjectNew: newNs}))
+}
+
+func TestNetworkInfoReconciler_SubnetMapFunc(t *testing.T) {
+	subnet1 := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet1", Namespace: "ns1"},
+		Status:     v1alpha1.SubnetStatus{NetworkAddresses: []string{"172.26.0.0/28"}},
+	}
+	r := createNetworkInfoReconciler([]client.Object{
+		&v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Namespace: "ns1"}, VPCs: []v1alpha1.VPCState{{Name: "vpc1"}}},
+		&v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Name: "ns2", Namespace: "ns2"}, VPCs: []v1alpha1.VPCState{{Name: "vpc2"}}},
+		subnet1,
+	})
+	requests := r.subnetMapFunc(context.TODO(), subnet1)
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "ns1"}}}, requests)
+
+	oldSubnet := &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet1", Namespace: "ns1"}}
+	newSubnet := oldSubnet.DeepCopy()
+	newSubnet.Status.Conditions = []v1alpha1.Condition{{Type: v1alpha1.Ready, Status: corev1.ConditionTrue}}
+	assert.False(t, subnetStateChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldSubnet, ObjectNew: newSubnet}))
+	newSubnet.Status.NetworkAddresses = []string{"172.26.0.0/28"}
+	assert.True(t, subnetStateChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldSubnet, ObjectNew: newSubnet}))
+
+	oldSubnetSet := &v1alpha1.SubnetSet{ObjectMeta: metav1.ObjectMeta{Name: "pod-default", Namespace: "ns1"}}
+	newSubnetSet := oldSubnetSet.DeepCopy()
+	newSubnetSet.Status.Subnets = []v1alpha1.SubnetInfo{{NetworkAddresses: []string{"172.26.0.16/28"}}}
+	assert.True(t, subnetStateChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldSubnetSet, ObjectNew: newSubnetSet}))
+	assert.False(t, subnetStateChangedPredicate.Create(event.CreateEvent{Object: newSubnetSet}))
+
+	// The VPC state and conditions updated by the reconciler don't requeue the NetworkInfo CR.
+	oldNetworkInfo := &v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Namespace: "ns1", ResourceVersion: "1"}}
+	newNetworkInfo := oldNetworkInfo.DeepCopy()
+	newNetworkInfo.ResourceVersion = "2"
+	newNetworkInfo.VPCs = []v1alpha1.VPCState{{Name: "vpc1", Subnets: []v1alpha1.SubnetState{{Name: "subnet1", Kind: "Subnet"}}}}
+	newNetworkInfo.Conditions = []v1alpha1.Condition{{Type: v1alpha1.VPCReady, Status: corev1.ConditionTrue}}
+	assert.False(t, networkInfoChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldNetworkInfo, ObjectNew: newNetworkInfo}))
+	newNetworkInfo.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	assert.True(t, networkInfoChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldNetworkInfo, ObjectNew: newNetworkInfo}))
+	assert.True(t, subnetStateChangedPredicate.Delete(event.DeleteEvent{Object: newSubnetSet}))
+}
+
+type fakeProjectsClient struct {
+	orgs.ProjectsClient
+	err error
+}
+
+func (c *fakeProjectsClient) Get(_ string, _ string, _ *bool) (model.Project, error) {
+	return model.Project{}, c.err
+}
+
+type fakeVpcsClient struct {
+	projects.VpcsClient
+	err error
+}
+
+func (c *fakeVpcsClient) Get(_ string, _ string, _ string) (model.Vpc, error) {
+	return model.Vpc{}, c.err
+}
+
+type fakeProfilesClient struct {
+	projects.VpcConnectivityProfilesClient
+	err error
+}
+
+func (c *fakeProfilesClient) Get(_ string, _ string, _ string) (model.VpcConnectivityProfile, error) {
+	return model.VpcConnectivityProfile{}, c.err
+}
+
+func TestNetworkInfoReconciler_ValidateNSXResources(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		spec       v1alpha1.VPCNetworkConfigurationSpec
+		projectErr error
+		vpcErr     error
+		profileErr error
+		expErr     string
+	}{
+		{
+			name: "all exist",
+			spec: v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", VPCConnectivityProfile: "/orgs/default/projects/p1/vpc-connectivity-profiles/default"},
+		},
+		{
+			name:       "project not found",
+			spec:       v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1"},
+			projectErr: vapierrors.NotFound{},
+			expErr:     "nsxProject /orgs/default/projects/p1 does not exist on NSX",
+		},
+		{
+			name:       "NSX unavailable",
+			spec:       v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1"},
+			projectErr: vapierrors.ServiceUnavailable{},
+		},
+		{
+			name:   "pre-created VPC not found",
+			spec:   v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", VPC: "/orgs/default/projects/p1/vpcs/vpc1"},
+			vpcErr: vapierrors.NotFound{},
+			expErr: "vpc /orgs/default/projects/p1/vpcs/vpc1 does not exist on NSX",
+		},
+		{
+			name:       "vpcConnectivityProfile not found",
+			spec:       v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", VPCConnectivityProfile: "default"},
+			profileErr: vapierrors.NotFound{},
+			expErr:     "vpcConnectivityProfile default does not exist on NSX",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &NetworkInfoReconciler{
+				Service: &vpc.VPCService{
+					Service: servicecommon.Service{
+						NSXClient: &nsx.Client{
+							ProjectClient:                 &fakeProjectsClient{err: tc.projectErr},
+							VPCClient:                     &fakeVpcsClient{err: tc.vpcErr},
+							VPCConnectivityProfilesClient: &fakeProfilesClient{err: tc.profileErr},
+						},
+					},
+				},
+			}
+			err := r.validateNSXResources(&v1alpha1.VPCNetworkConfiguration{Spec: tc.spec})
+			if tc.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expErr)
+			}
+		})
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"reflect"
 	"slices"
+	"strings"
 
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
@@ -39,6 +42,10 @@ func setNetworkInfoVPCStatus(client client.Client, ctx context.Context, obj clie
 	if len(networkInfo.VPCs) > 0 {
 		existingVPC = &networkInfo.VPCs[0]
 	}
+	// The IP utilization is reported by the IP utilization collector.
+	if createdVPC.IPUtilization == nil {
+		createdVPC.IPUtilization = existingVPC.IPUtilization
+	}
 	slices.Sort(existingVPC.PrivateIPs)
 	slices.Sort(createdVPC.PrivateIPs)
 	if reflect.DeepEqual(*existingVPC, *createdVPC) {
@@ -49,6 +56,87 @@ func setNetworkInfoVPCStatus(client client.Client, ctx context.Context, obj clie
 	return
 }
 
+// setNetworkInfoConditions merges the conditions into the NetworkInfo, the NetworkInfo is updated only if
+// any condition is changed. The conditions are merged into the latest NetworkInfo on conflict, e.g. the VPC state
+// is updated by the IP utilization collector meanwhile.
+func setNetworkInfoConditions(ctx context.Context, client client.Client, networkInfo *v1alpha1.NetworkInfo, conditions ...*v1alpha1.Condition) {
+	key := apitypes.NamespacedName{Namespace: networkInfo.Namespace, Name: networkInfo.Name}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		conditionsUpdated := false
+		for _, condition := range conditions {
+			if mergeStatusCondition(&networkInfo.Conditions, condition) {
+				conditionsUpdated = true
+			}
+		}
+		if !conditionsUpdated {
+			return nil
+		}
+		err := client.Update(ctx, networkInfo)
+		if apierrors.IsConflict(err) {
+			if getErr := client.Get(ctx, key, networkInfo); getErr != nil {
+				return getErr
+			}
+		}
+		return err
+	})
+	if err != nil {
+		log.Error(err, "Failed to update NetworkInfo conditions", "NetworkInfo", key)
+		return
+	}
+	log.V(1).Info("Updated NetworkInfo conditions", "NetworkInfo", key, "conditions", networkInfo.Conditions)
+}
+
+// getSubnetState returns the access mode and network addresses of the Subnet or SubnetSet.
+func getSubnetState(obj client.Object) v1alpha1.SubnetState {
+	switch o := obj.(type) {
+	case *v1alpha1.Subnet:
+		return v1alpha1.SubnetState{
+			Name:             o.Name,
+			Kind:             "Subnet",
+			AccessMode:       o.Spec.AccessMode,
+			NetworkAddresses: o.Status.NetworkAddresses,
+		}
+	case *v1alpha1.SubnetSet:
+		state := v1alpha1.SubnetState{
+			Name:       o.Name,
+			Kind:       "SubnetSet",
+			AccessMode: o.Spec.AccessMode,
+		}
+		for _, subnetInfo := range o.Status.Subnets {
+			state.NetworkAddresses = append(state.NetworkAddresses, subnetInfo.NetworkAddresses...)
+		}
+		return state
+	}
+	return v1alpha1.SubnetState{}
+}
+
+// listSubnetStates lists the Subnets and SubnetSets in the Namespace, the SubnetSets are after the Subnets
+// and both are sorted by name.
+func listSubnetStates(ctx context.Context, kubeClient client.Client, ns string) ([]v1alpha1.SubnetState, error) {
+	subnetList := &v1alpha1.SubnetList{}
+	if err := kubeClient.List(ctx, subnetList, client.InNamespace(ns)); err != nil {
+		return nil, err
+	}
+	subnetSetList := &v1alpha1.SubnetSetList{}
+	if err := kubeClient.List(ctx, subnetSetList, client.InNamespace(ns)); err != nil {
+		return nil, err
+	}
+	var states []v1alpha1.SubnetState
+	for i := range subnetList.Items {
+		states = append(states, getSubnetState(&subnetList.Items[i]))
+	}
+	for i := range subnetSetList.Items {
+		states = append(states, getSubnetState(&subnetSetList.Items[i]))
+	}
+	slices.SortFunc(states, func(a, b v1alpha1.SubnetState) int {
+		if a.Kind != b.Kind {
+			return strings.Compare(a.Kind, b.Kind)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return states, nil
+}
+
 func setVPCNetworkConfigurationStatusWithLBS(ctx context.Context, client client.Client, ncName, vpcName, aviSubnetPath, nsxLBSPath, vpcPath string) {
 	// read v1alpha1.VPCNetworkConfiguration by ncName
 	nc := &v1alpha1.VPCNetworkConfiguration{}
@@ -146,15 +234,12 @@ func mergeStatusCondition(conditions *[]v1alpha1.Condition, newCondition *v1alph
 	existingCondition := getExistingConditionOfType(newCondition.Type, *conditions)
 	if existingCondition != nil {
 		// Don't compare the timestamp.
-		existingCondition.LastTransitionTime = metav1.Time{}
-	}
-
-	if reflect.DeepEqual(existingCondition, newCondition) {
-		log.V(2).Info("conditions already match", "New Condition", newCondition, "Existing Condition", existingCondition)
-		return false
-	}
-
-	if existingCondition != nil {
+		compared := *existingCondition
+		compared.LastTransitionTime = newCondition.LastTransitionTime
+		if reflect.DeepEqual(compared, *newCondition) {
+			log.V(2).Info("conditions already match", "New Condition", newCondition, "Existing Condition", existingCondition)
+			return false
+		}
 		existingCondition.Reason = newCondition.Reason
 		existingCondition.Message = newCondition.Message
 		existingCondition.Status = newCondition.Status
@@ -220,6 +305,81 @@ func updateVPCNetworkConfigurationStatusWithAliveVPCs(ctx context.Context, clien
 	}
 }
 
+// isNamespaceRolloutPending returns true if the latest generation of the VPCNetworkConfiguration is not applied
+// to the VPC of the Namespace yet.
+func isNamespaceRolloutPending(nc *v1alpha1.VPCNetworkConfiguration, ns string) bool {
+	if nc == nil {
+		return true
+	}
+	for _, rollout := range nc.Status.Namespaces {
+		if rollout.Name == ns {
+			return rollout.ObservedGeneration != nc.Generation || rollout.Message != ""
+		}
+	}
+	return true
+}
+
+// setVPCNetworkConfigurationStatusWithRollout records the result of applying the generation of the VPCNetworkConfiguration
+// to the VPC of the Namespace. The failed Namespace keeps the generation applied last time.
+func setVPCNetworkConfigurationStatusWithRollout(ctx context.Context, client client.Client, nc *v1alpha1.VPCNetworkConfiguration, ns string, rolloutErr error) {
+	if nc == nil {
+		return
+	}
+	// The VPCNetworkConfiguration status is updated by the NetworkInfo reconciles of all the Namespaces using it.
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latestNC := &v1alpha1.VPCNetworkConfiguration{}
+		if err := client.Get(ctx, apitypes.NamespacedName{Name: nc.Name}, latestNC); err != nil {
+			return err
+		}
+		newRollout := v1alpha1.NamespaceRolloutStatus{Name: ns, ObservedGeneration: nc.Generation}
+		index := slices.IndexFunc(latestNC.Status.Namespaces, func(rollout v1alpha1.NamespaceRolloutStatus) bool {
+			return rollout.Name == ns
+		})
+		if rolloutErr != nil {
+			newRollout.ObservedGeneration = 0
+			if index >= 0 {
+				newRollout.ObservedGeneration = latestNC.Status.Namespaces[index].ObservedGeneration
+			}
+			newRollout.Message = rolloutErr.Error()
+		}
+		if index >= 0 {
+			if latestNC.Status.Namespaces[index] == newRollout {
+				return nil
+			}
+			latestNC.Status.Namespaces[index] = newRollout
+		} else {
+			latestNC.Status.Namespaces = append(latestNC.Status.Namespaces, newRollout)
+		}
+		return client.Status().Update(ctx, latestNC)
+	})
+	if err != nil {
+		log.Error(err, "Failed to update VPCNetworkConfiguration rollout status", "Name", nc.Name, "Namespace", ns)
+		return
+	}
+	log.V(1).Info("Updated VPCNetworkConfiguration rollout status", "Name", nc.Name, "Namespace", ns, "Generation", nc.Generation, "Error", rolloutErr)
+}
+
+// deleteVPCNetworkConfigurationStatusRollout removes the rollout status of the deleted Namespace.
+func deleteVPCNetworkConfigurationStatusRollout(ctx context.Context, client client.Client, ncName string, ns string) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		nc := &v1alpha1.VPCNetworkConfiguration{}
+		if err := client.Get(ctx, apitypes.NamespacedName{Name: ncName}, nc); err != nil {
+			return err
+		}
+		rollouts := slices.DeleteFunc(slices.Clone(nc.Status.Namespaces), func(rollout v1alpha1.NamespaceRolloutStatus) bool {
+			return rollout.Name == ns
+		})
+		if len(rollouts) == len(nc.Status.Namespaces) {
+			return nil
+		}
+		nc.Status.Namespaces = rollouts
+		return client.Status().Update(ctx, nc)
+	})
+	if err != nil {
+		log.Error(err, "Failed to delete VPCNetworkConfiguration rollout status", "Name", ncName, "Namespace", ns)
+	}
+}
+
 func filterTagFromNSXVPC(nsxVPC *model.Vpc, tagName string) string {
 	tags := nsxVPC.Tags
 	for _, tag := range tags {
@@ -348,3 +348,78 @@ func TestGetNSNetworkCondition(t *testing.T) {
 	}
 	require.True(t, nsConditionEquals(vpcNotReadyCondition, *nsMsgVPCCreateUpdateError.getNSNetworkCondition(msgErr)))
 }
+
+func TestSetNetworkInfoConditions(t *testing.T) {
+	scheme := clientgoscheme.Scheme
+	v1alpha1.AddToScheme(scheme)
+	ctx := context.TODO()
+	networkInfo := &v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "ns1"}}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(networkInfo).Build()
+
+	msgErr := fmt.Errorf("failed to connect to NSX")
+	setNetworkInfoConditions(ctx, k8sClient, networkInfo, nsMsgVPCCreateUpdateError.getNetworkInfoCondition(msgErr), newNetworkInfoCondition(v1alpha1.LBReady, true, ""))
+	updated := &v1alpha1.NetworkInfo{}
+	require.NoError(t, k8sClient.Get(ctx, apitypes.NamespacedName{Namespace: "ns1", Name: "ns1"}, updated))
+	require.Len(t, updated.Conditions, 2)
+	assert.Equal(t, v1alpha1.VPCReady, updated.Conditions[0].Type)
+	assert.Equal(t, corev1.ConditionFalse, updated.Conditions[0].Status)
+	assert.Equal(t, NSReasonVPCNotReady, updated.Conditions[0].Reason)
+	assert.Equal(t, "Error happened to create or update VPC: failed to connect to NSX", updated.Conditions[0].Message)
+	assert.Equal(t, v1alpha1.LBReady, updated.Conditions[1].Type)
+	assert.Equal(t, corev1.ConditionTrue, updated.Conditions[1].Status)
+	transitionTime := updated.Conditions[1].LastTransitionTime
+	assert.False(t, transitionTime.IsZero())
+
+	// The unchanged condition keeps the transition time.
+	setNetworkInfoConditions(ctx, k8sClient, updated, nsMsgVPCIsReady.getNetworkInfoCondition(), newNetworkInfoCondition(v1alpha1.LBReady, true, ""))
+	require.NoError(t, k8sClient.Get(ctx, apitypes.NamespacedName{Namespace: "ns1", Name: "ns1"}, updated))
+	assert.Equal(t, corev1.ConditionTrue, updated.Conditions[0].Status)
+	assert.Empty(t, updated.Conditions[0].Reason)
+	assert.Equal(t, transitionTime.Unix(), updated.Conditions[1].LastTransitionTime.Unix())
+
+	// The conditions are merged into the latest NetworkInfo on conflict.
+	stale := updated.DeepCopy()
+	updated.VPCs = []v1alpha1.VPCState{{Name: "vpc1"}}
+	require.NoError(t, k8sClient.Update(ctx, updated))
+	setNetworkInfoConditions(ctx, k8sClient, stale, nsMsgVPCCreateUpdateError.getNetworkInfoCondition(msgErr))
+	require.NoError(t, k8sClient.Get(ctx, apitypes.NamespacedName{Namespace: "ns1", Name: "ns1"}, updated))
+	assert.Equal(t, "vpc1", updated.VPCs[0].Name)
+	assert.Equal(t, corev1.ConditionFalse, updated.Conditions[0].Status)
+}
+
+func TestSetVPCNetworkConfigurationStatusWithRollout(t *testing.T) {
+	ctx := context.TODO()
+	scheme := clientgoscheme.Scheme
+	v1alpha1.AddToScheme(scheme)
+	nc := &v1alpha1.VPCNetworkConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "ncName", Generation: 2},
+		Status: v1alpha1.VPCNetworkConfigurationStatus{
+			Namespaces: []v1alpha1.NamespaceRolloutStatus{{Name: "ns1", ObservedGeneration: 1}},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nc).WithStatusSubresource(&v1alpha1.VPCNetworkConfiguration{}).Build()
+	assert.NoError(t, client.Get(ctx, apitypes.NamespacedName{Name: "ncName"}, nc))
+	assert.True(t, isNamespaceRolloutPending(nc, "ns1"))
+	assert.True(t, isNamespaceRolloutPending(nc, "ns2"))
+	assert.True(t, isNamespaceRolloutPending(nil, "ns1"))
+
+	// The failed Namespace keeps the generation applied last time.
+	setVPCNetworkConfigurationStatusWithRollout(ctx, client, nc, "ns1", fmt.Errorf("failed to update VPC"))
+	setVPCNetworkConfigurationStatusWithRollout(ctx, client, nc, "ns2", nil)
+	actualCR := &v1alpha1.VPCNetworkConfiguration{}
+	assert.NoError(t, client.Get(ctx, apitypes.NamespacedName{Name: "ncName"}, actualCR))
+	assert.Equal(t, []v1alpha1.NamespaceRolloutStatus{
+		{Name: "ns1", ObservedGeneration: 1, Message: "failed to update VPC"},
+		{Name: "ns2", ObservedGeneration: 2},
+	}, actualCR.Status.Namespaces)
+	assert.True(t, isNamespaceRolloutPending(actualCR, "ns1"))
+	assert.False(t, isNamespaceRolloutPending(actualCR, "ns2"))
+
+	setVPCNetworkConfigurationStatusWithRollout(ctx, client, nc, "ns1", nil)
+	assert.NoError(t, client.Get(ctx, apitypes.NamespacedName{Name: "ncName"}, actualCR))
+	assert.False(t, isNamespaceRolloutPending(actualCR, "ns1"))
+
+	deleteVPCNetworkConfigurationStatusRollout(ctx, client, "ncName", "ns1")
+	assert.NoError(t, client.Get(ctx, apitypes.NamespacedName{Name: "ncName"}, actualCR))
+	assert.Equal(t, []v1alpha1.NamespaceRolloutStatus{{Name: "ns2", ObservedGeneration: 2}}, actualCR.Status.Namespaces)
+}
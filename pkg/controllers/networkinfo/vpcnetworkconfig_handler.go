@@ -26,7 +26,9 @@ var (
 // VPCNetworkConfigurationHandler handles VPC NetworkConfiguration event, and reconcile VPC event:
 // - VPC Network Configuration creation: Add VPC Network Configuration into cache.
 // - VPC Network Configuration deletion: Delete VPC Network Configuration from cache.
-// - VPC Network Configuration update:	Only support updating external/private ipblocks, update values in cache
+// - VPC Network Configuration update:	Update values in cache and requeue the NetworkInfo CRs using it, the new private
+//   ipblocks are appended to and the connectivity profile is switched on the existing VPCs, the new default Subnet
+//   size only applies to the Subnets created later. The rollout is reported in the VPC Network Configuration status.
 
 type VPCNetworkConfigurationHandler struct {
 	Client              client.Client
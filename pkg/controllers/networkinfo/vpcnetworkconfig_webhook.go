@@ -0,0 +1,259 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package networkinfo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+)
+
+// +kubebuilder:webhook:path=/validate-crd-nsx-vmware-com-v1alpha1-vpcnetworkconfiguration,mutating=false,failurePolicy=fail,sideEffects=None,groups=crd.nsx.vmware.com,resources=vpcnetworkconfigurations,verbs=create;update;delete,versions=v1alpha1,name=vpcnetworkconfiguration.validating.crd.nsx.vmware.com,admissionReviewVersions=v1
+
+var (
+	nsxProjectPathRegex = regexp.MustCompile(`^/orgs/[^/]+/projects/[^/]+$`)
+	nsxVPCPathRegex     = regexp.MustCompile(`^/orgs/[^/]+/projects/[^/]+/vpcs/[^/]+$`)
+)
+
+// VPCNetworkConfigurationValidator rejects the VPCNetworkConfiguration which is invalid or can't be applied to
+// the existing VPCs, e.g. removing the private IPs which Subnets are allocated from or changing the nsxProject and
+// vpc, and the deletion of the VPCNetworkConfiguration still used by Namespaces.
+type VPCNetworkConfigurationValidator struct {
+	Client     client.Client
+	decoder    admission.Decoder
+	vpcService *vpc.VPCService
+}
+
+// Handle handles admission requests.
+func (v *VPCNetworkConfigurationValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log.V(1).Info("Handling request", "user", req.UserInfo.Username, "operation", req.Operation)
+	switch req.Operation {
+	case admissionv1.Create:
+		nc := &v1alpha1.VPCNetworkConfiguration{}
+		if err := v.decoder.Decode(req, nc); err != nil {
+			log.Error(err, "error while decoding VPCNetworkConfiguration", "VPCNetworkConfiguration", req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if err := v.validateSpec(ctx, nc); err != nil {
+			return admission.Denied(fmt.Sprintf("VPCNetworkConfiguration %s is invalid: %v", nc.Name, err))
+		}
+	case admissionv1.Update:
+		nc := &v1alpha1.VPCNetworkConfiguration{}
+		if err := v.decoder.Decode(req, nc); err != nil {
+			log.Error(err, "error while decoding VPCNetworkConfiguration", "VPCNetworkConfiguration", req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		oldNC := &v1alpha1.VPCNetworkConfiguration{}
+		if err := v.decoder.DecodeRaw(req.OldObject, oldNC); err != nil {
+			log.Error(err, "error while decoding old VPCNetworkConfiguration", "VPCNetworkConfiguration", req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		// The spec is not validated if unchanged, so that the metadata of the existing invalid
+		// VPCNetworkConfiguration, e.g. the finalizers, can still be updated.
+		if !reflect.DeepEqual(oldNC.Spec, nc.Spec) {
+			if err := v.validateSpec(ctx, nc); err != nil {
+				return admission.Denied(fmt.Sprintf("VPCNetworkConfiguration %s is invalid: %v", nc.Name, err))
+			}
+		}
+		if oldNC.Spec.NSXProject != nc.Spec.NSXProject || oldNC.Spec.VPC != nc.Spec.VPC {
+			if namespaces := v.vpcService.GetNamespacesByNetworkconfigName(nc.Name); len(namespaces) > 0 {
+				slices.Sort(namespaces)
+				return admission.Denied(fmt.Sprintf("VPCNetworkConfiguration %s is used by Namespace(s) %s, nsxProject and vpc cannot be changed", nc.Name, strings.Join(namespaces, ", ")))
+			}
+		}
+		if err := v.validatePrivateIPs(ctx, oldNC, nc); err != nil {
+			return admission.Denied(fmt.Sprintf("VPCNetworkConfiguration %s has invalid privateIPs: %v", nc.Name, err))
+		}
+	case admissionv1.Delete:
+		if namespaces := v.vpcService.GetNamespacesByNetworkconfigName(req.Name); len(namespaces) > 0 {
+			slices.Sort(namespaces)
+			return admission.Denied(fmt.Sprintf("VPCNetworkConfiguration %s is used by Namespace(s) %s and cannot be deleted", req.Name, strings.Join(namespaces, ", ")))
+		}
+	}
+	return admission.Allowed("")
+}
+
+// validateSpec checks the syntax of the VPCNetworkConfiguration spec. The NSX resources it refers to are checked
+// by the NetworkInfo reconciler when the VPCNetworkConfiguration is applied to the VPCs, which reports the result
+// in the VPCNetworkConfiguration status.
+func (v *VPCNetworkConfigurationValidator) validateSpec(ctx context.Context, nc *v1alpha1.VPCNetworkConfiguration) error {
+	spec := nc.Spec
+	if !nsxProjectPathRegex.MatchString(spec.NSXProject) {
+		return fmt.Errorf("nsxProject %q must be in the format /orgs/<org>/projects/<project>", spec.NSXProject)
+	}
+	if spec.DefaultSubnetSize != 0 && spec.DefaultSubnetSize&(spec.DefaultSubnetSize-1) != 0 {
+		return fmt.Errorf("defaultSubnetSize %d must be a power of 2", spec.DefaultSubnetSize)
+	}
+	if spec.VPC != "" {
+		// Only the subnet size and access mode take effect with the pre-created VPC.
+		if spec.VPCConnectivityProfile != "" || len(spec.PrivateIPs) > 0 {
+			return fmt.Errorf("vpcConnectivityProfile and privateIPs cannot be set with the pre-created vpc")
+		}
+		if !nsxVPCPathRegex.MatchString(spec.VPC) || !strings.HasPrefix(spec.VPC, spec.NSXProject+"/vpcs/") {
+			return fmt.Errorf("vpc %q must be in the format %s/vpcs/<vpc>", spec.VPC, spec.NSXProject)
+		}
+	} else {
+		// The VPC connectivity profile can be either an ID or a path in the NSX project.
+		profileID, found := strings.CutPrefix(spec.VPCConnectivityProfile, spec.NSXProject+"/vpc-connectivity-profiles/")
+		if !found {
+			profileID = spec.VPCConnectivityProfile
+		}
+		if strings.Contains(profileID, "/") {
+			return fmt.Errorf("vpcConnectivityProfile %q must be an ID or in the format %s/vpc-connectivity-profiles/<profile>", spec.VPCConnectivityProfile, spec.NSXProject)
+		}
+		if err := v.validatePrivateIPOverlap(ctx, nc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePrivateIPOverlap checks the private IPs are valid CIDRs and don't overlap with each other or the
+// private IPs of the other VPCNetworkConfigurations in the same NSX project, which share the project transit
+// gateway.
+func (v *VPCNetworkConfigurationValidator) validatePrivateIPOverlap(ctx context.Context, nc *v1alpha1.VPCNetworkConfiguration) error {
+	var prefixes []netip.Prefix
+	for _, cidr := range nc.Spec.PrivateIPs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return fmt.Errorf("privateIPs %q is not a valid CIDR", cidr)
+		}
+		for i, existing := range prefixes {
+			if prefix.Overlaps(existing) {
+				return fmt.Errorf("privateIPs %s overlaps with %s", cidr, nc.Spec.PrivateIPs[i])
+			}
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	if len(prefixes) == 0 {
+		return nil
+	}
+	ncList := &v1alpha1.VPCNetworkConfigurationList{}
+	if err := v.Client.List(ctx, ncList); err != nil {
+		log.Error(err, "Failed to list VPCNetworkConfigurations")
+		return err
+	}
+	for _, other := range ncList.Items {
+		if other.Name == nc.Name || other.Spec.VPC != "" || other.Spec.NSXProject != nc.Spec.NSXProject {
+			continue
+		}
+		for _, cidr := range other.Spec.PrivateIPs {
+			otherPrefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				continue
+			}
+			for i, prefix := range prefixes {
+				if prefix.Overlaps(otherPrefix) {
+					return fmt.Errorf("privateIPs %s overlaps with %s of VPCNetworkConfiguration %s", nc.Spec.PrivateIPs[i], cidr, other.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validatePrivateIPs checks no Subnet or IP address allocation is allocated from the private IPs removed from the
+// VPCNetworkConfiguration in the Namespaces using it, including the NSX Subnets and IP address allocations not
+// managed by the Subnet CRs. The removal is rejected if NSX is unreachable, as the removed private IPs are deleted
+// from the VPCs. The private IPs are ignored with the pre-created VPC.
+func (v *VPCNetworkConfigurationValidator) validatePrivateIPs(ctx context.Context, oldNC, nc *v1alpha1.VPCNetworkConfiguration) error {
+	if nc.Spec.VPC != "" {
+		return nil
+	}
+	var removed []netip.Prefix
+	for _, cidr := range oldNC.Spec.PrivateIPs {
+		if slices.Contains(nc.Spec.PrivateIPs, cidr) {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(cidr); err == nil {
+			removed = append(removed, prefix)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	namespaces := v.vpcService.GetNamespacesByNetworkconfigName(nc.Name)
+	slices.Sort(namespaces)
+	for _, ns := range namespaces {
+		subnetCIDRs, err := v.listSubnetCIDRs(ctx, ns)
+		if err != nil {
+			return err
+		}
+		for _, subnetCIDR := range subnetCIDRs {
+			subnetPrefix, err := netip.ParsePrefix(subnetCIDR)
+			if err != nil {
+				continue
+			}
+			for _, prefix := range removed {
+				if prefix.Overlaps(subnetPrefix) {
+					return fmt.Errorf("%s is in use by the Subnet %s of Namespace %s and cannot be removed", prefix, subnetCIDR, ns)
+				}
+			}
+		}
+		nsxAddresses, err := v.vpcService.ListVPCAddressesInUse(ns)
+		if err != nil {
+			return fmt.Errorf("failed to check the NSX Subnets and IP address allocations of Namespace %s: %w", ns, err)
+		}
+		for _, address := range nsxAddresses {
+			addressPrefix, err := parseAddressPrefix(address)
+			if err != nil {
+				continue
+			}
+			for _, prefix := range removed {
+				if prefix.Overlaps(addressPrefix) {
+					return fmt.Errorf("%s is in use by the NSX Subnet or IP address allocation %s of Namespace %s and cannot be removed", prefix, address, ns)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseAddressPrefix parses a CIDR or a single IP address as a prefix.
+func parseAddressPrefix(address string) (netip.Prefix, error) {
+	if strings.Contains(address, "/") {
+		return netip.ParsePrefix(address)
+	}
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// listSubnetCIDRs returns the CIDRs of the Subnets and SubnetSets in the Namespace.
+func (v *VPCNetworkConfigurationValidator) listSubnetCIDRs(ctx context.Context, ns string) ([]string, error) {
+	var cidrs []string
+	subnetList := &v1alpha1.SubnetList{}
+	if err := v.Client.List(ctx, subnetList, client.InNamespace(ns)); err != nil {
+		log.Error(err, "Failed to list Subnets", "Namespace", ns)
+		return nil, err
+	}
+	for _, subnet := range subnetList.Items {
+		cidrs = append(cidrs, subnet.Status.NetworkAddresses...)
+	}
+	subnetSetList := &v1alpha1.SubnetSetList{}
+	if err := v.Client.List(ctx, subnetSetList, client.InNamespace(ns)); err != nil {
+		log.Error(err, "Failed to list SubnetSets", "Namespace", ns)
+		return nil, err
+	}
+	for _, subnetSet := range subnetSetList.Items {
+		for _, subnetInfo := range subnetSet.Status.Subnets {
+			cidrs = append(cidrs, subnetInfo.NetworkAddresses...)
+		}
+	}
+	return cidrs, nil
+}
@@ -0,0 +1,251 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package networkinfo
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+)
+
+func TestVPCNetworkConfigurationValidator_Handle(t *testing.T) {
+	scheme := clientgoscheme.Scheme
+	v1alpha1.AddToScheme(scheme)
+	vpcService := &vpc.VPCService{}
+	v := &VPCNetworkConfigurationValidator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v1alpha1.VPCNetworkConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "nc2"},
+				Spec:       v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"10.0.0.0/16"}},
+			},
+			&v1alpha1.VPCNetworkConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "nc3"},
+				Spec:       v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p2", PrivateIPs: []string{"10.1.0.0/16"}},
+			},
+			&v1alpha1.Subnet{
+				ObjectMeta: metav1.ObjectMeta{Name: "subnet1", Namespace: "ns1"},
+				Status:     v1alpha1.SubnetStatus{NetworkAddresses: []string{"172.27.0.16/28"}},
+			},
+			&v1alpha1.SubnetSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "subnetset1", Namespace: "ns2"},
+				Status:     v1alpha1.SubnetSetStatus{Subnets: []v1alpha1.SubnetInfo{{NetworkAddresses: []string{"172.26.0.0/28"}}}},
+			},
+		).Build(),
+		decoder:    admission.NewDecoder(scheme),
+		vpcService: vpcService,
+	}
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(vpcService), "GetNamespacesByNetworkconfigName", func(_ *vpc.VPCService, nc string) []string {
+		if nc == "nc1" {
+			return []string{"ns1", "ns2"}
+		}
+		return nil
+	})
+	patches.ApplyMethod(reflect.TypeOf(vpcService), "ListVPCAddressesInUse", func(_ *vpc.VPCService, ns string) ([]string, error) {
+		if ns == "ns1" {
+			return []string{"172.29.0.0/28", "172.30.0.5"}, nil
+		}
+		return nil, nil
+	})
+	defer patches.Reset()
+
+	newRequest := func(operation admissionv1.Operation, oldSpec, newSpec v1alpha1.VPCNetworkConfigurationSpec) admission.Request {
+		oldObj, _ := json.Marshal(&v1alpha1.VPCNetworkConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "nc1"}, Spec: oldSpec})
+		newObj, _ := json.Marshal(&v1alpha1.VPCNetworkConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "nc1"}, Spec: newSpec})
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			Name:      "nc1",
+			Object:    runtime.RawExtension{Raw: newObj},
+			OldObject: runtime.RawExtension{Raw: oldObj},
+		}}
+	}
+
+	for _, tc := range []struct {
+		name        string
+		operation   admissionv1.Operation
+		oldSpec     v1alpha1.VPCNetworkConfigurationSpec
+		newSpec     v1alpha1.VPCNetworkConfigurationSpec
+		expAllowed  bool
+		expErrorMsg string
+	}{
+		{
+			name:       "create",
+			operation:  admissionv1.Create,
+			newSpec:    v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/16"}},
+			expAllowed: true,
+		},
+		{
+			name:       "append private IPs",
+			operation:  admissionv1.Update,
+			oldSpec:    v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/16"}},
+			newSpec:    v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/16", "172.28.0.0/16"}},
+			expAllowed: true,
+		},
+		{
+			name:       "remove private IPs not in use",
+			operation:  admissionv1.Update,
+			oldSpec:    v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/16", "172.28.0.0/16"}},
+			newSpec:    v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/16"}},
+			expAllowed: true,
+		},
+		{
+			name:        "remove private IPs in use",
+			operation:   admissionv1.Update,
+			oldSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/16", "172.27.0.0/16"}},
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/16"}},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 has invalid privateIPs: 172.27.0.0/16 is in use by the Subnet 172.27.0.16/28 of Namespace ns1 and cannot be removed",
+		},
+		{
+			name:        "remove private IPs in use by SubnetSet",
+			operation:   admissionv1.Update,
+			oldSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/16", "172.28.0.0/16"}},
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.28.0.0/16"}},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 has invalid privateIPs: 172.26.0.0/16 is in use by the Subnet 172.26.0.0/28 of Namespace ns2 and cannot be removed",
+		},
+		{
+			name:        "remove private IPs in use by NSX Subnet",
+			operation:   admissionv1.Update,
+			oldSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/28", "172.29.0.0/16"}},
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/28"}},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 has invalid privateIPs: 172.29.0.0/16 is in use by the NSX Subnet or IP address allocation 172.29.0.0/28 of Namespace ns1 and cannot be removed",
+		},
+		{
+			name:        "remove private IPs in use by NSX IP address allocation",
+			operation:   admissionv1.Update,
+			oldSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/28", "172.30.0.0/16"}},
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/28"}},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 has invalid privateIPs: 172.30.0.0/16 is in use by the NSX Subnet or IP address allocation 172.30.0.5 of Namespace ns1 and cannot be removed",
+		},
+		{
+			name:       "remove private IPs with pre-created VPC",
+			operation:  admissionv1.Update,
+			oldSpec:    v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", VPC: "/orgs/default/projects/p1/vpcs/vpc1", PrivateIPs: []string{"172.27.0.0/16"}},
+			newSpec:    v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", VPC: "/orgs/default/projects/p1/vpcs/vpc1"},
+			expAllowed: true,
+		},
+		{
+			name:        "change nsxProject in use",
+			operation:   admissionv1.Update,
+			oldSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/16"}},
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p3", PrivateIPs: []string{"172.26.0.0/16"}},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 is used by Namespace(s) ns1, ns2, nsxProject and vpc cannot be changed",
+		},
+		{
+			name:        "change vpc in use",
+			operation:   admissionv1.Update,
+			oldSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", VPC: "/orgs/default/projects/p1/vpcs/vpc1"},
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", VPC: "/orgs/default/projects/p1/vpcs/vpc2"},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 is used by Namespace(s) ns1, ns2, nsxProject and vpc cannot be changed",
+		},
+		{
+			name:       "update metadata of invalid config",
+			operation:  admissionv1.Update,
+			oldSpec:    v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "p1"},
+			newSpec:    v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "p1"},
+			expAllowed: true,
+		},
+		{
+			name:        "invalid nsxProject",
+			operation:   admissionv1.Create,
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "p1"},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 is invalid: nsxProject \"p1\" must be in the format /orgs/<org>/projects/<project>",
+		},
+		{
+			name:        "defaultSubnetSize not power of 2",
+			operation:   admissionv1.Update,
+			oldSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", DefaultSubnetSize: 32},
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", DefaultSubnetSize: 48},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 is invalid: defaultSubnetSize 48 must be a power of 2",
+		},
+		{
+			name:        "pre-created VPC with privateIPs",
+			operation:   admissionv1.Create,
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", VPC: "/orgs/default/projects/p1/vpcs/vpc1", PrivateIPs: []string{"172.26.0.0/16"}},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 is invalid: vpcConnectivityProfile and privateIPs cannot be set with the pre-created vpc",
+		},
+		{
+			name:        "pre-created VPC in another project",
+			operation:   admissionv1.Create,
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", VPC: "/orgs/default/projects/p2/vpcs/vpc1"},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 is invalid: vpc \"/orgs/default/projects/p2/vpcs/vpc1\" must be in the format /orgs/default/projects/p1/vpcs/<vpc>",
+		},
+		{
+			name:       "vpcConnectivityProfile ID",
+			operation:  admissionv1.Create,
+			newSpec:    v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", VPCConnectivityProfile: "default"},
+			expAllowed: true,
+		},
+		{
+			name:        "vpcConnectivityProfile in another project",
+			operation:   admissionv1.Create,
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", VPCConnectivityProfile: "/orgs/default/projects/p2/vpc-connectivity-profiles/default"},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 is invalid: vpcConnectivityProfile \"/orgs/default/projects/p2/vpc-connectivity-profiles/default\" must be an ID or in the format /orgs/default/projects/p1/vpc-connectivity-profiles/<profile>",
+		},
+		{
+			name:        "invalid privateIPs",
+			operation:   admissionv1.Create,
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0"}},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 is invalid: privateIPs \"172.26.0.0\" is not a valid CIDR",
+		},
+		{
+			name:        "overlapping privateIPs",
+			operation:   admissionv1.Create,
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"172.26.0.0/16", "172.26.1.0/24"}},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 is invalid: privateIPs 172.26.1.0/24 overlaps with 172.26.0.0/16",
+		},
+		{
+			name:        "privateIPs overlapping with another config",
+			operation:   admissionv1.Create,
+			newSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"10.0.0.0/8"}},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 is invalid: privateIPs 10.0.0.0/8 overlaps with 10.0.0.0/16 of VPCNetworkConfiguration nc2",
+		},
+		{
+			name:       "privateIPs overlapping with config in another project",
+			operation:  admissionv1.Create,
+			newSpec:    v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1", PrivateIPs: []string{"10.1.0.0/16"}},
+			expAllowed: true,
+		},
+		{
+			name:        "delete config in use",
+			operation:   admissionv1.Delete,
+			oldSpec:     v1alpha1.VPCNetworkConfigurationSpec{NSXProject: "/orgs/default/projects/p1"},
+			expAllowed:  false,
+			expErrorMsg: "VPCNetworkConfiguration nc1 is used by Namespace(s) ns1, ns2 and cannot be deleted",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			response := v.Handle(context.TODO(), newRequest(tc.operation, tc.oldSpec, tc.newSpec))
+			assert.Equal(t, tc.expAllowed, response.Allowed)
+			if !tc.expAllowed {
+				assert.Equal(t, tc.expErrorMsg, response.Result.Message)
+			}
+		})
+	}
+}
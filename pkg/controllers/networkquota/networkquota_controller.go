@@ -0,0 +1,186 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package networkquota
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+var (
+	log                       = &logger.Log
+	ResultNormal              = common.ResultNormal
+	ResultRequeue             = common.ResultRequeue
+	MetricResTypeNetworkQuota = common.MetricResTypeNetworkQuota
+)
+
+const (
+	ReasonNetworkQuotaReady    = "NetworkQuotaReady"
+	ReasonNetworkQuotaNotReady = "NetworkQuotaNotReady"
+)
+
+// NetworkQuotaReconciler reconciles a NetworkQuota object. The NetworkQuota is enforced by the webhooks of the
+// limited resources, this controller only reports the usage of the network resources in the status.
+type NetworkQuotaReconciler struct {
+	Client        client.Client
+	Scheme        *apimachineryruntime.Scheme
+	Recorder      record.EventRecorder
+	StatusUpdater common.StatusUpdater
+	VPCService    servicecommon.VPCServiceProvider
+}
+
+func setNetworkQuotaReadyStatusTrue(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, args ...interface{}) {
+	networkQuota := obj.(*v1alpha1.NetworkQuota)
+	updateNetworkQuotaStatus(client, ctx, networkQuota, v1alpha1.Condition{
+		Type:               v1alpha1.Ready,
+		Status:             v1.ConditionTrue,
+		Message:            "Usage of the network resources has been calculated",
+		Reason:             ReasonNetworkQuotaReady,
+		LastTransitionTime: transitionTime,
+	}, args...)
+}
+
+func setNetworkQuotaReadyStatusFalse(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, err error, args ...interface{}) {
+	networkQuota := obj.(*v1alpha1.NetworkQuota)
+	updateNetworkQuotaStatus(client, ctx, networkQuota, v1alpha1.Condition{
+		Type:               v1alpha1.Ready,
+		Status:             v1.ConditionFalse,
+		Message:            fmt.Sprintf("Error occurred while processing the NetworkQuota CR. Error: %v", err),
+		Reason:             ReasonNetworkQuotaNotReady,
+		LastTransitionTime: transitionTime,
+	}, args...)
+}
+
+// updateNetworkQuotaStatus updates the status of the NetworkQuota only if it is changed. The usage is passed
+// in args if it is calculated.
+func updateNetworkQuotaStatus(client client.Client, ctx context.Context, networkQuota *v1alpha1.NetworkQuota, newCondition v1alpha1.Condition, args ...interface{}) {
+	updated := mergeNetworkQuotaStatusCondition(networkQuota, &newCondition)
+	if len(args) == 1 {
+		used := args[0].(v1alpha1.NetworkQuotaResources)
+		if !reflect.DeepEqual(networkQuota.Status.Used, used) {
+			networkQuota.Status.Used = used
+			updated = true
+		}
+	}
+	if !updated {
+		return
+	}
+	if err := client.Status().Update(ctx, networkQuota); err != nil {
+		log.Error(err, "Failed to update NetworkQuota status", "NetworkQuota", networkQuota.Namespace+"/"+networkQuota.Name)
+		return
+	}
+	log.V(1).Info("Updated NetworkQuota status", "NetworkQuota", networkQuota.Namespace+"/"+networkQuota.Name, "New Condition", newCondition)
+}
+
+func mergeNetworkQuotaStatusCondition(networkQuota *v1alpha1.NetworkQuota, newCondition *v1alpha1.Condition) bool {
+	for i := range networkQuota.Status.Conditions {
+		matchedCondition := &networkQuota.Status.Conditions[i]
+		if matchedCondition.Type != newCondition.Type {
+			continue
+		}
+		if matchedCondition.Status == newCondition.Status && matchedCondition.Reason == newCondition.Reason && matchedCondition.Message == newCondition.Message {
+			return false
+		}
+		if matchedCondition.Status != newCondition.Status {
+			matchedCondition.LastTransitionTime = newCondition.LastTransitionTime
+		}
+		matchedCondition.Reason = newCondition.Reason
+		matchedCondition.Message = newCondition.Message
+		matchedCondition.Status = newCondition.Status
+		return true
+	}
+	networkQuota.Status.Conditions = append(networkQuota.Status.Conditions, *newCondition)
+	return true
+}
+
+func (r *NetworkQuotaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := &v1alpha1.NetworkQuota{}
+	log.Info("Reconciling NetworkQuota CR", "NetworkQuota", req.NamespacedName)
+	r.StatusUpdater.IncreaseSyncTotal()
+
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.StatusUpdater.DeleteSuccess(req.NamespacedName, nil)
+			return ResultNormal, nil
+		}
+		log.Error(err, "Unable to fetch NetworkQuota CR", "req", req.NamespacedName)
+		return ResultRequeue, err
+	}
+	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ResultNormal, nil
+	}
+
+	r.StatusUpdater.IncreaseUpdateTotal()
+	usage, err := common.ComputeNetworkQuotaUsage(ctx, r.Client, r.VPCService, obj.Namespace)
+	if err != nil {
+		r.StatusUpdater.UpdateFail(ctx, obj, err, "failed to calculate the usage of the network resources", setNetworkQuotaReadyStatusFalse)
+		return ResultRequeue, err
+	}
+	r.StatusUpdater.UpdateSuccess(ctx, obj, setNetworkQuotaReadyStatusTrue, usage.ToResources())
+	return ResultNormal, nil
+}
+
+// namespaceResourceMapFunc enqueues the NetworkQuotas in the Namespace of the limited resource, so that the
+// usage in the status is updated when the resources are created, realized or deleted.
+func (r *NetworkQuotaReconciler) namespaceResourceMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	networkQuotaList := &v1alpha1.NetworkQuotaList{}
+	if err := r.Client.List(ctx, networkQuotaList, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.Error(err, "Failed to list NetworkQuota CR", "Namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, networkQuota := range networkQuotaList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: networkQuota.Namespace, Name: networkQuota.Name}})
+	}
+	return requests
+}
+
+func (r *NetworkQuotaReconciler) setupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.NetworkQuota{}).
+		WithOptions(
+			controller.Options{
+				MaxConcurrentReconciles: common.NumReconcile(),
+			}).
+		Watches(&v1alpha1.Subnet{}, handler.EnqueueRequestsFromMapFunc(r.namespaceResourceMapFunc)).
+		Watches(&v1alpha1.SubnetSet{}, handler.EnqueueRequestsFromMapFunc(r.namespaceResourceMapFunc)).
+		Watches(&v1alpha1.SubnetPort{}, handler.EnqueueRequestsFromMapFunc(r.namespaceResourceMapFunc)).
+		Watches(&v1alpha1.IPAddressAllocation{}, handler.EnqueueRequestsFromMapFunc(r.namespaceResourceMapFunc)).
+		Watches(&v1alpha1.StaticRoute{}, handler.EnqueueRequestsFromMapFunc(r.namespaceResourceMapFunc)).
+		Complete(r)
+}
+
+func StartNetworkQuotaController(mgr ctrl.Manager, vpcService servicecommon.VPCServiceProvider, cf *config.NSXOperatorConfig) {
+	networkQuotaReconciler := NetworkQuotaReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Recorder:   mgr.GetEventRecorderFor("networkquota-controller"),
+		VPCService: vpcService,
+	}
+	networkQuotaReconciler.StatusUpdater = common.NewStatusUpdater(networkQuotaReconciler.Client, cf, networkQuotaReconciler.Recorder, MetricResTypeNetworkQuota, "NetworkQuota", "NetworkQuota")
+	if err := networkQuotaReconciler.setupWithManager(mgr); err != nil {
+		log.Error(err, "Failed to create controller", "controller", "NetworkQuota")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,110 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package networkquota
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+type fakeVPCService struct {
+	servicecommon.VPCServiceProvider
+}
+
+func (s *fakeVPCService) GetVPCNetworkConfigByNamespace(ns string) *servicecommon.VPCNetworkConfigInfo {
+	return &servicecommon.VPCNetworkConfigInfo{DefaultSubnetSize: 32}
+}
+
+func createNetworkQuotaReconciler(objs ...client.Object) *NetworkQuotaReconciler {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(newScheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.NetworkQuota{}).Build()
+	r := &NetworkQuotaReconciler{
+		Client:     fakeClient,
+		Scheme:     newScheme,
+		Recorder:   &record.FakeRecorder{},
+		VPCService: &fakeVPCService{},
+	}
+	r.StatusUpdater = common.NewStatusUpdater(r.Client, &config.NSXOperatorConfig{NsxConfig: &config.NsxConfig{}}, r.Recorder, MetricResTypeNetworkQuota, "NetworkQuota", "NetworkQuota")
+	return r
+}
+
+func TestNetworkQuotaReconciler_Reconcile(t *testing.T) {
+	quota := &v1alpha1.NetworkQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "quota-1"},
+		Spec:       v1alpha1.NetworkQuotaSpec{Hard: v1alpha1.NetworkQuotaResources{Subnets: servicecommon.Int64(10)}},
+	}
+	r := createNetworkQuotaReconciler(quota,
+		&v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "subnet-1"}},
+		&v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "subnet-2"}, Status: v1alpha1.SubnetStatus{NetworkAddresses: []string{"10.0.0.0/28"}}},
+		&v1alpha1.SubnetPort{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "port-1"}},
+		&v1alpha1.SubnetPort{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-2", Name: "port-2"}},
+		&v1alpha1.StaticRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "route-1"}},
+	)
+	ctx := context.TODO()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns-1", Name: "quota-1"}}
+
+	result, err := r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, ResultNormal, result)
+	updated := &v1alpha1.NetworkQuota{}
+	assert.NoError(t, r.Client.Get(ctx, req.NamespacedName, updated))
+	assert.Equal(t, common.NetworkQuotaUsage{Subnets: 2, SubnetIPs: 48, SubnetPorts: 1, StaticRoutes: 1}.ToResources(), updated.Status.Used)
+	assert.Equal(t, 1, len(updated.Status.Conditions))
+	assert.Equal(t, v1.ConditionTrue, updated.Status.Conditions[0].Status)
+	assert.Equal(t, ReasonNetworkQuotaReady, updated.Status.Conditions[0].Reason)
+
+	// The usage is not calculated.
+	patches := gomonkey.ApplyFunc(common.ComputeNetworkQuotaUsage, func(_ context.Context, _ client.Client, _ servicecommon.VPCServiceProvider, _ string) (*common.NetworkQuotaUsage, error) {
+		return nil, errors.New("list failure")
+	})
+	defer patches.Reset()
+	result, err = r.Reconcile(ctx, req)
+	assert.Error(t, err)
+	assert.Equal(t, ResultRequeue, result)
+	assert.NoError(t, r.Client.Get(ctx, req.NamespacedName, updated))
+	assert.Equal(t, v1.ConditionFalse, updated.Status.Conditions[0].Status)
+	assert.Equal(t, ReasonNetworkQuotaNotReady, updated.Status.Conditions[0].Reason)
+	assert.Equal(t, servicecommon.Int64(2), updated.Status.Used.Subnets)
+
+	// The NetworkQuota is deleted.
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns-1", Name: "quota-2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, ResultNormal, result)
+}
+
+func TestNetworkQuotaReconciler_namespaceResourceMapFunc(t *testing.T) {
+	r := createNetworkQuotaReconciler(
+		&v1alpha1.NetworkQuota{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "quota-1"}},
+		&v1alpha1.NetworkQuota{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "quota-2"}},
+		&v1alpha1.NetworkQuota{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-2", Name: "quota-3"}},
+	)
+	requests := r.namespaceResourceMapFunc(context.TODO(), &v1alpha1.SubnetPort{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "port-1"}})
+	assert.ElementsMatch(t, []ctrl.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns-1", Name: "quota-1"}},
+		{NamespacedName: types.NamespacedName{Namespace: "ns-1", Name: "quota-2"}},
+	}, requests)
+	assert.Empty(t, r.namespaceResourceMapFunc(context.TODO(), &v1alpha1.StaticRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-3", Name: "route-1"}}))
+}
@@ -7,22 +7,28 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
 	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetport"
 )
@@ -43,6 +49,11 @@ type PodReconciler struct {
 	NodeServiceReader servicecommon.NodeServiceReader
 	Recorder          record.EventRecorder
 	StatusUpdater     common.StatusUpdater
+
+	// subnetPortPoolDemands stores the SubnetPort pools requested by the Pods, keyed by the SubnetSet UID
+	// and the node name.
+	subnetPortPoolDemands sync.Map
+	subnetPortPoolRefill  chan struct{}
 }
 
 func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -99,13 +110,29 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 			r.StatusUpdater.UpdateFail(ctx, pod, err, "", nil)
 			return common.ResultRequeue, err
 		}
+		networks, err := parsePodNetworks(pod)
+		if err != nil {
+			// No need to requeue as the Pod is reconciled again when the annotation is updated.
+			r.StatusUpdater.UpdateFail(ctx, pod, err, "invalid additional networks", nil)
+			return common.ResultNormal, nil
+		}
+		if err := r.reconcilePodInterfaces(ctx, pod, networks, contextID); err != nil {
+			r.StatusUpdater.UpdateFail(ctx, pod, err, "failed to create or update additional networks", nil)
+			return common.ResultRequeue, err
+		}
 		r.StatusUpdater.UpdateSuccess(ctx, pod, nil)
 	} else {
-		subnetPortID := r.SubnetPortService.BuildSubnetPortId(&pod.ObjectMeta)
+		subnetPortID := r.SubnetPortService.GetSubnetPortIDForPod(pod)
 		if err := r.SubnetPortService.DeleteSubnetPortById(subnetPortID); err != nil {
 			r.StatusUpdater.DeleteFail(req.NamespacedName, pod, err)
 			return common.ResultRequeue, err
 		}
+		for _, nsxSubnetPort := range r.SubnetPortService.ListPodInterfacePorts(string(pod.UID)) {
+			if err := r.SubnetPortService.DeleteSubnetPort(nsxSubnetPort); err != nil {
+				r.StatusUpdater.DeleteFail(req.NamespacedName, pod, err)
+				return common.ResultRequeue, err
+			}
+		}
 		r.StatusUpdater.DeleteSuccess(req.NamespacedName, pod)
 	}
 	return common.ResultNormal, nil
@@ -135,18 +162,40 @@ func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			controller.Options{
 				MaxConcurrentReconciles: common.NumReconcile(),
 			}).
+		Watches(&v1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.namespaceMapFunc),
+			builder.WithPredicates(common.NamespaceLabelTagsChangedPredicate(r.SubnetPortService.NSXConfig.GetLabelTagKeys()))).
 		Complete(r)
 }
 
+// namespaceMapFunc enqueues the Pods in the Namespace to update the Namespace labels mirrored to the tags of
+// the NSX SubnetPorts.
+func (r *PodReconciler) namespaceMapFunc(ctx context.Context, ns client.Object) []reconcile.Request {
+	podList := &v1.PodList{}
+	if err := r.Client.List(ctx, podList, client.InNamespace(ns.GetName())); err != nil {
+		log.Error(err, "Failed to list Pods in Namespace", "Namespace", ns.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, pod := range podList.Items {
+		if pod.Spec.HostNetwork {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}})
+	}
+	return requests
+}
+
 func StartPodController(mgr ctrl.Manager, subnetPortService *subnetport.SubnetPortService, subnetService servicecommon.SubnetServiceProvider, vpcService servicecommon.VPCServiceProvider, nodeService servicecommon.NodeServiceReader) {
-	podPortReconciler := PodReconciler{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		SubnetService:     subnetService,
-		SubnetPortService: subnetPortService,
-		VPCService:        vpcService,
-		NodeServiceReader: nodeService,
-		Recorder:          mgr.GetEventRecorderFor("pod-controller"),
+	podPortReconciler := &PodReconciler{
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		SubnetService:        subnetService,
+		SubnetPortService:    subnetPortService,
+		VPCService:           vpcService,
+		NodeServiceReader:    nodeService,
+		Recorder:             mgr.GetEventRecorderFor("pod-controller"),
+		subnetPortPoolRefill: make(chan struct{}, 1),
 	}
 	podPortReconciler.StatusUpdater = common.NewStatusUpdater(podPortReconciler.Client, podPortReconciler.SubnetPortService.NSXConfig, podPortReconciler.Recorder, MetricResTypePod, "SubnetPort", "Pod")
 	if err := podPortReconciler.Start(mgr); err != nil {
@@ -154,6 +203,10 @@ func StartPodController(mgr ctrl.Manager, subnetPortService *subnetport.SubnetPo
 		os.Exit(1)
 	}
 	go common.GenericGarbageCollector(make(chan bool), servicecommon.GCInterval, podPortReconciler.CollectGarbage)
+	if podPortReconciler.subnetPortPoolSize() > 0 {
+		metrics.InitializeSubnetPortPoolMetrics()
+		go podPortReconciler.runSubnetPortPoolRefill(make(chan bool))
+	}
 }
 
 // Start setup manager and launch GC
@@ -168,6 +221,7 @@ func (r *PodReconciler) Start(mgr ctrl.Manager) error {
 // CollectGarbage  collect Pod which has been removed from crd.
 func (r *PodReconciler) CollectGarbage(ctx context.Context) {
 	log.Info("pod garbage collector started")
+	r.collectSubnetPortPoolGarbage(ctx)
 	nsxSubnetPortSet := r.SubnetPortService.ListNSXSubnetPortIDForPod()
 	if len(nsxSubnetPortSet) == 0 {
 		return
@@ -181,8 +235,12 @@ func (r *PodReconciler) CollectGarbage(ctx context.Context) {
 
 	PodSet := sets.New[string]()
 	for _, pod := range podList.Items {
-		subnetPortID := r.SubnetPortService.BuildSubnetPortId(&pod.ObjectMeta)
+		subnetPortID := r.SubnetPortService.GetSubnetPortIDForPod(&pod)
 		PodSet.Insert(subnetPortID)
+		// The NSX subnet ports of the additional networks are deleted with the Pod.
+		for _, nsxSubnetPort := range r.SubnetPortService.ListPodInterfacePorts(string(pod.UID)) {
+			PodSet.Insert(*nsxSubnetPort.Id)
+		}
 	}
 
 	diffSet := nsxSubnetPortSet.Difference(PodSet)
@@ -199,7 +257,7 @@ func (r *PodReconciler) CollectGarbage(ctx context.Context) {
 }
 
 func (r *PodReconciler) GetSubnetPathForPod(ctx context.Context, pod *v1.Pod) (bool, string, error) {
-	subnetPortIDForPod := r.SubnetPortService.BuildSubnetPortId(&pod.ObjectMeta)
+	subnetPortIDForPod := r.SubnetPortService.GetSubnetPortIDForPod(pod)
 	subnetPath := r.SubnetPortService.GetSubnetPathForSubnetPortFromStore(subnetPortIDForPod)
 	if len(subnetPath) > 0 {
 		log.V(1).Info("NSX subnet port had been created, returning the existing NSX subnet path", "pod.UID", pod.UID, "subnetPath", subnetPath)
@@ -209,8 +267,12 @@ func (r *PodReconciler) GetSubnetPathForPod(ctx context.Context, pod *v1.Pod) (b
 	if err != nil {
 		return false, "", err
 	}
+	if nsxSubnetPort := r.acquirePoolPort(pod, subnetSet); nsxSubnetPort != nil {
+		log.Info("got NSX subnet port from the SubnetPort pool for pod", "nsxSubnetPort.Id", *nsxSubnetPort.Id, "subnetPath", *nsxSubnetPort.ParentPath, "pod.Name", pod.Name, "pod.UID", pod.UID)
+		return true, *nsxSubnetPort.ParentPath, nil
+	}
 	log.Info("got default subnetset for pod, allocating the NSX subnet", "subnetSet.Name", subnetSet.Name, "subnetSet.UID", subnetSet.UID, "pod.Name", pod.Name, "pod.UID", pod.UID)
-	subnetPath, err = common.AllocateSubnetFromSubnetSet(subnetSet, r.VPCService, r.SubnetService, r.SubnetPortService)
+	subnetPath, err = common.AllocateSubnetFromSubnetSet(r.Client, subnetSet, r.VPCService, r.SubnetService, r.SubnetPortService)
 	if err != nil {
 		return false, subnetPath, err
 	}
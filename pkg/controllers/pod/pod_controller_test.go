@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -52,7 +53,10 @@ func TestPodReconciler_Reconcile(t *testing.T) {
 					},
 				},
 			},
-			SubnetPortStore: &subnetport.SubnetPortStore{},
+			SubnetPortStore: &subnetport.SubnetPortStore{ResourceStore: servicecommon.ResourceStore{
+				Indexer:     cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{servicecommon.TagScopePodUID: func(obj interface{}) ([]string, error) { return nil, nil }}),
+				BindingType: model.VpcSubnetPortBindingType(),
+			}},
 		},
 		SubnetService: &subnet.SubnetService{
 			SubnetStore: &subnet.SubnetStore{},
@@ -290,6 +294,10 @@ func TestPodReconciler_CollectGarbage(t *testing.T) {
 				},
 			},
 		},
+		SubnetPortStore: &subnetport.SubnetPortStore{ResourceStore: servicecommon.ResourceStore{
+			Indexer:     cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{servicecommon.TagScopePodUID: func(obj interface{}) ([]string, error) { return nil, nil }}),
+			BindingType: model.VpcSubnetPortBindingType(),
+		}},
 	}
 	r := &PodReconciler{
 		Client:            k8sClient,
@@ -377,9 +385,17 @@ func TestSubnetPortReconciler_GetSubnetPathForPod(t *testing.T) {
 	defer mockCtl.Finish()
 	subnetPath := "subnet-path-1"
 	r := &PodReconciler{
-		Client:            k8sClient,
-		SubnetPortService: &subnetport.SubnetPortService{},
-		SubnetService:     &subnet.SubnetService{},
+		Client: k8sClient,
+		SubnetPortService: &subnetport.SubnetPortService{
+			Service: servicecommon.Service{
+				NSXConfig: &config.NSXOperatorConfig{NsxConfig: &config.NsxConfig{}},
+			},
+			SubnetPortStore: &subnetport.SubnetPortStore{ResourceStore: servicecommon.ResourceStore{
+				Indexer:     cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{servicecommon.TagScopePodUID: func(obj interface{}) ([]string, error) { return nil, nil }}),
+				BindingType: model.VpcSubnetPortBindingType(),
+			}},
+		},
+		SubnetService: &subnet.SubnetService{},
 	}
 
 	tests := []struct {
@@ -433,7 +449,7 @@ func TestSubnetPortReconciler_GetSubnetPathForPod(t *testing.T) {
 						}, nil
 					})
 				patches.ApplyFunc(common.AllocateSubnetFromSubnetSet,
-					func(subnetSet *v1alpha1.SubnetSet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider, subnetPortService servicecommon.SubnetPortServiceProvider) (string, error) {
+					func(_ client.Client, subnetSet *v1alpha1.SubnetSet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider, subnetPortService servicecommon.SubnetPortServiceProvider) (string, error) {
 						return "", errors.New("failed to create subnet")
 					})
 				return patches
@@ -457,7 +473,7 @@ func TestSubnetPortReconciler_GetSubnetPathForPod(t *testing.T) {
 						}, nil
 					})
 				patches.ApplyFunc(common.AllocateSubnetFromSubnetSet,
-					func(subnetSet *v1alpha1.SubnetSet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider, subnetPortService servicecommon.SubnetPortServiceProvider) (string, error) {
+					func(_ client.Client, subnetSet *v1alpha1.SubnetSet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider, subnetPortService servicecommon.SubnetPortServiceProvider) (string, error) {
 						return subnetPath, nil
 					})
 				return patches
@@ -0,0 +1,237 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetport"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+// primaryInterfaceName is the name of the network interface realized by the NSX SubnetPort on the
+// default Pod SubnetSet.
+const primaryInterfaceName = "eth0"
+
+// maxInterfaceNameLength is the max length of the network interface name in Linux.
+const maxInterfaceNameLength = 15
+
+// podNetwork is an additional network requested in the Pod annotation nsx.vmware.com/networks, e.g.
+// [{"subnet": "subnet-1", "interface": "net1", "ipAddress": "10.0.0.10"}, {"subnetSet": "subnetset-1"}]
+type podNetwork struct {
+	// Name of the Subnet in the Namespace of the Pod.
+	Subnet string `json:"subnet,omitempty"`
+	// Name of the SubnetSet in the Namespace of the Pod.
+	SubnetSet string `json:"subnetSet,omitempty"`
+	// Name of the network interface in the Pod, net<index> is used if it is not set.
+	Interface string `json:"interface,omitempty"`
+	// Static IPv4 address of the network interface, only supported on a Subnet.
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+func (n *podNetwork) name() string {
+	if n.Subnet != "" {
+		return n.Subnet
+	}
+	return n.SubnetSet
+}
+
+// networkStatus is the status of a network interface of the Pod in the format of the Multus annotation
+// k8s.v1.cni.cncf.io/network-status.
+type networkStatus struct {
+	Name      string   `json:"name"`
+	Interface string   `json:"interface,omitempty"`
+	IPs       []string `json:"ips,omitempty"`
+	Mac       string   `json:"mac,omitempty"`
+	Default   bool     `json:"default"`
+}
+
+// parsePodNetworks returns the additional networks requested in the Pod annotation, the networks
+// without the interface name are named net1, net2, ... by their indexes as Multus does.
+func parsePodNetworks(pod *v1.Pod) ([]podNetwork, error) {
+	value := pod.Annotations[servicecommon.AnnotationPodNetworks]
+	if value == "" {
+		return nil, nil
+	}
+	var networks []podNetwork
+	if err := json.Unmarshal([]byte(value), &networks); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", servicecommon.AnnotationPodNetworks, err)
+	}
+	interfaceNames := sets.New[string](primaryInterfaceName)
+	for i := range networks {
+		network := &networks[i]
+		if (network.Subnet == "") == (network.SubnetSet == "") {
+			return nil, fmt.Errorf("exactly one of subnet and subnetSet must be set for network %d", i)
+		}
+		if network.IPAddress != "" {
+			if network.Subnet == "" {
+				return nil, fmt.Errorf("ipAddress can only be requested on a Subnet for network %d", i)
+			}
+			if ip := net.ParseIP(network.IPAddress); ip == nil || ip.To4() == nil {
+				return nil, fmt.Errorf("ipAddress %s must be an IPv4 address for network %d", network.IPAddress, i)
+			}
+		}
+		if network.Interface == "" {
+			network.Interface = fmt.Sprintf("net%d", i+1)
+		}
+		if len(network.Interface) > maxInterfaceNameLength {
+			return nil, fmt.Errorf("interface name %s is longer than %d characters", network.Interface, maxInterfaceNameLength)
+		}
+		if interfaceNames.Has(network.Interface) {
+			return nil, fmt.Errorf("interface name %s is duplicated", network.Interface)
+		}
+		interfaceNames.Insert(network.Interface)
+	}
+	return networks, nil
+}
+
+// reconcilePodInterfaces creates or updates the NSX SubnetPorts for the additional networks of the Pod,
+// deletes the NSX SubnetPorts of the networks removed from the Pod annotation, and records the realized
+// network interfaces in the network-status annotation of the Pod.
+func (r *PodReconciler) reconcilePodInterfaces(ctx context.Context, pod *v1.Pod, networks []podNetwork, contextID string) error {
+	existingPorts := r.SubnetPortService.ListPodInterfacePorts(string(pod.UID))
+	statuses := make([]networkStatus, 0, len(networks))
+	for i := range networks {
+		network := &networks[i]
+		isExisting, nsxSubnetPath, err := r.getSubnetPathForPodNetwork(ctx, pod, network, existingPorts[network.Interface])
+		if err != nil {
+			log.Error(err, "failed to get NSX subnet for pod network", "pod.Name", pod.Name, "pod.UID", pod.UID, "interface", network.Interface)
+			return err
+		}
+		delete(existingPorts, network.Interface)
+		if !isExisting {
+			defer r.SubnetPortService.ReleasePortInSubnet(nsxSubnetPath)
+		}
+		nsxSubnet, err := r.SubnetService.GetSubnetByPath(nsxSubnetPath)
+		if err != nil {
+			return err
+		}
+		podInterface := &subnetport.PodInterface{Pod: pod, Name: network.Interface, IPAddress: network.IPAddress}
+		nsxSubnetPortState, err := r.SubnetPortService.CreateOrUpdateSubnetPort(podInterface, nsxSubnet, contextID, &pod.ObjectMeta.Labels)
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, buildNetworkStatus(pod.Namespace, network, nsxSubnetPortState))
+	}
+	for interfaceName, nsxSubnetPort := range existingPorts {
+		log.Info("deleting the NSX subnet port of the removed pod network", "pod.Name", pod.Name, "pod.UID", pod.UID, "interface", interfaceName)
+		if err := r.SubnetPortService.DeleteSubnetPort(nsxSubnetPort); err != nil {
+			return err
+		}
+	}
+	return r.updateNetworkStatus(ctx, pod, statuses)
+}
+
+// getSubnetPathForPodNetwork returns the path of the NSX Subnet for the additional network of the Pod.
+// The existing NSX SubnetPort is deleted if the network or the static IP address of the interface is
+// changed in the Pod annotation, so that it is created again on the new network.
+func (r *PodReconciler) getSubnetPathForPodNetwork(ctx context.Context, pod *v1.Pod, network *podNetwork, existingPort *model.VpcSubnetPort) (bool, string, error) {
+	if existingPort != nil && existingPort.ParentPath != nil {
+		nsxSubnet, err := r.SubnetService.GetSubnetByPath(*existingPort.ParentPath)
+		if err == nil && isSubnetOfPodNetwork(nsxSubnet, network) && subnetport.GetStaticIPAddress(existingPort) == network.IPAddress {
+			return true, *existingPort.ParentPath, nil
+		}
+		log.Info("pod network is changed, deleting the stale subnet port", "pod.UID", pod.UID, "interface", network.Interface, "subnetPath", *existingPort.ParentPath)
+		if err := r.SubnetPortService.DeleteSubnetPort(existingPort); err != nil {
+			return false, "", err
+		}
+	}
+	namespacedName := types.NamespacedName{Namespace: pod.Namespace, Name: network.name()}
+	if network.Subnet != "" {
+		subnet := &v1alpha1.Subnet{}
+		if err := r.Client.Get(ctx, namespacedName, subnet); err != nil {
+			return false, "", fmt.Errorf("failed to get Subnet %s: %w", namespacedName, err)
+		}
+		subnetList := r.SubnetService.GetSubnetsByIndex(servicecommon.TagScopeSubnetCRUID, string(subnet.UID))
+		if len(subnetList) != 1 {
+			return false, "", fmt.Errorf("expected one NSX subnet for Subnet %s, found %d", namespacedName, len(subnetList))
+		}
+		if !r.SubnetPortService.AllocatePortFromSubnet(subnetList[0]) {
+			return false, "", fmt.Errorf("no valid IP in Subnet %s", *subnetList[0].Path)
+		}
+		return false, *subnetList[0].Path, nil
+	}
+	subnetSet := &v1alpha1.SubnetSet{}
+	if err := r.Client.Get(ctx, namespacedName, subnetSet); err != nil {
+		return false, "", fmt.Errorf("failed to get SubnetSet %s: %w", namespacedName, err)
+	}
+	subnetPath, err := common.AllocateSubnetFromSubnetSet(r.Client, subnetSet, r.VPCService, r.SubnetService, r.SubnetPortService)
+	if err != nil {
+		return false, "", err
+	}
+	return false, subnetPath, nil
+}
+
+func isSubnetOfPodNetwork(nsxSubnet *model.VpcSubnet, network *podNetwork) bool {
+	if network.Subnet != "" {
+		return nsxutil.FindTag(nsxSubnet.Tags, servicecommon.TagScopeSubnetCRName) == network.Subnet
+	}
+	return nsxutil.FindTag(nsxSubnet.Tags, servicecommon.TagScopeSubnetSetCRName) == network.SubnetSet
+}
+
+func buildNetworkStatus(namespace string, network *podNetwork, nsxSubnetPortState *model.SegmentPortState) networkStatus {
+	status := networkStatus{
+		Name:      namespace + "/" + network.name(),
+		Interface: network.Interface,
+	}
+	if nsxSubnetPortState == nil {
+		return status
+	}
+	for _, realizedBinding := range nsxSubnetPortState.RealizedBindings {
+		if realizedBinding.Binding == nil {
+			continue
+		}
+		if realizedBinding.Binding.IpAddress != nil {
+			status.IPs = append(status.IPs, *realizedBinding.Binding.IpAddress)
+		}
+		if status.Mac == "" && realizedBinding.Binding.MacAddress != nil {
+			status.Mac = strings.Trim(*realizedBinding.Binding.MacAddress, "\"")
+		}
+	}
+	return status
+}
+
+// updateNetworkStatus records the realized additional network interfaces in the network-status annotation
+// of the Pod, the annotation is removed if the Pod has no additional network.
+func (r *PodReconciler) updateNetworkStatus(ctx context.Context, pod *v1.Pod, statuses []networkStatus) error {
+	value := ""
+	if len(statuses) > 0 {
+		data, err := json.Marshal(statuses)
+		if err != nil {
+			return err
+		}
+		value = string(data)
+	}
+	if existing, ok := pod.Annotations[servicecommon.AnnotationPodNetworkStatus]; existing == value && (ok || value == "") {
+		return nil
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	if value == "" {
+		delete(pod.Annotations, servicecommon.AnnotationPodNetworkStatus)
+	} else {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[servicecommon.AnnotationPodNetworkStatus] = value
+	}
+	if err := r.Client.Patch(ctx, pod, patch); err != nil {
+		log.Error(err, "failed to update the network status of pod", "pod.Name", pod.Name, "pod.UID", pod.UID)
+		return err
+	}
+	log.Info("updated the network status of pod", "pod.Name", pod.Name, "pod.UID", pod.UID, "networkStatus", value)
+	return nil
+}
@@ -0,0 +1,186 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	gomonkey "github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetport"
+)
+
+func TestParsePodNetworks(t *testing.T) {
+	tests := []struct {
+		name             string
+		annotation       string
+		expectedNetworks []podNetwork
+		expectedErr      string
+	}{
+		{
+			name: "No additional network",
+		},
+		{
+			name:       "Additional networks",
+			annotation: `[{"subnet": "subnet-1", "ipAddress": "10.0.0.10"}, {"subnetSet": "subnetset-1", "interface": "data0"}, {"subnetSet": "subnetset-2"}]`,
+			expectedNetworks: []podNetwork{
+				{Subnet: "subnet-1", Interface: "net1", IPAddress: "10.0.0.10"},
+				{SubnetSet: "subnetset-1", Interface: "data0"},
+				{SubnetSet: "subnetset-2", Interface: "net3"},
+			},
+		},
+		{
+			name:        "Invalid JSON",
+			annotation:  `{"subnet": "subnet-1"}`,
+			expectedErr: "invalid annotation nsx.vmware.com/networks",
+		},
+		{
+			name:        "Both Subnet and SubnetSet",
+			annotation:  `[{"subnet": "subnet-1", "subnetSet": "subnetset-1"}]`,
+			expectedErr: "exactly one of subnet and subnetSet must be set for network 0",
+		},
+		{
+			name:        "Static IP on SubnetSet",
+			annotation:  `[{"subnetSet": "subnetset-1", "ipAddress": "10.0.0.10"}]`,
+			expectedErr: "ipAddress can only be requested on a Subnet for network 0",
+		},
+		{
+			name:        "Invalid static IP",
+			annotation:  `[{"subnet": "subnet-1", "ipAddress": "fd00::10"}]`,
+			expectedErr: "ipAddress fd00::10 must be an IPv4 address for network 0",
+		},
+		{
+			name:        "Duplicated interface name",
+			annotation:  `[{"subnet": "subnet-1", "interface": "net2"}, {"subnet": "subnet-2"}]`,
+			expectedErr: "interface name net2 is duplicated",
+		},
+		{
+			name:        "Primary interface name",
+			annotation:  `[{"subnet": "subnet-1", "interface": "eth0"}]`,
+			expectedErr: "interface name eth0 is duplicated",
+		},
+		{
+			name:        "Long interface name",
+			annotation:  `[{"subnet": "subnet-1", "interface": "interface-name-1"}]`,
+			expectedErr: "interface name interface-name-1 is longer than 15 characters",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{servicecommon.AnnotationPodNetworks: tt.annotation}}}
+			networks, err := parsePodNetworks(pod)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.expectedNetworks, networks)
+		})
+	}
+}
+
+func TestBuildNetworkStatus(t *testing.T) {
+	network := &podNetwork{Subnet: "subnet-1", Interface: "net1"}
+	assert.Equal(t, networkStatus{Name: "ns-1/subnet-1", Interface: "net1"}, buildNetworkStatus("ns-1", network, nil))
+	status := buildNetworkStatus("ns-1", network, &model.SegmentPortState{
+		RealizedBindings: []model.AddressBindingEntry{
+			{Binding: &model.PacketAddressClassifier{IpAddress: servicecommon.String("10.0.0.10"), MacAddress: servicecommon.String("\"04:50:56:00:00:01\"")}},
+		},
+	})
+	assert.Equal(t, networkStatus{Name: "ns-1/subnet-1", Interface: "net1", IPs: []string{"10.0.0.10"}, Mac: "04:50:56:00:00:01"}, status)
+}
+
+func TestPodReconciler_reconcilePodInterfaces(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(newScheme))
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "ns-1", UID: "pod-uid"}}
+	subnetCR := &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet-1", Namespace: "ns-1", UID: "subnet-uid"}}
+	nsxSubnet := &model.VpcSubnet{
+		Id:   servicecommon.String("subnet-1"),
+		Path: servicecommon.String("/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1"),
+		Tags: []model.Tag{{Scope: servicecommon.String(servicecommon.TagScopeSubnetCRName), Tag: servicecommon.String("subnet-1")}},
+	}
+	stalePort := &model.VpcSubnetPort{
+		Id:         servicecommon.String("pod-1_pod-uid_net2"),
+		Path:       servicecommon.String(*nsxSubnet.Path + "/ports/pod-1_pod-uid_net2"),
+		ParentPath: nsxSubnet.Path,
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(pod.DeepCopy(), subnetCR).Build()
+	r := &PodReconciler{
+		Client:            k8sClient,
+		SubnetPortService: &subnetport.SubnetPortService{},
+		SubnetService:     &subnet.SubnetService{},
+	}
+
+	var createdInterfaces, deletedPorts []string
+	patches := gomonkey.ApplyMethod(r.SubnetPortService, "ListPodInterfacePorts", func(_ *subnetport.SubnetPortService, _ string) map[string]*model.VpcSubnetPort {
+		return map[string]*model.VpcSubnetPort{"net2": stalePort}
+	})
+	defer patches.Reset()
+	patches.ApplyMethod(r.SubnetService, "GetSubnetsByIndex", func(_ *subnet.SubnetService, key, value string) []*model.VpcSubnet {
+		assert.Equal(t, "subnet-uid", value)
+		return []*model.VpcSubnet{nsxSubnet}
+	})
+	patches.ApplyMethod(r.SubnetService, "GetSubnetByPath", func(_ *subnet.SubnetService, path string) (*model.VpcSubnet, error) {
+		return nsxSubnet, nil
+	})
+	patches.ApplyMethod(r.SubnetPortService, "AllocatePortFromSubnet", func(_ *subnetport.SubnetPortService, _ *model.VpcSubnet) bool {
+		return true
+	})
+	patches.ApplyMethod(r.SubnetPortService, "ReleasePortInSubnet", func(_ *subnetport.SubnetPortService, _ string) {})
+	patches.ApplyMethod(r.SubnetPortService, "CreateOrUpdateSubnetPort", func(_ *subnetport.SubnetPortService, obj interface{}, _ *model.VpcSubnet, _ string, _ *map[string]string) (*model.SegmentPortState, error) {
+		podInterface := obj.(*subnetport.PodInterface)
+		createdInterfaces = append(createdInterfaces, podInterface.Name)
+		return &model.SegmentPortState{RealizedBindings: []model.AddressBindingEntry{
+			{Binding: &model.PacketAddressClassifier{IpAddress: servicecommon.String(podInterface.IPAddress), MacAddress: servicecommon.String("04:50:56:00:00:01")}},
+		}}, nil
+	})
+	patches.ApplyMethod(r.SubnetPortService, "DeleteSubnetPort", func(_ *subnetport.SubnetPortService, nsxSubnetPort *model.VpcSubnetPort) error {
+		deletedPorts = append(deletedPorts, *nsxSubnetPort.Id)
+		return nil
+	})
+
+	// The NSX subnet port is created for the new network, and deleted for the removed network.
+	err := r.reconcilePodInterfaces(context.TODO(), pod.DeepCopy(), []podNetwork{{Subnet: "subnet-1", Interface: "net1", IPAddress: "10.0.0.10"}}, "node-1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"net1"}, createdInterfaces)
+	assert.Equal(t, []string{"pod-1_pod-uid_net2"}, deletedPorts)
+	updatedPod := &v1.Pod{}
+	assert.Nil(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "ns-1", Name: "pod-1"}, updatedPod))
+	var statuses []networkStatus
+	assert.Nil(t, json.Unmarshal([]byte(updatedPod.Annotations[servicecommon.AnnotationPodNetworkStatus]), &statuses))
+	assert.Equal(t, []networkStatus{{Name: "ns-1/subnet-1", Interface: "net1", IPs: []string{"10.0.0.10"}, Mac: "04:50:56:00:00:01"}}, statuses)
+
+	// The existing NSX subnet port is deleted and created again if the static IP is changed.
+	createdInterfaces, deletedPorts = nil, nil
+	err = r.reconcilePodInterfaces(context.TODO(), updatedPod, []podNetwork{{Subnet: "subnet-1", Interface: "net2", IPAddress: "10.0.0.11"}}, "node-1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"net2"}, createdInterfaces)
+	assert.Equal(t, []string{"pod-1_pod-uid_net2"}, deletedPorts)
+
+	// The network status is removed if the Pod has no additional network.
+	patches.ApplyMethod(r.SubnetPortService, "ListPodInterfacePorts", func(_ *subnetport.SubnetPortService, _ string) map[string]*model.VpcSubnetPort {
+		return nil
+	})
+	assert.Nil(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "ns-1", Name: "pod-1"}, updatedPod))
+	assert.Nil(t, r.reconcilePodInterfaces(context.TODO(), updatedPod, nil, "node-1"))
+	assert.Nil(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "ns-1", Name: "pod-1"}, updatedPod))
+	_, ok := updatedPod.Annotations[servicecommon.AnnotationPodNetworkStatus]
+	assert.False(t, ok)
+}
@@ -0,0 +1,193 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package pod
+
+import (
+	"context"
+	"time"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetport"
+)
+
+// subnetPortPoolRefillInterval is the interval to refill the SubnetPort pools if no Pod takes a
+// NSX SubnetPort from them.
+const subnetPortPoolRefillInterval = 60 * time.Second
+
+// subnetPortPoolDemand is a SubnetPort pool requested by a Pod scheduled on the node, the pool is kept
+// filled until the node or the SubnetSet is deleted.
+type subnetPortPoolDemand struct {
+	SubnetSetUID types.UID
+	SubnetSet    types.NamespacedName
+	NodeName     string
+}
+
+func (d subnetPortPoolDemand) key() string {
+	return string(d.SubnetSetUID) + "/" + d.NodeName
+}
+
+func (r *PodReconciler) subnetPortPoolSize() int {
+	return r.SubnetPortService.NSXConfig.SubnetPortPoolSize
+}
+
+// acquirePoolPort takes a NSX SubnetPort for the Pod from the SubnetPort pool of the SubnetSet on the node
+// of the Pod, nil is returned if the pool is disabled or empty. The pool is refilled in the background.
+func (r *PodReconciler) acquirePoolPort(pod *v1.Pod, subnetSet *v1alpha1.SubnetSet) *model.VpcSubnetPort {
+	if r.subnetPortPoolSize() <= 0 {
+		return nil
+	}
+	demand := subnetPortPoolDemand{
+		SubnetSetUID: subnetSet.UID,
+		SubnetSet:    types.NamespacedName{Namespace: subnetSet.Namespace, Name: subnetSet.Name},
+		NodeName:     pod.Spec.NodeName,
+	}
+	r.subnetPortPoolDemands.Store(demand.key(), demand)
+	defer r.triggerSubnetPortPoolRefill()
+	nsxSubnetPort := r.SubnetPortService.AcquirePoolPort(pod, string(subnetSet.UID), pod.Spec.NodeName)
+	if nsxSubnetPort == nil {
+		metrics.SubnetPortPoolMissTotal.WithLabelValues(subnetSet.Namespace, subnetSet.Name).Inc()
+		return nil
+	}
+	metrics.SubnetPortPoolHitTotal.WithLabelValues(subnetSet.Namespace, subnetSet.Name).Inc()
+	return nsxSubnetPort
+}
+
+// triggerSubnetPortPoolRefill wakes up the refill of the SubnetPort pools without blocking, a refill
+// which is already pending covers the new demand.
+func (r *PodReconciler) triggerSubnetPortPoolRefill() {
+	select {
+	case r.subnetPortPoolRefill <- struct{}{}:
+	default:
+	}
+}
+
+// runSubnetPortPoolRefill refills the SubnetPort pools periodically and whenever a Pod takes a NSX
+// SubnetPort from a pool or finds it empty.
+func (r *PodReconciler) runSubnetPortPoolRefill(cancel chan bool) {
+	ctx := context.Background()
+	ticker := time.NewTicker(subnetPortPoolRefillInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-cancel:
+			return
+		case <-ticker.C:
+		case <-r.subnetPortPoolRefill:
+		}
+		r.refillSubnetPortPools(ctx)
+	}
+}
+
+// refillSubnetPortPools creates the NSX SubnetPorts for the SubnetPort pools requested by the Pods and
+// the existing pools until each of them has subnetport_pool_size NSX SubnetPorts.
+func (r *PodReconciler) refillSubnetPortPools(ctx context.Context) {
+	demands := make(map[string]subnetPortPoolDemand)
+	for _, pool := range r.SubnetPortService.ListSubnetPortPools() {
+		demand := subnetPortPoolDemand{
+			SubnetSetUID: types.UID(pool.SubnetSetUID),
+			SubnetSet:    types.NamespacedName{Namespace: pool.SubnetSetNamespace, Name: pool.SubnetSetName},
+			NodeName:     pool.NodeName,
+		}
+		demands[demand.key()] = demand
+	}
+	r.subnetPortPoolDemands.Range(func(key, value any) bool {
+		demands[key.(string)] = value.(subnetPortPoolDemand)
+		return true
+	})
+	for key, demand := range demands {
+		if err := r.refillSubnetPortPool(ctx, demand); err != nil {
+			log.Error(err, "failed to refill SubnetPort pool", "subnetSet", demand.SubnetSet, "node", demand.NodeName)
+		} else {
+			log.V(1).Info("refilled SubnetPort pool", "pool", key)
+		}
+	}
+}
+
+func (r *PodReconciler) refillSubnetPortPool(ctx context.Context, demand subnetPortPoolDemand) error {
+	subnetSet := &v1alpha1.SubnetSet{}
+	if err := r.Client.Get(ctx, demand.SubnetSet, subnetSet); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The NSX SubnetPorts in the pool are deleted by the garbage collector.
+			r.subnetPortPoolDemands.Delete(demand.key())
+			return nil
+		}
+		return err
+	}
+	if subnetSet.UID != demand.SubnetSetUID || !subnetSet.DeletionTimestamp.IsZero() {
+		r.subnetPortPoolDemands.Delete(demand.key())
+		return nil
+	}
+	node, err := r.GetNodeByName(demand.NodeName)
+	if err != nil {
+		if getErr := r.Client.Get(ctx, types.NamespacedName{Name: demand.NodeName}, &v1.Node{}); apierrors.IsNotFound(getErr) {
+			// The NSX SubnetPorts in the pool are deleted by the garbage collector.
+			r.subnetPortPoolDemands.Delete(demand.key())
+			return nil
+		}
+		return err
+	}
+	for count := len(r.SubnetPortService.ListPoolPorts(string(subnetSet.UID), demand.NodeName)); count < r.subnetPortPoolSize(); count++ {
+		if err := r.createPoolPort(subnetSet, demand.NodeName, *node.UniqueId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PodReconciler) createPoolPort(subnetSet *v1alpha1.SubnetSet, nodeName, contextID string) error {
+	nsxSubnetPath, err := common.AllocateSubnetFromSubnetSet(r.Client, subnetSet, r.VPCService, r.SubnetService, r.SubnetPortService)
+	if err != nil {
+		return err
+	}
+	defer r.SubnetPortService.ReleasePortInSubnet(nsxSubnetPath)
+	nsxSubnet, err := r.SubnetService.GetSubnetByPath(nsxSubnetPath)
+	if err != nil {
+		return err
+	}
+	return r.SubnetPortService.CreatePoolPort(subnetSet, nodeName, nsxSubnet, contextID)
+}
+
+// collectSubnetPortPoolGarbage deletes the NSX SubnetPorts in the SubnetPort pools of the deleted nodes and
+// SubnetSets, and the ones exceeding the pool size. All of them are deleted if the pool is disabled.
+func (r *PodReconciler) collectSubnetPortPoolGarbage(ctx context.Context) {
+	for _, pool := range r.SubnetPortService.ListSubnetPortPools() {
+		size := r.subnetPortPoolSize()
+		if size > 0 && !r.isSubnetPortPoolValid(ctx, &pool) {
+			size = 0
+		}
+		if size >= len(pool.Ports) {
+			continue
+		}
+		for _, nsxSubnetPort := range pool.Ports[size:] {
+			log.V(1).Info("GC collected pool subnet port", "NSXSubnetPortID", *nsxSubnetPort.Id, "node", pool.NodeName)
+			r.StatusUpdater.IncreaseDeleteTotal()
+			if err := r.SubnetPortService.DeletePoolPort(nsxSubnetPort); err != nil {
+				r.StatusUpdater.IncreaseDeleteFailTotal()
+			} else {
+				r.StatusUpdater.IncreaseDeleteSuccessTotal()
+			}
+		}
+	}
+}
+
+// isSubnetPortPoolValid returns false if the node or the SubnetSet of the SubnetPort pool is deleted. The pool
+// is treated as valid if it fails to get them, so that the NSX SubnetPorts are not deleted by mistake.
+func (r *PodReconciler) isSubnetPortPoolValid(ctx context.Context, pool *subnetport.SubnetPortPool) bool {
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: pool.NodeName}, &v1.Node{}); apierrors.IsNotFound(err) {
+		return false
+	}
+	subnetSet := &v1alpha1.SubnetSet{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: pool.SubnetSetNamespace, Name: pool.SubnetSetName}, subnetSet)
+	if apierrors.IsNotFound(err) {
+		return false
+	}
+	return err != nil || string(subnetSet.UID) == pool.SubnetSetUID
+}
@@ -0,0 +1,145 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gomonkey "github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetport"
+)
+
+func TestPodReconciler_SubnetPortPool(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(newScheme))
+	subnetSet := &v1alpha1.SubnetSet{ObjectMeta: metav1.ObjectMeta{Name: "pod-default", Namespace: "ns-1", UID: "subnetset-uid"}}
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "ns-1", UID: "pod-uid"}, Spec: v1.PodSpec{NodeName: "node-1"}}
+	k8sClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(subnetSet, node).Build()
+	r := &PodReconciler{
+		Client: k8sClient,
+		SubnetPortService: &subnetport.SubnetPortService{
+			Service: servicecommon.Service{
+				NSXConfig: &config.NSXOperatorConfig{NsxConfig: &config.NsxConfig{SubnetPortPoolSize: 2}},
+			},
+		},
+		SubnetService:        &subnet.SubnetService{},
+		subnetPortPoolRefill: make(chan struct{}, 1),
+	}
+	r.StatusUpdater = common.NewStatusUpdater(k8sClient, r.SubnetPortService.NSXConfig, r.Recorder, MetricResTypePod, "SubnetPort", "Pod")
+
+	poolPorts := map[string][]*model.VpcSubnetPort{}
+	patches := gomonkey.ApplyMethod(r.SubnetPortService, "AcquirePoolPort", func(_ *subnetport.SubnetPortService, _ *v1.Pod, subnetSetUID, nodeName string) *model.VpcSubnetPort {
+		key := subnetSetUID + "/" + nodeName
+		if len(poolPorts[key]) == 0 {
+			return nil
+		}
+		nsxSubnetPort := poolPorts[key][0]
+		poolPorts[key] = poolPorts[key][1:]
+		return nsxSubnetPort
+	})
+	defer patches.Reset()
+	patches.ApplyMethod(r.SubnetPortService, "ListPoolPorts", func(_ *subnetport.SubnetPortService, subnetSetUID, nodeName string) []*model.VpcSubnetPort {
+		return poolPorts[subnetSetUID+"/"+nodeName]
+	})
+	patches.ApplyMethod(r.SubnetPortService, "ListSubnetPortPools", func(_ *subnetport.SubnetPortService) []subnetport.SubnetPortPool {
+		var pools []subnetport.SubnetPortPool
+		for _, nodeName := range []string{"node-1", "node-2"} {
+			if ports := poolPorts["subnetset-uid/"+nodeName]; len(ports) > 0 {
+				pools = append(pools, subnetport.SubnetPortPool{SubnetSetUID: "subnetset-uid", SubnetSetNamespace: "ns-1", SubnetSetName: "pod-default", NodeName: nodeName, Ports: ports})
+			}
+		}
+		return pools
+	})
+	patches.ApplyMethod(r, "GetNodeByName", func(_ *PodReconciler, nodeName string) (*model.HostTransportNode, error) {
+		if nodeName != "node-1" {
+			return nil, fmt.Errorf("node %s not found", nodeName)
+		}
+		return &model.HostTransportNode{UniqueId: servicecommon.String(nodeName + "-uid")}, nil
+	})
+	patches.ApplyFunc(common.AllocateSubnetFromSubnetSet, func(_ client.Client, _ *v1alpha1.SubnetSet, _ servicecommon.VPCServiceProvider, _ servicecommon.SubnetServiceProvider, _ servicecommon.SubnetPortServiceProvider) (string, error) {
+		return "subnet-path", nil
+	})
+	patches.ApplyMethod(r.SubnetPortService, "ReleasePortInSubnet", func(_ *subnetport.SubnetPortService, _ string) {})
+	patches.ApplyMethod(r.SubnetService, "GetSubnetByPath", func(_ *subnet.SubnetService, path string) (*model.VpcSubnet, error) {
+		return &model.VpcSubnet{Path: servicecommon.String(path)}, nil
+	})
+	var createdPorts int
+	patches.ApplyMethod(r.SubnetPortService, "CreatePoolPort", func(_ *subnetport.SubnetPortService, subnetSet *v1alpha1.SubnetSet, nodeName string, nsxSubnet *model.VpcSubnet, contextID string) error {
+		assert.Equal(t, nodeName+"-uid", contextID)
+		createdPorts++
+		key := string(subnetSet.UID) + "/" + nodeName
+		poolPorts[key] = append(poolPorts[key], &model.VpcSubnetPort{Id: servicecommon.String("pool-port"), ParentPath: nsxSubnet.Path})
+		return nil
+	})
+	var deletedPorts int
+	patches.ApplyMethod(r.SubnetPortService, "DeletePoolPort", func(_ *subnetport.SubnetPortService, _ *model.VpcSubnetPort) error {
+		deletedPorts++
+		return nil
+	})
+
+	// The pool is empty for the first Pod, which requests the pool to be filled.
+	assert.Nil(t, r.acquirePoolPort(pod, subnetSet))
+	assert.Equal(t, 1, len(r.subnetPortPoolRefill))
+	<-r.subnetPortPoolRefill
+	r.refillSubnetPortPools(context.TODO())
+	assert.Equal(t, 2, createdPorts)
+	assert.Equal(t, 2, len(poolPorts["subnetset-uid/node-1"]))
+
+	// The next Pod takes a NSX SubnetPort from the pool, which is refilled.
+	nsxSubnetPort := r.acquirePoolPort(pod, subnetSet)
+	assert.NotNil(t, nsxSubnetPort)
+	r.refillSubnetPortPools(context.TODO())
+	assert.Equal(t, 3, createdPorts)
+
+	// The NSX SubnetPorts exceeding the pool size and the pools of the deleted nodes are collected.
+	poolPorts["subnetset-uid/node-1"] = append(poolPorts["subnetset-uid/node-1"], &model.VpcSubnetPort{Id: servicecommon.String("extra-port")})
+	poolPorts["subnetset-uid/node-2"] = []*model.VpcSubnetPort{{Id: servicecommon.String("stale-port")}}
+	r.collectSubnetPortPoolGarbage(context.TODO())
+	assert.Equal(t, 2, deletedPorts)
+
+	// All the NSX SubnetPorts in the pools are collected if the pool is disabled.
+	deletedPorts = 0
+	delete(poolPorts, "subnetset-uid/node-2")
+	r.SubnetPortService.NSXConfig.SubnetPortPoolSize = 0
+	assert.Nil(t, r.acquirePoolPort(pod, subnetSet))
+	r.collectSubnetPortPoolGarbage(context.TODO())
+	assert.Equal(t, 3, deletedPorts)
+
+	// The demand is dropped if the node is deleted.
+	r.SubnetPortService.NSXConfig.SubnetPortPoolSize = 2
+	deletedNodeDemand := subnetPortPoolDemand{SubnetSetUID: "subnetset-uid", SubnetSet: types.NamespacedName{Namespace: "ns-1", Name: "pod-default"}, NodeName: "node-2"}
+	r.subnetPortPoolDemands.Store(deletedNodeDemand.key(), deletedNodeDemand)
+	r.refillSubnetPortPools(context.TODO())
+	_, ok := r.subnetPortPoolDemands.Load("subnetset-uid/node-2")
+	assert.False(t, ok)
+	_, ok = r.subnetPortPoolDemands.Load("subnetset-uid/node-1")
+	assert.True(t, ok)
+
+	// The demand is dropped if the SubnetSet is deleted.
+	assert.Nil(t, k8sClient.Delete(context.TODO(), subnetSet))
+	poolPorts = map[string][]*model.VpcSubnetPort{}
+	r.refillSubnetPortPools(context.TODO())
+	_, ok = r.subnetPortPoolDemands.Load("subnetset-uid/node-1")
+	assert.False(t, ok)
+}
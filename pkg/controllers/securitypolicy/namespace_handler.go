@@ -14,16 +14,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
 // We should consider the below scenarios:
 // When a namespace's label is changed and if there are pods in this namespace,
 // we should reconcile the corresponding security policy.
+// When a namespace's label mirrored to the NSX tags is changed, we should reconcile
+// the security policies in this namespace to update the tags of the groups.
 
 type EnqueueRequestForNamespace struct {
 	Client                   client.Client
 	SecurityPolicyReconciler *SecurityPolicyReconciler
+	// LabelTagKeys are the keys of the namespace labels mirrored to the tags of the groups
+	LabelTagKeys []string
 }
 
 func (e *EnqueueRequestForNamespace) Create(_ context.Context, _ event.CreateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
@@ -48,6 +53,13 @@ func (e *EnqueueRequestForNamespace) Update(_ context.Context, updateEvent event
 		return
 	}
 
+	oldObj := updateEvent.ObjectOld.(*v1.Namespace)
+	if common.IsLabelTagsChanged(e.LabelTagKeys, oldObj.Labels, obj.Labels) {
+		if err := reconcileSecurityPolicyInNamespace(e.SecurityPolicyReconciler, e.Client, obj.Name, l); err != nil {
+			log.Error(err, "Failed to reconcile security policy for label tags update", "namespace", obj.Name)
+		}
+	}
+
 	podList := &v1.PodList{}
 	err := e.Client.List(context.Background(), podList, client.InNamespace(obj.Name))
 	if err != nil {
@@ -0,0 +1,122 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
+)
+
+// CollectRuleStatistics collects the NSX rule statistics of SecurityPolicies and NetworkPolicies,
+// publishes them as metrics, and summarizes them in the SecurityPolicy status.
+func (r *SecurityPolicyReconciler) CollectRuleStatistics(ctx context.Context) {
+	log.V(1).Info("SecurityPolicy rule statistics collector started")
+	ruleStatistics, err := r.Service.CollectRuleStatistics()
+	if err != nil {
+		// The statistics which are collected successfully are still published.
+		log.Error(err, "Failed to collect rule statistics of some NSX SecurityPolicies")
+	}
+	setRuleStatisticsMetrics(ruleStatistics)
+
+	summaries := map[types.NamespacedName]*v1alpha1.RuleStatisticsSummary{}
+	policyUIDs := map[types.NamespacedName]string{}
+	unusedRules := map[types.NamespacedName]sets.Set[string]{}
+	for _, rs := range ruleStatistics {
+		if rs.PolicyType != servicecommon.ResourceTypeSecurityPolicy {
+			continue
+		}
+		key := types.NamespacedName{Namespace: rs.Namespace, Name: rs.PolicyName}
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &v1alpha1.RuleStatisticsSummary{}
+			summaries[key] = summary
+			policyUIDs[key] = rs.PolicyUID
+			unusedRules[key] = sets.New[string]()
+		}
+		summary.HitCount += rs.HitCount
+		summary.PacketCount += rs.PacketCount
+		summary.ByteCount += rs.ByteCount
+		summary.SessionCount += rs.SessionCount
+		if rs.HitCount == 0 {
+			unusedRules[key].Insert(rs.RuleName)
+		}
+	}
+	for key, summary := range summaries {
+		summary.UnusedRules = sets.List(unusedRules[key])
+		if err := r.updateRuleStatisticsSummary(ctx, key, policyUIDs[key], summary); err != nil {
+			log.Error(err, "Failed to update SecurityPolicy rule statistics", "securitypolicy", key)
+		}
+	}
+}
+
+func setRuleStatisticsMetrics(ruleStatistics []securitypolicy.RuleStatistics) {
+	// Reset the metrics to remove the deleted rules.
+	metrics.RuleHitCount.Reset()
+	metrics.RulePacketCount.Reset()
+	metrics.RuleByteCount.Reset()
+	metrics.RuleSessionCount.Reset()
+	for _, rs := range ruleStatistics {
+		// The expanded NSX rules of a named port rule share the same rule name, so the statistics are added up.
+		labels := []string{rs.Namespace, rs.PolicyName, rs.PolicyType, rs.RuleName}
+		metrics.RuleHitCount.WithLabelValues(labels...).Add(float64(rs.HitCount))
+		metrics.RulePacketCount.WithLabelValues(labels...).Add(float64(rs.PacketCount))
+		metrics.RuleByteCount.WithLabelValues(labels...).Add(float64(rs.ByteCount))
+		metrics.RuleSessionCount.WithLabelValues(labels...).Add(float64(rs.SessionCount))
+	}
+}
+
+func (r *SecurityPolicyReconciler) updateRuleStatisticsSummary(ctx context.Context, key types.NamespacedName, uid string, summary *v1alpha1.RuleStatisticsSummary) error {
+	var obj client.Object
+	if securitypolicy.IsVPCEnabled(r.Service) {
+		obj = &crdv1alpha1.SecurityPolicy{}
+	} else {
+		obj = &v1alpha1.SecurityPolicy{}
+	}
+	if err := r.Client.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if string(obj.GetUID()) != uid {
+		log.V(1).Info("SecurityPolicy is recreated, skip updating rule statistics", "securitypolicy", key)
+		return nil
+	}
+
+	secPolicy, ok := obj.(*v1alpha1.SecurityPolicy)
+	if !ok {
+		secPolicy = securitypolicy.VPCToT1(obj.(*crdv1alpha1.SecurityPolicy))
+	}
+	if existing := secPolicy.Status.RuleStatistics; existing != nil && isRuleStatisticsSummaryEqual(existing, summary) {
+		return nil
+	}
+	summary.LastUpdateTime = metav1.Now()
+	secPolicy.Status.RuleStatistics = summary
+	// Conditions is required in status.
+	if secPolicy.Status.Conditions == nil {
+		secPolicy.Status.Conditions = []v1alpha1.Condition{}
+	}
+	if securitypolicy.IsVPCEnabled(r.Service) {
+		return r.Client.Status().Update(ctx, securitypolicy.T1ToVPC(secPolicy))
+	}
+	return r.Client.Status().Update(ctx, secPolicy)
+}
+
+func isRuleStatisticsSummaryEqual(a, b *v1alpha1.RuleStatisticsSummary) bool {
+	if a.HitCount != b.HitCount || a.PacketCount != b.PacketCount || a.ByteCount != b.ByteCount || a.SessionCount != b.SessionCount {
+		return false
+	}
+	return sets.New(a.UnusedRules...).Equal(sets.New(b.UnusedRules...))
+}
@@ -0,0 +1,92 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
+)
+
+func TestSecurityPolicyReconciler_CollectRuleStatistics(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(crdv1alpha1.AddToScheme(newScheme))
+	sp := &crdv1alpha1.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "sp1", UID: "sp1-uid"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(sp).WithStatusSubresource(sp).Build()
+	service := &securitypolicy.SecurityPolicyService{
+		Service: servicecommon.Service{
+			NSXConfig: &config.NSXOperatorConfig{
+				CoeConfig: &config.CoeConfig{EnableVPCNetwork: true},
+			},
+		},
+	}
+	r := &SecurityPolicyReconciler{Client: fakeClient, Service: service}
+
+	ruleStatistics := []securitypolicy.RuleStatistics{
+		{
+			Namespace: "ns1", PolicyName: "sp1", PolicyUID: "sp1-uid", PolicyType: servicecommon.ResourceTypeSecurityPolicy,
+			RuleID: "r1", RuleName: "rule1", HitCount: 3, PacketCount: 10, ByteCount: 100, SessionCount: 1,
+		},
+		{
+			Namespace: "ns1", PolicyName: "sp1", PolicyUID: "sp1-uid", PolicyType: servicecommon.ResourceTypeSecurityPolicy,
+			RuleID: "r2", RuleName: "rule2",
+		},
+		{
+			Namespace: "ns1", PolicyName: "np1", PolicyUID: "np1-uid", PolicyType: servicecommon.ResourceTypeNetworkPolicy,
+			RuleID: "r3", RuleName: "rule3", HitCount: 2,
+		},
+		// The SecurityPolicy is deleted.
+		{
+			Namespace: "ns1", PolicyName: "sp2", PolicyUID: "sp2-uid", PolicyType: servicecommon.ResourceTypeSecurityPolicy,
+			RuleID: "r4", RuleName: "rule4", HitCount: 2,
+		},
+	}
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(service), "CollectRuleStatistics", func(_ *securitypolicy.SecurityPolicyService) ([]securitypolicy.RuleStatistics, error) {
+		return ruleStatistics, nil
+	})
+	defer patches.Reset()
+
+	r.CollectRuleStatistics(context.TODO())
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(metrics.RuleHitCount.WithLabelValues("ns1", "sp1", servicecommon.ResourceTypeSecurityPolicy, "rule1")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.RuleHitCount.WithLabelValues("ns1", "np1", servicecommon.ResourceTypeNetworkPolicy, "rule3")))
+	assert.Equal(t, float64(10), testutil.ToFloat64(metrics.RulePacketCount.WithLabelValues("ns1", "sp1", servicecommon.ResourceTypeSecurityPolicy, "rule1")))
+
+	updated := &crdv1alpha1.SecurityPolicy{}
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "ns1", Name: "sp1"}, updated))
+	require.NotNil(t, updated.Status.RuleStatistics)
+	assert.Equal(t, int64(3), updated.Status.RuleStatistics.HitCount)
+	assert.Equal(t, int64(10), updated.Status.RuleStatistics.PacketCount)
+	assert.Equal(t, int64(100), updated.Status.RuleStatistics.ByteCount)
+	assert.Equal(t, int64(1), updated.Status.RuleStatistics.SessionCount)
+	assert.Equal(t, []string{"rule2"}, updated.Status.RuleStatistics.UnusedRules)
+
+	// The status is not updated if the statistics are not changed.
+	lastUpdateTime := updated.Status.RuleStatistics.LastUpdateTime
+	resourceVersion := updated.ResourceVersion
+	r.CollectRuleStatistics(context.TODO())
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "ns1", Name: "sp1"}, updated))
+	assert.Equal(t, resourceVersion, updated.ResourceVersion)
+	assert.True(t, lastUpdateTime.Equal(&updated.Status.RuleStatistics.LastUpdateTime))
+}
@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -26,12 +27,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmv1alpha1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	"github.com/vmware-tanzu/nsx-operator/pkg/metrics"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
 	_ "github.com/vmware-tanzu/nsx-operator/pkg/nsx/ratelimiter"
 	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
@@ -173,6 +179,22 @@ func (r *SecurityPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return ResultNormal, nil
 		}
 
+		// The privileged tier is checked again in T1 mode, in case the legacy SecurityPolicy is admitted
+		// without the webhook.
+		if !securitypolicy.IsVPCEnabled(r.Service) {
+			allowed, err := r.isTierAllowed(ctx, realObj)
+			if err != nil {
+				r.StatusUpdater.UpdateFail(ctx, realObj, err, "", setSecurityPolicyReadyStatusFalse, r.Service)
+				return ResultRequeue, err
+			}
+			if !allowed {
+				err = errors.New(tierNotAllowedMessage(securitypolicy.GetSecurityPolicyTier(realObj), realObj.Namespace))
+				r.StatusUpdater.UpdateFail(ctx, realObj, err, "", setSecurityPolicyReadyStatusFalse, r.Service)
+				// Retry later as the SecurityPolicyTierBindings are not watched.
+				return ResultRequeueAfter5mins, nil
+			}
+		}
+
 		log.Info("Reconciling CR to create or update securitypolicy", "securitypolicy", req.NamespacedName)
 		if err := r.Service.CreateOrUpdateSecurityPolicy(realObj); err != nil {
 			if errors.As(err, &nsxutil.RestrictionError{}) {
@@ -214,6 +236,16 @@ func (r *SecurityPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	return ResultNormal, nil
 }
 
+// isTierAllowed checks if the SecurityPolicy can use its tier, the SecurityPolicy already realized in a privileged
+// tier keeps it, the same as the webhook allows the SecurityPolicies not changing the tier.
+func (r *SecurityPolicyReconciler) isTierAllowed(ctx context.Context, obj *v1alpha1.SecurityPolicy) (bool, error) {
+	tier := securitypolicy.GetSecurityPolicyTier(obj)
+	if !securitypolicy.IsPrivilegedTier(tier) || r.Service.IsTierRealized(obj) {
+		return true, nil
+	}
+	return isTierAllowed(ctx, r.Client, obj.Namespace, tier)
+}
+
 func setSecurityPolicyReadyStatusTrue(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, args ...interface{}) {
 	if len(args) != 1 {
 		log.Error(nil, "Service is needed when setting SecurityPolicy status")
@@ -310,7 +342,19 @@ func getExistingConditionOfType(conditionType v1alpha1.ConditionType, existingCo
 func (r *SecurityPolicyReconciler) setupWithManager(mgr ctrl.Manager) error {
 	var blr *builder.Builder
 	if securitypolicy.IsVPCEnabled(r.Service) {
-		blr = ctrl.NewControllerManagedBy(mgr).For(&crdv1alpha1.SecurityPolicy{})
+		// Named ports can be resolved from VirtualMachines in VPC network, so watch the VMs and
+		// VirtualMachineServices as well.
+		blr = ctrl.NewControllerManagedBy(mgr).For(&crdv1alpha1.SecurityPolicy{}).
+			Watches(
+				&vmv1alpha1.VirtualMachine{},
+				&EnqueueRequestForVM{Client: k8sClient(mgr), SecurityPolicyReconciler: r},
+				builder.WithPredicates(PredicateFuncsVM),
+			).
+			Watches(
+				&vmv1alpha1.VirtualMachineService{},
+				&EnqueueRequestForVMService{Client: k8sClient(mgr), SecurityPolicyReconciler: r},
+				builder.WithPredicates(PredicateFuncsVMService),
+			)
 	} else {
 		blr = ctrl.NewControllerManagedBy(mgr).For(&v1alpha1.SecurityPolicy{})
 	}
@@ -321,7 +365,7 @@ func (r *SecurityPolicyReconciler) setupWithManager(mgr ctrl.Manager) error {
 			}).
 		Watches(
 			&v1.Namespace{},
-			&EnqueueRequestForNamespace{Client: k8sClient(mgr), SecurityPolicyReconciler: r},
+			&EnqueueRequestForNamespace{Client: k8sClient(mgr), SecurityPolicyReconciler: r, LabelTagKeys: r.Service.NSXConfig.GetLabelTagKeys()},
 			builder.WithPredicates(PredicateFuncsNs),
 		).
 		Watches(
@@ -333,11 +377,21 @@ func (r *SecurityPolicyReconciler) setupWithManager(mgr ctrl.Manager) error {
 }
 
 // Start setup manager and launch GC
-func (r *SecurityPolicyReconciler) Start(mgr ctrl.Manager) error {
+func (r *SecurityPolicyReconciler) Start(mgr ctrl.Manager, hookServer webhook.Server) error {
 	err := r.setupWithManager(mgr)
 	if err != nil {
 		return err
 	}
+	if hookServer != nil {
+		validator := &SecurityPolicyValidator{
+			Client:         mgr.GetClient(),
+			Service:        r.Service,
+			decoder:        admission.NewDecoder(mgr.GetScheme()),
+			RejectConflict: r.Service.NSXConfig.RejectSecurityPolicyConflict,
+		}
+		hookServer.Register("/validate-crd-nsx-vmware-com-v1alpha1-securitypolicy", &webhook.Admission{Handler: validator})
+		hookServer.Register("/validate-nsx-vmware-com-v1alpha1-securitypolicy", &webhook.Admission{Handler: validator})
+	}
 	return nil
 }
 
@@ -416,6 +470,11 @@ func (r *SecurityPolicyReconciler) listSecurityPolciyCRIDs() (sets.Set[string],
 func reconcileSecurityPolicy(r *SecurityPolicyReconciler, pkgclient client.Client, pods []v1.Pod, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
 	podPortNames := getAllPodPortNames(pods)
 	log.V(1).Info("POD named port", "podPortNames", podPortNames)
+	return reconcileSecurityPolicyByPortNames(r, pkgclient, podPortNames, q)
+}
+
+// reconcileSecurityPolicyByPortNames enqueues the security policies whose rules refer to any of the given port names.
+func reconcileSecurityPolicyByPortNames(r *SecurityPolicyReconciler, pkgclient client.Client, portNames sets.Set[string], q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
 	var spList client.ObjectList
 	if securitypolicy.IsVPCEnabled(r.Service) {
 		spList = &crdv1alpha1.SecurityPolicyList{}
@@ -434,12 +493,12 @@ func reconcileSecurityPolicy(r *SecurityPolicyReconciler, pkgclient client.Clien
 		o := spList.(*crdv1alpha1.SecurityPolicyList)
 		for i := 0; i < len(o.Items); i++ {
 			realObj := securitypolicy.VPCToT1(&o.Items[i])
-			shouldReconcile(realObj, q, podPortNames)
+			shouldReconcile(realObj, q, portNames)
 		}
 	case *v1alpha1.SecurityPolicyList:
 		o := spList.(*v1alpha1.SecurityPolicyList)
 		for i := 0; i < len(o.Items); i++ {
-			shouldReconcile(&o.Items[i], q, podPortNames)
+			shouldReconcile(&o.Items[i], q, portNames)
 		}
 	}
 	return nil
@@ -472,7 +531,37 @@ func shouldReconcile(securityPolicy *v1alpha1.SecurityPolicy, q workqueue.TypedR
 	}
 }
 
-func StartSecurityPolicyController(mgr ctrl.Manager, commonService servicecommon.Service, vpcService servicecommon.VPCServiceProvider) {
+// reconcileSecurityPolicyInNamespace enqueues all the security policies in the Namespace.
+func reconcileSecurityPolicyInNamespace(r *SecurityPolicyReconciler, pkgclient client.Client, ns string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	var spList client.ObjectList
+	if securitypolicy.IsVPCEnabled(r.Service) {
+		spList = &crdv1alpha1.SecurityPolicyList{}
+	} else {
+		spList = &v1alpha1.SecurityPolicyList{}
+	}
+	if err := pkgclient.List(context.Background(), spList, client.InNamespace(ns)); err != nil {
+		log.Error(err, "Failed to list the security policy in namespace", "namespace", ns)
+		return err
+	}
+	var names []string
+	switch o := spList.(type) {
+	case *crdv1alpha1.SecurityPolicyList:
+		for i := range o.Items {
+			names = append(names, o.Items[i].Name)
+		}
+	case *v1alpha1.SecurityPolicyList:
+		for i := range o.Items {
+			names = append(names, o.Items[i].Name)
+		}
+	}
+	for _, name := range names {
+		log.Info("Reconcile security policy because of namespace label change", "namespace", ns, "name", name)
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: ns}})
+	}
+	return nil
+}
+
+func StartSecurityPolicyController(mgr ctrl.Manager, commonService servicecommon.Service, vpcService servicecommon.VPCServiceProvider, hookServer webhook.Server) {
 	securityPolicyReconcile := SecurityPolicyReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -480,9 +569,13 @@ func StartSecurityPolicyController(mgr ctrl.Manager, commonService servicecommon
 	}
 	securityPolicyReconcile.Service = securitypolicy.GetSecurityService(commonService, vpcService)
 	securityPolicyReconcile.StatusUpdater = common.NewStatusUpdater(securityPolicyReconcile.Client, securityPolicyReconcile.Service.NSXConfig, securityPolicyReconcile.Recorder, MetricResTypeSecurityPolicy, "SecurityPolicy", "SecurityPolicy")
-	if err := securityPolicyReconcile.Start(mgr); err != nil {
+	if err := securityPolicyReconcile.Start(mgr, hookServer); err != nil {
 		log.Error(err, "Failed to create controller", "controller", "SecurityPolicy")
 		os.Exit(1)
 	}
 	go common.GenericGarbageCollector(make(chan bool), servicecommon.GCInterval, securityPolicyReconcile.CollectGarbage)
+	if interval := securityPolicyReconcile.Service.NSXConfig.RuleStatisticsInterval; interval > 0 {
+		metrics.InitializeRuleStatisticsMetrics()
+		go wait.UntilWithContext(context.Background(), securityPolicyReconcile.CollectRuleStatistics, time.Duration(interval)*time.Second)
+	}
 }
@@ -9,13 +9,16 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/golang/mock/gomock"
@@ -29,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -74,6 +78,7 @@ func fakeService() *securitypolicy.SecurityPolicyService {
 				NsxConfig: &config.NsxConfig{
 					EnforcementPoint: "vmc-enforcementpoint",
 				},
+				K8sConfig: &config.K8sConfig{},
 			},
 		},
 	}
@@ -270,6 +275,55 @@ func Test_cleanSecurityPolicyErrorAnnotation(t *testing.T) {
 	assert.NotContains(t, securityPolicy.Annotations, ctrcommon.NSXOperatorError)
 }
 
+func TestSecurityPolicyReconciler_IsTierAllowed(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(newScheme))
+	assert.NoError(t, crdv1alpha1.AddToScheme(newScheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform-ns", Labels: map[string]string{"team": "platform"}}},
+		&crdv1alpha1.SecurityPolicyTierBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+			Spec: crdv1alpha1.SecurityPolicyTierBindingSpec{
+				Tiers:             []crdv1alpha1.SecurityPolicyTier{crdv1alpha1.SecurityPolicyTierInfrastructure},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+			},
+		},
+	).Build()
+	r := &SecurityPolicyReconciler{Client: fakeClient, Service: &securitypolicy.SecurityPolicyService{}}
+	realized := false
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(r.Service), "IsTierRealized", func(_ *securitypolicy.SecurityPolicyService, _ *v1alpha1.SecurityPolicy) bool {
+		return realized
+	})
+	defer patches.Reset()
+
+	newPolicy := func(namespace string, tier v1alpha1.SecurityPolicyTier) *v1alpha1.SecurityPolicy {
+		return &v1alpha1.SecurityPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "sp1"}, Spec: v1alpha1.SecurityPolicySpec{Tier: tier}}
+	}
+	for _, tc := range []struct {
+		name   string
+		policy *v1alpha1.SecurityPolicy
+		exp    bool
+	}{
+		{name: "application tier", policy: newPolicy("ns1", ""), exp: true},
+		{name: "granted tier", policy: newPolicy("platform-ns", v1alpha1.SecurityPolicyTierInfrastructure), exp: true},
+		{name: "tier not granted", policy: newPolicy("platform-ns", v1alpha1.SecurityPolicyTierEmergency), exp: false},
+		{name: "Namespace not selected", policy: newPolicy("ns1", v1alpha1.SecurityPolicyTierInfrastructure), exp: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			allowed, err := r.isTierAllowed(context.TODO(), tc.policy)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, allowed)
+		})
+	}
+
+	// The SecurityPolicy already realized in the privileged tier keeps it.
+	realized = true
+	allowed, err := r.isTierAllowed(context.TODO(), newPolicy("ns1", v1alpha1.SecurityPolicyTierInfrastructure))
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
 func TestSecurityPolicyReconciler_Reconcile(t *testing.T) {
 	mockCtl := gomock.NewController(t)
 	defer mockCtl.Finish()
@@ -902,10 +956,12 @@ func TestStartSecurityPolicyController(t *testing.T) {
 	mgr, _ := ctrl.NewManager(&rest.Config{}, manager.Options{})
 
 	exitCalled := false // Variable to check if osExit was called
+	var ruleStatisticsPeriod atomic.Int64
 	testCases := []struct {
-		name         string
-		expectErrStr string
-		patches      func() *gomonkey.Patches
+		name                         string
+		expectErrStr                 string
+		patches                      func() *gomonkey.Patches
+		expectedRuleStatisticsPeriod time.Duration
 	}{
 		// expected no error when starting the SecurityPolicy controller
 		{
@@ -921,11 +977,37 @@ func TestStartSecurityPolicyController(t *testing.T) {
 				patches.ApplyFunc(securitypolicy.GetSecurityService, func(service common.Service, vpcService common.VPCServiceProvider) *securitypolicy.SecurityPolicyService {
 					return fakeService()
 				})
-				patches.ApplyMethod(reflect.TypeOf(&SecurityPolicyReconciler{}), "Start", func(_ *SecurityPolicyReconciler, r ctrl.Manager) error {
+				patches.ApplyMethod(reflect.TypeOf(&SecurityPolicyReconciler{}), "Start", func(_ *SecurityPolicyReconciler, r ctrl.Manager, hookServer webhook.Server) error {
+					return nil
+				})
+				return patches
+			},
+		},
+		// expected the rule statistics to be collected on their own interval
+		{
+			name: "Start SecurityPolicy Controller with rule statistics",
+			patches: func() *gomonkey.Patches {
+				patches := gomonkey.ApplyFunc(ctrcommon.GenericGarbageCollector, func(cancel chan bool, timeout time.Duration, f func(ctx context.Context)) {
+					return
+				})
+				patches.ApplyFunc(wait.UntilWithContext, func(ctx context.Context, f func(context.Context), period time.Duration) {
+					ruleStatisticsPeriod.Store(int64(period))
+				})
+				patches.ApplyFunc(os.Exit, func(code int) {
+					assert.FailNow(t, "os.Exit should not be called")
+					return
+				})
+				patches.ApplyFunc(securitypolicy.GetSecurityService, func(service common.Service, vpcService common.VPCServiceProvider) *securitypolicy.SecurityPolicyService {
+					securityPolicyService := fakeService()
+					securityPolicyService.NSXConfig.RuleStatisticsInterval = 30
+					return securityPolicyService
+				})
+				patches.ApplyMethod(reflect.TypeOf(&SecurityPolicyReconciler{}), "Start", func(_ *SecurityPolicyReconciler, r ctrl.Manager, hookServer webhook.Server) error {
 					return nil
 				})
 				return patches
 			},
+			expectedRuleStatisticsPeriod: 30 * time.Second,
 		},
 		{
 			name:         "Start SecurityPolicy controller return error",
@@ -951,10 +1033,15 @@ func TestStartSecurityPolicyController(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
+			ruleStatisticsPeriod.Store(0)
 			patches := testCase.patches()
 			defer patches.Reset()
 
-			StartSecurityPolicyController(mgr, commonService, vpcService)
+			StartSecurityPolicyController(mgr, commonService, vpcService, nil)
+			// The rule statistics are collected in a goroutine.
+			assert.Eventually(t, func() bool {
+				return time.Duration(ruleStatisticsPeriod.Load()) == testCase.expectedRuleStatisticsPeriod
+			}, time.Second, 10*time.Millisecond)
 
 			if testCase.expectErrStr != "" {
 				assert.Equal(t, exitCalled, true)
@@ -0,0 +1,162 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
+)
+
+// +kubebuilder:webhook:path=/validate-crd-nsx-vmware-com-v1alpha1-securitypolicy,mutating=false,failurePolicy=fail,sideEffects=None,
+// groups=crd.nsx.vmware.com,resources=securitypolicies,verbs=create;update,versions=v1alpha1,
+// name=securitypolicy.validating.crd.nsx.vmware.com,admissionReviewVersions=v1
+
+// +kubebuilder:webhook:path=/validate-nsx-vmware-com-v1alpha1-securitypolicy,mutating=false,failurePolicy=fail,sideEffects=None,
+// groups=nsx.vmware.com,resources=securitypolicies,verbs=create;update,versions=v1alpha1,
+// name=securitypolicy.validating.nsx.vmware.com,admissionReviewVersions=v1
+
+var NSXOperatorSA = "system:serviceaccount:vmware-system-nsx:ncp-svc-account"
+
+// SecurityPolicyValidator analyzes the rules of the SecurityPolicy against the existing ones in the
+// Namespace, and warns or rejects the SecurityPolicy with rules which never match.
+type SecurityPolicyValidator struct {
+	Client  client.Client
+	Service *securitypolicy.SecurityPolicyService
+	decoder admission.Decoder
+	// RejectConflict denies the request if any conflict is found, otherwise the conflicts are
+	// returned as admission warnings.
+	RejectConflict bool
+}
+
+// Handle handles admission requests.
+func (v *SecurityPolicyValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var obj *v1alpha1.SecurityPolicy
+	var oldTier v1alpha1.SecurityPolicyTier
+	var existingPolicies []v1alpha1.SecurityPolicy
+	if req.Kind.Group == crdv1alpha1.GroupVersion.Group {
+		vpcObj := &crdv1alpha1.SecurityPolicy{}
+		if err := v.decoder.Decode(req, vpcObj); err != nil {
+			log.Error(err, "Failed to decode SecurityPolicy", "SecurityPolicy", req.Namespace+"/"+req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		obj = securitypolicy.VPCToT1(vpcObj)
+		if req.Operation == admissionv1.Update {
+			oldObj := &crdv1alpha1.SecurityPolicy{}
+			if err := v.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+				log.Error(err, "Failed to decode old SecurityPolicy", "SecurityPolicy", req.Namespace+"/"+req.Name)
+				return admission.Errored(http.StatusBadRequest, err)
+			}
+			oldTier = securitypolicy.GetSecurityPolicyTier(securitypolicy.VPCToT1(oldObj))
+		}
+		policyList := &crdv1alpha1.SecurityPolicyList{}
+		if err := v.Client.List(ctx, policyList, client.InNamespace(req.Namespace)); err != nil {
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to list SecurityPolicy: %v", err))
+		}
+		for i := range policyList.Items {
+			existingPolicies = append(existingPolicies, *securitypolicy.VPCToT1(&policyList.Items[i]))
+		}
+	} else {
+		obj = &v1alpha1.SecurityPolicy{}
+		if err := v.decoder.Decode(req, obj); err != nil {
+			log.Error(err, "Failed to decode SecurityPolicy", "SecurityPolicy", req.Namespace+"/"+req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if req.Operation == admissionv1.Update {
+			oldObj := &v1alpha1.SecurityPolicy{}
+			if err := v.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+				log.Error(err, "Failed to decode old SecurityPolicy", "SecurityPolicy", req.Namespace+"/"+req.Name)
+				return admission.Errored(http.StatusBadRequest, err)
+			}
+			oldTier = securitypolicy.GetSecurityPolicyTier(oldObj)
+		}
+		policyList := &v1alpha1.SecurityPolicyList{}
+		if err := v.Client.List(ctx, policyList, client.InNamespace(req.Namespace)); err != nil {
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to list SecurityPolicy: %v", err))
+		}
+		existingPolicies = policyList.Items
+	}
+
+	log.V(1).Info("Handling request", "user", req.UserInfo.Username, "operation", req.Operation)
+	// The SecurityPolicies already using the privileged tier are not affected by the removal of SecurityPolicyTierBinding.
+	if tier := securitypolicy.GetSecurityPolicyTier(obj); securitypolicy.IsPrivilegedTier(tier) && tier != oldTier && req.UserInfo.Username != NSXOperatorSA {
+		allowed, err := isTierAllowed(ctx, v.Client, req.Namespace, tier)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !allowed {
+			return admission.Denied(tierNotAllowedMessage(tier, req.Namespace))
+		}
+	}
+	conflicts, err := v.Service.AnalyzeRuleConflicts(obj, existingPolicies)
+	if err != nil {
+		return admission.Denied(fmt.Sprintf("SecurityPolicy %s/%s is invalid: %v", req.Namespace, req.Name, err))
+	}
+	if len(conflicts) == 0 {
+		return admission.Allowed("")
+	}
+
+	messages := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		messages = append(messages, fmt.Sprintf("%s: %s", conflict.Reason, conflict.Message))
+	}
+	log.Info("Found conflicting rules in SecurityPolicy", "SecurityPolicy", req.Namespace+"/"+req.Name, "conflicts", messages)
+	if v.RejectConflict {
+		return admission.Denied(strings.Join(messages, "; "))
+	}
+	return admission.Allowed("").WithWarnings(messages...)
+}
+
+func tierNotAllowedMessage(tier v1alpha1.SecurityPolicyTier, namespace string) string {
+	return fmt.Sprintf("SecurityPolicy tier %s is not allowed in Namespace %s, it must be granted by a SecurityPolicyTierBinding", tier, namespace)
+}
+
+// isTierAllowed checks whether any SecurityPolicyTierBinding grants the tier to the Namespace.
+func isTierAllowed(ctx context.Context, c client.Client, namespace string, tier v1alpha1.SecurityPolicyTier) (bool, error) {
+	bindingList := &crdv1alpha1.SecurityPolicyTierBindingList{}
+	if err := c.List(ctx, bindingList); err != nil {
+		return false, fmt.Errorf("failed to list SecurityPolicyTierBinding: %v", err)
+	}
+	ns := &v1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, fmt.Errorf("failed to get Namespace %s: %v", namespace, err)
+	}
+	for _, binding := range bindingList.Items {
+		if binding.Spec.NamespaceSelector == nil {
+			continue
+		}
+		grantsTier := false
+		for _, t := range binding.Spec.Tiers {
+			if string(t) == string(tier) {
+				grantsTier = true
+				break
+			}
+		}
+		if !grantsTier {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(binding.Spec.NamespaceSelector)
+		if err != nil {
+			log.Error(err, "Invalid namespaceSelector in SecurityPolicyTierBinding", "SecurityPolicyTierBinding", binding.Name)
+			continue
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
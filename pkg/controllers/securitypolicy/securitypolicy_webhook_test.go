@@ -0,0 +1,225 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	crdv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/securitypolicy"
+)
+
+func TestSecurityPolicyValidator(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(crdv1alpha1.AddToScheme(newScheme))
+
+	allow := crdv1alpha1.RuleActionAllow
+	drop := crdv1alpha1.RuleActionDrop
+	ingress := crdv1alpha1.RuleDirectionIngress
+	newPolicy := func(name string, priority int, action *crdv1alpha1.RuleAction, port int) *crdv1alpha1.SecurityPolicy {
+		return &crdv1alpha1.SecurityPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name},
+			Spec: crdv1alpha1.SecurityPolicySpec{
+				Priority: priority,
+				AppliedTo: []crdv1alpha1.SecurityPolicyTarget{
+					{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+				},
+				Rules: []crdv1alpha1.SecurityPolicyRule{
+					{
+						Action:    action,
+						Direction: &ingress,
+						Ports:     []crdv1alpha1.SecurityPolicyPort{{Protocol: "TCP", Port: intstr.FromInt(port)}},
+					},
+				},
+			},
+		}
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(newPolicy("sp0", 5, &drop, 80)).Build()
+	service := &securitypolicy.SecurityPolicyService{
+		Service: common.Service{
+			NSXConfig: &config.NSXOperatorConfig{
+				CoeConfig: &config.CoeConfig{EnableVPCNetwork: true},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name           string
+		policy         *crdv1alpha1.SecurityPolicy
+		rejectConflict bool
+		isAllowed      bool
+		expWarnings    int
+		msg            string
+	}{
+		{
+			name:      "Create SecurityPolicy without conflict",
+			policy:    newPolicy("sp1", 10, &allow, 443),
+			isAllowed: true,
+		},
+		{
+			name:        "Create conflicting SecurityPolicy with warnings",
+			policy:      newPolicy("sp1", 10, &allow, 80),
+			isAllowed:   true,
+			expWarnings: 1,
+		},
+		{
+			name:           "Create conflicting SecurityPolicy rejected",
+			policy:         newPolicy("sp1", 10, &allow, 80),
+			rejectConflict: true,
+			isAllowed:      false,
+			msg:            "RuleOverlap: rules[0] of SecurityPolicy ns1/sp0 and rules[0] of SecurityPolicy ns1/sp1 match identical traffic with conflicting actions, rules[0] of SecurityPolicy ns1/sp1 never matches",
+		},
+	}
+	for _, testCase := range testcases {
+		t.Run(testCase.name, func(t *testing.T) {
+			validator := &SecurityPolicyValidator{
+				Client:         fakeClient,
+				Service:        service,
+				decoder:        admission.NewDecoder(newScheme),
+				RejectConflict: testCase.rejectConflict,
+			}
+			req := admission.Request{}
+			jsonData, err := json.Marshal(testCase.policy)
+			assert.NoError(t, err)
+			req.Object.Raw = jsonData
+			req.Kind = metav1.GroupVersionKind{Group: crdv1alpha1.GroupVersion.Group, Version: "v1alpha1", Kind: "SecurityPolicy"}
+			req.Namespace = testCase.policy.Namespace
+			req.Name = testCase.policy.Name
+			req.Operation = admissionv1.Create
+			response := validator.Handle(context.TODO(), req)
+			assert.Equal(t, testCase.isAllowed, response.Allowed)
+			assert.Len(t, response.Warnings, testCase.expWarnings)
+			if testCase.msg != "" {
+				assert.Equal(t, testCase.msg, response.Result.Message)
+			}
+		})
+	}
+}
+
+func TestSecurityPolicyValidator_Tier(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(crdv1alpha1.AddToScheme(newScheme))
+
+	newPolicy := func(namespace string, tier crdv1alpha1.SecurityPolicyTier) *crdv1alpha1.SecurityPolicy {
+		return &crdv1alpha1.SecurityPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "sp1"},
+			Spec:       crdv1alpha1.SecurityPolicySpec{Priority: 10, Tier: tier},
+		}
+	}
+	binding := &crdv1alpha1.SecurityPolicyTierBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+		Spec: crdv1alpha1.SecurityPolicyTierBindingSpec{
+			Tiers:             []crdv1alpha1.SecurityPolicyTier{crdv1alpha1.SecurityPolicyTierInfrastructure},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform-ns", Labels: map[string]string{"team": "platform"}}},
+		binding,
+	).Build()
+	validator := &SecurityPolicyValidator{
+		Client: fakeClient,
+		Service: &securitypolicy.SecurityPolicyService{
+			Service: common.Service{
+				NSXConfig: &config.NSXOperatorConfig{
+					CoeConfig: &config.CoeConfig{EnableVPCNetwork: true},
+				},
+			},
+		},
+		decoder: admission.NewDecoder(newScheme),
+	}
+
+	testcases := []struct {
+		name      string
+		policy    *crdv1alpha1.SecurityPolicy
+		oldPolicy *crdv1alpha1.SecurityPolicy
+		user      string
+		isAllowed bool
+		msg       string
+	}{
+		{
+			name:      "Application tier is allowed in any Namespace",
+			policy:    newPolicy("ns1", crdv1alpha1.SecurityPolicyTierApplication),
+			isAllowed: true,
+		},
+		{
+			name:      "Privileged tier is denied without SecurityPolicyTierBinding",
+			policy:    newPolicy("ns1", crdv1alpha1.SecurityPolicyTierInfrastructure),
+			isAllowed: false,
+			msg:       "SecurityPolicy tier Infrastructure is not allowed in Namespace ns1, it must be granted by a SecurityPolicyTierBinding",
+		},
+		{
+			name:      "Privileged tier is allowed by SecurityPolicyTierBinding",
+			policy:    newPolicy("platform-ns", crdv1alpha1.SecurityPolicyTierInfrastructure),
+			isAllowed: true,
+		},
+		{
+			name:      "Privileged tier not in SecurityPolicyTierBinding is denied",
+			policy:    newPolicy("platform-ns", crdv1alpha1.SecurityPolicyTierEmergency),
+			isAllowed: false,
+			msg:       "SecurityPolicy tier Emergency is not allowed in Namespace platform-ns, it must be granted by a SecurityPolicyTierBinding",
+		},
+		{
+			name:      "Update SecurityPolicy without changing the privileged tier",
+			policy:    newPolicy("ns1", crdv1alpha1.SecurityPolicyTierEnvironment),
+			oldPolicy: newPolicy("ns1", crdv1alpha1.SecurityPolicyTierEnvironment),
+			isAllowed: true,
+		},
+		{
+			name:      "Update SecurityPolicy to the privileged tier",
+			policy:    newPolicy("ns1", crdv1alpha1.SecurityPolicyTierEnvironment),
+			oldPolicy: newPolicy("ns1", ""),
+			isAllowed: false,
+			msg:       "SecurityPolicy tier Environment is not allowed in Namespace ns1, it must be granted by a SecurityPolicyTierBinding",
+		},
+		{
+			name:      "NSX Operator is allowed to use the privileged tier",
+			policy:    newPolicy("ns1", crdv1alpha1.SecurityPolicyTierEmergency),
+			user:      NSXOperatorSA,
+			isAllowed: true,
+		},
+	}
+	for _, testCase := range testcases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := admission.Request{}
+			jsonData, err := json.Marshal(testCase.policy)
+			assert.NoError(t, err)
+			req.Object.Raw = jsonData
+			req.Operation = admissionv1.Create
+			if testCase.oldPolicy != nil {
+				jsonData, err = json.Marshal(testCase.oldPolicy)
+				assert.NoError(t, err)
+				req.OldObject.Raw = jsonData
+				req.Operation = admissionv1.Update
+			}
+			req.Kind = metav1.GroupVersionKind{Group: crdv1alpha1.GroupVersion.Group, Version: "v1alpha1", Kind: "SecurityPolicy"}
+			req.Namespace = testCase.policy.Namespace
+			req.Name = testCase.policy.Name
+			req.UserInfo.Username = testCase.user
+			response := validator.Handle(context.TODO(), req)
+			assert.Equal(t, testCase.isAllowed, response.Allowed)
+			if testCase.msg != "" {
+				assert.Equal(t, testCase.msg, response.Result.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmv1alpha1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+// A VM's named ports are the port names of the VirtualMachineServices selecting it, so we should
+// reconcile the security policy when:
+// A VM is added or deleted, or its labels, IP or power state are changed.
+// A VirtualMachineService is added or deleted, or its ports or selector are changed.
+
+type EnqueueRequestForVM struct {
+	Client                   client.Client
+	SecurityPolicyReconciler *SecurityPolicyReconciler
+}
+
+func (e *EnqueueRequestForVM) Create(_ context.Context, createEvent event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.Raw(createEvent.Object, q)
+}
+
+func (e *EnqueueRequestForVM) Update(_ context.Context, updateEvent event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.Raw(updateEvent.ObjectNew, q)
+}
+
+func (e *EnqueueRequestForVM) Delete(_ context.Context, deleteEvent event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.Raw(deleteEvent.Object, q)
+}
+
+func (e *EnqueueRequestForVM) Generic(_ context.Context, genericEvent event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.Raw(genericEvent.Object, q)
+}
+
+func (e *EnqueueRequestForVM) Raw(obj client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	vm, ok := obj.(*vmv1alpha1.VirtualMachine)
+	if !ok {
+		log.Error(nil, "Unknown object type", "object", obj)
+		return
+	}
+	if isInSysNs, err := util.IsSystemNamespace(e.Client, vm.Namespace, nil); err != nil {
+		log.Error(err, "Failed to fetch namespace", "namespace", vm.Namespace)
+		return
+	} else if isInSysNs {
+		log.V(2).Info("VM is in system namespace, do nothing")
+		return
+	}
+
+	vmServiceList := &vmv1alpha1.VirtualMachineServiceList{}
+	if err := e.Client.List(context.Background(), vmServiceList, client.InNamespace(vm.Namespace)); err != nil {
+		log.Error(err, "Failed to list VirtualMachineServices", "namespace", vm.Namespace)
+		return
+	}
+	vmPortNames := getVMPortNames(vm, vmServiceList.Items)
+	if vmPortNames.Len() == 0 {
+		log.V(2).Info("VM has no named port, do nothing", "namespace", vm.Namespace, "name", vm.Name)
+		return
+	}
+	log.V(1).Info("VM named port", "vmPortNames", vmPortNames)
+	if err := reconcileSecurityPolicyByPortNames(e.SecurityPolicyReconciler, e.Client, vmPortNames, q); err != nil {
+		log.Error(err, "Failed to reconcile security policy")
+	}
+}
+
+// getVMPortNames returns the port names of the VirtualMachineServices which select the VM.
+func getVMPortNames(vm *vmv1alpha1.VirtualMachine, vmServices []vmv1alpha1.VirtualMachineService) sets.Set[string] {
+	vmPortNames := sets.New[string]()
+	for _, vmService := range vmServices {
+		if len(vmService.Spec.Selector) == 0 || !labels.SelectorFromSet(vmService.Spec.Selector).Matches(labels.Set(vm.Labels)) {
+			continue
+		}
+		vmPortNames.Insert(getVMServicePortNames(&vmService).UnsortedList()...)
+	}
+	return vmPortNames
+}
+
+type EnqueueRequestForVMService struct {
+	Client                   client.Client
+	SecurityPolicyReconciler *SecurityPolicyReconciler
+}
+
+func (e *EnqueueRequestForVMService) Create(_ context.Context, createEvent event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.Raw(q, createEvent.Object)
+}
+
+func (e *EnqueueRequestForVMService) Update(_ context.Context, updateEvent event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// The port names removed from the old VirtualMachineService should be considered as well.
+	e.Raw(q, updateEvent.ObjectOld, updateEvent.ObjectNew)
+}
+
+func (e *EnqueueRequestForVMService) Delete(_ context.Context, deleteEvent event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.Raw(q, deleteEvent.Object)
+}
+
+func (e *EnqueueRequestForVMService) Generic(_ context.Context, genericEvent event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.Raw(q, genericEvent.Object)
+}
+
+func (e *EnqueueRequestForVMService) Raw(q workqueue.TypedRateLimitingInterface[reconcile.Request], objs ...client.Object) {
+	vmPortNames := sets.New[string]()
+	for _, obj := range objs {
+		vmService, ok := obj.(*vmv1alpha1.VirtualMachineService)
+		if !ok {
+			log.Error(nil, "Unknown object type", "object", obj)
+			return
+		}
+		if isInSysNs, err := util.IsSystemNamespace(e.Client, vmService.Namespace, nil); err != nil {
+			log.Error(err, "Failed to fetch namespace", "namespace", vmService.Namespace)
+			return
+		} else if isInSysNs {
+			log.V(2).Info("VirtualMachineService is in system namespace, do nothing")
+			return
+		}
+		vmPortNames.Insert(getVMServicePortNames(vmService).UnsortedList()...)
+	}
+	if err := reconcileSecurityPolicyByPortNames(e.SecurityPolicyReconciler, e.Client, vmPortNames, q); err != nil {
+		log.Error(err, "Failed to reconcile security policy")
+	}
+}
+
+func getVMServicePortNames(vmService *vmv1alpha1.VirtualMachineService) sets.Set[string] {
+	portNames := sets.New[string]()
+	for _, port := range vmService.Spec.Ports {
+		if port.Name != "" {
+			portNames.Insert(port.Name)
+		}
+	}
+	return portNames
+}
+
+var PredicateFuncsVM = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return true
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldObj := e.ObjectOld.(*vmv1alpha1.VirtualMachine)
+		newObj := e.ObjectNew.(*vmv1alpha1.VirtualMachine)
+		log.V(1).Info("Receive VM update event", "namespace", oldObj.Namespace, "name", oldObj.Name)
+		if reflect.DeepEqual(oldObj.ObjectMeta.Labels, newObj.ObjectMeta.Labels) &&
+			oldObj.Status.VmIp == newObj.Status.VmIp && oldObj.Status.PowerState == newObj.Status.PowerState {
+			log.V(1).Info("VM label, IP and power state are not changed, ignore it", "name", oldObj.Name)
+			return false
+		}
+		return true
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return true
+	},
+}
+
+var PredicateFuncsVMService = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		if s, ok := e.Object.(*vmv1alpha1.VirtualMachineService); ok {
+			return getVMServicePortNames(s).Len() > 0
+		}
+		return false
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldObj := e.ObjectOld.(*vmv1alpha1.VirtualMachineService)
+		newObj := e.ObjectNew.(*vmv1alpha1.VirtualMachineService)
+		log.V(1).Info("Receive VirtualMachineService update event", "namespace", oldObj.Namespace, "name", oldObj.Name)
+		if reflect.DeepEqual(oldObj.Spec.Ports, newObj.Spec.Ports) && reflect.DeepEqual(oldObj.Spec.Selector, newObj.Spec.Selector) {
+			log.V(1).Info("VirtualMachineService ports and selector are not changed, ignore it", "name", oldObj.Name)
+			return false
+		}
+		return true
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		if s, ok := e.Object.(*vmv1alpha1.VirtualMachineService); ok {
+			return getVMServicePortNames(s).Len() > 0
+		}
+		return false
+	},
+}
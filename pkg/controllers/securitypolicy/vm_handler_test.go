@@ -0,0 +1,80 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	vmv1alpha1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+func newVMService(name string, selector map[string]string, portNames ...string) vmv1alpha1.VirtualMachineService {
+	vmService := vmv1alpha1.VirtualMachineService{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name},
+		Spec:       vmv1alpha1.VirtualMachineServiceSpec{Selector: selector},
+	}
+	for _, portName := range portNames {
+		vmService.Spec.Ports = append(vmService.Spec.Ports, vmv1alpha1.VirtualMachineServicePort{Name: portName})
+	}
+	return vmService
+}
+
+func Test_getVMPortNames(t *testing.T) {
+	vm := &vmv1alpha1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "vm1", Labels: map[string]string{"app": "web"}},
+	}
+	vmServices := []vmv1alpha1.VirtualMachineService{
+		newVMService("svc1", map[string]string{"app": "web"}, "http", ""),
+		newVMService("svc2", map[string]string{"app": "db"}, "mysql"),
+		newVMService("svc3", nil, "ssh"),
+	}
+	assert.Equal(t, sets.New[string]("http"), getVMPortNames(vm, vmServices))
+}
+
+func TestEnqueueRequestForVMService_Update(t *testing.T) {
+	oldSvc := newVMService("svc1", map[string]string{"app": "web"}, "http")
+	newSvc := newVMService("svc1", map[string]string{"app": "web"}, "https")
+	evt := event.UpdateEvent{ObjectOld: &oldSvc, ObjectNew: &newSvc}
+
+	var gotPortNames sets.Set[string]
+	patches := gomonkey.ApplyFunc(reconcileSecurityPolicyByPortNames, func(r *SecurityPolicyReconciler, client client.Client, portNames sets.Set[string],
+		q workqueue.TypedRateLimitingInterface[reconcile.Request],
+	) error {
+		gotPortNames = portNames
+		return nil
+	})
+	defer patches.Reset()
+	patches.ApplyFunc(util.IsSystemNamespace, func(client client.Client, ns string, obj *v1.Namespace) (bool, error) {
+		return false, nil
+	})
+
+	e := &EnqueueRequestForVMService{}
+	e.Update(context.TODO(), evt, nil)
+	assert.Equal(t, sets.New[string]("http", "https"), gotPortNames)
+}
+
+func TestPredicateFuncsVM(t *testing.T) {
+	oldVM := &vmv1alpha1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "vm1", Labels: map[string]string{"app": "web"}},
+		Status:     vmv1alpha1.VirtualMachineStatus{VmIp: "1.1.1.1"},
+	}
+	newVM := oldVM.DeepCopy()
+	newVM.Spec.ClassName = "small"
+	assert.False(t, PredicateFuncsVM.Update(event.UpdateEvent{ObjectOld: oldVM, ObjectNew: newVM}))
+
+	newVM.Status.VmIp = "1.1.1.2"
+	assert.True(t, PredicateFuncsVM.Update(event.UpdateEvent{ObjectOld: oldVM, ObjectNew: newVM}))
+}
@@ -0,0 +1,267 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package sharedvpc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+)
+
+var (
+	log                    = &logger.Log
+	ResultNormal           = common.ResultNormal
+	ResultRequeue          = common.ResultRequeue
+	MetricResTypeSharedVPC = common.MetricResTypeSharedVPC
+)
+
+const (
+	ReasonSharedVPCReady         = "SharedVPCReady"
+	ReasonOwnerNamespaceNotFound = "OwnerNamespaceNotFound"
+	ReasonOwnerNamespaceDeleted  = "OwnerNamespaceDeleted"
+	ReasonVPCNotReady            = "VPCNotReady"
+)
+
+// SharedVPCReconciler reconciles a SharedVPC object. The VPC itself is created for the owner Namespace by the
+// NetworkInfo controller and found by the consumer Namespaces through the SharedVPC, this controller only
+// reports the consumers using the VPC and the state of the VPC in the status.
+type SharedVPCReconciler struct {
+	Client        client.Client
+	Scheme        *apimachineryruntime.Scheme
+	Recorder      record.EventRecorder
+	StatusUpdater common.StatusUpdater
+	VPCService    *vpc.VPCService
+}
+
+func setSharedVPCReadyStatusTrue(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, args ...interface{}) {
+	sharedVPC := obj.(*v1alpha1.SharedVPC)
+	updateSharedVPCStatus(client, ctx, sharedVPC, v1alpha1.Condition{
+		Type:               v1alpha1.Ready,
+		Status:             v1.ConditionTrue,
+		Message:            "VPC of the owner Namespace is shared with the consumer Namespaces",
+		Reason:             ReasonSharedVPCReady,
+		LastTransitionTime: transitionTime,
+	}, args...)
+}
+
+func setSharedVPCReadyStatusFalse(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, err error, args ...interface{}) {
+	sharedVPC := obj.(*v1alpha1.SharedVPC)
+	updateSharedVPCStatus(client, ctx, sharedVPC, v1alpha1.Condition{
+		Type:               v1alpha1.Ready,
+		Status:             v1.ConditionFalse,
+		Message:            fmt.Sprintf("Error occurred while processing the SharedVPC CR. Error: %v", err),
+		Reason:             "SharedVPCNotReady",
+		LastTransitionTime: transitionTime,
+	}, args...)
+}
+
+// updateSharedVPCStatus updates the status of the SharedVPC only if it is changed. The existing consumer
+// Namespaces and the VPC path are passed in args if they are resolved.
+func updateSharedVPCStatus(client client.Client, ctx context.Context, sharedVPC *v1alpha1.SharedVPC, newCondition v1alpha1.Condition, args ...interface{}) {
+	updated := mergeSharedVPCStatusCondition(sharedVPC, &newCondition)
+	if len(args) == 2 {
+		consumers, vpcPath := args[0].([]string), args[1].(string)
+		if !slices.Equal(sharedVPC.Status.Consumers, consumers) || sharedVPC.Status.VPCPath != vpcPath {
+			sharedVPC.Status.Consumers = consumers
+			sharedVPC.Status.VPCPath = vpcPath
+			updated = true
+		}
+	}
+	if !updated {
+		return
+	}
+	if err := client.Status().Update(ctx, sharedVPC); err != nil {
+		log.Error(err, "Failed to update SharedVPC status", "SharedVPC", sharedVPC.Name)
+		return
+	}
+	log.V(1).Info("Updated SharedVPC status", "SharedVPC", sharedVPC.Name, "New Condition", newCondition)
+}
+
+func mergeSharedVPCStatusCondition(sharedVPC *v1alpha1.SharedVPC, newCondition *v1alpha1.Condition) bool {
+	for i := range sharedVPC.Status.Conditions {
+		matchedCondition := &sharedVPC.Status.Conditions[i]
+		if matchedCondition.Type != newCondition.Type {
+			continue
+		}
+		if matchedCondition.Status == newCondition.Status && matchedCondition.Reason == newCondition.Reason && matchedCondition.Message == newCondition.Message {
+			return false
+		}
+		if matchedCondition.Status != newCondition.Status {
+			matchedCondition.LastTransitionTime = newCondition.LastTransitionTime
+		}
+		matchedCondition.Reason = newCondition.Reason
+		matchedCondition.Message = newCondition.Message
+		matchedCondition.Status = newCondition.Status
+		return true
+	}
+	sharedVPC.Status.Conditions = append(sharedVPC.Status.Conditions, *newCondition)
+	return true
+}
+
+func (r *SharedVPCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := &v1alpha1.SharedVPC{}
+	log.Info("Reconciling SharedVPC CR", "SharedVPC", req.Name)
+	r.StatusUpdater.IncreaseSyncTotal()
+
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.StatusUpdater.DeleteSuccess(req.NamespacedName, nil)
+			return ResultNormal, nil
+		}
+		log.Error(err, "Unable to fetch SharedVPC CR", "req", req.NamespacedName)
+		return ResultRequeue, err
+	}
+	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ResultNormal, nil
+	}
+
+	r.StatusUpdater.IncreaseUpdateTotal()
+	ownerExists, err := r.namespaceExists(ctx, obj.Spec.OwnerNamespace)
+	if err != nil {
+		r.StatusUpdater.UpdateFail(ctx, obj, err, "failed to get the owner Namespace", setSharedVPCReadyStatusFalse)
+		return ResultRequeue, err
+	}
+	var consumers []string
+	for _, ns := range obj.Spec.ConsumerNamespaces {
+		exists, err := r.namespaceExists(ctx, ns)
+		if err != nil {
+			r.StatusUpdater.UpdateFail(ctx, obj, err, "failed to get the consumer Namespace", setSharedVPCReadyStatusFalse)
+			return ResultRequeue, err
+		}
+		if exists {
+			consumers = append(consumers, ns)
+		}
+	}
+	vpcPath := ""
+	for _, nsxVPC := range r.VPCService.GetVPCsByNamespace(obj.Spec.OwnerNamespace) {
+		if nsxVPC.Path != nil {
+			vpcPath = *nsxVPC.Path
+			break
+		}
+	}
+
+	// The SharedVPC is reconciled again once the VPC is created or the owner Namespace is created, since the
+	// NetworkInfo and Namespace events are mapped to it.
+	switch {
+	case !ownerExists && vpcPath != "":
+		updateSharedVPCStatus(r.Client, ctx, obj, newNotReadyCondition(ReasonOwnerNamespaceDeleted,
+			fmt.Sprintf("Owner Namespace %s is deleted, the VPC is kept until all the consumer Namespaces are deleted", obj.Spec.OwnerNamespace)), consumers, vpcPath)
+	case !ownerExists:
+		updateSharedVPCStatus(r.Client, ctx, obj, newNotReadyCondition(ReasonOwnerNamespaceNotFound,
+			fmt.Sprintf("Owner Namespace %s is not found", obj.Spec.OwnerNamespace)), consumers, vpcPath)
+	case vpcPath == "":
+		updateSharedVPCStatus(r.Client, ctx, obj, newNotReadyCondition(ReasonVPCNotReady,
+			fmt.Sprintf("VPC of the owner Namespace %s is not created yet", obj.Spec.OwnerNamespace)), consumers, vpcPath)
+	default:
+		r.StatusUpdater.UpdateSuccess(ctx, obj, setSharedVPCReadyStatusTrue, consumers, vpcPath)
+	}
+	return ResultNormal, nil
+}
+
+func newNotReadyCondition(reason, message string) v1alpha1.Condition {
+	return v1alpha1.Condition{
+		Type:               v1alpha1.Ready,
+		Status:             v1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+// namespaceExists returns true if the Namespace exists and is not being deleted.
+func (r *SharedVPCReconciler) namespaceExists(ctx context.Context, name string) (bool, error) {
+	ns := &v1.Namespace{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return ns.DeletionTimestamp.IsZero(), nil
+}
+
+// listSharedVPCRequests enqueues the SharedVPCs which the Namespace owns or consumes.
+func (r *SharedVPCReconciler) listSharedVPCRequests(ctx context.Context, ns string) []reconcile.Request {
+	sharedVPCList := &v1alpha1.SharedVPCList{}
+	if err := r.Client.List(ctx, sharedVPCList); err != nil {
+		log.Error(err, "Failed to list SharedVPC CR")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, sharedVPC := range sharedVPCList.Items {
+		if sharedVPC.Spec.OwnerNamespace == ns || slices.Contains(sharedVPC.Spec.ConsumerNamespaces, ns) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: sharedVPC.Name}})
+		}
+	}
+	return requests
+}
+
+// namespaceMapFunc enqueues the SharedVPCs referring to the Namespace, so that the consumers in the status are
+// updated when the Namespaces come and go.
+func (r *SharedVPCReconciler) namespaceMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.listSharedVPCRequests(ctx, obj.GetName())
+}
+
+// networkInfoMapFunc enqueues the SharedVPCs referring to the Namespace of the NetworkInfo, so that the VPC path
+// in the status is updated when the VPC of the owner Namespace is created.
+func (r *SharedVPCReconciler) networkInfoMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.listSharedVPCRequests(ctx, obj.GetNamespace())
+}
+
+func (r *SharedVPCReconciler) setupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.SharedVPC{}).
+		WithOptions(
+			controller.Options{
+				MaxConcurrentReconciles: common.NumReconcile(),
+			}).
+		Watches(&v1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.namespaceMapFunc)).
+		Watches(&v1alpha1.NetworkInfo{}, handler.EnqueueRequestsFromMapFunc(r.networkInfoMapFunc)).
+		Complete(r)
+}
+
+func StartSharedVPCController(mgr ctrl.Manager, vpcService *vpc.VPCService, hookServer webhook.Server, cf *config.NSXOperatorConfig) {
+	sharedVPCReconciler := SharedVPCReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Recorder:   mgr.GetEventRecorderFor("sharedvpc-controller"),
+		VPCService: vpcService,
+	}
+	sharedVPCReconciler.StatusUpdater = common.NewStatusUpdater(sharedVPCReconciler.Client, cf, sharedVPCReconciler.Recorder, MetricResTypeSharedVPC, "VPC", "SharedVPC")
+	if err := sharedVPCReconciler.setupWithManager(mgr); err != nil {
+		log.Error(err, "Failed to create controller", "controller", "SharedVPC")
+		os.Exit(1)
+	}
+	if hookServer != nil {
+		hookServer.Register("/validate-crd-nsx-vmware-com-v1alpha1-sharedvpc",
+			&webhook.Admission{
+				Handler: &SharedVPCValidator{
+					Client:     mgr.GetClient(),
+					decoder:    admission.NewDecoder(mgr.GetScheme()),
+					vpcService: vpcService,
+				},
+			})
+	}
+}
@@ -0,0 +1,145 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package sharedvpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+)
+
+func createSharedVPCReconciler(objs ...client.Object) *SharedVPCReconciler {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(newScheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.SharedVPC{}).Build()
+	r := &SharedVPCReconciler{
+		Client:     fakeClient,
+		Scheme:     newScheme,
+		Recorder:   &record.FakeRecorder{},
+		VPCService: &vpc.VPCService{},
+	}
+	r.StatusUpdater = common.NewStatusUpdater(r.Client, &config.NSXOperatorConfig{NsxConfig: &config.NsxConfig{}}, r.Recorder, MetricResTypeSharedVPC, "VPC", "SharedVPC")
+	return r
+}
+
+func TestSharedVPCReconciler_Reconcile(t *testing.T) {
+	sharedVPC := &v1alpha1.SharedVPC{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-1"},
+		Spec:       v1alpha1.SharedVPCSpec{OwnerNamespace: "ns-owner", ConsumerNamespaces: []string{"ns-1", "ns-2"}},
+	}
+	vpcPath := "/orgs/default/projects/p1/vpcs/vpc-1"
+	tests := []struct {
+		name              string
+		namespaces        []string
+		vpcs              []*model.Vpc
+		expectedReason    string
+		expectedStatus    v1.ConditionStatus
+		expectedConsumers []string
+		expectedVPCPath   string
+	}{
+		{
+			name:              "Ready",
+			namespaces:        []string{"ns-owner", "ns-1"},
+			vpcs:              []*model.Vpc{{Path: &vpcPath}},
+			expectedReason:    ReasonSharedVPCReady,
+			expectedStatus:    v1.ConditionTrue,
+			expectedConsumers: []string{"ns-1"},
+			expectedVPCPath:   vpcPath,
+		},
+		{
+			name:              "VPCNotReady",
+			namespaces:        []string{"ns-owner", "ns-1", "ns-2"},
+			expectedReason:    ReasonVPCNotReady,
+			expectedStatus:    v1.ConditionFalse,
+			expectedConsumers: []string{"ns-1", "ns-2"},
+		},
+		{
+			name:           "OwnerNamespaceNotFound",
+			expectedReason: ReasonOwnerNamespaceNotFound,
+			expectedStatus: v1.ConditionFalse,
+		},
+		{
+			name:              "OwnerNamespaceDeleted",
+			namespaces:        []string{"ns-2"},
+			vpcs:              []*model.Vpc{{Path: &vpcPath}},
+			expectedReason:    ReasonOwnerNamespaceDeleted,
+			expectedStatus:    v1.ConditionFalse,
+			expectedConsumers: []string{"ns-2"},
+			expectedVPCPath:   vpcPath,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := []client.Object{sharedVPC.DeepCopy()}
+			for _, ns := range tt.namespaces {
+				objs = append(objs, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+			}
+			r := createSharedVPCReconciler(objs...)
+			patches := gomonkey.ApplyMethod(reflect.TypeOf(r.VPCService), "GetVPCsByNamespace", func(_ *vpc.VPCService, ns string) []*model.Vpc {
+				assert.Equal(t, "ns-owner", ns)
+				return tt.vpcs
+			})
+			defer patches.Reset()
+
+			ctx := context.TODO()
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "shared-1"}})
+			assert.NoError(t, err)
+			assert.Equal(t, ResultNormal, result)
+
+			obj := &v1alpha1.SharedVPC{}
+			assert.NoError(t, r.Client.Get(ctx, types.NamespacedName{Name: "shared-1"}, obj))
+			assert.Equal(t, tt.expectedConsumers, obj.Status.Consumers)
+			assert.Equal(t, tt.expectedVPCPath, obj.Status.VPCPath)
+			assert.Len(t, obj.Status.Conditions, 1)
+			assert.Equal(t, tt.expectedReason, obj.Status.Conditions[0].Reason)
+			assert.Equal(t, tt.expectedStatus, obj.Status.Conditions[0].Status)
+		})
+	}
+}
+
+func TestSharedVPCReconciler_ReconcileNotFound(t *testing.T) {
+	r := createSharedVPCReconciler()
+	result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "shared-1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, ResultNormal, result)
+}
+
+func TestSharedVPCReconciler_MapFunc(t *testing.T) {
+	r := createSharedVPCReconciler(
+		&v1alpha1.SharedVPC{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-1"},
+			Spec:       v1alpha1.SharedVPCSpec{OwnerNamespace: "ns-owner", ConsumerNamespaces: []string{"ns-1"}},
+		},
+		&v1alpha1.SharedVPC{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-2"},
+			Spec:       v1alpha1.SharedVPCSpec{OwnerNamespace: "ns-owner-2"},
+		},
+	)
+	ctx := context.TODO()
+	expected := []ctrl.Request{{NamespacedName: types.NamespacedName{Name: "shared-1"}}}
+	assert.Equal(t, expected, r.namespaceMapFunc(ctx, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1"}}))
+	assert.Equal(t, expected, r.networkInfoMapFunc(ctx, &v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Name: "ns-owner", Namespace: "ns-owner"}}))
+	assert.Empty(t, r.namespaceMapFunc(ctx, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-3"}}))
+}
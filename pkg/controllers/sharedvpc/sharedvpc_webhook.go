@@ -0,0 +1,254 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package sharedvpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+)
+
+// +kubebuilder:webhook:path=/validate-crd-nsx-vmware-com-v1alpha1-sharedvpc,mutating=false,failurePolicy=fail,sideEffects=None,groups=crd.nsx.vmware.com,resources=sharedvpcs,verbs=create;update;delete,versions=v1alpha1,name=sharedvpc.validating.crd.nsx.vmware.com,admissionReviewVersions=v1
+
+// SharedVPCValidator validates the SharedVPC. A Namespace owns at most one shared VPC and uses at most one
+// shared VPC, an owner can't be a consumer at the same time so that no sharing cycle is formed, and a
+// Namespace already having its own VPC can't join a shared VPC. The requester must be authorized to update
+// the owner Namespace and the joining consumer Namespaces. The consumer Namespaces using the shared VPC
+// can't be removed from the SharedVPC until they are deleted.
+type SharedVPCValidator struct {
+	Client     client.Client
+	decoder    admission.Decoder
+	vpcService *vpc.VPCService
+}
+
+// Handle handles admission requests.
+func (v *SharedVPCValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log.V(1).Info("Handling request", "user", req.UserInfo.Username, "operation", req.Operation)
+	switch req.Operation {
+	case admissionv1.Create:
+		sharedVPC := &v1alpha1.SharedVPC{}
+		if err := v.decoder.Decode(req, sharedVPC); err != nil {
+			log.Error(err, "error while decoding SharedVPC", "SharedVPC", req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if err := validateSpec(sharedVPC); err != nil {
+			return admission.Denied(fmt.Sprintf("SharedVPC %s is invalid: %v", sharedVPC.Name, err))
+		}
+		if _, err := v.getNamespace(ctx, sharedVPC.Spec.OwnerNamespace); err != nil {
+			if apierrors.IsNotFound(err) {
+				return admission.Denied(fmt.Sprintf("owner Namespace %s of SharedVPC %s does not exist", sharedVPC.Spec.OwnerNamespace, sharedVPC.Name))
+			}
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		return v.validateSharing(ctx, req, sharedVPC, sharedVPC.Spec.ConsumerNamespaces, true)
+	case admissionv1.Update:
+		sharedVPC := &v1alpha1.SharedVPC{}
+		if err := v.decoder.Decode(req, sharedVPC); err != nil {
+			log.Error(err, "error while decoding SharedVPC", "SharedVPC", req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		oldSharedVPC := &v1alpha1.SharedVPC{}
+		if err := v.decoder.DecodeRaw(req.OldObject, oldSharedVPC); err != nil {
+			log.Error(err, "error while decoding old SharedVPC", "SharedVPC", req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		// The spec is not validated if unchanged, so that the metadata, e.g. the finalizers, can still be updated.
+		if reflect.DeepEqual(oldSharedVPC.Spec, sharedVPC.Spec) {
+			return admission.Allowed("")
+		}
+		if oldSharedVPC.Spec.OwnerNamespace != sharedVPC.Spec.OwnerNamespace {
+			return admission.Denied(fmt.Sprintf("ownerNamespace of SharedVPC %s is immutable", sharedVPC.Name))
+		}
+		if err := validateSpec(sharedVPC); err != nil {
+			return admission.Denied(fmt.Sprintf("SharedVPC %s is invalid: %v", sharedVPC.Name, err))
+		}
+		oldConsumers := sets.New(oldSharedVPC.Spec.ConsumerNamespaces...)
+		newConsumers := sets.New(sharedVPC.Spec.ConsumerNamespaces...)
+		existing, err := v.listExistingNamespaces(ctx, sets.List(oldConsumers.Difference(newConsumers)))
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if len(existing) > 0 {
+			return admission.Denied(fmt.Sprintf("consumer Namespace(s) %s of SharedVPC %s still exist and cannot be removed", strings.Join(existing, ", "), sharedVPC.Name))
+		}
+		return v.validateSharing(ctx, req, sharedVPC, sets.List(newConsumers.Difference(oldConsumers)), false)
+	case admissionv1.Delete:
+		oldSharedVPC := &v1alpha1.SharedVPC{}
+		if err := v.decoder.DecodeRaw(req.OldObject, oldSharedVPC); err != nil {
+			log.Error(err, "error while decoding old SharedVPC", "SharedVPC", req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		existing, err := v.listExistingNamespaces(ctx, oldSharedVPC.Spec.ConsumerNamespaces)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if len(existing) > 0 {
+			return admission.Denied(fmt.Sprintf("SharedVPC %s is used by consumer Namespace(s) %s and cannot be deleted", req.Name, strings.Join(existing, ", ")))
+		}
+	}
+	return admission.Allowed("")
+}
+
+func validateSpec(sharedVPC *v1alpha1.SharedVPC) error {
+	if sharedVPC.Spec.OwnerNamespace == "" {
+		return fmt.Errorf("ownerNamespace is required")
+	}
+	seen := sets.New[string]()
+	for _, ns := range sharedVPC.Spec.ConsumerNamespaces {
+		if ns == "" {
+			return fmt.Errorf("consumerNamespaces contains an empty Namespace")
+		}
+		if ns == sharedVPC.Spec.OwnerNamespace {
+			return fmt.Errorf("owner Namespace %s cannot be a consumer of its own VPC", ns)
+		}
+		if seen.Has(ns) {
+			return fmt.Errorf("consumer Namespace %s is duplicated", ns)
+		}
+		seen.Insert(ns)
+	}
+	return nil
+}
+
+// validateSharing checks the owner Namespace and the joining consumer Namespaces against the other SharedVPCs,
+// the legacy annotation "nsx.vmware.com/shared_vpc_namespace" and the existing VPCs, and checks the requester is
+// authorized to update those Namespaces.
+func (v *SharedVPCValidator) validateSharing(ctx context.Context, req admission.Request, sharedVPC *v1alpha1.SharedVPC, joiningConsumers []string, checkOwner bool) admission.Response {
+	owner := sharedVPC.Spec.OwnerNamespace
+	sharedVPCList := &v1alpha1.SharedVPCList{}
+	if err := v.Client.List(ctx, sharedVPCList); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	for _, other := range sharedVPCList.Items {
+		if other.Name == sharedVPC.Name {
+			continue
+		}
+		if checkOwner && other.Spec.OwnerNamespace == owner {
+			return admission.Denied(fmt.Sprintf("Namespace %s already owns SharedVPC %s", owner, other.Name))
+		}
+		if checkOwner && slices.Contains(other.Spec.ConsumerNamespaces, owner) {
+			return admission.Denied(fmt.Sprintf("owner Namespace %s is a consumer of SharedVPC %s", owner, other.Name))
+		}
+		for _, ns := range joiningConsumers {
+			if other.Spec.OwnerNamespace == ns {
+				return admission.Denied(fmt.Sprintf("consumer Namespace %s is the owner of SharedVPC %s", ns, other.Name))
+			}
+			if slices.Contains(other.Spec.ConsumerNamespaces, ns) {
+				return admission.Denied(fmt.Sprintf("consumer Namespace %s is already a consumer of SharedVPC %s", ns, other.Name))
+			}
+		}
+	}
+
+	if checkOwner {
+		ownerNS, err := v.getNamespace(ctx, owner)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if sharedNS := getAnnotatedSharedNamespace(ownerNS); sharedNS != "" && sharedNS != owner {
+			return admission.Denied(fmt.Sprintf("owner Namespace %s shares the VPC of Namespace %s", owner, sharedNS))
+		}
+	}
+	for _, ns := range joiningConsumers {
+		consumerNS, err := v.getNamespace(ctx, ns)
+		if err != nil {
+			// The consumer Namespace can be declared before it is created, so that it never creates its own VPC.
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if sharedNS := getAnnotatedSharedNamespace(consumerNS); sharedNS != "" && sharedNS != owner {
+			return admission.Denied(fmt.Sprintf("consumer Namespace %s shares the VPC of Namespace %s", ns, sharedNS))
+		}
+		if len(v.vpcService.GetVPCsByNamespace(ns)) > 0 {
+			return admission.Denied(fmt.Sprintf("consumer Namespace %s already has its own VPC", ns))
+		}
+	}
+
+	namespaces := joiningConsumers
+	if checkOwner {
+		namespaces = append([]string{owner}, joiningConsumers...)
+	}
+	for _, ns := range namespaces {
+		allowed, err := v.isAuthorized(ctx, req, ns)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !allowed {
+			return admission.Denied(fmt.Sprintf("user %s is not allowed to update Namespace %s", req.UserInfo.Username, ns))
+		}
+	}
+	return admission.Allowed("")
+}
+
+// isAuthorized checks whether the requester is allowed to update the Namespace by a SubjectAccessReview.
+func (v *SharedVPCValidator) isAuthorized(ctx context.Context, req admission.Request, ns string) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(req.UserInfo.Extra))
+	for k, val := range req.UserInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(val)
+	}
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			UID:    req.UserInfo.UID,
+			Groups: req.UserInfo.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:     "update",
+				Resource: "namespaces",
+				Name:     ns,
+			},
+		},
+	}
+	if err := v.Client.Create(ctx, sar); err != nil {
+		log.Error(err, "Failed to create SubjectAccessReview", "user", req.UserInfo.Username, "Namespace", ns)
+		return false, err
+	}
+	return sar.Status.Allowed, nil
+}
+
+func (v *SharedVPCValidator) getNamespace(ctx context.Context, name string) (*v1.Namespace, error) {
+	ns := &v1.Namespace{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+// listExistingNamespaces returns the Namespaces in the list which still exist.
+func (v *SharedVPCValidator) listExistingNamespaces(ctx context.Context, names []string) ([]string, error) {
+	var existing []string
+	for _, name := range names {
+		if _, err := v.getNamespace(ctx, name); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		existing = append(existing, name)
+	}
+	return existing, nil
+}
+
+func getAnnotatedSharedNamespace(ns *v1.Namespace) string {
+	if ns == nil {
+		return ""
+	}
+	return ns.Annotations[servicecommon.AnnotationSharedVPCNamespace]
+}
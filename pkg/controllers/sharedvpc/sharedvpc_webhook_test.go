@@ -0,0 +1,189 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package sharedvpc
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
+)
+
+func TestSharedVPCValidator_Handle(t *testing.T) {
+	scheme := clientgoscheme.Scheme
+	v1alpha1.AddToScheme(scheme)
+	vpcService := &vpc.VPCService{}
+	namespaces := []client.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-owner"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-with-vpc"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-annotated", Annotations: map[string]string{servicecommon.AnnotationSharedVPCNamespace: "ns-other"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-other"}},
+		&v1alpha1.SharedVPC{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-other"},
+			Spec:       v1alpha1.SharedVPCSpec{OwnerNamespace: "ns-other", ConsumerNamespaces: []string{"ns-other-1"}},
+		},
+	}
+	v := &SharedVPCValidator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespaces...).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if sar, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+					sar.Status.Allowed = sar.Spec.User == "admin"
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build(),
+		decoder:    admission.NewDecoder(scheme),
+		vpcService: vpcService,
+	}
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(vpcService), "GetVPCsByNamespace", func(_ *vpc.VPCService, ns string) []*model.Vpc {
+		if ns == "ns-with-vpc" {
+			return []*model.Vpc{{}}
+		}
+		return nil
+	})
+	defer patches.Reset()
+
+	newRequest := func(operation admissionv1.Operation, user string, oldSpec, newSpec v1alpha1.SharedVPCSpec) admission.Request {
+		oldObj, _ := json.Marshal(&v1alpha1.SharedVPC{ObjectMeta: metav1.ObjectMeta{Name: "shared-1"}, Spec: oldSpec})
+		newObj, _ := json.Marshal(&v1alpha1.SharedVPC{ObjectMeta: metav1.ObjectMeta{Name: "shared-1"}, Spec: newSpec})
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			Name:      "shared-1",
+			UserInfo:  authenticationv1.UserInfo{Username: user},
+			Object:    runtime.RawExtension{Raw: newObj},
+			OldObject: runtime.RawExtension{Raw: oldObj},
+		}}
+	}
+	spec := func(owner string, consumers ...string) v1alpha1.SharedVPCSpec {
+		return v1alpha1.SharedVPCSpec{OwnerNamespace: owner, ConsumerNamespaces: consumers}
+	}
+
+	tests := []struct {
+		name    string
+		req     admission.Request
+		allowed bool
+		msg     string
+	}{
+		{
+			name:    "Create",
+			req:     newRequest(admissionv1.Create, "admin", v1alpha1.SharedVPCSpec{}, spec("ns-owner", "ns-1", "ns-new")),
+			allowed: true,
+		},
+		{
+			name: "CreateUnauthorized",
+			req:  newRequest(admissionv1.Create, "user", v1alpha1.SharedVPCSpec{}, spec("ns-owner", "ns-1")),
+			msg:  "user user is not allowed to update Namespace ns-owner",
+		},
+		{
+			name: "CreateOwnerNotFound",
+			req:  newRequest(admissionv1.Create, "admin", v1alpha1.SharedVPCSpec{}, spec("ns-missing", "ns-1")),
+			msg:  "owner Namespace ns-missing of SharedVPC shared-1 does not exist",
+		},
+		{
+			name: "CreateOwnerIsConsumer",
+			req:  newRequest(admissionv1.Create, "admin", v1alpha1.SharedVPCSpec{}, spec("ns-owner", "ns-owner")),
+			msg:  "owner Namespace ns-owner cannot be a consumer of its own VPC",
+		},
+		{
+			name: "CreateDuplicatedConsumer",
+			req:  newRequest(admissionv1.Create, "admin", v1alpha1.SharedVPCSpec{}, spec("ns-owner", "ns-1", "ns-1")),
+			msg:  "consumer Namespace ns-1 is duplicated",
+		},
+		{
+			name: "CreateOwnerAlreadyOwns",
+			req:  newRequest(admissionv1.Create, "admin", v1alpha1.SharedVPCSpec{}, spec("ns-other", "ns-1")),
+			msg:  "Namespace ns-other already owns SharedVPC shared-other",
+		},
+		{
+			name: "CreateCycle",
+			req:  newRequest(admissionv1.Create, "admin", v1alpha1.SharedVPCSpec{}, spec("ns-owner", "ns-other")),
+			msg:  "consumer Namespace ns-other is the owner of SharedVPC shared-other",
+		},
+		{
+			name: "CreateConsumerOfAnother",
+			req:  newRequest(admissionv1.Create, "admin", v1alpha1.SharedVPCSpec{}, spec("ns-owner", "ns-other-1")),
+			msg:  "consumer Namespace ns-other-1 is already a consumer of SharedVPC shared-other",
+		},
+		{
+			name: "CreateConsumerAnnotated",
+			req:  newRequest(admissionv1.Create, "admin", v1alpha1.SharedVPCSpec{}, spec("ns-owner", "ns-annotated")),
+			msg:  "consumer Namespace ns-annotated shares the VPC of Namespace ns-other",
+		},
+		{
+			name: "CreateConsumerWithVPC",
+			req:  newRequest(admissionv1.Create, "admin", v1alpha1.SharedVPCSpec{}, spec("ns-owner", "ns-with-vpc")),
+			msg:  "consumer Namespace ns-with-vpc already has its own VPC",
+		},
+		{
+			name:    "UpdateAddConsumer",
+			req:     newRequest(admissionv1.Update, "admin", spec("ns-owner", "ns-1"), spec("ns-owner", "ns-1", "ns-new")),
+			allowed: true,
+		},
+		{
+			name:    "UpdateSpecUnchanged",
+			req:     newRequest(admissionv1.Update, "user", spec("ns-owner", "ns-1"), spec("ns-owner", "ns-1")),
+			allowed: true,
+		},
+		{
+			name: "UpdateOwner",
+			req:  newRequest(admissionv1.Update, "admin", spec("ns-owner", "ns-1"), spec("ns-1")),
+			msg:  "ownerNamespace of SharedVPC shared-1 is immutable",
+		},
+		{
+			name: "UpdateRemoveExistingConsumer",
+			req:  newRequest(admissionv1.Update, "admin", spec("ns-owner", "ns-1"), spec("ns-owner")),
+			msg:  "consumer Namespace(s) ns-1 of SharedVPC shared-1 still exist and cannot be removed",
+		},
+		{
+			name:    "UpdateRemoveDeletedConsumer",
+			req:     newRequest(admissionv1.Update, "user", spec("ns-owner", "ns-1", "ns-deleted"), spec("ns-owner", "ns-1")),
+			allowed: true,
+		},
+		{
+			name: "UpdateAddConsumerUnauthorized",
+			req:  newRequest(admissionv1.Update, "user", spec("ns-owner", "ns-1"), spec("ns-owner", "ns-1", "ns-new")),
+			msg:  "user user is not allowed to update Namespace ns-new",
+		},
+		{
+			name: "DeleteWithConsumers",
+			req:  newRequest(admissionv1.Delete, "admin", spec("ns-owner", "ns-1", "ns-deleted"), v1alpha1.SharedVPCSpec{}),
+			msg:  "SharedVPC shared-1 is used by consumer Namespace(s) ns-1 and cannot be deleted",
+		},
+		{
+			name:    "DeleteWithoutConsumers",
+			req:     newRequest(admissionv1.Delete, "admin", spec("ns-owner", "ns-deleted"), v1alpha1.SharedVPCSpec{}),
+			allowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := v.Handle(context.TODO(), tt.req)
+			assert.Equal(t, tt.allowed, resp.Allowed)
+			if tt.msg != "" {
+				assert.Contains(t, resp.Result.Message, tt.msg)
+			}
+		})
+	}
+}
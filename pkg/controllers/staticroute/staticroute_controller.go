@@ -18,6 +18,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 
@@ -179,11 +181,20 @@ func (r *StaticRouteReconciler) setupWithManager(mgr ctrl.Manager) error {
 }
 
 // Start setup manager and launch GC
-func (r *StaticRouteReconciler) Start(mgr ctrl.Manager) error {
+func (r *StaticRouteReconciler) Start(mgr ctrl.Manager, hookServer webhook.Server) error {
 	err := r.setupWithManager(mgr)
 	if err != nil {
 		return err
 	}
+	if hookServer != nil {
+		hookServer.Register("/validate-crd-nsx-vmware-com-v1alpha1-staticroute",
+			&webhook.Admission{
+				Handler: &StaticRouteValidator{
+					Client:  mgr.GetClient(),
+					decoder: admission.NewDecoder(mgr.GetScheme()),
+				},
+			})
+	}
 	return nil
 }
 
@@ -229,7 +240,7 @@ func (r *StaticRouteReconciler) CollectGarbage(ctx context.Context) {
 	}
 }
 
-func StartStaticRouteController(mgr ctrl.Manager, staticRouteService *staticroute.StaticRouteService) {
+func StartStaticRouteController(mgr ctrl.Manager, staticRouteService *staticroute.StaticRouteService, hookServer webhook.Server) {
 	staticRouteReconcile := StaticRouteReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -237,7 +248,7 @@ func StartStaticRouteController(mgr ctrl.Manager, staticRouteService *staticrout
 	}
 	staticRouteReconcile.Service = staticRouteService
 	staticRouteReconcile.StatusUpdater = common.NewStatusUpdater(staticRouteReconcile.Client, staticRouteReconcile.Service.NSXConfig, staticRouteReconcile.Recorder, MetricResTypeStaticRoute, "StaticRoute", "StaticRoute")
-	if err := staticRouteReconcile.Start(mgr); err != nil {
+	if err := staticRouteReconcile.Start(mgr, hookServer); err != nil {
 		log.Error(err, "failed to create controller", "controller", "StaticRoute")
 		os.Exit(1)
 	}
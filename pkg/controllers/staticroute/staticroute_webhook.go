@@ -0,0 +1,45 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package staticroute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+)
+
+// +kubebuilder:webhook:path=/validate-crd-nsx-vmware-com-v1alpha1-staticroute,mutating=false,failurePolicy=fail,sideEffects=None,groups=crd.nsx.vmware.com,resources=staticroutes,verbs=create,versions=v1alpha1,name=staticroute.validating.crd.nsx.vmware.com,admissionReviewVersions=v1
+
+// StaticRouteValidator denies the StaticRoute exceeding the NetworkQuotas in the Namespace.
+type StaticRouteValidator struct {
+	Client  client.Client
+	decoder admission.Decoder
+}
+
+// Handle handles admission requests.
+func (v *StaticRouteValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+	staticRoute := &v1alpha1.StaticRoute{}
+	if err := v.decoder.Decode(req, staticRoute); err != nil {
+		log.Error(err, "error while decoding StaticRoute", "StaticRoute", req.Namespace+"/"+req.Name)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	msg, err := common.CheckNetworkQuota(ctx, v.Client, nil, staticRoute.Namespace, common.NetworkQuotaUsage{StaticRoutes: 1})
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if msg != "" {
+		return admission.Denied(fmt.Sprintf("StaticRoute %s/%s is denied: %s", staticRoute.Namespace, staticRoute.Name, msg))
+	}
+	return admission.Allowed("")
+}
@@ -0,0 +1,81 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package staticroute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+func TestStaticRouteValidator_Handle(t *testing.T) {
+	raw, _ := json.Marshal(&v1alpha1.StaticRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "route2"},
+		Spec:       v1alpha1.StaticRouteSpec{Network: "10.10.0.0/16"},
+	})
+	existingRoute := &v1alpha1.StaticRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "route1"}}
+	newQuota := func(limit int64) *v1alpha1.NetworkQuota {
+		return &v1alpha1.NetworkQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "quota1"},
+			Spec:       v1alpha1.NetworkQuotaSpec{Hard: v1alpha1.NetworkQuotaResources{StaticRoutes: common.Int64(limit)}},
+		}
+	}
+	tests := []struct {
+		name  string
+		op    admissionv1.Operation
+		quota *v1alpha1.NetworkQuota
+		want  admission.Response
+	}{
+		{
+			name:  "update",
+			op:    admissionv1.Update,
+			quota: newQuota(0),
+			want:  admission.Allowed(""),
+		},
+		{
+			name: "create without NetworkQuota",
+			op:   admissionv1.Create,
+			want: admission.Allowed(""),
+		},
+		{
+			name:  "create within quota",
+			op:    admissionv1.Create,
+			quota: newQuota(2),
+			want:  admission.Allowed(""),
+		},
+		{
+			name:  "create exceeding quota",
+			op:    admissionv1.Create,
+			quota: newQuota(1),
+			want:  admission.Denied("StaticRoute ns1/route2 is denied: NetworkQuota quota1 exceeded: staticRoutes (used 1, requested 1, limit 1)"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := clientgoscheme.Scheme
+			v1alpha1.AddToScheme(scheme)
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingRoute)
+			if tt.quota != nil {
+				builder.WithObjects(tt.quota)
+			}
+			v := &StaticRouteValidator{
+				Client:  builder.Build(),
+				decoder: admission.NewDecoder(scheme),
+			}
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: tt.op, Object: runtime.RawExtension{Raw: raw}}}
+			assert.Equal(t, tt.want, v.Handle(context.TODO(), req))
+		})
+	}
+}
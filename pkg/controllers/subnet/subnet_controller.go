@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
@@ -28,7 +30,9 @@ import (
 	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetbinding"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpc"
 	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
 var (
@@ -118,6 +122,10 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 	r.StatusUpdater.IncreaseUpdateTotal()
 
+	if subnetCR.Spec.NSXSubnetPath != "" {
+		return r.importSubnet(ctx, subnetCR)
+	}
+
 	// Spec mutation check and update if necessary
 	specChanged := false
 	if subnetCR.Spec.AccessMode == "" {
@@ -125,7 +133,7 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		specChanged = true
 	}
 
-	if subnetCR.Spec.IPv4SubnetSize == 0 {
+	if subnetCR.Spec.IPv4SubnetSize == 0 && util.HasIPFamily(subnetCR.Spec.IPFamilies, v1alpha1.IPFamilyIPv4) {
 		vpcNetworkConfig := r.VPCService.GetVPCNetworkConfigByNamespace(subnetCR.Namespace)
 		if vpcNetworkConfig == nil {
 			err := fmt.Errorf("VPCNetworkConfig not found for Subnet CR")
@@ -135,6 +143,10 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		subnetCR.Spec.IPv4SubnetSize = vpcNetworkConfig.DefaultSubnetSize
 		specChanged = true
 	}
+	if subnetCR.Spec.IPv6SubnetSize == 0 && util.HasIPFamily(subnetCR.Spec.IPFamilies, v1alpha1.IPFamilyIPv6) {
+		subnetCR.Spec.IPv6SubnetSize = servicecommon.IPv6SubnetSize
+		specChanged = true
+	}
 	if specChanged {
 		if err := r.Client.Update(ctx, subnetCR); err != nil {
 			r.StatusUpdater.UpdateFail(ctx, subnetCR, err, "Failed to update Subnet", setSubnetReadyStatusFalse)
@@ -154,6 +166,10 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		log.Info("No VPC info found, requeueing", "Namespace", req.Namespace)
 		return ResultRequeueAfter10sec, nil
 	}
+	if err := r.recreateSubnetForAccessMode(ctx, subnetCR, vpcInfoList[0]); err != nil {
+		r.StatusUpdater.UpdateFail(ctx, subnetCR, err, "Failed to change Subnet access mode", setSubnetReadyStatusFalse)
+		return ResultRequeue, err
+	}
 	// Create or update the subnet in NSX
 	if _, err := r.SubnetService.CreateOrUpdateSubnet(subnetCR, vpcInfoList[0], tags); err != nil {
 		if errors.As(err, &nsxutil.ExceedTagsError{}) {
@@ -172,6 +188,65 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	return ctrl.Result{}, nil
 }
 
+// importSubnet adopts the existing NSX Subnet in the pre-created VPC of the Namespace for the Subnet CR,
+// the spec of the Subnet CR is not applied to the NSX Subnet, only the status is updated from it.
+func (r *SubnetReconciler) importSubnet(ctx context.Context, subnetCR *v1alpha1.Subnet) (ctrl.Result, error) {
+	vpcNetworkConfig := r.VPCService.GetVPCNetworkConfigByNamespace(subnetCR.Namespace)
+	if vpcNetworkConfig == nil {
+		err := fmt.Errorf("VPCNetworkConfig not found for Subnet CR")
+		r.StatusUpdater.UpdateFail(ctx, subnetCR, err, "Failed to find VPCNetworkConfig", setSubnetReadyStatusFalse)
+		return ResultRequeue, err
+	}
+	if !vpc.IsPreCreatedVPC(*vpcNetworkConfig) || !strings.HasPrefix(subnetCR.Spec.NSXSubnetPath, vpcNetworkConfig.VPCPath+"/subnets/") {
+		// No need to requeue as the nsxSubnetPath is immutable.
+		err := fmt.Errorf("NSX Subnet %s is not in the pre-created VPC of Namespace %s", subnetCR.Spec.NSXSubnetPath, subnetCR.Namespace)
+		r.StatusUpdater.UpdateFail(ctx, subnetCR, err, "Failed to import Subnet", setSubnetReadyStatusFalse, err.Error())
+		return ResultNormal, nil
+	}
+	tags := r.SubnetService.GenerateSubnetNSTags(subnetCR)
+	if tags == nil {
+		log.Error(nil, "Failed to generate Subnet tags", "Subnet", subnetCR.Namespace+"/"+subnetCR.Name)
+		return ResultRequeue, errors.New("failed to generate Subnet tags")
+	}
+	if _, err := r.SubnetService.ImportSubnet(subnetCR, tags); err != nil {
+		r.StatusUpdater.UpdateFail(ctx, subnetCR, err, "Failed to import Subnet", setSubnetReadyStatusFalse, err.Error())
+		return ResultRequeue, err
+	}
+	if err := r.updateSubnetStatus(subnetCR); err != nil {
+		r.StatusUpdater.UpdateFail(ctx, subnetCR, err, "Failed to update Subnet status", setSubnetReadyStatusFalse)
+		return ResultRequeue, err
+	}
+	r.StatusUpdater.UpdateSuccess(ctx, subnetCR, setSubnetReadyStatusTrue)
+	return ResultNormal, nil
+}
+
+// recreateSubnetForAccessMode deletes the NSX Subnet if its access mode is changed, the NSX Subnet is
+// created again with the new access mode afterwards. The NSX Subnet is not deleted if the IP blocks of
+// the new access mode are not available, or it is still used by SubnetPorts or SubnetConnectionBindingMaps.
+func (r *SubnetReconciler) recreateSubnetForAccessMode(ctx context.Context, subnetCR *v1alpha1.Subnet, vpcInfo servicecommon.VPCResourceInfo) error {
+	for _, nsxSubnet := range r.SubnetService.ListSubnetCreatedBySubnet(string(subnetCR.UID)) {
+		if !subnet.IsAccessModeChanged(nsxSubnet, subnetCR.Spec.AccessMode) {
+			continue
+		}
+		if err := r.VPCService.ValidateAccessModeIPBlocks(subnetCR.Namespace, vpcInfo, string(subnetCR.Spec.AccessMode)); err != nil {
+			return err
+		}
+		if portNums := len(r.SubnetPortService.GetPortsOfSubnet(*nsxSubnet.Id)); portNums > 0 {
+			return fmt.Errorf("cannot change access mode of Subnet %s, still attached by %d port(s)", *nsxSubnet.Id, portNums)
+		}
+		if bindings := r.getNSXSubnetBindingsBySubnet(string(subnetCR.UID)); len(bindings) > 0 {
+			return fmt.Errorf("cannot change access mode of Subnet %s, still used by SubnetConnectionBindingMap %s", *nsxSubnet.Id, bindings[0].GetName())
+		}
+		setSubnetAccessModeUpdatingStatus(r.Client, ctx, subnetCR, metav1.Now(), *nsxSubnet.AccessMode)
+		if err := r.SubnetService.DeleteSubnet(*nsxSubnet); err != nil {
+			return err
+		}
+		r.SubnetPortService.DeletePortCount(*nsxSubnet.Path)
+		log.Info("Deleted NSX Subnet to change the access mode", "ID", *nsxSubnet.Id, "oldAccessMode", *nsxSubnet.AccessMode, "accessMode", subnetCR.Spec.AccessMode)
+	}
+	return nil
+}
+
 func (r *SubnetReconciler) deleteSubnetByID(subnetID string) error {
 	nsxSubnets := r.SubnetService.SubnetStore.GetByIndex(servicecommon.TagScopeSubnetCRUID, subnetID)
 	return r.deleteSubnets(nsxSubnets)
@@ -206,7 +281,11 @@ func (r *SubnetReconciler) deleteSubnetByName(name, ns string) error {
 
 func (r *SubnetReconciler) updateSubnetStatus(obj *v1alpha1.Subnet) error {
 	// if the nsxSubnet is nil, GetSubnetByKey will return error: NSX subnet not found in store
-	nsxSubnet, err := r.SubnetService.GetSubnetByKey(r.SubnetService.BuildSubnetID(obj))
+	key := r.SubnetService.BuildSubnetID(obj)
+	if obj.Spec.NSXSubnetPath != "" {
+		key = path.Base(obj.Spec.NSXSubnetPath)
+	}
+	nsxSubnet, err := r.SubnetService.GetSubnetByKey(key)
 	if err != nil {
 		return fmt.Errorf("failed to get NSX Subnet from store: %v", err)
 	}
@@ -225,6 +304,8 @@ func (r *SubnetReconciler) updateSubnetStatus(obj *v1alpha1.Subnet) error {
 			obj.Status.DHCPServerAddresses = append(obj.Status.DHCPServerAddresses, *status.DhcpServerAddress)
 		}
 	}
+	ipUtilization := r.SubnetPortService.GetIPUtilization(nsxSubnet)
+	obj.Status.IPUtilization = &ipUtilization
 	return nil
 }
 
@@ -265,6 +346,19 @@ func setSubnetReadyStatusFalse(client client.Client, ctx context.Context, obj cl
 	updateSubnetStatusConditions(client, ctx, subnet, newConditions)
 }
 
+func setSubnetAccessModeUpdatingStatus(client client.Client, ctx context.Context, subnet *v1alpha1.Subnet, transitionTime metav1.Time, oldAccessMode string) {
+	newConditions := []v1alpha1.Condition{
+		{
+			Type:               v1alpha1.Ready,
+			Status:             v1.ConditionFalse,
+			Message:            fmt.Sprintf("NSX Subnet is being re-created to change the access mode from %s to %s", oldAccessMode, subnet.Spec.AccessMode),
+			Reason:             "AccessModeUpdating",
+			LastTransitionTime: transitionTime,
+		},
+	}
+	updateSubnetStatusConditions(client, ctx, subnet, newConditions)
+}
+
 func (r *SubnetReconciler) setSubnetDeletionFailedStatus(ctx context.Context, subnet *v1alpha1.Subnet, transitionTime metav1.Time, msg string, reason string) {
 	newConditions := []v1alpha1.Condition{
 		{
@@ -359,8 +453,9 @@ func (r *SubnetReconciler) start(mgr ctrl.Manager, hookServer webhook.Server) er
 		hookServer.Register("/validate-crd-nsx-vmware-com-v1alpha1-subnet",
 			&webhook.Admission{
 				Handler: &SubnetValidator{
-					Client:  mgr.GetClient(),
-					decoder: admission.NewDecoder(mgr.GetScheme()),
+					Client:     mgr.GetClient(),
+					decoder:    admission.NewDecoder(mgr.GetScheme()),
+					vpcService: r.VPCService,
 				},
 			})
 	}
@@ -18,6 +18,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -172,7 +173,10 @@ func createFakeSubnetReconciler(objs []client.Object) *SubnetReconciler {
 				},
 			},
 		},
-		SubnetStore: &subnet.SubnetStore{},
+		SubnetStore: &subnet.SubnetStore{ResourceStore: common.ResourceStore{
+			Indexer:     cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{common.TagScopeSubnetCRUID: func(obj interface{}) ([]string, error) { return nil, nil }}),
+			BindingType: model.VpcSubnetBindingType(),
+		}},
 	}
 
 	subnetPortService := &subnetport.SubnetPortService{
@@ -556,6 +560,9 @@ func TestSubnetReconciler_Reconcile(t *testing.T) {
 					fakeStatus.NetworkAddress = &value
 					return []model.VpcSubnetStatus{fakeStatus}, nil
 				})
+				patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "GetIPUtilization", func(_ *subnetport.SubnetPortService, _ *model.VpcSubnet) v1alpha1.IPUtilization {
+					return v1alpha1.IPUtilization{Total: 12, Allocated: 1, Available: 11}
+				})
 				return patches
 			},
 			existingSubnetCR: createNewSubnet(),
@@ -994,3 +1001,145 @@ func patchSuccessfulReconcileSubnetWorkflow(r *SubnetReconciler, patches *gomonk
 	})
 	return patches
 }
+
+func TestSubnetReconciler_recreateSubnetForAccessMode(t *testing.T) {
+	subnetCR := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet-1", Namespace: "ns-1", UID: "subnet-uid"},
+		Spec:       v1alpha1.SubnetSpec{AccessMode: v1alpha1.AccessMode(v1alpha1.AccessModeProject)},
+	}
+	nsxSubnet := &model.VpcSubnet{
+		Id:         common.String("subnet-1"),
+		Path:       common.String("/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1"),
+		AccessMode: common.String("Private"),
+	}
+	vpcInfo := common.VPCResourceInfo{OrgID: "default", ProjectID: "project-1", VPCID: "vpc-1"}
+	testCases := []struct {
+		name          string
+		nsxSubnet     *model.VpcSubnet
+		ipBlocksErr   error
+		ports         []*model.VpcSubnetPort
+		expectErrStr  string
+		expectDeleted bool
+	}{
+		{
+			name:      "AccessMode not changed",
+			nsxSubnet: &model.VpcSubnet{Id: nsxSubnet.Id, Path: nsxSubnet.Path, AccessMode: common.String("Private_TGW")},
+		},
+		{
+			name:          "AccessMode changed",
+			nsxSubnet:     nsxSubnet,
+			expectDeleted: true,
+		},
+		{
+			name:         "No IP blocks for AccessMode",
+			nsxSubnet:    nsxSubnet,
+			ipBlocksErr:  errors.New("no private TGW IP blocks"),
+			expectErrStr: "no private TGW IP blocks",
+		},
+		{
+			name:         "Subnet with SubnetPorts",
+			nsxSubnet:    nsxSubnet,
+			ports:        []*model.VpcSubnetPort{{Id: common.String("port-1")}},
+			expectErrStr: "cannot change access mode of Subnet subnet-1, still attached by 1 port(s)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := createFakeSubnetReconciler([]client.Object{subnetCR.DeepCopy()})
+			deleted := false
+			patches := gomonkey.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+				return []*model.VpcSubnet{tc.nsxSubnet}
+			})
+			defer patches.Reset()
+			patches.ApplyMethod(reflect.TypeOf(r.VPCService), "ValidateAccessModeIPBlocks", func(_ *vpc.VPCService, _ string, _ common.VPCResourceInfo, _ string) error {
+				return tc.ipBlocksErr
+			})
+			patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "GetPortsOfSubnet", func(_ *subnetport.SubnetPortService, _ string) []*model.VpcSubnetPort {
+				return tc.ports
+			})
+			patches.ApplyPrivateMethod(reflect.TypeOf(r), "getNSXSubnetBindingsBySubnet", func(_ *SubnetReconciler, _ string) []*v1alpha1.SubnetConnectionBindingMap {
+				return nil
+			})
+			patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, _ model.VpcSubnet) error {
+				deleted = true
+				return nil
+			})
+			patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "DeletePortCount", func(_ *subnetport.SubnetPortService, _ string) {})
+
+			err := r.recreateSubnetForAccessMode(context.TODO(), subnetCR.DeepCopy(), vpcInfo)
+			if tc.expectErrStr != "" {
+				assert.ErrorContains(t, err, tc.expectErrStr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectDeleted, deleted)
+		})
+	}
+}
+
+func TestSubnetReconciler_importSubnet(t *testing.T) {
+	subnetPath := "/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1"
+	subnetCR := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet-1", Namespace: "ns-1", UID: "subnet-uid"},
+		Spec:       v1alpha1.SubnetSpec{NSXSubnetPath: subnetPath},
+	}
+	testCases := []struct {
+		name           string
+		vpcPath        string
+		importErr      error
+		expectErrStr   string
+		expectResult   ctrl.Result
+		expectImported bool
+	}{
+		{
+			name:           "Import Subnet in pre-created VPC",
+			vpcPath:        "/orgs/default/projects/project-1/vpcs/vpc-1",
+			expectImported: true,
+		},
+		{
+			name:         "Namespace with auto-created VPC",
+			expectResult: ResultNormal,
+		},
+		{
+			name:         "NSX Subnet in another VPC",
+			vpcPath:      "/orgs/default/projects/project-1/vpcs/vpc-2",
+			expectResult: ResultNormal,
+		},
+		{
+			name:         "Failed to import Subnet",
+			vpcPath:      "/orgs/default/projects/project-1/vpcs/vpc-1",
+			importErr:    errors.New("NSX Subnet is already used by Subnet subnet-2"),
+			expectErrStr: "NSX Subnet is already used by Subnet subnet-2",
+			expectResult: ResultRequeue,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := createFakeSubnetReconciler([]client.Object{subnetCR.DeepCopy()})
+			imported := false
+			patches := gomonkey.ApplyMethod(reflect.TypeOf(r.VPCService), "GetVPCNetworkConfigByNamespace", func(_ *vpc.VPCService, _ string) *common.VPCNetworkConfigInfo {
+				return &common.VPCNetworkConfigInfo{VPCPath: tc.vpcPath}
+			})
+			defer patches.Reset()
+			patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "GenerateSubnetNSTags", func(_ *subnet.SubnetService, _ client.Object) []model.Tag {
+				return []model.Tag{{Scope: common.String(common.TagScopeVMNamespace), Tag: common.String("ns-1")}}
+			})
+			patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "ImportSubnet", func(_ *subnet.SubnetService, _ *v1alpha1.Subnet, _ []model.Tag) (*model.VpcSubnet, error) {
+				imported = tc.importErr == nil
+				return &model.VpcSubnet{Id: common.String("subnet-1"), Path: common.String(subnetPath)}, tc.importErr
+			})
+			patches.ApplyPrivateMethod(reflect.TypeOf(r), "updateSubnetStatus", func(_ *SubnetReconciler, _ *v1alpha1.Subnet) error {
+				return nil
+			})
+
+			result, err := r.importSubnet(context.TODO(), subnetCR.DeepCopy())
+			if tc.expectErrStr != "" {
+				assert.ErrorContains(t, err, tc.expectErrStr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectResult, result)
+			assert.Equal(t, tc.expectImported, imported)
+		})
+	}
+}
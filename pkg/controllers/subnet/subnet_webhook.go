@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
@@ -18,11 +22,12 @@ var NSXOperatorSA = "system:serviceaccount:vmware-system-nsx:ncp-svc-account"
 // Create validator instead of using the existing one in controller-runtime because the existing one can't
 // inspect admission.Request in Handle function.
 
-// +kubebuilder:webhook:path=/validate-crd-nsx-vmware-com-v1alpha1-subnet,mutating=false,failurePolicy=fail,sideEffects=None,groups=crd.nsx.vmware.com,resources=subnets,verbs=delete,versions=v1alpha1,name=subnet.validating.crd.nsx.vmware.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-crd-nsx-vmware-com-v1alpha1-subnet,mutating=false,failurePolicy=fail,sideEffects=None,groups=crd.nsx.vmware.com,resources=subnets,verbs=create;update;delete,versions=v1alpha1,name=subnet.validating.crd.nsx.vmware.com,admissionReviewVersions=v1
 
 type SubnetValidator struct {
-	Client  client.Client
-	decoder admission.Decoder
+	Client     client.Client
+	decoder    admission.Decoder
+	vpcService servicecommon.VPCServiceProvider
 }
 
 // Handle handles admission requests.
@@ -42,9 +47,57 @@ func (v *SubnetValidator) Handle(ctx context.Context, req admission.Request) adm
 	log.V(1).Info("Handling request", "user", req.UserInfo.Username, "operation", req.Operation)
 	switch req.Operation {
 	case admissionv1.Create:
+		if err := validateImportedSubnet(subnet); err != nil {
+			return admission.Denied(fmt.Sprintf("Subnet %s/%s has invalid spec: %v", subnet.Namespace, subnet.Name, err))
+		}
 		if subnet.Spec.IPv4SubnetSize != 0 && !util.IsPowerOfTwo(subnet.Spec.IPv4SubnetSize) {
 			return admission.Denied(fmt.Sprintf("Subnet %s/%s has invalid size %d, which must be power of 2", subnet.Namespace, subnet.Name, subnet.Spec.IPv4SubnetSize))
 		}
+		if err := util.ValidateSubnetIPFamilies(subnet.Spec.IPFamilies, subnet.Spec.IPv4SubnetSize, subnet.Spec.IPv6SubnetSize, subnet.Spec.IPAddresses); err != nil {
+			return admission.Denied(fmt.Sprintf("Subnet %s/%s has invalid IP settings: %v", subnet.Namespace, subnet.Name, err))
+		}
+		if err := util.ValidateSubnetDHCPConfig(subnet.Spec.SubnetDHCPConfig); err != nil {
+			return admission.Denied(fmt.Sprintf("Subnet %s/%s has invalid DHCP configuration: %v", subnet.Namespace, subnet.Name, err))
+		}
+		if err := util.ValidateReservedIPRanges(subnet.Spec.ReservedIPRanges, subnetCIDRs(subnet)); err != nil {
+			return admission.Denied(fmt.Sprintf("Subnet %s/%s has invalid reserved IP ranges: %v", subnet.Namespace, subnet.Name, err))
+		}
+		// The Subnets created by nsx-operator are limited too, so that they are counted against the NetworkQuotas.
+		requested := common.SubnetQuotaUsage(subnet, common.DefaultSubnetSizeOfNamespace(v.vpcService, subnet.Namespace))
+		msg, err := common.CheckNetworkQuota(ctx, v.Client, v.vpcService, subnet.Namespace, requested)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if msg != "" {
+			return admission.Denied(fmt.Sprintf("Subnet %s/%s is denied: %s", subnet.Namespace, subnet.Name, msg))
+		}
+	case admissionv1.Update:
+		oldSubnet := &v1alpha1.Subnet{}
+		if err := v.decoder.DecodeRaw(req.OldObject, oldSubnet); err != nil {
+			log.Error(err, "error while decoding old Subnet", "Subnet", req.Namespace+"/"+req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if err := validateImportedSubnet(subnet); err != nil {
+			return admission.Denied(fmt.Sprintf("Subnet %s/%s has invalid spec: %v", subnet.Namespace, subnet.Name, err))
+		}
+		if util.IsAccessModeChanged(oldSubnet.Spec.AccessMode, subnet.Spec.AccessMode) {
+			if len(subnet.Spec.IPAddresses) > 0 {
+				return admission.Denied(fmt.Sprintf("accessMode of Subnet %s/%s with ipAddresses cannot be changed", subnet.Namespace, subnet.Name))
+			}
+			hasSubnetPort, err := v.checkSubnetPort(ctx, subnet.Namespace, subnet.Name)
+			if err != nil {
+				return admission.Errored(http.StatusBadRequest, err)
+			}
+			if hasSubnetPort {
+				return admission.Denied(fmt.Sprintf("accessMode of Subnet %s/%s with SubnetPorts cannot be changed", subnet.Namespace, subnet.Name))
+			}
+		}
+		if err := util.ValidateSubnetDHCPConfig(subnet.Spec.SubnetDHCPConfig); err != nil {
+			return admission.Denied(fmt.Sprintf("Subnet %s/%s has invalid DHCP configuration: %v", subnet.Namespace, subnet.Name, err))
+		}
+		if err := util.ValidateReservedIPRanges(subnet.Spec.ReservedIPRanges, subnetCIDRs(subnet)); err != nil {
+			return admission.Denied(fmt.Sprintf("Subnet %s/%s has invalid reserved IP ranges: %v", subnet.Namespace, subnet.Name, err))
+		}
 	case admissionv1.Delete:
 		if req.UserInfo.Username != NSXOperatorSA {
 			hasSubnetPort, err := v.checkSubnetPort(ctx, subnet.Namespace, subnet.Name)
@@ -59,6 +112,49 @@ func (v *SubnetValidator) Handle(ctx context.Context, req admission.Request) adm
 	return admission.Allowed("")
 }
 
+// subnetCIDRs returns the CIDRs specified for the Subnet, or the CIDRs allocated to it if none is specified.
+func subnetCIDRs(subnet *v1alpha1.Subnet) []string {
+	if len(subnet.Spec.IPAddresses) > 0 {
+		return subnet.Spec.IPAddresses
+	}
+	return subnet.Status.NetworkAddresses
+}
+
+// validateImportedSubnet checks no other field is set in the spec of the Subnet importing an existing
+// NSX Subnet, as the NSX Subnet is adopted as it is.
+func validateImportedSubnet(subnet *v1alpha1.Subnet) error {
+	spec := subnet.Spec
+	if spec.NSXSubnetPath == "" {
+		return nil
+	}
+	var fields []string
+	if spec.IPv4SubnetSize != 0 {
+		fields = append(fields, "ipv4SubnetSize")
+	}
+	if len(spec.IPFamilies) > 0 {
+		fields = append(fields, "ipFamilies")
+	}
+	if spec.IPv6SubnetSize != 0 {
+		fields = append(fields, "ipv6SubnetSize")
+	}
+	if spec.AccessMode != "" {
+		fields = append(fields, "accessMode")
+	}
+	if len(spec.IPAddresses) > 0 {
+		fields = append(fields, "ipAddresses")
+	}
+	if len(spec.ReservedIPRanges) > 0 {
+		fields = append(fields, "reservedIPRanges")
+	}
+	if !reflect.DeepEqual(spec.SubnetDHCPConfig, v1alpha1.SubnetDHCPConfig{}) {
+		fields = append(fields, "subnetDHCPConfig")
+	}
+	if len(fields) > 0 {
+		return fmt.Errorf("%s cannot be set with nsxSubnetPath", strings.Join(fields, ", "))
+	}
+	return nil
+}
+
 func (v *SubnetValidator) checkSubnetPort(ctx context.Context, ns string, subnetName string) (bool, error) {
 	crdSubnetPorts := &v1alpha1.SubnetPortList{}
 	err := v.Client.List(ctx, crdSubnetPorts, client.InNamespace(ns))
@@ -14,10 +14,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	mock_client "github.com/vmware-tanzu/nsx-operator/pkg/mock/controller-runtime/client"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 )
 
 func TestSubnetValidator_Handle(t *testing.T) {
@@ -51,6 +53,93 @@ func TestSubnetValidator_Handle(t *testing.T) {
 			IPv4SubnetSize: 24,
 		},
 	})
+	req3, _ := json.Marshal(&v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-3",
+			Name:      "subnet-3",
+		},
+		Spec: v1alpha1.SubnetSpec{
+			IPv4SubnetSize: 16,
+			IPFamilies:     []v1alpha1.IPFamily{v1alpha1.IPFamilyIPv6},
+		},
+	})
+	req4, _ := json.Marshal(&v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-4",
+			Name:      "subnet-4",
+		},
+		Spec: v1alpha1.SubnetSpec{
+			IPv4SubnetSize: 16,
+			IPv6SubnetSize: 64,
+			IPFamilies:     []v1alpha1.IPFamily{v1alpha1.IPFamilyIPv4, v1alpha1.IPFamilyIPv6},
+			IPAddresses:    []string{"10.0.0.0/28", "2001:db8::/64"},
+		},
+	})
+	req5, _ := json.Marshal(&v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-5",
+			Name:      "subnet-5",
+		},
+		Spec: v1alpha1.SubnetSpec{
+			SubnetDHCPConfig: v1alpha1.SubnetDHCPConfig{
+				Mode: v1alpha1.DHCPConfigMode(v1alpha1.DHCPConfigModeRelay),
+				DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{
+					DNSServers: []string{"10.0.0.2"},
+				},
+			},
+		},
+	})
+	req6, _ := json.Marshal(&v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-6",
+			Name:      "subnet-6",
+		},
+		Spec: v1alpha1.SubnetSpec{
+			ReservedIPRanges: []string{"10.0.0.20-10.0.0.10"},
+		},
+	})
+	req10, _ := json.Marshal(&v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-6",
+			Name:      "subnet-6",
+		},
+		Spec: v1alpha1.SubnetSpec{
+			ReservedIPRanges: []string{"10.0.0.10-10.0.0.20"},
+		},
+		Status: v1alpha1.SubnetStatus{
+			NetworkAddresses: []string{"10.0.0.0/28"},
+		},
+	})
+	req7, _ := json.Marshal(&v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "subnet-1",
+		},
+		Spec: v1alpha1.SubnetSpec{
+			IPv4SubnetSize: 16,
+			AccessMode:     v1alpha1.AccessMode(v1alpha1.AccessModePublic),
+		},
+	})
+	req8, _ := json.Marshal(&v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-8",
+			Name:      "subnet-8",
+		},
+		Spec: v1alpha1.SubnetSpec{
+			NSXSubnetPath: "/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1",
+		},
+	})
+	req9, _ := json.Marshal(&v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-8",
+			Name:      "subnet-8",
+		},
+		Spec: v1alpha1.SubnetSpec{
+			NSXSubnetPath:  "/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1",
+			IPv4SubnetSize: 16,
+			AccessMode:     v1alpha1.AccessMode(v1alpha1.AccessModePublic),
+		},
+	})
 	type args struct {
 		req admission.Request
 	}
@@ -139,8 +228,134 @@ func TestSubnetValidator_Handle(t *testing.T) {
 				Operation: admissionv1.Create,
 				Object:    runtime.RawExtension{Raw: req1},
 			}}},
+			prepareFunc: func(t *testing.T) {
+				k8sClient.EXPECT().List(gomock.Any(), &v1alpha1.NetworkQuotaList{}, gomock.Any()).Return(nil)
+			},
+			want: admission.Allowed(""),
+		},
+		{
+			name: "CreateSubnet with IPv4SubnetSize for IPv6 only",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: req3},
+			}}},
+			want: admission.Denied("Subnet ns-3/subnet-3 has invalid IP settings: ipv4SubnetSize can only be set with IPv4 family"),
+		},
+		{
+			name: "CreateDualStackSubnet",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: req4},
+			}}},
+			prepareFunc: func(t *testing.T) {
+				k8sClient.EXPECT().List(gomock.Any(), &v1alpha1.NetworkQuotaList{}, gomock.Any()).Return(nil)
+			},
+			want: admission.Allowed(""),
+		},
+		{
+			name: "CreateSubnet with DHCP server config for DHCPRelay mode",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: req5},
+			}}},
+			want: admission.Denied("Subnet ns-5/subnet-5 has invalid DHCP configuration: dhcpServerAdditionalConfig is only applicable for DHCPServer mode"),
+		},
+		{
+			name: "UpdateSubnet with DHCP server config for DHCPRelay mode",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				Object:    runtime.RawExtension{Raw: req5},
+				OldObject: runtime.RawExtension{Raw: req5},
+			}}},
+			want: admission.Denied("Subnet ns-5/subnet-5 has invalid DHCP configuration: dhcpServerAdditionalConfig is only applicable for DHCPServer mode"),
+		},
+		{
+			name: "CreateSubnet with invalid reserved IP ranges",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: req6},
+			}}},
+			want: admission.Denied("Subnet ns-6/subnet-6 has invalid reserved IP ranges: invalid IP range 10.0.0.20-10.0.0.10, the start IP is greater than the end IP"),
+		},
+		{
+			name: "UpdateSubnet with reserved IP ranges out of the Subnet CIDR",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				Object:    runtime.RawExtension{Raw: req10},
+				OldObject: runtime.RawExtension{Raw: req10},
+			}}},
+			want: admission.Denied("Subnet ns-6/subnet-6 has invalid reserved IP ranges: IP range 10.0.0.10-10.0.0.20 is not in the Subnet CIDRs 10.0.0.0/28"),
+		},
+		{
+			name: "CreateSubnet importing NSX Subnet",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: req8},
+			}}},
+			prepareFunc: func(t *testing.T) {
+				k8sClient.EXPECT().List(gomock.Any(), &v1alpha1.NetworkQuotaList{}, gomock.Any()).Return(nil)
+			},
+			want: admission.Allowed(""),
+		},
+		{
+			name: "CreateSubnet importing NSX Subnet with other fields",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: req9},
+			}}},
+			want: admission.Denied("Subnet ns-8/subnet-8 has invalid spec: ipv4SubnetSize, accessMode cannot be set with nsxSubnetPath"),
+		},
+		{
+			name: "UpdateSubnet importing NSX Subnet with other fields",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				Object:    runtime.RawExtension{Raw: req9},
+				OldObject: runtime.RawExtension{Raw: req8},
+			}}},
+			want: admission.Denied("Subnet ns-8/subnet-8 has invalid spec: ipv4SubnetSize, accessMode cannot be set with nsxSubnetPath"),
+		},
+		{
+			name: "UpdateSubnet",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				Object:    runtime.RawExtension{Raw: req1},
+				OldObject: runtime.RawExtension{Raw: req1},
+			}}},
 			want: admission.Allowed(""),
 		},
+		{
+			name: "UpdateSubnet AccessMode",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				Object:    runtime.RawExtension{Raw: req7},
+				OldObject: runtime.RawExtension{Raw: req1},
+			}}},
+			prepareFunc: func(t *testing.T) {
+				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: admission.Allowed(""),
+		},
+		{
+			name: "UpdateSubnet AccessMode with SubnetPorts",
+			args: args{req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				Object:    runtime.RawExtension{Raw: req7},
+				OldObject: runtime.RawExtension{Raw: req1},
+			}}},
+			prepareFunc: func(t *testing.T) {
+				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Do(func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					a := list.(*v1alpha1.SubnetPortList)
+					a.Items = append(a.Items, v1alpha1.SubnetPort{
+						ObjectMeta: metav1.ObjectMeta{Name: "subnetport-1", Namespace: "ns-1"},
+						Spec: v1alpha1.SubnetPortSpec{
+							Subnet: "subnet-1",
+						},
+					})
+					return nil
+				})
+			},
+			want: admission.Denied("accessMode of Subnet ns-1/subnet-1 with SubnetPorts cannot be changed"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -152,3 +367,34 @@ func TestSubnetValidator_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestSubnetValidator_HandleNetworkQuota(t *testing.T) {
+	scheme := clientgoscheme.Scheme
+	v1alpha1.AddToScheme(scheme)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "subnet-1"}},
+		&v1alpha1.NetworkQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "quota-1"},
+			Spec:       v1alpha1.NetworkQuotaSpec{Hard: v1alpha1.NetworkQuotaResources{Subnets: servicecommon.Int64(1)}},
+		},
+	).Build()
+	v := &SubnetValidator{
+		Client:  k8sClient,
+		decoder: admission.NewDecoder(scheme),
+	}
+	raw, _ := json.Marshal(&v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "subnet-2"},
+		Spec:       v1alpha1.SubnetSpec{IPv4SubnetSize: 16},
+	})
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: runtime.RawExtension{Raw: raw}}}
+	assert.Equal(t, admission.Denied("Subnet ns-1/subnet-2 is denied: NetworkQuota quota-1 exceeded: subnets (used 1, requested 1, limit 1)"), v.Handle(context.TODO(), req))
+	// The Subnets created by nsx-operator are limited too.
+	req.UserInfo.Username = NSXOperatorSA
+	assert.Equal(t, admission.Denied("Subnet ns-1/subnet-2 is denied: NetworkQuota quota-1 exceeded: subnets (used 1, requested 1, limit 1)"), v.Handle(context.TODO(), req))
+	req.UserInfo.Username = ""
+
+	// Updating the existing Subnet is not limited.
+	req.Operation = admissionv1.Update
+	req.OldObject = runtime.RawExtension{Raw: raw}
+	assert.Equal(t, admission.Allowed(""), v.Handle(context.TODO(), req))
+}
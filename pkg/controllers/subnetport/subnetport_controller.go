@@ -124,16 +124,21 @@ func (r *SubnetPortReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			return common.ResultRequeue, err
 		}
 		subnetPort.Status.Attachment = v1alpha1.PortAttachment{ID: *nsxSubnetPortState.Attachment.Id}
-		subnetPort.Status.NetworkInterfaceConfig = v1alpha1.NetworkInterfaceConfig{
-			IPAddresses: []v1alpha1.NetworkInterfaceIPAddress{
-				{
-					Gateway: "",
-				},
-			},
+		subnetPort.Status.NetworkInterfaceConfig = v1alpha1.NetworkInterfaceConfig{}
+		// The SubnetPort on a dual-stack Subnet has a realized binding for each IP family.
+		for _, realizedBinding := range nsxSubnetPortState.RealizedBindings {
+			subnetPort.Status.NetworkInterfaceConfig.IPAddresses = append(subnetPort.Status.NetworkInterfaceConfig.IPAddresses, v1alpha1.NetworkInterfaceIPAddress{
+				IPAddress: *realizedBinding.Binding.IpAddress,
+			})
 		}
 		if len(nsxSubnetPortState.RealizedBindings) > 0 {
-			subnetPort.Status.NetworkInterfaceConfig.IPAddresses[0].IPAddress = *nsxSubnetPortState.RealizedBindings[0].Binding.IpAddress
 			subnetPort.Status.NetworkInterfaceConfig.MACAddress = strings.Trim(*nsxSubnetPortState.RealizedBindings[0].Binding.MacAddress, "\"")
+		} else {
+			subnetPort.Status.NetworkInterfaceConfig.IPAddresses = []v1alpha1.NetworkInterfaceIPAddress{
+				{
+					Gateway: "",
+				},
+			}
 		}
 		err = r.updateSubnetStatusOnSubnetPort(subnetPort, nsxSubnetPath)
 		if err != nil {
@@ -223,11 +228,29 @@ func (r *SubnetPortReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&vmv1alpha1.VirtualMachine{},
 			handler.EnqueueRequestsFromMapFunc(r.vmMapFunc),
 			builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Watches(&v1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.namespaceMapFunc),
+			builder.WithPredicates(common.NamespaceLabelTagsChangedPredicate(r.SubnetPortService.NSXConfig.GetLabelTagKeys()))).
 		Watches(&v1alpha1.AddressBinding{},
 				handler.EnqueueRequestsFromMapFunc(r.addressBindingMapFunc)).
 		Complete(r) // TODO: watch the virtualmachine event and update the labels on NSX subnet port.
 }
 
+// namespaceMapFunc enqueues the SubnetPorts in the Namespace to update the Namespace labels mirrored to the
+// tags of the NSX SubnetPorts.
+func (r *SubnetPortReconciler) namespaceMapFunc(ctx context.Context, ns client.Object) []reconcile.Request {
+	subnetPortList := &v1alpha1.SubnetPortList{}
+	if err := r.Client.List(ctx, subnetPortList, client.InNamespace(ns.GetName())); err != nil {
+		log.Error(err, "Failed to list SubnetPorts in Namespace", "Namespace", ns.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, subnetPort := range subnetPortList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: subnetPort.Namespace, Name: subnetPort.Name}})
+	}
+	return requests
+}
+
 func (r *SubnetPortReconciler) vmMapFunc(_ context.Context, vm client.Object) []reconcile.Request {
 	subnetPortList := &v1alpha1.SubnetPortList{}
 	var requests []reconcile.Request
@@ -282,6 +305,14 @@ func StartSubnetPortController(mgr ctrl.Manager, subnetPortService *subnetport.S
 					decoder: admission.NewDecoder(mgr.GetScheme()),
 				},
 			})
+		hookServer.Register("/validate-crd-nsx-vmware-com-v1alpha1-subnetport",
+			&webhook.Admission{
+				Handler: &SubnetPortValidator{
+					Client:     mgr.GetClient(),
+					decoder:    admission.NewDecoder(mgr.GetScheme()),
+					vpcService: vpcService,
+				},
+			})
 	}
 	go common.GenericGarbageCollector(make(chan bool), servicecommon.GCInterval, subnetPortReconciler.CollectGarbage)
 }
@@ -356,6 +387,12 @@ func setSubnetPortReadyStatusFalse(client client.Client, ctx context.Context, ob
 	}
 	subnetPort := obj.(*v1alpha1.SubnetPort)
 	subnetPortService := args[0].(*subnetport.SubnetPortService)
+	reason := "SubnetPortNotReady"
+	// Report the specific reason if NSX rejects the requested static addresses.
+	staticAddressErr := &subnetport.StaticAddressError{}
+	if errors.As(err, &staticAddressErr) {
+		reason = staticAddressErr.Reason
+	}
 	newConditions := []v1alpha1.Condition{
 		{
 			Type:   v1alpha1.Ready,
@@ -364,7 +401,7 @@ func setSubnetPortReadyStatusFalse(client client.Client, ctx context.Context, ob
 				"error occurred while processing the SubnetPort CR. Error: %v",
 				err,
 			),
-			Reason:             "SubnetPortNotReady",
+			Reason:             reason,
 			LastTransitionTime: transitionTime,
 		},
 	}
@@ -479,7 +516,7 @@ func (r *SubnetPortReconciler) CheckAndGetSubnetPathForSubnetPort(ctx context.Co
 			return
 		}
 		log.Info("got subnetset for subnetport CR, allocating the NSX subnet", "subnetSet.Name", subnetSet.Name, "subnetSet.UID", subnetSet.UID, "subnetPort.Name", subnetPort.Name, "subnetPort.UID", subnetPort.UID)
-		subnetPath, err = common.AllocateSubnetFromSubnetSet(subnetSet, r.VPCService, r.SubnetService, r.SubnetPortService)
+		subnetPath, err = common.AllocateSubnetFromSubnetSet(r.Client, subnetSet, r.VPCService, r.SubnetService, r.SubnetPortService)
 		log.Info("allocated Subnet for SubnetPort", "subnetPath", subnetPath, "subnetPort.Name", subnetPort.Name, "subnetPort.UID", subnetPort.UID)
 		if err != nil {
 			return
@@ -496,7 +533,7 @@ func (r *SubnetPortReconciler) CheckAndGetSubnetPathForSubnetPort(ctx context.Co
 			return
 		}
 		log.Info("got default subnetset for subnetport CR, allocating the NSX subnet", "subnetSet.Name", subnetSet.Name, "subnetSet.UID", subnetSet.UID, "subnetPort.Name", subnetPort.Name, "subnetPort.UID", subnetPort.UID)
-		subnetPath, err = common.AllocateSubnetFromSubnetSet(subnetSet, r.VPCService, r.SubnetService, r.SubnetPortService)
+		subnetPath, err = common.AllocateSubnetFromSubnetSet(r.Client, subnetSet, r.VPCService, r.SubnetService, r.SubnetPortService)
 		log.Info("allocated Subnet for SubnetPort", "subnetPath", subnetPath, "subnetPort.Name", subnetPort.Name, "subnetPort.UID", subnetPort.UID)
 		if err != nil {
 			return
@@ -506,15 +543,31 @@ func (r *SubnetPortReconciler) CheckAndGetSubnetPathForSubnetPort(ctx context.Co
 }
 
 func (r *SubnetPortReconciler) updateSubnetStatusOnSubnetPort(subnetPort *v1alpha1.SubnetPort, nsxSubnetPath string) error {
-	gateway, prefix, err := r.SubnetPortService.GetGatewayPrefixForSubnetPort(subnetPort, nsxSubnetPath)
+	gateways, err := r.SubnetPortService.GetGatewayPrefixesForSubnetPort(subnetPort, nsxSubnetPath)
 	if err != nil {
 		return err
 	}
-	// For now, we have an assumption that one subnetport only have one IP address
-	if len(subnetPort.Status.NetworkInterfaceConfig.IPAddresses[0].IPAddress) > 0 {
-		subnetPort.Status.NetworkInterfaceConfig.IPAddresses[0].IPAddress += fmt.Sprintf("/%d", prefix)
+	for i := range subnetPort.Status.NetworkInterfaceConfig.IPAddresses {
+		ipAddress := &subnetPort.Status.NetworkInterfaceConfig.IPAddresses[i]
+		ipFamily := v1alpha1.IPFamilyIPv4
+		if len(ipAddress.IPAddress) > 0 {
+			if ipFamily, err = util.GetIPFamily(ipAddress.IPAddress); err != nil {
+				return err
+			}
+		}
+		gateway, ok := gateways[ipFamily]
+		if !ok && len(ipAddress.IPAddress) == 0 {
+			// The IP address is not realized on the IPv6 only Subnet.
+			gateway, ok = gateways[v1alpha1.IPFamilyIPv6]
+		}
+		if !ok {
+			continue
+		}
+		if len(ipAddress.IPAddress) > 0 {
+			ipAddress.IPAddress += fmt.Sprintf("/%d", gateway.Prefix)
+		}
+		ipAddress.Gateway = gateway.Gateway
 	}
-	subnetPort.Status.NetworkInterfaceConfig.IPAddresses[0].Gateway = gateway
 	nsxSubnet, err := r.SubnetService.GetSubnetByPath(nsxSubnetPath)
 	if err != nil {
 		return err
@@ -807,7 +807,7 @@ func TestSubnetPortReconciler_CheckAndGetSubnetPathForSubnetPort(t *testing.T) {
 					return nil
 				})
 				patches.ApplyFunc(common.AllocateSubnetFromSubnetSet,
-					func(subnetSet *v1alpha1.SubnetSet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider, subnetPortService servicecommon.SubnetPortServiceProvider) (string, error) {
+					func(_ client.Client, subnetSet *v1alpha1.SubnetSet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider, subnetPortService servicecommon.SubnetPortServiceProvider) (string, error) {
 						return "subnet-path-1", nil
 					})
 				return patches
@@ -862,7 +862,7 @@ func TestSubnetPortReconciler_CheckAndGetSubnetPathForSubnetPort(t *testing.T) {
 						return subnetSetCR, nil
 					})
 				patches.ApplyFunc(common.AllocateSubnetFromSubnetSet,
-					func(subnetSet *v1alpha1.SubnetSet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider, subnetPortService servicecommon.SubnetPortServiceProvider) (string, error) {
+					func(_ client.Client, subnetSet *v1alpha1.SubnetSet, vpcService servicecommon.VPCServiceProvider, subnetService servicecommon.SubnetServiceProvider, subnetPortService servicecommon.SubnetPortServiceProvider) (string, error) {
 						return "subnet-path-1", nil
 					})
 				return patches
@@ -895,11 +895,14 @@ func TestSubnetPortReconciler_CheckAndGetSubnetPathForSubnetPort(t *testing.T) {
 }
 
 func TestSubnetPortReconciler_updateSubnetStatusOnSubnetPort(t *testing.T) {
-	patchesGetGatewayPrefixForSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).GetGatewayPrefixForSubnetPort,
-		func(s *subnetport.SubnetPortService, obj *v1alpha1.SubnetPort, nsxSubnetPath string) (string, int, error) {
-			return "10.0.0.1", 28, nil
+	patchesGetGatewayPrefixesForSubnetPort := gomonkey.ApplyFunc((*subnetport.SubnetPortService).GetGatewayPrefixesForSubnetPort,
+		func(s *subnetport.SubnetPortService, obj *v1alpha1.SubnetPort, nsxSubnetPath string) (map[v1alpha1.IPFamily]subnetport.SubnetGateway, error) {
+			return map[v1alpha1.IPFamily]subnetport.SubnetGateway{
+				v1alpha1.IPFamilyIPv4: {Gateway: "10.0.0.1", Prefix: 28},
+				v1alpha1.IPFamilyIPv6: {Gateway: "2001:db8::1", Prefix: 64},
+			}, nil
 		})
-	defer patchesGetGatewayPrefixForSubnetPort.Reset()
+	defer patchesGetGatewayPrefixesForSubnetPort.Reset()
 	patchesGetSubnetByPath := gomonkey.ApplyFunc((*subnet.SubnetService).GetSubnetByPath,
 		func(s *subnet.SubnetService, path string) (*model.VpcSubnet, error) {
 			return &model.VpcSubnet{
@@ -912,6 +915,7 @@ func TestSubnetPortReconciler_updateSubnetStatusOnSubnetPort(t *testing.T) {
 			NetworkInterfaceConfig: v1alpha1.NetworkInterfaceConfig{
 				IPAddresses: []v1alpha1.NetworkInterfaceIPAddress{
 					{IPAddress: "10.0.0.2"},
+					{IPAddress: "2001:db8::2"},
 				},
 			},
 		},
@@ -930,6 +934,10 @@ func TestSubnetPortReconciler_updateSubnetStatusOnSubnetPort(t *testing.T) {
 						IPAddress: "10.0.0.2/28",
 						Gateway:   "10.0.0.1",
 					},
+					{
+						IPAddress: "2001:db8::2/64",
+						Gateway:   "2001:db8::1",
+					},
 				},
 				LogicalSwitchUUID: "realization-id-1",
 			},
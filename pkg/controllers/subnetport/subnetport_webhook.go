@@ -0,0 +1,161 @@
+package subnetport
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+// Create validator instead of using the existing one in controller-runtime because the existing one can't
+// inspect admission.Request in Handle function.
+
+//+kubebuilder:webhook:path=/validate-crd-nsx-vmware-com-v1alpha1-subnetport,mutating=false,failurePolicy=fail,sideEffects=None,groups=crd.nsx.vmware.com,resources=subnetports,verbs=create;update,versions=v1alpha1,name=subnetport.validating.crd.nsx.vmware.com,admissionReviewVersions=v1
+
+type SubnetPortValidator struct {
+	Client     client.Client
+	decoder    admission.Decoder
+	vpcService servicecommon.VPCServiceProvider
+}
+
+// Handle handles admission requests.
+func (v *SubnetPortValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation == admissionv1.Delete {
+		return admission.Allowed("")
+	}
+	subnetPort := &v1alpha1.SubnetPort{}
+	if err := v.decoder.Decode(req, subnetPort); err != nil {
+		log.Error(err, "error while decoding SubnetPort", "SubnetPort", req.Namespace+"/"+req.Name)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	switch req.Operation {
+	case admissionv1.Create:
+		msg, err := common.CheckNetworkQuota(ctx, v.Client, v.vpcService, subnetPort.Namespace, common.NetworkQuotaUsage{SubnetPorts: 1})
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if msg != "" {
+			return admission.Denied(fmt.Sprintf("SubnetPort %s/%s is denied: %s", subnetPort.Namespace, subnetPort.Name, msg))
+		}
+		if subnetPort.Spec.IPAddress == "" && subnetPort.Spec.MACAddress == "" {
+			return admission.Allowed("")
+		}
+		subnetPortList := &v1alpha1.SubnetPortList{}
+		if err := v.Client.List(ctx, subnetPortList, client.InNamespace(subnetPort.Namespace)); err != nil {
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to list SubnetPort: %v", err))
+		}
+		if err := validateStaticAddressConflict(subnetPort, subnetPortList.Items); err != nil {
+			return admission.Denied(fmt.Sprintf("SubnetPort %s/%s has invalid static address: %v", subnetPort.Namespace, subnetPort.Name, err))
+		}
+		if subnetPort.Spec.IPAddress == "" {
+			return admission.Allowed("")
+		}
+		if subnetPort.Spec.Subnet == "" {
+			return admission.Denied(fmt.Sprintf("SubnetPort %s/%s has invalid static address: ipAddress can only be requested when the parent Subnet is specified", subnetPort.Namespace, subnetPort.Name))
+		}
+		subnet := &v1alpha1.Subnet{}
+		if err := v.Client.Get(ctx, types.NamespacedName{Namespace: subnetPort.Namespace, Name: subnetPort.Spec.Subnet}, subnet); err != nil {
+			if apierrors.IsNotFound(err) {
+				return admission.Denied(fmt.Sprintf("SubnetPort %s/%s has invalid static address: parent Subnet %s is not found", subnetPort.Namespace, subnetPort.Name, subnetPort.Spec.Subnet))
+			}
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to get Subnet: %v", err))
+		}
+		if err := validateStaticIPAddressInSubnet(subnetPort.Spec.IPAddress, subnet); err != nil {
+			return admission.Denied(fmt.Sprintf("SubnetPort %s/%s has invalid static address: %v", subnetPort.Namespace, subnetPort.Name, err))
+		}
+	case admissionv1.Update:
+		oldSubnetPort := &v1alpha1.SubnetPort{}
+		if err := v.decoder.DecodeRaw(req.OldObject, oldSubnetPort); err != nil {
+			log.Error(err, "error while decoding SubnetPort", "SubnetPort", req.Namespace+"/"+req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if oldSubnetPort.Spec.IPAddress != subnetPort.Spec.IPAddress || oldSubnetPort.Spec.MACAddress != subnetPort.Spec.MACAddress {
+			return admission.Denied(fmt.Sprintf("ipAddress and macAddress of SubnetPort %s/%s are immutable", subnetPort.Namespace, subnetPort.Name))
+		}
+	}
+	return admission.Allowed("")
+}
+
+// validateStaticIPAddressInSubnet checks the requested IP address is a usable IPv4 address inside
+// the Subnet, and not reserved in the Subnet.
+func validateStaticIPAddressInSubnet(ipAddress string, subnet *v1alpha1.Subnet) error {
+	ip := net.ParseIP(ipAddress).To4()
+	if ip == nil {
+		return fmt.Errorf("ipAddress %s must be an IPv4 address", ipAddress)
+	}
+	dhcpMode := string(subnet.Spec.SubnetDHCPConfig.Mode)
+	if dhcpMode != "" && dhcpMode != v1alpha1.DHCPConfigModeDeactivated {
+		return fmt.Errorf("ipAddress cannot be requested in Subnet %s with DHCP enabled, use the DHCP static bindings instead", subnet.Name)
+	}
+	cidrs := subnet.Status.NetworkAddresses
+	if len(cidrs) == 0 {
+		cidrs = subnet.Spec.IPAddresses
+	}
+	var ipNet *net.IPNet
+	for _, cidr := range cidrs {
+		if _, n, err := net.ParseCIDR(cidr); err == nil && n.IP.To4() != nil && n.Contains(ip) {
+			ipNet = n
+			break
+		}
+	}
+	if ipNet == nil {
+		if len(cidrs) == 0 {
+			return fmt.Errorf("the CIDR of Subnet %s is not allocated yet", subnet.Name)
+		}
+		return fmt.Errorf("ipAddress %s is not inside Subnet %s", ipAddress, subnet.Name)
+	}
+	broadcast := make(net.IP, net.IPv4len)
+	for i := range broadcast {
+		broadcast[i] = ipNet.IP.To4()[i] | ^ipNet.Mask[i]
+	}
+	if ip.Equal(ipNet.IP) || ip.Equal(broadcast) {
+		return fmt.Errorf("ipAddress %s is the network or broadcast address of Subnet %s", ipAddress, subnet.Name)
+	}
+	for _, gateway := range subnet.Status.GatewayAddresses {
+		if strings.Split(gateway, "/")[0] == ip.String() {
+			return fmt.Errorf("ipAddress %s is the gateway address of Subnet %s", ipAddress, subnet.Name)
+		}
+	}
+	if util.IPInRanges(ip, subnet.Spec.ReservedIPRanges) {
+		return fmt.Errorf("ipAddress %s is reserved in Subnet %s", ipAddress, subnet.Name)
+	}
+	return nil
+}
+
+// validateStaticAddressConflict checks the requested IP address and MAC address are not requested by
+// or allocated to the other SubnetPorts in the Namespace.
+func validateStaticAddressConflict(subnetPort *v1alpha1.SubnetPort, subnetPorts []v1alpha1.SubnetPort) error {
+	for _, other := range subnetPorts {
+		if other.Name == subnetPort.Name {
+			continue
+		}
+		if ipAddress := subnetPort.Spec.IPAddress; ipAddress != "" {
+			if other.Spec.IPAddress == ipAddress {
+				return fmt.Errorf("ipAddress %s is already requested by SubnetPort %s", ipAddress, other.Name)
+			}
+			for _, otherIP := range other.Status.NetworkInterfaceConfig.IPAddresses {
+				if strings.Split(otherIP.IPAddress, "/")[0] == ipAddress {
+					return fmt.Errorf("ipAddress %s is already used by SubnetPort %s", ipAddress, other.Name)
+				}
+			}
+		}
+		if macAddress := subnetPort.Spec.MACAddress; macAddress != "" {
+			if strings.EqualFold(other.Spec.MACAddress, macAddress) || strings.EqualFold(other.Status.NetworkInterfaceConfig.MACAddress, macAddress) {
+				return fmt.Errorf("macAddress %s is already used by SubnetPort %s", macAddress, other.Name)
+			}
+		}
+	}
+	return nil
+}
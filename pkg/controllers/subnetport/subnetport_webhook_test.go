@@ -0,0 +1,150 @@
+package subnetport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+func TestSubnetPortValidator_Handle(t *testing.T) {
+	newSubnetPort := func(name, subnet, ipAddress, macAddress string) []byte {
+		raw, _ := json.Marshal(&v1alpha1.SubnetPort{
+			ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: name},
+			Spec:       v1alpha1.SubnetPortSpec{Subnet: subnet, IPAddress: ipAddress, MACAddress: macAddress},
+		})
+		return raw
+	}
+	createRequest := func(raw []byte) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: runtime.RawExtension{Raw: raw}}}
+	}
+	tests := []struct {
+		name string
+		req  admission.Request
+		want admission.Response
+	}{
+		{
+			name: "delete",
+			req:  admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Delete}},
+			want: admission.Allowed(""),
+		},
+		{
+			name: "create without static address",
+			req:  createRequest(newSubnetPort("sp1", "subnet1", "", "")),
+			want: admission.Allowed(""),
+		},
+		{
+			name: "create with static address",
+			req:  createRequest(newSubnetPort("sp1", "subnet1", "10.0.0.10", "00:50:56:00:00:01")),
+			want: admission.Allowed(""),
+		},
+		{
+			name: "create with IPv6 address",
+			req:  createRequest(newSubnetPort("sp1", "subnet1", "2001:db8::10", "")),
+			want: admission.Denied("SubnetPort ns1/sp1 has invalid static address: ipAddress 2001:db8::10 must be an IPv4 address"),
+		},
+		{
+			name: "create without Subnet",
+			req:  createRequest(newSubnetPort("sp1", "", "10.0.0.10", "")),
+			want: admission.Denied("SubnetPort ns1/sp1 has invalid static address: ipAddress can only be requested when the parent Subnet is specified"),
+		},
+		{
+			name: "create with Subnet not found",
+			req:  createRequest(newSubnetPort("sp1", "subnet2", "10.0.0.10", "")),
+			want: admission.Denied("SubnetPort ns1/sp1 has invalid static address: parent Subnet subnet2 is not found"),
+		},
+		{
+			name: "create with IP outside Subnet",
+			req:  createRequest(newSubnetPort("sp1", "subnet1", "10.0.1.10", "")),
+			want: admission.Denied("SubnetPort ns1/sp1 has invalid static address: ipAddress 10.0.1.10 is not inside Subnet subnet1"),
+		},
+		{
+			name: "create with gateway IP",
+			req:  createRequest(newSubnetPort("sp1", "subnet1", "10.0.0.1", "")),
+			want: admission.Denied("SubnetPort ns1/sp1 has invalid static address: ipAddress 10.0.0.1 is the gateway address of Subnet subnet1"),
+		},
+		{
+			name: "create with reserved IP",
+			req:  createRequest(newSubnetPort("sp1", "subnet1", "10.0.0.21", "")),
+			want: admission.Denied("SubnetPort ns1/sp1 has invalid static address: ipAddress 10.0.0.21 is reserved in Subnet subnet1"),
+		},
+		{
+			name: "create with IP used by another SubnetPort",
+			req:  createRequest(newSubnetPort("sp1", "subnet1", "10.0.0.5", "")),
+			want: admission.Denied("SubnetPort ns1/sp1 has invalid static address: ipAddress 10.0.0.5 is already used by SubnetPort sp2"),
+		},
+		{
+			name: "create with MAC used by another SubnetPort",
+			req:  createRequest(newSubnetPort("sp1", "subnet1", "", "00:50:56:00:00:02")),
+			want: admission.Denied("SubnetPort ns1/sp1 has invalid static address: macAddress 00:50:56:00:00:02 is already used by SubnetPort sp2"),
+		},
+		{
+			name: "update static address",
+			req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				Object:    runtime.RawExtension{Raw: newSubnetPort("sp1", "subnet1", "10.0.0.11", "")},
+				OldObject: runtime.RawExtension{Raw: newSubnetPort("sp1", "subnet1", "10.0.0.10", "")},
+			}},
+			want: admission.Denied("ipAddress and macAddress of SubnetPort ns1/sp1 are immutable"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := clientgoscheme.Scheme
+			v1alpha1.AddToScheme(scheme)
+			ctx := context.TODO()
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&v1alpha1.Subnet{
+					ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: "subnet1"},
+					Spec:       v1alpha1.SubnetSpec{ReservedIPRanges: []string{"10.0.0.20-10.0.0.30"}},
+					Status: v1alpha1.SubnetStatus{
+						NetworkAddresses: []string{"10.0.0.0/24"},
+						GatewayAddresses: []string{"10.0.0.1/24"},
+					},
+				},
+				&v1alpha1.SubnetPort{
+					ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: "sp2"},
+					Spec:       v1alpha1.SubnetPortSpec{Subnet: "subnet1"},
+					Status: v1alpha1.SubnetPortStatus{NetworkInterfaceConfig: v1alpha1.NetworkInterfaceConfig{
+						IPAddresses: []v1alpha1.NetworkInterfaceIPAddress{{IPAddress: "10.0.0.5/24"}},
+						MACAddress:  "00:50:56:00:00:02",
+					}},
+				},
+			).Build()
+			v := &SubnetPortValidator{
+				Client:  client,
+				decoder: admission.NewDecoder(scheme),
+			}
+			assert.Equal(t, tt.want, v.Handle(ctx, tt.req))
+		})
+	}
+}
+
+func TestSubnetPortValidator_HandleNetworkQuota(t *testing.T) {
+	scheme := clientgoscheme.Scheme
+	v1alpha1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&v1alpha1.SubnetPort{ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: "sp1"}},
+		&v1alpha1.NetworkQuota{
+			ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: "quota1"},
+			Spec:       v1alpha1.NetworkQuotaSpec{Hard: v1alpha1.NetworkQuotaResources{SubnetPorts: servicecommon.Int64(1)}},
+		},
+	).Build()
+	v := &SubnetPortValidator{
+		Client:  client,
+		decoder: admission.NewDecoder(scheme),
+	}
+	raw, _ := json.Marshal(&v1alpha1.SubnetPort{ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: "sp2"}})
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: runtime.RawExtension{Raw: raw}}}
+	assert.Equal(t, admission.Denied("SubnetPort ns1/sp2 is denied: NetworkQuota quota1 exceeded: subnetPorts (used 1, requested 1, limit 1)"), v.Handle(context.TODO(), req))
+}
@@ -0,0 +1,91 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package subnetset
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+// compactSubnetSet deletes the NSX Subnets of the SubnetSet which have stayed empty for the grace period,
+// while keeping the minimum number of Subnets and MinFreeIPs of the SubnetSet. The SubnetSet lock is held
+// so that no SubnetPort is allocated to the Subnets during the compaction, the SubnetPorts under creation
+// are counted by IsEmptySubnet. The paths of the NSX Subnets of the SubnetSet are added to nsxSubnetPaths.
+func (r *SubnetSetReconciler) compactSubnetSet(subnetSet *v1alpha1.SubnetSet, now time.Time, nsxSubnetPaths sets.Set[string]) error {
+	gracePeriod := time.Duration(r.SubnetService.NSXConfig.SubnetSetCompactionGracePeriod) * time.Second
+	minSubnets := r.SubnetService.NSXConfig.SubnetSetMinSubnets
+	if r.emptySubnetSince == nil {
+		r.emptySubnetSince = make(map[string]time.Time)
+	}
+
+	subnetSetLock := common.LockSubnetSet(subnetSet.GetUID())
+	nsxSubnets := r.SubnetService.SubnetStore.GetByIndex(servicecommon.TagScopeSubnetSetCRUID, string(subnetSet.GetUID()))
+	var candidates []*model.VpcSubnet
+	freeIPs := 0
+	for _, nsxSubnet := range nsxSubnets {
+		nsxSubnetPaths.Insert(*nsxSubnet.Path)
+		if subnetSet.Spec.MinFreeIPs > 0 {
+			freeIPs += r.SubnetPortService.GetFreeIPCount(nsxSubnet)
+		}
+		if !r.SubnetPortService.IsEmptySubnet(*nsxSubnet.Id, *nsxSubnet.Path) {
+			delete(r.emptySubnetSince, *nsxSubnet.Path)
+			continue
+		}
+		since, ok := r.emptySubnetSince[*nsxSubnet.Path]
+		if !ok {
+			since = now
+			r.emptySubnetSince[*nsxSubnet.Path] = now
+		}
+		if now.Sub(since) >= gracePeriod {
+			candidates = append(candidates, nsxSubnet)
+		}
+	}
+	// Delete the Subnets which have been empty for the longest time first.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return r.emptySubnetSince[*candidates[i].Path].Before(r.emptySubnetSince[*candidates[j].Path])
+	})
+
+	remaining := len(nsxSubnets)
+	deleted := false
+	var deleteErr error
+	for _, nsxSubnet := range candidates {
+		if remaining <= minSubnets {
+			break
+		}
+		// Keep the empty Subnet if the SubnetSet would have less free IPs than MinFreeIPs without it.
+		subnetFreeIPs := 0
+		if subnetSet.Spec.MinFreeIPs > 0 {
+			subnetFreeIPs = r.SubnetPortService.GetFreeIPCount(nsxSubnet)
+			if freeIPs-subnetFreeIPs < subnetSet.Spec.MinFreeIPs {
+				continue
+			}
+		}
+		if _, err := r.deleteSubnets([]*model.VpcSubnet{nsxSubnet}, true); err != nil {
+			deleteErr = err
+			continue
+		}
+		remaining--
+		freeIPs -= subnetFreeIPs
+		deleted = true
+		delete(r.emptySubnetSince, *nsxSubnet.Path)
+		log.Info("Deleted empty Subnet of SubnetSet", "SubnetSet", subnetSet.Namespace+"/"+subnetSet.Name, "Subnet", *nsxSubnet.Id)
+		r.Recorder.Event(subnetSet, v1.EventTypeNormal, common.ReasonSubnetCompacted, fmt.Sprintf("Empty Subnet %s has been deleted", *nsxSubnet.Id))
+	}
+	common.UnlockSubnetSet(subnetSet.GetUID(), subnetSetLock)
+	if deleted {
+		if err := r.SubnetService.UpdateSubnetSetStatus(subnetSet); err != nil {
+			return err
+		}
+	}
+	return deleteErr
+}
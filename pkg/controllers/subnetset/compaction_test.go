@@ -0,0 +1,91 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package subnetset
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetbinding"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetport"
+)
+
+func TestSubnetSetReconciler_compactSubnetSet(t *testing.T) {
+	r := createFakeSubnetSetReconciler(nil)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	r.SubnetService.NSXConfig.SubnetSetCompactionGracePeriod = 60
+	r.SubnetService.NSXConfig.SubnetSetMinSubnets = 1
+
+	subnetSet := &v1alpha1.SubnetSet{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       "subnetset-uid-1",
+			Name:      "subnetset-1",
+			Namespace: "ns-1",
+		},
+	}
+	newSubnet := func(id string) *model.VpcSubnet {
+		return &model.VpcSubnet{Id: common.String(id), Path: common.String("/orgs/default/projects/p1/vpcs/v1/subnets/" + id)}
+	}
+	nsxSubnets := []*model.VpcSubnet{newSubnet("subnet-1"), newSubnet("subnet-2"), newSubnet("subnet-3")}
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(r.SubnetService.SubnetStore), "GetByIndex", func(_ *subnet.SubnetStore, key string, value string) []*model.VpcSubnet {
+		return nsxSubnets
+	})
+	defer patches.Reset()
+	// subnet-1 has a SubnetPort, subnet-2 and subnet-3 are empty.
+	patches.ApplyMethod(reflect.TypeOf(r.SubnetPortService), "GetPortsOfSubnet", func(_ *subnetport.SubnetPortService, id string) []*model.VpcSubnetPort {
+		if id == "subnet-1" {
+			return []*model.VpcSubnetPort{{}}
+		}
+		return nil
+	})
+	patches.ApplyMethod(reflect.TypeOf(r.BindingService), "DeleteSubnetConnectionBindingMapsByParentSubnet", func(_ *subnetbinding.BindingService, parentSubnet *model.VpcSubnet) error {
+		return nil
+	})
+	var deletedSubnets []string
+	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "DeleteSubnet", func(_ *subnet.SubnetService, nsxSubnet model.VpcSubnet) error {
+		deletedSubnets = append(deletedSubnets, *nsxSubnet.Id)
+		return nil
+	})
+	patches.ApplyMethod(reflect.TypeOf(r.SubnetService), "UpdateSubnetSetStatus", func(_ *subnet.SubnetService, obj *v1alpha1.SubnetSet) error {
+		return nil
+	})
+
+	now := time.Now()
+	paths := sets.New[string]()
+	// The empty Subnets are kept within the grace period.
+	assert.NoError(t, r.compactSubnetSet(subnetSet, now, paths))
+	assert.Empty(t, deletedSubnets)
+	assert.Equal(t, 3, paths.Len())
+
+	// subnet-3 has been empty for longer than subnet-2.
+	r.emptySubnetSince[*nsxSubnets[1].Path] = now.Add(30 * time.Second)
+	assert.NoError(t, r.compactSubnetSet(subnetSet, now.Add(61*time.Second), paths))
+	assert.Equal(t, []string{"subnet-3"}, deletedSubnets)
+	assert.Equal(t, "Normal SubnetCompacted Empty Subnet subnet-3 has been deleted", <-recorder.Events)
+
+	// The minimum number of Subnets is kept.
+	r.SubnetService.NSXConfig.SubnetSetMinSubnets = 2
+	nsxSubnets = nsxSubnets[:2]
+	assert.NoError(t, r.compactSubnetSet(subnetSet, now.Add(120*time.Second), paths))
+	assert.Equal(t, []string{"subnet-3"}, deletedSubnets)
+
+	// The empty Subnet with an in-flight SubnetPort allocation is kept.
+	r.SubnetService.NSXConfig.SubnetSetMinSubnets = 0
+	nsxSubnets = nsxSubnets[1:]
+	assert.True(t, r.SubnetPortService.AllocatePortFromSubnet(&model.VpcSubnet{Id: nsxSubnets[0].Id, Path: nsxSubnets[0].Path, Ipv4SubnetSize: common.Int64(16)}))
+	assert.NoError(t, r.compactSubnetSet(subnetSet, now.Add(180*time.Second), paths))
+	assert.Equal(t, []string{"subnet-3"}, deletedSubnets)
+}
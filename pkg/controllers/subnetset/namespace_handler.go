@@ -16,10 +16,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
 )
 
 // SubnetSet controller should watch event of Namespace, when there are some updates of Namespace labels,
 // controller should build tags and update VpcSubnetSetSet according to new labels.
+// The SubnetSets are also requeued to be realized in the new VPC after the Namespace is moved to another VPCNetworkConfiguration.
 
 type EnqueueRequestForNamespace struct {
 	Client client.Client
@@ -53,7 +55,7 @@ var PredicateFuncsNs = predicate.Funcs{
 		oldObj := e.ObjectOld.(*v1.Namespace)
 		newObj := e.ObjectNew.(*v1.Namespace)
 		log.V(1).Info("Receive Namespace update event", "Name", oldObj.Name)
-		if reflect.DeepEqual(oldObj.ObjectMeta.Labels, newObj.ObjectMeta.Labels) {
+		if reflect.DeepEqual(oldObj.ObjectMeta.Labels, newObj.ObjectMeta.Labels) && !common.IsNamespaceVPCSwitched(oldObj, newObj) {
 			log.Info("Label of Namespace is not changed, ignore it", "name", oldObj.Name)
 			return false
 		}
@@ -131,6 +131,12 @@ func TestPredicateFuncsNs_UpdateFunc(t *testing.T) {
 	result = PredicateFuncsNs.UpdateFunc(noChangeEvent)
 	assert.False(t, result, "Expected no action when labels have not changed")
 
+	// Test with the Namespace switched to another VPC
+	switchedNamespace := oldNamespace.DeepCopy()
+	switchedNamespace.Annotations = map[string]string{"nsx.vmware.com/applied_vpc_network_config": "nc-new"}
+	result = PredicateFuncsNs.UpdateFunc(event.UpdateEvent{ObjectOld: oldNamespace, ObjectNew: switchedNamespace})
+	assert.True(t, result, "Expected update event to trigger requeue when the VPC is switched")
+
 	res := PredicateFuncsNs.CreateFunc(event.CreateEvent{Object: newNamespace})
 	assert.False(t, res, "Expected no action when labels have not changed")
 
@@ -29,6 +29,7 @@ import (
 	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnet"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/subnetbinding"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
 var (
@@ -49,6 +50,8 @@ type SubnetSetReconciler struct {
 	BindingService    *subnetbinding.BindingService
 	Recorder          record.EventRecorder
 	StatusUpdater     common.StatusUpdater
+	// emptySubnetSince records when the NSX Subnets of the SubnetSets are found empty, keyed by the Subnet path.
+	emptySubnetSince map[string]time.Time
 }
 
 func (r *SubnetSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -125,7 +128,7 @@ func (r *SubnetSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		subnetsetCR.Spec.AccessMode = v1alpha1.AccessMode(v1alpha1.AccessModePrivate)
 		specChanged = true
 	}
-	if subnetsetCR.Spec.IPv4SubnetSize == 0 {
+	if subnetsetCR.Spec.IPv4SubnetSize == 0 && util.HasIPFamily(subnetsetCR.Spec.IPFamilies, v1alpha1.IPFamilyIPv4) {
 		vpcNetworkConfig := r.VPCService.GetVPCNetworkConfigByNamespace(subnetsetCR.Namespace)
 		if vpcNetworkConfig == nil {
 			err := fmt.Errorf("failed to find VPCNetworkConfig for Namespace %s", subnetsetCR.Namespace)
@@ -135,6 +138,10 @@ func (r *SubnetSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		subnetsetCR.Spec.IPv4SubnetSize = vpcNetworkConfig.DefaultSubnetSize
 		specChanged = true
 	}
+	if subnetsetCR.Spec.IPv6SubnetSize == 0 && util.HasIPFamily(subnetsetCR.Spec.IPFamilies, v1alpha1.IPFamilyIPv6) {
+		subnetsetCR.Spec.IPv6SubnetSize = servicecommon.IPv6SubnetSize
+		specChanged = true
+	}
 
 	if specChanged {
 		err := r.Client.Update(ctx, subnetsetCR)
@@ -145,6 +152,11 @@ func (r *SubnetSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	nsxSubnets := r.SubnetService.SubnetStore.GetByIndex(servicecommon.TagScopeSubnetSetCRUID, string(subnetsetCR.UID))
+	nsxSubnets, err := r.deleteSubnetsForAccessMode(subnetsetCR, nsxSubnets)
+	if err != nil {
+		r.StatusUpdater.UpdateFail(ctx, subnetsetCR, err, "Failed to change SubnetSet access mode", setSubnetSetReadyStatusFalse)
+		return ResultRequeue, err
+	}
 	if len(nsxSubnets) > 0 {
 		// update SubnetSet tags if labels of namespace changed
 		tags := r.SubnetService.GenerateSubnetNSTags(subnetsetCR)
@@ -158,7 +170,7 @@ func (r *SubnetSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			r.StatusUpdater.UpdateFail(ctx, subnetsetCR, err, "Exceed tags limit", setSubnetSetReadyStatusFalse)
 			return ResultNormal, nil
 		}
-		if err := r.SubnetService.UpdateSubnetSet(subnetsetCR.Namespace, nsxSubnets, tags, string(subnetsetCR.Spec.SubnetDHCPConfig.Mode)); err != nil {
+		if err := r.SubnetService.UpdateSubnetSet(subnetsetCR.Namespace, nsxSubnets, tags, subnetsetCR.Spec.SubnetDHCPConfig); err != nil {
 			r.StatusUpdater.UpdateFail(ctx, subnetsetCR, err, "Failed to update SubnetSet", setSubnetSetReadyStatusFalse)
 			return ResultRequeue, nil
 		}
@@ -306,14 +318,22 @@ func (r *SubnetSetReconciler) CollectGarbage(ctx context.Context) {
 	}
 
 	crdSubnetSetIDsSet := sets.New[string]()
-	for _, subnetSet := range crdSubnetSetList.Items {
+	nsxSubnetPaths := sets.New[string]()
+	for i := range crdSubnetSetList.Items {
+		subnetSet := &crdSubnetSetList.Items[i]
 		crdSubnetSetIDsSet.Insert(string(subnetSet.UID))
-		if err := r.deleteSubnetForSubnetSet(subnetSet, true, true); err != nil {
+		if err := r.compactSubnetSet(subnetSet, startTime, nsxSubnetPaths); err != nil {
 			r.StatusUpdater.IncreaseDeleteFailTotal()
 		} else {
 			r.StatusUpdater.IncreaseDeleteSuccessTotal()
 		}
 	}
+	// Forget the Subnets which are deleted or not of any SubnetSet.
+	for path := range r.emptySubnetSince {
+		if !nsxSubnetPaths.Has(path) {
+			delete(r.emptySubnetSince, path)
+		}
+	}
 
 	subnetSetIDs := r.SubnetService.ListSubnetSetIDsFromNSXSubnets()
 	subnetSetIDsToDelete := subnetSetIDs.Difference(crdSubnetSetIDsSet)
@@ -372,6 +392,45 @@ func (r *SubnetSetReconciler) deleteSubnetForSubnetSet(subnetSet v1alpha1.Subnet
 	return nil
 }
 
+// deleteSubnetsForAccessMode deletes the NSX Subnets of the SubnetSet whose access mode is changed, the
+// NSX Subnets with the new access mode are created when the SubnetPorts are allocated from the SubnetSet.
+// It returns the NSX Subnets with the current access mode.
+func (r *SubnetSetReconciler) deleteSubnetsForAccessMode(subnetSet *v1alpha1.SubnetSet, nsxSubnets []*model.VpcSubnet) ([]*model.VpcSubnet, error) {
+	var staleSubnets, subnets []*model.VpcSubnet
+	for _, nsxSubnet := range nsxSubnets {
+		if subnet.IsAccessModeChanged(nsxSubnet, subnetSet.Spec.AccessMode) {
+			staleSubnets = append(staleSubnets, nsxSubnet)
+		} else {
+			subnets = append(subnets, nsxSubnet)
+		}
+	}
+	if len(staleSubnets) == 0 {
+		return nsxSubnets, nil
+	}
+	if vpcInfoList := r.VPCService.ListVPCInfo(subnetSet.Namespace); len(vpcInfoList) > 0 {
+		if err := r.VPCService.ValidateAccessModeIPBlocks(subnetSet.Namespace, vpcInfoList[0], string(subnetSet.Spec.AccessMode)); err != nil {
+			return nil, err
+		}
+	}
+	if bindings := r.getNSXSubnetBindingsBySubnetSet(string(subnetSet.UID)); len(bindings) > 0 {
+		return nil, fmt.Errorf("cannot change access mode of SubnetSet %s/%s, still used by SubnetConnectionBindingMap %s", subnetSet.Namespace, subnetSet.Name, bindings[0].GetName())
+	}
+	subnetSetLock := common.LockSubnetSet(subnetSet.GetUID())
+	hasStaleSubnetPort, err := r.deleteSubnets(staleSubnets, false)
+	common.UnlockSubnetSet(subnetSet.GetUID(), subnetSetLock)
+	if err != nil {
+		return nil, err
+	}
+	if hasStaleSubnetPort {
+		return nil, fmt.Errorf("cannot change access mode of SubnetSet %s/%s, Subnets are still attached by ports", subnetSet.Namespace, subnetSet.Name)
+	}
+	log.Info("Deleted NSX Subnets to change the access mode", "SubnetSet", subnetSet.Namespace+"/"+subnetSet.Name, "subnetCount", len(staleSubnets), "accessMode", subnetSet.Spec.AccessMode)
+	if err := r.SubnetService.UpdateSubnetSetStatus(subnetSet); err != nil {
+		return nil, err
+	}
+	return subnets, nil
+}
+
 // deleteSubnets deletes all the specified NSX Subnets.
 // If any of the Subnets have stale SubnetPorts, they are skipped. The final result returns true.
 // If there is an error while deleting any NSX Subnet, it is skipped, and the final result returns an error.
@@ -447,8 +506,9 @@ func (r *SubnetSetReconciler) Start(mgr ctrl.Manager, hookServer webhook.Server)
 		hookServer.Register("/validate-crd-nsx-vmware-com-v1alpha1-subnetset",
 			&webhook.Admission{
 				Handler: &SubnetSetValidator{
-					Client:  mgr.GetClient(),
-					decoder: admission.NewDecoder(mgr.GetScheme()),
+					Client:     mgr.GetClient(),
+					decoder:    admission.NewDecoder(mgr.GetScheme()),
+					vpcService: r.VPCService,
 				},
 			})
 	}
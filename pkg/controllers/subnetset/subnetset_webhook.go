@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	ctlcommon "github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
@@ -27,8 +28,9 @@ var NSXOperatorSA = "system:serviceaccount:vmware-system-nsx:ncp-svc-account"
 // name=subnetset.validating.crd.nsx.vmware.com,admissionReviewVersions=v1
 
 type SubnetSetValidator struct {
-	Client  client.Client
-	decoder admission.Decoder
+	Client     client.Client
+	decoder    admission.Decoder
+	vpcService common.VPCServiceProvider
 }
 
 func defaultSubnetSetLabelChanged(oldSubnetSet, subnetSet *v1alpha1.SubnetSet) bool {
@@ -67,9 +69,29 @@ func (v *SubnetSetValidator) Handle(ctx context.Context, req admission.Request)
 		if subnetSet.Spec.IPv4SubnetSize != 0 && !util.IsPowerOfTwo(subnetSet.Spec.IPv4SubnetSize) {
 			return admission.Denied(fmt.Sprintf("SubnetSet %s/%s has invalid size %d, which must be power of 2", subnetSet.Namespace, subnetSet.Name, subnetSet.Spec.IPv4SubnetSize))
 		}
+		if err := util.ValidateSubnetIPFamilies(subnetSet.Spec.IPFamilies, subnetSet.Spec.IPv4SubnetSize, subnetSet.Spec.IPv6SubnetSize, nil); err != nil {
+			return admission.Denied(fmt.Sprintf("SubnetSet %s/%s has invalid IP settings: %v", subnetSet.Namespace, subnetSet.Name, err))
+		}
+		if err := validateGrowthPolicy(subnetSet); err != nil {
+			return admission.Denied(fmt.Sprintf("SubnetSet %s/%s has invalid growth policy: %v", subnetSet.Namespace, subnetSet.Name, err))
+		}
+		if err := util.ValidateSubnetDHCPConfig(subnetSet.Spec.SubnetDHCPConfig); err != nil {
+			return admission.Denied(fmt.Sprintf("SubnetSet %s/%s has invalid DHCP configuration: %v", subnetSet.Namespace, subnetSet.Name, err))
+		}
 		if isDefaultSubnetSet(subnetSet) && req.UserInfo.Username != NSXOperatorSA {
 			return admission.Denied("default SubnetSet only can be created by nsx-operator")
 		}
+		// Only the default SubnetSets, which are required by the Namespace network, are not limited.
+		if !isDefaultSubnetSet(subnetSet) {
+			requested := ctlcommon.SubnetSetQuotaUsage(subnetSet, ctlcommon.DefaultSubnetSizeOfNamespace(v.vpcService, subnetSet.Namespace))
+			msg, err := ctlcommon.CheckNetworkQuota(ctx, v.Client, v.vpcService, subnetSet.Namespace, requested)
+			if err != nil {
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+			if msg != "" {
+				return admission.Denied(fmt.Sprintf("SubnetSet %s/%s is denied: %s", subnetSet.Namespace, subnetSet.Name, msg))
+			}
+		}
 	case admissionv1.Update:
 		oldSubnetSet := &v1alpha1.SubnetSet{}
 		if err := v.decoder.DecodeRaw(req.OldObject, oldSubnetSet); err != nil {
@@ -79,6 +101,21 @@ func (v *SubnetSetValidator) Handle(ctx context.Context, req admission.Request)
 		if defaultSubnetSetLabelChanged(oldSubnetSet, subnetSet) {
 			return admission.Denied(fmt.Sprintf("SubnetSet label %s only can't be updated", common.LabelDefaultSubnetSet))
 		}
+		if util.IsAccessModeChanged(oldSubnetSet.Spec.AccessMode, subnetSet.Spec.AccessMode) {
+			hasSubnetPort, err := v.checkSubnetPort(ctx, subnetSet.Namespace, subnetSet.Name)
+			if err != nil {
+				return admission.Errored(http.StatusBadRequest, err)
+			}
+			if hasSubnetPort {
+				return admission.Denied(fmt.Sprintf("accessMode of SubnetSet %s/%s with SubnetPorts cannot be changed", subnetSet.Namespace, subnetSet.Name))
+			}
+		}
+		if err := validateGrowthPolicy(subnetSet); err != nil {
+			return admission.Denied(fmt.Sprintf("SubnetSet %s/%s has invalid growth policy: %v", subnetSet.Namespace, subnetSet.Name, err))
+		}
+		if err := util.ValidateSubnetDHCPConfig(subnetSet.Spec.SubnetDHCPConfig); err != nil {
+			return admission.Denied(fmt.Sprintf("SubnetSet %s/%s has invalid DHCP configuration: %v", subnetSet.Namespace, subnetSet.Name, err))
+		}
 	case admissionv1.Delete:
 		if isDefaultSubnetSet(subnetSet) && req.UserInfo.Username != NSXOperatorSA {
 			return admission.Denied("default SubnetSet only can be deleted by nsx-operator")
@@ -96,6 +133,21 @@ func (v *SubnetSetValidator) Handle(ctx context.Context, req admission.Request)
 	return admission.Allowed("")
 }
 
+// validateGrowthPolicy checks the maximum size of the new Subnets is a valid Subnet size.
+func validateGrowthPolicy(subnetSet *v1alpha1.SubnetSet) error {
+	policy := subnetSet.Spec.GrowthPolicy
+	if policy == nil || policy.MaxSubnetSize == 0 {
+		return nil
+	}
+	if !util.IsPowerOfTwo(policy.MaxSubnetSize) {
+		return fmt.Errorf("maxSubnetSize %d must be power of 2", policy.MaxSubnetSize)
+	}
+	if policy.MaxSubnetSize < subnetSet.Spec.IPv4SubnetSize {
+		return fmt.Errorf("maxSubnetSize %d is less than ipv4SubnetSize %d", policy.MaxSubnetSize, subnetSet.Spec.IPv4SubnetSize)
+	}
+	return nil
+}
+
 func (v *SubnetSetValidator) checkSubnetPort(ctx context.Context, ns string, subnetSetName string) (bool, error) {
 	crdSubnetPorts := &v1alpha1.SubnetPortList{}
 	err := v.Client.List(ctx, crdSubnetPorts, client.InNamespace(ns))
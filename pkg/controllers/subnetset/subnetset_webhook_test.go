@@ -62,6 +62,42 @@ func TestSubnetSetValidator(t *testing.T) {
 		},
 	}
 
+	invalidIPv6SubnetSet := &v1alpha1.SubnetSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-subnetset",
+			Namespace: "ns-1",
+		},
+		Spec: v1alpha1.SubnetSetSpec{
+			IPv4SubnetSize: 32,
+			IPv6SubnetSize: 96,
+			IPFamilies:     []v1alpha1.IPFamily{v1alpha1.IPFamilyIPv4, v1alpha1.IPFamilyIPv6},
+		},
+	}
+
+	invalidGrowthSubnetSet := &v1alpha1.SubnetSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-subnetset",
+			Namespace: "ns-1",
+		},
+		Spec: v1alpha1.SubnetSetSpec{
+			IPv4SubnetSize: 64,
+			GrowthPolicy:   &v1alpha1.SubnetSetGrowthPolicy{Factor: 2, MaxSubnetSize: 32},
+		},
+	}
+
+	invalidDHCPSubnetSet := &v1alpha1.SubnetSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-subnetset",
+			Namespace: "ns-1",
+		},
+		Spec: v1alpha1.SubnetSetSpec{
+			SubnetDHCPConfig: v1alpha1.SubnetDHCPConfig{
+				Mode:            v1alpha1.DHCPConfigMode(v1alpha1.DHCPConfigModeRelay),
+				DHCPRelayConfig: &v1alpha1.DHCPRelayConfig{ServerAddresses: []string{"dhcp-server"}},
+			},
+		},
+	}
+
 	subnetSetWithStalePorts := &v1alpha1.SubnetSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "subnetset-1",
@@ -69,6 +105,12 @@ func TestSubnetSetValidator(t *testing.T) {
 		},
 	}
 
+	publicSubnetSetWithStalePorts := subnetSetWithStalePorts.DeepCopy()
+	publicSubnetSetWithStalePorts.Spec.AccessMode = v1alpha1.AccessMode(v1alpha1.AccessModePublic)
+
+	publicSubnetSet := subnetSet.DeepCopy()
+	publicSubnetSet.Spec.AccessMode = v1alpha1.AccessMode(v1alpha1.AccessModePublic)
+
 	fakeClient.Create(context.TODO(), &v1alpha1.SubnetPort{
 		ObjectMeta: metav1.ObjectMeta{Name: "subnetport-1", Namespace: "ns-1"},
 		Spec: v1alpha1.SubnetPortSpec{
@@ -115,6 +157,30 @@ func TestSubnetSetValidator(t *testing.T) {
 			isAllowed: false,
 			msg:       "SubnetSet ns-1/fake-subnetset has invalid size 24, which must be power of 2",
 		},
+		{
+			name:      "Create SubnetSet with invalid IPv6SubnetSize",
+			op:        admissionv1.Create,
+			subnetSet: invalidIPv6SubnetSet,
+			user:      "fake-user",
+			isAllowed: false,
+			msg:       "SubnetSet ns-1/fake-subnetset has invalid IP settings: ipv6SubnetSize 96 is not supported, it must be 64",
+		},
+		{
+			name:      "Create SubnetSet with invalid growth policy",
+			op:        admissionv1.Create,
+			subnetSet: invalidGrowthSubnetSet,
+			user:      "fake-user",
+			isAllowed: false,
+			msg:       "SubnetSet ns-1/fake-subnetset has invalid growth policy: maxSubnetSize 32 is less than ipv4SubnetSize 64",
+		},
+		{
+			name:      "Create SubnetSet with invalid DHCP relay server",
+			op:        admissionv1.Create,
+			subnetSet: invalidDHCPSubnetSet,
+			user:      "fake-user",
+			isAllowed: false,
+			msg:       "SubnetSet ns-1/fake-subnetset has invalid DHCP configuration: invalid DHCP relay server address dhcp-server",
+		},
 		{
 			name:      "Create normal SubnetSet",
 			op:        admissionv1.Create,
@@ -174,6 +240,41 @@ func TestSubnetSetValidator(t *testing.T) {
 			user:         "fake-user",
 			isAllowed:    true,
 		},
+		{
+			name:         "Update SubnetSet with invalid growth policy",
+			op:           admissionv1.Update,
+			oldSubnetSet: subnetSet,
+			subnetSet:    invalidGrowthSubnetSet,
+			user:         "fake-user",
+			isAllowed:    false,
+			msg:          "SubnetSet ns-1/fake-subnetset has invalid growth policy: maxSubnetSize 32 is less than ipv4SubnetSize 64",
+		},
+		{
+			name:         "Update SubnetSet with invalid DHCP relay server",
+			op:           admissionv1.Update,
+			oldSubnetSet: subnetSet,
+			subnetSet:    invalidDHCPSubnetSet,
+			user:         "fake-user",
+			isAllowed:    false,
+			msg:          "SubnetSet ns-1/fake-subnetset has invalid DHCP configuration: invalid DHCP relay server address dhcp-server",
+		},
+		{
+			name:         "Update SubnetSet AccessMode",
+			op:           admissionv1.Update,
+			oldSubnetSet: subnetSet,
+			subnetSet:    publicSubnetSet,
+			user:         "fake-user",
+			isAllowed:    true,
+		},
+		{
+			name:         "Update SubnetSet AccessMode with SubnetPorts",
+			op:           admissionv1.Update,
+			oldSubnetSet: subnetSetWithStalePorts,
+			subnetSet:    publicSubnetSetWithStalePorts,
+			user:         "fake-user",
+			isAllowed:    false,
+			msg:          "accessMode of SubnetSet ns-1/subnetset-1 with SubnetPorts cannot be changed",
+		},
 		{
 			name:         "Update default SubnetSet",
 			op:           admissionv1.Update,
@@ -204,3 +305,41 @@ func TestSubnetSetValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestSubnetSetValidatorNetworkQuota(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(newScheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(
+		&v1alpha1.SubnetSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "subnetset-1"},
+			Status: v1alpha1.SubnetSetStatus{Subnets: []v1alpha1.SubnetInfo{
+				{NetworkAddresses: []string{"10.0.0.0/26"}},
+			}},
+		},
+		&v1alpha1.NetworkQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "quota-1"},
+			Spec:       v1alpha1.NetworkQuotaSpec{Hard: v1alpha1.NetworkQuotaResources{SubnetIPs: common.Int64(128)}},
+		},
+	).Build()
+	validator := &SubnetSetValidator{
+		Client:  fakeClient,
+		decoder: admission.NewDecoder(newScheme),
+	}
+	createRequest := func(size int, username string) admission.Request {
+		raw, _ := json.Marshal(&v1alpha1.SubnetSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "subnetset-2"},
+			Spec:       v1alpha1.SubnetSetSpec{IPv4SubnetSize: size},
+		})
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: runtime.RawExtension{Raw: raw}}}
+		req.UserInfo.Username = username
+		return req
+	}
+
+	assert.Equal(t, admission.Allowed(""), validator.Handle(context.TODO(), createRequest(64, "")))
+	assert.Equal(t, admission.Denied("SubnetSet ns1/subnetset-2 is denied: NetworkQuota quota-1 exceeded: subnetIPs (used 64, requested 128, limit 128)"),
+		validator.Handle(context.TODO(), createRequest(128, "")))
+	// The SubnetSets created by nsx-operator are limited too.
+	assert.Equal(t, admission.Denied("SubnetSet ns1/subnetset-2 is denied: NetworkQuota quota-1 exceeded: subnetIPs (used 64, requested 128, limit 128)"),
+		validator.Handle(context.TODO(), createRequest(128, NSXOperatorSA)))
+}
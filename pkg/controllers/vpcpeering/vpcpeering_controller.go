@@ -0,0 +1,330 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package vpcpeering
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"reflect"
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	commonservice "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpcpeering"
+)
+
+var (
+	log                     = &logger.Log
+	ResultNormal            = common.ResultNormal
+	ResultRequeue           = common.ResultRequeue
+	MetricResTypeVPCPeering = common.MetricResTypeVPCPeering
+)
+
+// VPCPeeringReconciler reconciles a VPCPeering object
+type VPCPeeringReconciler struct {
+	Client        client.Client
+	Scheme        *apimachineryruntime.Scheme
+	Service       *vpcpeering.VPCPeeringService
+	Recorder      record.EventRecorder
+	StatusUpdater common.StatusUpdater
+}
+
+func setVPCPeeringReadyStatusTrue(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, args ...interface{}) {
+	vpcPeering := obj.(*v1alpha1.VPCPeering)
+	peers := vpcPeering.Status.Peers
+	if len(args) == 1 {
+		peers = args[0].([]v1alpha1.VPCPeeringPeerStatus)
+	}
+	updateVPCPeeringStatus(client, ctx, vpcPeering, v1alpha1.Condition{
+		Type:               v1alpha1.Ready,
+		Status:             v1.ConditionTrue,
+		Message:            "NSX static routes of the VPC peering have been successfully created/updated",
+		Reason:             "VPCPeeringReady",
+		LastTransitionTime: transitionTime,
+	}, peers)
+}
+
+func setVPCPeeringReadyStatusFalse(client client.Client, ctx context.Context, obj client.Object, transitionTime metav1.Time, err error, _ ...interface{}) {
+	vpcPeering := obj.(*v1alpha1.VPCPeering)
+	updateVPCPeeringStatus(client, ctx, vpcPeering, v1alpha1.Condition{
+		Type:               v1alpha1.Ready,
+		Status:             v1.ConditionFalse,
+		Message:            fmt.Sprintf("Error occurred while processing the VPCPeering CR. Error: %v", err),
+		Reason:             "VPCPeeringNotReady",
+		LastTransitionTime: transitionTime,
+	}, vpcPeering.Status.Peers)
+}
+
+// updateVPCPeeringStatus updates the status of the VPCPeering only if it is changed, so that the status update
+// doesn't trigger the reconciliation of the VPCPeering again.
+func updateVPCPeeringStatus(client client.Client, ctx context.Context, vpcPeering *v1alpha1.VPCPeering, newCondition v1alpha1.Condition, peers []v1alpha1.VPCPeeringPeerStatus) {
+	conditionUpdated := mergeVPCPeeringStatusCondition(vpcPeering, &newCondition)
+	if !conditionUpdated && reflect.DeepEqual(vpcPeering.Status.Peers, peers) {
+		return
+	}
+	vpcPeering.Status.Peers = peers
+	if err := client.Status().Update(ctx, vpcPeering); err != nil {
+		log.Error(err, "Failed to update VPCPeering status", "Name", vpcPeering.Name)
+		return
+	}
+	log.V(1).Info("Updated VPCPeering status", "Name", vpcPeering.Name, "New Condition", newCondition)
+}
+
+func mergeVPCPeeringStatusCondition(vpcPeering *v1alpha1.VPCPeering, newCondition *v1alpha1.Condition) bool {
+	for i := range vpcPeering.Status.Conditions {
+		matchedCondition := &vpcPeering.Status.Conditions[i]
+		if matchedCondition.Type != newCondition.Type {
+			continue
+		}
+		if matchedCondition.Status == newCondition.Status && matchedCondition.Reason == newCondition.Reason && matchedCondition.Message == newCondition.Message {
+			return false
+		}
+		if matchedCondition.Status != newCondition.Status {
+			matchedCondition.LastTransitionTime = newCondition.LastTransitionTime
+		}
+		matchedCondition.Reason = newCondition.Reason
+		matchedCondition.Message = newCondition.Message
+		matchedCondition.Status = newCondition.Status
+		return true
+	}
+	vpcPeering.Status.Conditions = append(vpcPeering.Status.Conditions, *newCondition)
+	return true
+}
+
+func (r *VPCPeeringReconciler) deleteVPCPeeringByName(name string) error {
+	for _, item := range r.Service.ListStaticRouteByName(name) {
+		log.Info("Deleting VPC peering static route", "Name", name, "nsxStaticRouteId", *item.Id)
+		if err := r.Service.DeleteStaticRoute(item); err != nil {
+			log.Error(err, "Failed to delete VPC peering static route", "nsxStaticRouteId", *item.Id)
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *VPCPeeringReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := &v1alpha1.VPCPeering{}
+	log.Info("Reconciling VPCPeering CR", "VPCPeering", req.NamespacedName)
+	r.StatusUpdater.IncreaseSyncTotal()
+
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			if err := r.deleteVPCPeeringByName(req.Name); err != nil {
+				r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
+				return ResultRequeue, err
+			}
+			r.StatusUpdater.DeleteSuccess(req.NamespacedName, nil)
+			return ResultNormal, nil
+		}
+		log.Error(err, "Unable to fetch VPCPeering CR", "req", req.NamespacedName)
+		return ResultRequeue, err
+	}
+
+	if !obj.ObjectMeta.DeletionTimestamp.IsZero() {
+		r.StatusUpdater.IncreaseDeleteTotal()
+		if err := r.Service.DeleteVPCPeeringByCR(obj); err != nil {
+			r.StatusUpdater.DeleteFail(req.NamespacedName, nil, err)
+			return ResultRequeue, err
+		}
+		r.StatusUpdater.DeleteSuccess(req.NamespacedName, nil)
+		return ResultNormal, nil
+	}
+
+	r.StatusUpdater.IncreaseUpdateTotal()
+	networks, err := r.resolveNetworks(ctx, obj)
+	if err != nil {
+		r.StatusUpdater.UpdateFail(ctx, obj, err, "failed to resolve the networks", setVPCPeeringReadyStatusFalse)
+		return ResultRequeue, err
+	}
+	peers, err := r.Service.CreateOrUpdateVPCPeering(obj, networks)
+	if err != nil {
+		r.StatusUpdater.UpdateFail(ctx, obj, err, "", setVPCPeeringReadyStatusFalse)
+		return ResultRequeue, err
+	}
+	r.StatusUpdater.UpdateSuccess(ctx, obj, setVPCPeeringReadyStatusTrue, peers)
+	return ResultNormal, nil
+}
+
+// resolveNetworks resolves the exchanged networks of each peer from the CIDRs and the network addresses of the
+// referenced Subnets in the peer Namespace. The CIDRs must be in the private IPs or the Subnets of the VPC of
+// the peer, so that a peer can't route the networks it doesn't own. The duplicated networks are removed.
+func (r *VPCPeeringReconciler) resolveNetworks(ctx context.Context, obj *v1alpha1.VPCPeering) ([][]string, error) {
+	networks := make([][]string, len(obj.Spec.Peers))
+	for i, peer := range obj.Spec.Peers {
+		var peerNetworks []string
+		var vpcNetworks []netip.Prefix
+		if len(peer.CIDRs) > 0 {
+			var err error
+			if vpcNetworks, err = r.getVPCNetworks(ctx, peer.Namespace); err != nil {
+				return nil, err
+			}
+		}
+		for _, cidr := range peer.CIDRs {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %s of Namespace %s: %w", cidr, peer.Namespace, err)
+			}
+			prefix = prefix.Masked()
+			if !slices.ContainsFunc(vpcNetworks, func(network netip.Prefix) bool { return prefixContains(network, prefix) }) {
+				return nil, fmt.Errorf("CIDR %s of Namespace %s is not in the private IPs or the Subnets of the VPC", cidr, peer.Namespace)
+			}
+			peerNetworks = append(peerNetworks, prefix.String())
+		}
+		for _, subnetName := range peer.Subnets {
+			subnet := &v1alpha1.Subnet{}
+			if err := r.Client.Get(ctx, types.NamespacedName{Namespace: peer.Namespace, Name: subnetName}, subnet); err != nil {
+				return nil, err
+			}
+			if len(subnet.Status.NetworkAddresses) == 0 {
+				return nil, fmt.Errorf("Subnet %s/%s has no network addresses", peer.Namespace, subnetName)
+			}
+			peerNetworks = append(peerNetworks, subnet.Status.NetworkAddresses...)
+		}
+		seen := sets.New[string]()
+		for _, network := range peerNetworks {
+			if !seen.Has(network) {
+				seen.Insert(network)
+				networks[i] = append(networks[i], network)
+			}
+		}
+	}
+	return networks, nil
+}
+
+// getVPCNetworks returns the private IPs and the network addresses of the Subnets of the VPC of the Namespace,
+// which are reported in the NetworkInfo of the Namespace.
+func (r *VPCPeeringReconciler) getVPCNetworks(ctx context.Context, namespace string) ([]netip.Prefix, error) {
+	networkInfoList := &v1alpha1.NetworkInfoList{}
+	if err := r.Client.List(ctx, networkInfoList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	var networks []netip.Prefix
+	appendNetworks := func(cidrs []string) {
+		for _, cidr := range cidrs {
+			if prefix, err := netip.ParsePrefix(cidr); err == nil {
+				networks = append(networks, prefix.Masked())
+			}
+		}
+	}
+	for _, networkInfo := range networkInfoList.Items {
+		for _, vpc := range networkInfo.VPCs {
+			appendNetworks(vpc.PrivateIPs)
+			for _, subnet := range vpc.Subnets {
+				appendNetworks(subnet.NetworkAddresses)
+			}
+		}
+	}
+	return networks, nil
+}
+
+// prefixContains returns true if the network contains the prefix.
+func prefixContains(network netip.Prefix, prefix netip.Prefix) bool {
+	return network.Addr().Is4() == prefix.Addr().Is4() && network.Bits() <= prefix.Bits() && network.Contains(prefix.Addr())
+}
+
+// referenceMapFunc returns a map function which enqueues the VPCPeerings with a peer matching the object.
+func (r *VPCPeeringReconciler) referenceMapFunc(matches func(peer *v1alpha1.VPCPeeringPeer, obj client.Object) bool) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		vpcPeeringList := &v1alpha1.VPCPeeringList{}
+		if err := r.Client.List(ctx, vpcPeeringList); err != nil {
+			log.Error(err, "Failed to list VPCPeering CR")
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range vpcPeeringList.Items {
+			vpcPeering := &vpcPeeringList.Items[i]
+			if slices.ContainsFunc(vpcPeering.Spec.Peers, func(peer v1alpha1.VPCPeeringPeer) bool { return matches(&peer, obj) }) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: vpcPeering.Name}})
+			}
+		}
+		return requests
+	}
+}
+
+func subnetMatches(peer *v1alpha1.VPCPeeringPeer, obj client.Object) bool {
+	return peer.Namespace == obj.GetNamespace() && slices.Contains(peer.Subnets, obj.GetName())
+}
+
+// networkInfoMatches enqueues the VPCPeering when the VPC of the peer Namespace is created or updated.
+func networkInfoMatches(peer *v1alpha1.VPCPeeringPeer, obj client.Object) bool {
+	return peer.Namespace == obj.GetNamespace()
+}
+
+func (r *VPCPeeringReconciler) setupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.VPCPeering{}).
+		WithOptions(
+			controller.Options{
+				MaxConcurrentReconciles: common.NumReconcile(),
+			}).
+		Watches(&v1alpha1.Subnet{}, handler.EnqueueRequestsFromMapFunc(r.referenceMapFunc(subnetMatches))).
+		Watches(&v1alpha1.NetworkInfo{}, handler.EnqueueRequestsFromMapFunc(r.referenceMapFunc(networkInfoMatches))).
+		Complete(r)
+}
+
+// CollectGarbage collects the NSX static routes whose VPCPeering CRs have been removed.
+// It implements the interface GarbageCollector method.
+func (r *VPCPeeringReconciler) CollectGarbage(ctx context.Context) {
+	log.Info("VPC peering garbage collector started")
+	nsxStaticRouteList := r.Service.ListStaticRoute()
+	if len(nsxStaticRouteList) == 0 {
+		return
+	}
+
+	crVPCPeeringList := &v1alpha1.VPCPeeringList{}
+	if err := r.Client.List(ctx, crVPCPeeringList); err != nil {
+		log.Error(err, "Failed to list VPCPeering CR")
+		return
+	}
+	crVPCPeeringSet := sets.New[string]()
+	for _, vpcPeering := range crVPCPeeringList.Items {
+		crVPCPeeringSet.Insert(string(vpcPeering.UID))
+	}
+
+	for _, nsxStaticRoute := range nsxStaticRouteList {
+		UID := r.Service.GetUID(nsxStaticRoute)
+		if UID == nil || crVPCPeeringSet.Has(*UID) {
+			continue
+		}
+		log.V(1).Info("GC collected VPC peering static route", "UID", *UID)
+		r.StatusUpdater.IncreaseDeleteTotal()
+		if err := r.Service.DeleteStaticRoute(nsxStaticRoute); err != nil {
+			r.StatusUpdater.IncreaseDeleteFailTotal()
+		} else {
+			r.StatusUpdater.IncreaseDeleteSuccessTotal()
+		}
+	}
+}
+
+func StartVPCPeeringController(mgr ctrl.Manager, vpcPeeringService *vpcpeering.VPCPeeringService) {
+	vpcPeeringReconciler := VPCPeeringReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Service:  vpcPeeringService,
+		Recorder: mgr.GetEventRecorderFor("vpcpeering-controller"),
+	}
+	vpcPeeringReconciler.StatusUpdater = common.NewStatusUpdater(vpcPeeringReconciler.Client, vpcPeeringReconciler.Service.NSXConfig, vpcPeeringReconciler.Recorder, MetricResTypeVPCPeering, "StaticRoute", "VPCPeering")
+	if err := vpcPeeringReconciler.setupWithManager(mgr); err != nil {
+		log.Error(err, "Failed to create controller", "controller", "VPCPeering")
+		os.Exit(1)
+	}
+	go common.GenericGarbageCollector(make(chan bool), commonservice.GCInterval, vpcPeeringReconciler.CollectGarbage)
+}
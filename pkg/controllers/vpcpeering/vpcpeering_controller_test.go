@@ -0,0 +1,240 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package vpcpeering
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/controllers/common"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/vpcpeering"
+)
+
+func createVPCPeeringReconciler(objs ...client.Object) *VPCPeeringReconciler {
+	newScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(newScheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(objs...).WithStatusSubresource(&v1alpha1.VPCPeering{}).Build()
+	service := &vpcpeering.VPCPeeringService{
+		Service: servicecommon.Service{
+			NSXConfig: &config.NSXOperatorConfig{
+				NsxConfig: &config.NsxConfig{},
+				CoeConfig: &config.CoeConfig{Cluster: "k8scl-one:test"},
+			},
+		},
+	}
+	r := &VPCPeeringReconciler{
+		Client:   fakeClient,
+		Scheme:   newScheme,
+		Service:  service,
+		Recorder: &record.FakeRecorder{},
+	}
+	r.StatusUpdater = common.NewStatusUpdater(r.Client, r.Service.NSXConfig, r.Recorder, MetricResTypeVPCPeering, "StaticRoute", "VPCPeering")
+	return r
+}
+
+func TestVPCPeeringReconciler_ResolveNetworks(t *testing.T) {
+	subnet := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet-1", Namespace: "ns-1"},
+		Status:     v1alpha1.SubnetStatus{NetworkAddresses: []string{"10.0.0.0/28"}},
+	}
+	pendingSubnet := &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet-2", Namespace: "ns-1"}}
+	networkInfo1 := &v1alpha1.NetworkInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns-1", Namespace: "ns-1"},
+		VPCs:       []v1alpha1.VPCState{{Name: "vpc-1", PrivateIPs: []string{"10.0.0.0/16"}}},
+	}
+	networkInfo2 := &v1alpha1.NetworkInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns-2", Namespace: "ns-2"},
+		VPCs: []v1alpha1.VPCState{{
+			Name:       "vpc-2",
+			PrivateIPs: []string{"172.16.0.0/16"},
+			Subnets:    []v1alpha1.SubnetState{{Name: "public", Kind: "Subnet", NetworkAddresses: []string{"10.1.0.0/24"}}},
+		}},
+	}
+	r := createVPCPeeringReconciler(subnet, pendingSubnet, networkInfo1, networkInfo2)
+	ctx := context.TODO()
+
+	obj := &v1alpha1.VPCPeering{
+		ObjectMeta: metav1.ObjectMeta{Name: "peering-1"},
+		Spec: v1alpha1.VPCPeeringSpec{Peers: []v1alpha1.VPCPeeringPeer{
+			{Namespace: "ns-1", Subnets: []string{"subnet-1"}, CIDRs: []string{"10.0.0.0/28", "10.0.1.5/24"}},
+			{Namespace: "ns-2", CIDRs: []string{"10.1.0.0/24"}},
+		}},
+	}
+	networks, err := r.resolveNetworks(ctx, obj)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"10.0.0.0/28", "10.0.1.0/24"}, {"10.1.0.0/24"}}, networks)
+
+	// The referenced Subnets are not found or not realized.
+	obj.Spec.Peers[0].Subnets = []string{"subnet-3"}
+	_, err = r.resolveNetworks(ctx, obj)
+	assert.ErrorContains(t, err, "not found")
+	obj.Spec.Peers[0].Subnets = []string{"subnet-2"}
+	_, err = r.resolveNetworks(ctx, obj)
+	assert.ErrorContains(t, err, "Subnet ns-1/subnet-2 has no network addresses")
+
+	obj.Spec.Peers[0].Subnets = nil
+	obj.Spec.Peers[1].CIDRs = []string{"10.1.0.0"}
+	_, err = r.resolveNetworks(ctx, obj)
+	assert.ErrorContains(t, err, "invalid CIDR 10.1.0.0 of Namespace ns-2")
+
+	// The CIDRs out of the VPC of the peer are rejected.
+	for _, cidr := range []string{"0.0.0.0/0", "10.1.0.0/23", "10.0.0.0/24", "fd00::/64"} {
+		obj.Spec.Peers[1].CIDRs = []string{cidr}
+		_, err = r.resolveNetworks(ctx, obj)
+		assert.ErrorContains(t, err, "CIDR "+cidr+" of Namespace ns-2 is not in the private IPs or the Subnets of the VPC")
+	}
+	obj.Spec.Peers[1].CIDRs = []string{"172.16.1.0/24", "10.1.0.128/25"}
+	networks, err = r.resolveNetworks(ctx, obj)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"172.16.1.0/24", "10.1.0.128/25"}, networks[1])
+
+	// The CIDRs are rejected before the VPC of the peer is reported.
+	obj.Spec.Peers[1].Namespace = "ns-3"
+	_, err = r.resolveNetworks(ctx, obj)
+	assert.ErrorContains(t, err, "CIDR 172.16.1.0/24 of Namespace ns-3 is not in the private IPs or the Subnets of the VPC")
+}
+
+func TestVPCPeeringReconciler_Reconcile(t *testing.T) {
+	vpcPeeringCR := &v1alpha1.VPCPeering{
+		ObjectMeta: metav1.ObjectMeta{Name: "peering-1", UID: "uid-1"},
+		Spec: v1alpha1.VPCPeeringSpec{Peers: []v1alpha1.VPCPeeringPeer{
+			{Namespace: "ns-1", CIDRs: []string{"10.0.0.0/24"}},
+			{Namespace: "ns-2", CIDRs: []string{"10.1.0.0/24"}},
+		}},
+	}
+	networkInfos := []client.Object{
+		&v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Name: "ns-1", Namespace: "ns-1"}, VPCs: []v1alpha1.VPCState{{Name: "vpc-1", PrivateIPs: []string{"10.0.0.0/16"}}}},
+		&v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Name: "ns-2", Namespace: "ns-2"}, VPCs: []v1alpha1.VPCState{{Name: "vpc-2", PrivateIPs: []string{"10.1.0.0/16"}}}},
+	}
+	r := createVPCPeeringReconciler(append(networkInfos, vpcPeeringCR)...)
+	ctx := context.TODO()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "peering-1"}}
+
+	// Failed to create the NSX static routes.
+	patches := gomonkey.ApplyMethod(r.Service, "CreateOrUpdateVPCPeering", func(_ *vpcpeering.VPCPeeringService, obj *v1alpha1.VPCPeering, networks [][]string) ([]v1alpha1.VPCPeeringPeerStatus, error) {
+		return nil, errors.New("patch failed")
+	})
+	_, err := r.Reconcile(ctx, req)
+	assert.ErrorContains(t, err, "patch failed")
+	obj := &v1alpha1.VPCPeering{}
+	assert.Nil(t, r.Client.Get(ctx, req.NamespacedName, obj))
+	assert.Equal(t, v1.ConditionFalse, obj.Status.Conditions[0].Status)
+	patches.Reset()
+
+	peers := []v1alpha1.VPCPeeringPeerStatus{
+		{Namespace: "ns-1", Routes: []v1alpha1.VPCPeeringRoute{{Network: "10.1.0.0/24", NextHop: "100.64.0.1"}}},
+		{Namespace: "ns-2", Routes: []v1alpha1.VPCPeeringRoute{{Network: "10.0.0.0/24", NextHop: "100.64.0.1"}}},
+	}
+	patches = gomonkey.ApplyMethod(r.Service, "CreateOrUpdateVPCPeering", func(_ *vpcpeering.VPCPeeringService, obj *v1alpha1.VPCPeering, networks [][]string) ([]v1alpha1.VPCPeeringPeerStatus, error) {
+		assert.Equal(t, [][]string{{"10.0.0.0/24"}, {"10.1.0.0/24"}}, networks)
+		return peers, nil
+	})
+	result, err := r.Reconcile(ctx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, ResultNormal, result)
+	assert.Nil(t, r.Client.Get(ctx, req.NamespacedName, obj))
+	assert.Equal(t, v1.ConditionTrue, obj.Status.Conditions[0].Status)
+	assert.Equal(t, peers, obj.Status.Peers)
+	patches.Reset()
+
+	// The NSX static routes are deleted by name if the CR is not found.
+	assert.Nil(t, r.Client.Delete(ctx, obj))
+	deleted := 0
+	patches = gomonkey.ApplyMethod(r.Service, "ListStaticRouteByName", func(_ *vpcpeering.VPCPeeringService, name string) []*model.StaticRoutes {
+		return []*model.StaticRoutes{{Id: servicecommon.String("peering-1_uid-1_1")}, {Id: servicecommon.String("peering-1_uid-1_2")}}
+	})
+	defer patches.Reset()
+	patches.ApplyMethod(r.Service, "DeleteStaticRoute", func(_ *vpcpeering.VPCPeeringService, nsxStaticRoute *model.StaticRoutes) error {
+		deleted++
+		return nil
+	})
+	result, err = r.Reconcile(ctx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, ResultNormal, result)
+	assert.Equal(t, 2, deleted)
+}
+
+func TestVPCPeeringReconciler_MapFunc(t *testing.T) {
+	vpcPeering1 := &v1alpha1.VPCPeering{
+		ObjectMeta: metav1.ObjectMeta{Name: "peering-1"},
+		Spec: v1alpha1.VPCPeeringSpec{Peers: []v1alpha1.VPCPeeringPeer{
+			{Namespace: "ns-1", Subnets: []string{"subnet-1"}},
+			{Namespace: "ns-2", CIDRs: []string{"10.1.0.0/24"}},
+		}},
+	}
+	vpcPeering2 := &v1alpha1.VPCPeering{
+		ObjectMeta: metav1.ObjectMeta{Name: "peering-2"},
+		Spec: v1alpha1.VPCPeeringSpec{Peers: []v1alpha1.VPCPeeringPeer{
+			{Namespace: "ns-1", CIDRs: []string{"10.0.0.0/24"}},
+			{Namespace: "ns-3", Subnets: []string{"subnet-1"}},
+		}},
+	}
+	r := createVPCPeeringReconciler(vpcPeering1, vpcPeering2)
+	ctx := context.TODO()
+
+	requests := r.referenceMapFunc(subnetMatches)(ctx, &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet-1", Namespace: "ns-1"}})
+	assert.Equal(t, []ctrl.Request{{NamespacedName: types.NamespacedName{Name: "peering-1"}}}, requests)
+	requests = r.referenceMapFunc(subnetMatches)(ctx, &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet-1", Namespace: "ns-2"}})
+	assert.Empty(t, requests)
+	requests = r.referenceMapFunc(networkInfoMatches)(ctx, &v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Name: "ns-1", Namespace: "ns-1"}})
+	assert.Equal(t, 2, len(requests))
+	requests = r.referenceMapFunc(networkInfoMatches)(ctx, &v1alpha1.NetworkInfo{ObjectMeta: metav1.ObjectMeta{Name: "ns-4", Namespace: "ns-4"}})
+	assert.Empty(t, requests)
+}
+
+func TestVPCPeeringReconciler_CollectGarbage(t *testing.T) {
+	vpcPeeringCR := &v1alpha1.VPCPeering{ObjectMeta: metav1.ObjectMeta{Name: "peering-1", UID: "uid-1"}}
+	r := createVPCPeeringReconciler(vpcPeeringCR)
+
+	patches := gomonkey.ApplyMethod(r.Service, "ListStaticRoute", func(_ *vpcpeering.VPCPeeringService) []*model.StaticRoutes {
+		return []*model.StaticRoutes{
+			{Id: servicecommon.String("peering-1_uid-1_1"), Tags: []model.Tag{{Scope: servicecommon.String(servicecommon.TagScopeVPCPeeringCRUID), Tag: servicecommon.String("uid-1")}}},
+			{Id: servicecommon.String("peering-2_uid-2_1"), Tags: []model.Tag{{Scope: servicecommon.String(servicecommon.TagScopeVPCPeeringCRUID), Tag: servicecommon.String("uid-2")}}},
+		}
+	})
+	defer patches.Reset()
+	var deleted []string
+	patches.ApplyMethod(r.Service, "DeleteStaticRoute", func(_ *vpcpeering.VPCPeeringService, nsxStaticRoute *model.StaticRoutes) error {
+		deleted = append(deleted, *nsxStaticRoute.Id)
+		return nil
+	})
+	r.CollectGarbage(context.TODO())
+	assert.Equal(t, []string{"peering-2_uid-2_1"}, deleted)
+}
+
+func TestMergeVPCPeeringStatusCondition(t *testing.T) {
+	transitionTime := metav1.Now()
+	vpcPeering := &v1alpha1.VPCPeering{}
+	readyCondition := v1alpha1.Condition{Type: v1alpha1.Ready, Status: v1.ConditionTrue, Reason: "VPCPeeringReady", LastTransitionTime: transitionTime}
+	assert.True(t, mergeVPCPeeringStatusCondition(vpcPeering, &readyCondition))
+
+	// The condition is not updated if only the transition time is changed.
+	readyCondition.LastTransitionTime = metav1.NewTime(transitionTime.Add(time.Minute))
+	assert.False(t, mergeVPCPeeringStatusCondition(vpcPeering, &readyCondition))
+
+	notReadyCondition := v1alpha1.Condition{Type: v1alpha1.Ready, Status: v1.ConditionFalse, Reason: "VPCPeeringNotReady", LastTransitionTime: readyCondition.LastTransitionTime}
+	assert.True(t, mergeVPCPeeringStatusCondition(vpcPeering, &notReadyCondition))
+	assert.Equal(t, 1, len(vpcPeering.Status.Conditions))
+	assert.Equal(t, notReadyCondition, vpcPeering.Status.Conditions[0])
+}
@@ -21,6 +21,15 @@ const (
 	ControllerDeleteTotalKey        = "controller_delete_total"
 	ControllerDeleteSuccessTotalKey = "controller_delete_success_total"
 	ControllerDeleteFailTotalKey    = "controller_delete_fail_total"
+	RuleHitCountKey                 = "security_policy_rule_hit_count"
+	RulePacketCountKey              = "security_policy_rule_packet_count"
+	RuleByteCountKey                = "security_policy_rule_byte_count"
+	RuleSessionCountKey             = "security_policy_rule_session_count"
+	SubnetIPTotalKey                = "subnet_ip_total"
+	SubnetIPAllocatedKey            = "subnet_ip_allocated"
+	SubnetIPAvailableKey            = "subnet_ip_available"
+	SubnetPortPoolHitTotalKey       = "subnetport_pool_hit_total"
+	SubnetPortPoolMissTotalKey      = "subnetport_pool_miss_total"
 	ScrapeTimeout                   = 30
 )
 
@@ -100,7 +109,105 @@ var (
 	)
 )
 
-var registerMetrics sync.Once
+var (
+	ruleStatisticsLabels = []string{"namespace", "policy", "policy_type", "rule"}
+	RuleHitCount         = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      RuleHitCountKey,
+			Help:      "Hit count of the NSX rules realized for SecurityPolicy and NetworkPolicy",
+		},
+		ruleStatisticsLabels,
+	)
+	RulePacketCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      RulePacketCountKey,
+			Help:      "Number of packets matching the NSX rules realized for SecurityPolicy and NetworkPolicy",
+		},
+		ruleStatisticsLabels,
+	)
+	RuleByteCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      RuleByteCountKey,
+			Help:      "Number of bytes matching the NSX rules realized for SecurityPolicy and NetworkPolicy",
+		},
+		ruleStatisticsLabels,
+	)
+	RuleSessionCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      RuleSessionCountKey,
+			Help:      "Number of sessions matching the NSX rules realized for SecurityPolicy and NetworkPolicy",
+		},
+		ruleStatisticsLabels,
+	)
+)
+
+var (
+	subnetIPUtilizationLabels = []string{"namespace", "kind", "name", "nsx_subnet"}
+	SubnetIPTotal             = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      SubnetIPTotalKey,
+			Help:      "Number of the IPs which can be allocated to the SubnetPorts in the NSX Subnet of Subnet and SubnetSet",
+		},
+		subnetIPUtilizationLabels,
+	)
+	SubnetIPAllocated = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      SubnetIPAllocatedKey,
+			Help:      "Number of the IPs allocated to the SubnetPorts in the NSX Subnet of Subnet and SubnetSet",
+		},
+		subnetIPUtilizationLabels,
+	)
+	SubnetIPAvailable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      SubnetIPAvailableKey,
+			Help:      "Number of the IPs available for the new SubnetPorts in the NSX Subnet of Subnet and SubnetSet",
+		},
+		subnetIPUtilizationLabels,
+	)
+)
+
+var (
+	subnetPortPoolLabels   = []string{"namespace", "subnetset"}
+	SubnetPortPoolHitTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      SubnetPortPoolHitTotalKey,
+			Help:      "Total number of the Pods which got a pre-created NSX SubnetPort from the SubnetPort pool",
+		},
+		subnetPortPoolLabels,
+	)
+	SubnetPortPoolMissTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricNamespace,
+			Subsystem: MetricSubsystem,
+			Name:      SubnetPortPoolMissTotalKey,
+			Help:      "Total number of the Pods for which the NSX SubnetPort was created as the SubnetPort pool was empty",
+		},
+		subnetPortPoolLabels,
+	)
+)
+
+var (
+	registerMetrics                    sync.Once
+	registerRuleStatisticsMetrics      sync.Once
+	registerSubnetIPUtilizationMetrics sync.Once
+	registerSubnetPortPoolMetrics      sync.Once
+)
 
 // Register all metrics.
 func Register(m ...prometheus.Collector) {
@@ -124,6 +231,33 @@ func InitializePrometheusMetrics() {
 	)
 }
 
+// InitializeRuleStatisticsMetrics registers the rule statistics metrics, which are exposed
+// only if the rule statistics collection is enabled.
+func InitializeRuleStatisticsMetrics() {
+	registerRuleStatisticsMetrics.Do(func() {
+		log.Info("Initializing rule statistics metrics")
+		metrics.Registry.MustRegister(RuleHitCount, RulePacketCount, RuleByteCount, RuleSessionCount)
+	})
+}
+
+// InitializeSubnetIPUtilizationMetrics registers the Subnet IP utilization metrics, which are exposed
+// only if the IP utilization report is enabled.
+func InitializeSubnetIPUtilizationMetrics() {
+	registerSubnetIPUtilizationMetrics.Do(func() {
+		log.Info("Initializing Subnet IP utilization metrics")
+		metrics.Registry.MustRegister(SubnetIPTotal, SubnetIPAllocated, SubnetIPAvailable)
+	})
+}
+
+// InitializeSubnetPortPoolMetrics registers the SubnetPort pool metrics, which are exposed
+// only if the SubnetPort pool is enabled.
+func InitializeSubnetPortPoolMetrics() {
+	registerSubnetPortPoolMetrics.Do(func() {
+		log.Info("Initializing SubnetPort pool metrics")
+		metrics.Registry.MustRegister(SubnetPortPoolHitTotal, SubnetPortPoolMissTotal)
+	})
+}
+
 func AreMetricsExposed(cf *config.NSXOperatorConfig) bool {
 	if cf.EnforcementPoint == "vmc-enforcementpoint" {
 		return true
@@ -5,6 +5,7 @@ import (
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 )
 
@@ -52,6 +53,11 @@ func (m *MockVPCServiceProvider) ListVPCInfo(ns string) []common.VPCResourceInfo
 	return arg.Get(0).([]common.VPCResourceInfo)
 }
 
+func (m *MockVPCServiceProvider) ValidateAccessModeIPBlocks(ns string, vpcInfo common.VPCResourceInfo, accessMode string) error {
+	arg := m.Called(ns, vpcInfo, accessMode)
+	return arg.Error(0)
+}
+
 type MockSubnetServiceProvider struct {
 	mock.Mock
 }
@@ -91,6 +97,16 @@ func (m *MockSubnetPortServiceProvider) AllocatePortFromSubnet(subnet *model.Vpc
 	return true
 }
 
+func (m *MockSubnetPortServiceProvider) GetFreeIPCount(subnet *model.VpcSubnet) int {
+	arg := m.Called(subnet)
+	return arg.Int(0)
+}
+
+func (m *MockSubnetPortServiceProvider) GetIPUtilization(subnet *model.VpcSubnet) v1alpha1.IPUtilization {
+	arg := m.Called(subnet)
+	return arg.Get(0).(v1alpha1.IPUtilization)
+}
+
 func (m *MockSubnetPortServiceProvider) ReleasePortInSubnet(path string) {
 	return
 }
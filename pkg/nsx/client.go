@@ -62,6 +62,8 @@ type Client struct {
 	RuleClient     security_policies.RulesClient
 	InfraClient    nsx_policy.InfraClient
 
+	SecurityPolicyStatisticsClient security_policies.StatisticsClient
+
 	ClusterControlPlanesClient enforcement_points.ClusterControlPlanesClient
 	HostTransPortNodesClient   enforcement_points.HostTransportNodesClient
 	SubnetStatusClient         subnets.StatusClient
@@ -75,6 +77,8 @@ type Client struct {
 	VPCSecurityClient vpcs.SecurityPoliciesClient
 	VPCRuleClient     vpc_sp.RulesClient
 
+	VPCSecurityPolicyStatisticsClient vpc_sp.StatisticsClient
+
 	OrgRootClient                     nsx_policy.OrgRootClient
 	ProjectInfraClient                projects.InfraClient
 	VPCClient                         projects.VpcsClient
@@ -88,6 +92,7 @@ type Client struct {
 	IPPoolClient                      subnets.IpPoolsClient
 	IPAllocationClient                ip_pools.IpAllocationsClient
 	SubnetsClient                     vpcs.SubnetsClient
+	DhcpStaticBindingConfigsClient    subnets.DhcpStaticBindingConfigsClient
 	IPAddressAllocationClient         vpcs.IpAddressAllocationsClient
 	VPCLBSClient                      vpcs.VpcLbsClient
 	VpcLbVirtualServersClient         vpcs.VpcLbVirtualServersClient
@@ -96,6 +101,7 @@ type Client struct {
 	ProjectClient                     orgs.ProjectsClient
 	TransitGatewayClient              projects.TransitGatewaysClient
 	TransitGatewayAttachmentClient    transit_gateways.AttachmentsClient
+	TransitGatewayStaticRouteClient   TransitGatewayStaticRoutesClient
 	CertificateClient                 infra.CertificatesClient
 	ShareClient                       infra.SharesClient
 	SharedResourceClient              shares.ResourcesClient
@@ -162,6 +168,7 @@ func GetClient(cf *config.NSXOperatorConfig) *Client {
 	securityClient := domains.NewSecurityPoliciesClient(restConnector(cluster))
 	ruleClient := security_policies.NewRulesClient(restConnector(cluster))
 	infraClient := nsx_policy.NewInfraClient(restConnector(cluster))
+	securityPolicyStatisticsClient := security_policies.NewStatisticsClient(restConnector(cluster))
 
 	clusterControlPlanesClient := enforcement_points.NewClusterControlPlanesClient(restConnector(cluster))
 	hostTransportNodesClient := enforcement_points.NewHostTransportNodesClient(restConnector(cluster))
@@ -185,6 +192,7 @@ func GetClient(cf *config.NSXOperatorConfig) *Client {
 	ipPoolClient := subnets.NewIpPoolsClient(restConnector(cluster))
 	ipAllocationClient := ip_pools.NewIpAllocationsClient(restConnector(cluster))
 	subnetsClient := vpcs.NewSubnetsClient(restConnector(cluster))
+	dhcpStaticBindingConfigsClient := subnets.NewDhcpStaticBindingConfigsClient(restConnector(cluster))
 	subnetStatusClient := subnets.NewStatusClient(restConnector(cluster))
 	ipAddressAllocationClient := vpcs.NewIpAddressAllocationsClient(restConnectorAllowOverwrite(cluster))
 	vpcLBSClient := vpcs.NewVpcLbsClient(restConnector(cluster))
@@ -194,6 +202,7 @@ func GetClient(cf *config.NSXOperatorConfig) *Client {
 
 	vpcSecurityClient := vpcs.NewSecurityPoliciesClient(restConnector(cluster))
 	vpcRuleClient := vpc_sp.NewRulesClient(restConnector(cluster))
+	vpcSecurityPolicyStatisticsClient := vpc_sp.NewStatisticsClient(restConnector(cluster))
 
 	transitGatewayClient := projects.NewTransitGatewaysClient(restConnector(cluster))
 	transitGatewayAttachmentClient := transit_gateways.NewAttachmentsClient(restConnector(cluster))
@@ -245,6 +254,8 @@ func GetClient(cf *config.NSXOperatorConfig) *Client {
 		SubnetStatusClient:                subnetStatusClient,
 		VPCSecurityClient:                 vpcSecurityClient,
 		VPCRuleClient:                     vpcRuleClient,
+		SecurityPolicyStatisticsClient:    securityPolicyStatisticsClient,
+		VPCSecurityPolicyStatisticsClient: vpcSecurityPolicyStatisticsClient,
 		VPCLBSClient:                      vpcLBSClient,
 		VpcLbVirtualServersClient:         vpcLbVirtualServersClient,
 		VpcLbPoolsClient:                  vpcLbPoolsClient,
@@ -255,9 +266,11 @@ func GetClient(cf *config.NSXOperatorConfig) *Client {
 		IPPoolClient:                      ipPoolClient,
 		IPAllocationClient:                ipAllocationClient,
 		SubnetsClient:                     subnetsClient,
+		DhcpStaticBindingConfigsClient:    dhcpStaticBindingConfigsClient,
 		IPAddressAllocationClient:         ipAddressAllocationClient,
 		TransitGatewayClient:              transitGatewayClient,
 		TransitGatewayAttachmentClient:    transitGatewayAttachmentClient,
+		TransitGatewayStaticRouteClient:   NewTransitGatewayStaticRoutesClient(cluster),
 		SubnetConnectionBindingMapsClient: subnetConnectionBindingMapsClient,
 		CertificateClient:                 certificateClient,
 		ShareClient:                       shareClient,
@@ -4,10 +4,12 @@
 package nsx
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
@@ -374,7 +376,7 @@ func (cluster *Cluster) GetVersion() (*NsxVersion, error) {
 
 // HttpGet sends a http GET request to the cluster, exported for use
 func (cluster *Cluster) HttpGet(url string) (map[string]interface{}, error) {
-	resp, err := cluster.httpAction(url, "GET")
+	resp, err := cluster.httpAction(url, "GET", nil)
 	if err != nil {
 		log.Error(err, "Failed to do HTTP GET operation")
 		return nil, err
@@ -384,15 +386,18 @@ func (cluster *Cluster) HttpGet(url string) (map[string]interface{}, error) {
 	return respJson, err
 }
 
-func (cluster *Cluster) httpAction(url, method string) (*http.Response, error) {
+func (cluster *Cluster) httpAction(url, method string, body io.Reader) (*http.Response, error) {
 	ep := cluster.endpoints[0]
 	serverUrl := cluster.CreateServerUrl(cluster.endpoints[0].Host(), cluster.endpoints[0].Scheme())
 	url = fmt.Sprintf("%s/%s", serverUrl, url)
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		log.Error(err, "Failed to create HTTP request")
 		return nil, err
 	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	log.V(1).Info(method+" url", "url", req.URL)
 	resp, err := ep.client.Do(req)
 	if err != nil {
@@ -403,7 +408,7 @@ func (cluster *Cluster) httpAction(url, method string) (*http.Response, error) {
 
 // HttpDelete sends a http DELETE request to the cluster, exported for use
 func (cluster *Cluster) HttpDelete(url string) error {
-	_, err := cluster.httpAction(url, "DELETE")
+	_, err := cluster.httpAction(url, "DELETE", nil)
 	if err != nil {
 		log.Error(err, "Failed to do HTTP DELETE operation")
 		return err
@@ -411,6 +416,17 @@ func (cluster *Cluster) HttpDelete(url string) error {
 	return nil
 }
 
+// HttpPatch sends a http PATCH request with the JSON body to the cluster, exported for use
+func (cluster *Cluster) HttpPatch(url string, body []byte) error {
+	resp, err := cluster.httpAction(url, "PATCH", bytes.NewReader(body))
+	if err != nil {
+		log.Error(err, "Failed to do HTTP PATCH operation")
+		return err
+	}
+	err, _ = util.HandleHTTPResponse(resp, nil, true)
+	return err
+}
+
 func (nsxVersion *NsxVersion) Validate() error {
 	re, _ := regexp.Compile(`^([\d]+).([\d]+).([\d]+)`)
 	result := re.Find([]byte(nsxVersion.NodeVersion))
@@ -476,7 +492,7 @@ func (nsxVersion *NsxVersion) featureSupported(feature int) bool {
 }
 
 func (cluster *Cluster) FetchLicense() error {
-	resp, err := cluster.httpAction(LicenseAPI, "GET")
+	resp, err := cluster.httpAction(LicenseAPI, "GET", nil)
 	if err != nil {
 		log.Error(err, "Failed to get NSX license")
 		return err
@@ -22,6 +22,7 @@ type VPCServiceProvider interface {
 	GetVPCNetworkConfigByNamespace(ns string) *VPCNetworkConfigInfo
 	GetDefaultNetworkConfig() (bool, *VPCNetworkConfigInfo)
 	ListVPCInfo(ns string) []VPCResourceInfo
+	ValidateAccessModeIPBlocks(ns string, vpcInfo VPCResourceInfo, accessMode string) error
 }
 
 type SubnetServiceProvider interface {
@@ -35,6 +36,8 @@ type SubnetServiceProvider interface {
 type SubnetPortServiceProvider interface {
 	GetPortsOfSubnet(nsxSubnetID string) (ports []*model.VpcSubnetPort)
 	AllocatePortFromSubnet(subnet *model.VpcSubnet) bool
+	GetFreeIPCount(subnet *model.VpcSubnet) int
+	GetIPUtilization(subnet *model.VpcSubnet) v1alpha1.IPUtilization
 	ReleasePortInSubnet(path string)
 	IsEmptySubnet(id string, path string) bool
 	DeletePortCount(path string)
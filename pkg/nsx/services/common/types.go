@@ -23,6 +23,8 @@ const (
 	MaxIdLength                        int    = 255
 	MaxNameLength                      int    = 255
 	MaxSubnetNameLength                int    = 80
+	IPv6SubnetSize                     int    = 64
+	StaticIPv4PoolID                   string = "static-ipv4-default"
 	VPCLbResourcePathMinSegments       int    = 8
 	PriorityNetworkPolicyAllowRule     int    = 2010
 	PriorityNetworkPolicyIsolationRule int    = 2090
@@ -45,9 +47,15 @@ const (
 	TagScopeNetworkPolicyUID           string = "nsx-op/network_policy_uid"
 	TagScopeStaticRouteCRName          string = "nsx-op/static_route_name"
 	TagScopeStaticRouteCRUID           string = "nsx-op/static_route_uid"
+	TagScopeNATRuleCRName              string = "nsx-op/nat_rule_name"
+	TagScopeNATRuleCRUID               string = "nsx-op/nat_rule_uid"
+	TagScopeVPCPeeringCRName           string = "nsx-op/vpc_peering_name"
+	TagScopeVPCPeeringCRUID            string = "nsx-op/vpc_peering_uid"
 	TagScopeRuleID                     string = "nsx-op/rule_id"
 	TagScopeGroupType                  string = "nsx-op/group_type"
 	TagScopeSelectorHash               string = "nsx-op/selector_hash"
+	TagScopeDHCPStaticBindingHash      string = "nsx-op/dhcp_static_binding_hash"
+	TagScopeReservedIPRangesHash       string = "nsx-op/reserved_ip_ranges_hash"
 	TagScopeNSXServiceAccountCRName    string = "nsx-op/nsx_service_account_name"
 	TagScopeNSXServiceAccountCRUID     string = "nsx-op/nsx_service_account_uid"
 	TagScopeNSXShareCreatedFor         string = "nsx-op/nsx_share_created_for"
@@ -70,6 +78,7 @@ const (
 	SystemVPCNetworkConfigurationName  string = "system"
 	TagScopeSubnetCRUID                string = "nsx-op/subnet_uid"
 	TagScopeSubnetCRName               string = "nsx-op/subnet_name"
+	TagScopeSubnetImported             string = "nsx-op/subnet_imported"
 	TagScopeSubnetSetCRName            string = "nsx-op/subnetset_name"
 	TagScopeSubnetSetCRUID             string = "nsx-op/subnetset_uid"
 	TagScopeSubnetBindingCRName        string = "nsx-op/subnetbinding_name"
@@ -82,11 +91,19 @@ const (
 	TagValueShareNotCreated            string = "notShared"
 	TagValueSLB                        string = "SLB"
 	AnnotationVPCNetworkConfig         string = "nsx.vmware.com/vpc_network_config"
+	AnnotationAppliedVPCNetworkConfig  string = "nsx.vmware.com/applied_vpc_network_config"
+	AnnotationVPCMigrationTarget       string = "nsx.vmware.com/vpc_migration_target"
+	NamespaceConditionVPCMigration     string = "NamespaceVPCMigration"
+	VPCMigrationReasonCreatingVPC      string = "CreatingVPC"
 	AnnotationSharedVPCNamespace       string = "nsx.vmware.com/shared_vpc_namespace"
 	AnnotationDefaultNetworkConfig     string = "nsx.vmware.com/default"
 	AnnotationAttachmentRef            string = "nsx.vmware.com/attachment_ref"
+	AnnotationPodNetworks              string = "nsx.vmware.com/networks"
+	AnnotationPodNetworkStatus         string = "k8s.v1.cni.cncf.io/network-status"
 	TagScopePodName                    string = "nsx-op/pod_name"
 	TagScopePodUID                     string = "nsx-op/pod_uid"
+	TagScopePodInterface               string = "nsx-op/pod_interface"
+	TagScopeSubnetPortPoolNode         string = "nsx-op/subnetport_pool_node"
 	ValueMajorVersion                  string = "1"
 	ValueMinorVersion                  string = "0"
 	ValuePatchVersion                  string = "0"
@@ -95,6 +112,7 @@ const (
 	GCInterval       = 10 * 60 * time.Second
 	SubnetGCInterval = 60 * time.Second
 	DefaultSNATID    = "DEFAULT"
+	UserNATID        = "USER"
 	AVISubnetLBID    = "_services"
 
 	NSXServiceAccountFinalizerName = "nsxserviceaccount.nsx.vmware.com/finalizer"
@@ -102,9 +120,10 @@ const (
 	SubnetFinalizerName            = "subnet.nsx.vmware.com/finalizer"
 	SubnetSetFinalizerName         = "subnetset.nsx.vmware.com/finalizer"
 
-	IndexKeySubnetID            = "IndexKeySubnetID"
-	IndexKeyNodeName            = "IndexKeyNodeName"
-	GCValidationInterval uint16 = 720
+	IndexKeySubnetID              = "IndexKeySubnetID"
+	IndexKeyNodeName              = "IndexKeyNodeName"
+	IndexKeySubnetPortPool        = "IndexKeySubnetPortPool"
+	GCValidationInterval   uint16 = 720
 
 	RuleIngress            = "ingress"
 	RuleEgress             = "egress"
@@ -150,6 +169,7 @@ var (
 	ResourceTypeLBService                    = "LBService"
 	ResourceTypeVpcAttachment                = "VpcAttachment"
 	ResourceTypeStaticRoute                  = "StaticRoutes"
+	ResourceTypeNATRule                      = "PolicyVpcNatRule"
 	ResourceTypeShare                        = "Share"
 	ResourceTypeSharedResource               = "SharedResource"
 	ResourceTypeChildSharedResource          = "ChildSharedResource"
@@ -216,6 +236,9 @@ type VPCResourceInfo struct {
 	ID                string
 	ParentID          string
 	PrivateIpv4Blocks []string
+	// PrivateIPs are the private CIDRs of the VPC, the IPv6 prefixes of the Subnets are
+	// allocated from the IPv6 CIDRs.
+	PrivateIPs []string
 }
 
 type VPCNetworkConfigInfo struct {
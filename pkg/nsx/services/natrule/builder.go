@@ -0,0 +1,88 @@
+package natrule
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+// NATRuleNetworks is the networks of the NATRule resolved from the CIDRs or the referenced resources.
+type NATRuleNetworks struct {
+	SourceNetwork      []string
+	DestinationNetwork []string
+	TranslatedNetwork  string
+}
+
+// validateNetwork checks that the network is an IP address or a CIDR.
+func validateNetwork(network string) error {
+	if strings.Contains(network, "/") {
+		if _, _, err := net.ParseCIDR(network); err != nil {
+			return fmt.Errorf("invalid CIDR %q: %w", network, err)
+		}
+		return nil
+	}
+	if net.ParseIP(network) == nil {
+		return fmt.Errorf("invalid IP address %q", network)
+	}
+	return nil
+}
+
+func validateNetworks(networks *NATRuleNetworks) error {
+	for _, network := range networks.SourceNetwork {
+		if err := validateNetwork(network); err != nil {
+			return fmt.Errorf("invalid source network: %w", err)
+		}
+	}
+	for _, network := range networks.DestinationNetwork {
+		if err := validateNetwork(network); err != nil {
+			return fmt.Errorf("invalid destination network: %w", err)
+		}
+	}
+	if networks.TranslatedNetwork != "" {
+		if err := validateNetwork(networks.TranslatedNetwork); err != nil {
+			return fmt.Errorf("invalid translated network: %w", err)
+		}
+	}
+	return nil
+}
+
+func (service *NATRuleService) buildNATRule(obj *v1alpha1.NATRule, networks *NATRuleNetworks) (*model.PolicyVpcNatRule, error) {
+	if err := validateNetworks(networks); err != nil {
+		return nil, err
+	}
+	firewallMatch := string(obj.Spec.FirewallMatch)
+	if firewallMatch == "" {
+		firewallMatch = model.PolicyVpcNatRule_FIREWALL_MATCH_MATCH_INTERNAL_ADDRESS
+	}
+	natRule := &model.PolicyVpcNatRule{
+		Id:            String(util.GenerateIDByObject(obj)),
+		DisplayName:   String(util.GenerateTruncName(common.MaxNameLength, obj.Name, "", "", "", "")),
+		Action:        String(string(obj.Spec.Action)),
+		FirewallMatch: String(firewallMatch),
+		Enabled:       common.Bool(true),
+		Tags:          service.buildBasicTags(obj),
+	}
+	if obj.Spec.SequenceNumber != 0 {
+		natRule.SequenceNumber = common.Int64(obj.Spec.SequenceNumber)
+	}
+	if len(networks.SourceNetwork) > 0 {
+		natRule.SourceNetwork = String(strings.Join(networks.SourceNetwork, ","))
+	}
+	if len(networks.DestinationNetwork) > 0 {
+		natRule.DestinationNetwork = String(strings.Join(networks.DestinationNetwork, ","))
+	}
+	if networks.TranslatedNetwork != "" {
+		natRule.TranslatedNetwork = String(networks.TranslatedNetwork)
+	}
+	return natRule, nil
+}
+
+func (service *NATRuleService) buildBasicTags(obj *v1alpha1.NATRule) []model.Tag {
+	return util.BuildBasicTags(service.Service.NSXConfig.Cluster, obj, "")
+}
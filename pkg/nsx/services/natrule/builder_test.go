@@ -0,0 +1,74 @@
+package natrule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+func TestBuildNATRule(t *testing.T) {
+	service := createService()
+	obj := &v1alpha1.NATRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "snat-1", Namespace: "ns-1", UID: "uid-1"},
+		Spec: v1alpha1.NATRuleSpec{
+			Action:        v1alpha1.NATRuleActionSNAT,
+			SourceNetwork: &v1alpha1.NATRuleNetwork{SubnetName: "subnet-1"},
+			TranslatedNetwork: &v1alpha1.NATRuleTranslatedNetwork{
+				IPAddressAllocationName: "ipa-1",
+			},
+		},
+	}
+	networks := &NATRuleNetworks{SourceNetwork: []string{"10.0.0.0/28", "10.0.0.16/28"}, TranslatedNetwork: "192.168.0.10"}
+
+	natRule, err := service.buildNATRule(obj, networks)
+	assert.Nil(t, err)
+	assert.Equal(t, "snat-1_uid-1", *natRule.Id)
+	assert.Equal(t, "snat-1", *natRule.DisplayName)
+	assert.Equal(t, model.PolicyVpcNatRule_ACTION_SNAT, *natRule.Action)
+	assert.Equal(t, "10.0.0.0/28,10.0.0.16/28", *natRule.SourceNetwork)
+	assert.Nil(t, natRule.DestinationNetwork)
+	assert.Equal(t, "192.168.0.10", *natRule.TranslatedNetwork)
+	assert.Equal(t, model.PolicyVpcNatRule_FIREWALL_MATCH_MATCH_INTERNAL_ADDRESS, *natRule.FirewallMatch)
+	assert.Nil(t, natRule.SequenceNumber)
+	assert.Equal(t, "ns-1", nsxutil.FindTag(natRule.Tags, common.TagScopeNamespace))
+	assert.Equal(t, "snat-1", nsxutil.FindTag(natRule.Tags, common.TagScopeNATRuleCRName))
+	assert.Equal(t, "uid-1", nsxutil.FindTag(natRule.Tags, common.TagScopeNATRuleCRUID))
+
+	obj.Spec = v1alpha1.NATRuleSpec{
+		Action:        v1alpha1.NATRuleActionNoSNAT,
+		SourceNetwork: &v1alpha1.NATRuleNetwork{CIDRs: []string{"10.0.0.0/24"}},
+		FirewallMatch: v1alpha1.NATRuleFirewallMatchBypass,
+		DestinationNetwork: &v1alpha1.NATRuleNetwork{
+			CIDRs: []string{"172.16.0.0/16"},
+		},
+		SequenceNumber: 10,
+	}
+	natRule, err = service.buildNATRule(obj, &NATRuleNetworks{SourceNetwork: []string{"10.0.0.0/24"}, DestinationNetwork: []string{"172.16.0.0/16"}})
+	assert.Nil(t, err)
+	assert.Equal(t, model.PolicyVpcNatRule_ACTION_NO_SNAT, *natRule.Action)
+	assert.Equal(t, "172.16.0.0/16", *natRule.DestinationNetwork)
+	assert.Nil(t, natRule.TranslatedNetwork)
+	assert.Equal(t, model.PolicyVpcNatRule_FIREWALL_MATCH_BYPASS, *natRule.FirewallMatch)
+	assert.Equal(t, int64(10), *natRule.SequenceNumber)
+
+	// The invalid networks are rejected.
+	obj.Spec = v1alpha1.NATRuleSpec{
+		Action:             v1alpha1.NATRuleActionDNAT,
+		DestinationNetwork: &v1alpha1.NATRuleNetwork{CIDRs: []string{"192.168.0.10"}},
+		TranslatedNetwork:  &v1alpha1.NATRuleTranslatedNetwork{IPAddressAllocationName: "ipa-1"},
+	}
+	_, err = service.buildNATRule(obj, &NATRuleNetworks{DestinationNetwork: []string{"192.168.0.10"}, TranslatedNetwork: "10.0.0.0/33"})
+	assert.ErrorContains(t, err, "invalid translated network: invalid CIDR \"10.0.0.0/33\"")
+	_, err = service.buildNATRule(obj, &NATRuleNetworks{DestinationNetwork: []string{"192.168.0.10"}, TranslatedNetwork: "10.0.0.256"})
+	assert.ErrorContains(t, err, "invalid translated network: invalid IP address \"10.0.0.256\"")
+	_, err = service.buildNATRule(obj, &NATRuleNetworks{DestinationNetwork: []string{"192.168.0"}, TranslatedNetwork: "10.0.0.0/28"})
+	assert.ErrorContains(t, err, "invalid destination network")
+	_, err = service.buildNATRule(obj, &NATRuleNetworks{SourceNetwork: []string{"abc/24"}, TranslatedNetwork: "10.0.0.0/28"})
+	assert.ErrorContains(t, err, "invalid source network")
+}
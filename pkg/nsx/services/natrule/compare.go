@@ -0,0 +1,28 @@
+package natrule
+
+import (
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func stringEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// compareNATRule returns true if the NAT rule built from the CR doesn't change the existing NSX NAT rule
+func (service *NATRuleService) compareNATRule(existingNATRule *model.PolicyVpcNatRule, nsxNATRule *model.PolicyVpcNatRule) bool {
+	if !stringEqual(existingNATRule.Action, nsxNATRule.Action) ||
+		!stringEqual(existingNATRule.SourceNetwork, nsxNATRule.SourceNetwork) ||
+		!stringEqual(existingNATRule.DestinationNetwork, nsxNATRule.DestinationNetwork) ||
+		!stringEqual(existingNATRule.TranslatedNetwork, nsxNATRule.TranslatedNetwork) ||
+		!stringEqual(existingNATRule.FirewallMatch, nsxNATRule.FirewallMatch) {
+		return false
+	}
+	// NSX assigns a sequence number to the NAT rule if it is not specified.
+	if nsxNATRule.SequenceNumber != nil && (existingNATRule.SequenceNumber == nil || *existingNATRule.SequenceNumber != *nsxNATRule.SequenceNumber) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package natrule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+func TestCompareNATRule(t *testing.T) {
+	service := createService()
+	existingNATRule := &model.PolicyVpcNatRule{
+		Action:            String(model.PolicyVpcNatRule_ACTION_SNAT),
+		SourceNetwork:     String("10.0.0.0/24"),
+		TranslatedNetwork: String("192.168.0.10"),
+		FirewallMatch:     String(model.PolicyVpcNatRule_FIREWALL_MATCH_MATCH_INTERNAL_ADDRESS),
+		SequenceNumber:    common.Int64(100),
+	}
+	nsxNATRule := &model.PolicyVpcNatRule{
+		Action:            String(model.PolicyVpcNatRule_ACTION_SNAT),
+		SourceNetwork:     String("10.0.0.0/24"),
+		TranslatedNetwork: String("192.168.0.10"),
+		FirewallMatch:     String(model.PolicyVpcNatRule_FIREWALL_MATCH_MATCH_INTERNAL_ADDRESS),
+	}
+	// The sequence number assigned by NSX is ignored if it is not specified in the CR.
+	assert.True(t, service.compareNATRule(existingNATRule, nsxNATRule))
+
+	nsxNATRule.SequenceNumber = common.Int64(10)
+	assert.False(t, service.compareNATRule(existingNATRule, nsxNATRule))
+
+	nsxNATRule.SequenceNumber = nil
+	nsxNATRule.TranslatedNetwork = String("192.168.0.11")
+	assert.False(t, service.compareNATRule(existingNATRule, nsxNATRule))
+
+	nsxNATRule.TranslatedNetwork = String("192.168.0.10")
+	nsxNATRule.DestinationNetwork = String("172.16.0.0/16")
+	assert.False(t, service.compareNATRule(existingNATRule, nsxNATRule))
+}
@@ -0,0 +1,175 @@
+package natrule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+type NATRuleService struct {
+	common.Service
+	NATRuleStore *NATRuleStore
+	VPCService   common.VPCServiceProvider
+}
+
+var (
+	log    = &logger.Log
+	String = common.String
+)
+
+// InitializeNATRule sync NSX resources
+func InitializeNATRule(commonService common.Service, vpcService common.VPCServiceProvider) (*NATRuleService, error) {
+	wg := sync.WaitGroup{}
+	wgDone := make(chan bool)
+	fatalErrors := make(chan error)
+
+	wg.Add(1)
+	natRuleService := &NATRuleService{Service: commonService}
+	natRuleStore := &NATRuleStore{}
+	natRuleStore.Indexer = cache.NewIndexer(keyFunc, cache.Indexers{
+		common.TagScopeNATRuleCRUID: indexFunc,
+		common.TagScopeNamespace:    indexNATRuleNamespace,
+	})
+	natRuleStore.BindingType = model.PolicyVpcNatRuleBindingType()
+	natRuleService.NATRuleStore = natRuleStore
+	natRuleService.NSXConfig = commonService.NSXConfig
+	natRuleService.VPCService = vpcService
+
+	// Only the NAT rules created for the NATRule CRs are synced, the default SNAT rules of the VPC are skipped.
+	tags := []model.Tag{{Scope: String(common.TagScopeNATRuleCRUID)}}
+	go natRuleService.InitializeResourceStore(&wg, fatalErrors, common.ResourceTypeNATRule, tags, natRuleService.NATRuleStore)
+
+	go func() {
+		wg.Wait()
+		close(wgDone)
+	}()
+
+	select {
+	case <-wgDone:
+		break
+	case err := <-fatalErrors:
+		close(fatalErrors)
+		return natRuleService, err
+	}
+
+	return natRuleService, nil
+}
+
+// CreateOrUpdateNATRule creates or updates the NSX NAT rule of the NATRule CR in the VPC of the Namespace,
+// the networks are resolved from the CR by the caller. The realized NSX NAT rule is returned.
+func (service *NATRuleService) CreateOrUpdateNATRule(obj *v1alpha1.NATRule, networks *NATRuleNetworks) (*model.PolicyVpcNatRule, error) {
+	nsxNATRule, err := service.buildNATRule(obj, networks)
+	if err != nil {
+		return nil, err
+	}
+
+	existingNATRule := service.NATRuleStore.GetByKey(*nsxNATRule.Id)
+	if existingNATRule != nil && service.compareNATRule(existingNATRule, nsxNATRule) {
+		return existingNATRule, nil
+	}
+
+	vpc := service.VPCService.ListVPCInfo(obj.Namespace)
+	if len(vpc) == 0 {
+		return nil, fmt.Errorf("no vpc found for ns %s", obj.Namespace)
+	}
+	err = service.NSXClient.NATRuleClient.Patch(vpc[0].OrgID, vpc[0].ProjectID, vpc[0].ID, common.UserNATID, *nsxNATRule.Id, *nsxNATRule)
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		return nil, err
+	}
+	natRule, err := service.NSXClient.NATRuleClient.Get(vpc[0].OrgID, vpc[0].ProjectID, vpc[0].ID, common.UserNATID, *nsxNATRule.Id)
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		return nil, err
+	}
+	if err = service.NATRuleStore.Add(&natRule); err != nil {
+		return nil, err
+	}
+	log.Info("Successfully created or updated NSX NAT rule", "nsxNATRule", *natRule.Path)
+	return &natRule, nil
+}
+
+func (service *NATRuleService) DeleteNATRule(nsxNATRule *model.PolicyVpcNatRule) error {
+	vpcInfo, err := common.ParseVPCResourcePath(*nsxNATRule.Path)
+	if err != nil {
+		log.Error(err, "Failed to parse NSX VPC path for NAT rule", "path", *nsxNATRule.Path)
+		return err
+	}
+	if err := service.NSXClient.NATRuleClient.Delete(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, vpcInfo.ParentID, *nsxNATRule.Id); err != nil {
+		err = nsxutil.TransNSXApiError(err)
+		return err
+	}
+	if err := service.NATRuleStore.Delete(nsxNATRule); err != nil {
+		return err
+	}
+
+	log.Info("Successfully deleted NSX NAT rule", "nsxNATRule", *nsxNATRule.Id)
+	return nil
+}
+
+func (service *NATRuleService) DeleteNATRuleByCR(obj *v1alpha1.NATRule) error {
+	natRule := service.NATRuleStore.GetByKey(util.GenerateIDByObject(obj))
+	if natRule == nil {
+		return nil
+	}
+	return service.DeleteNATRule(natRule)
+}
+
+func (service *NATRuleService) GetUID(natRule *model.PolicyVpcNatRule) *string {
+	if natRule == nil {
+		return nil
+	}
+	for _, tag := range natRule.Tags {
+		if *tag.Scope == common.TagScopeNATRuleCRUID {
+			return tag.Tag
+		}
+	}
+	return nil
+}
+
+func (service *NATRuleService) ListNATRuleByName(ns, name string) []*model.PolicyVpcNatRule {
+	var result []*model.PolicyVpcNatRule
+	for _, obj := range service.NATRuleStore.GetByIndex(common.TagScopeNamespace, ns) {
+		natRule := obj.(*model.PolicyVpcNatRule)
+		if nsxutil.FindTag(natRule.Tags, common.TagScopeNATRuleCRName) == name {
+			result = append(result, natRule)
+		}
+	}
+	return result
+}
+
+func (service *NATRuleService) ListNATRule() []*model.PolicyVpcNatRule {
+	natRuleSet := []*model.PolicyVpcNatRule{}
+	for _, natRule := range service.NATRuleStore.List() {
+		natRuleSet = append(natRuleSet, natRule.(*model.PolicyVpcNatRule))
+	}
+	return natRuleSet
+}
+
+func (service *NATRuleService) Cleanup(ctx context.Context) error {
+	natRuleSet := service.ListNATRule()
+	log.Info("Cleanup NAT rule", "count", len(natRuleSet))
+	for _, natRule := range natRuleSet {
+		log.Info("Deleting NAT rule", "NAT rule path", *natRule.Path)
+		select {
+		case <-ctx.Done():
+			return errors.Join(nsxutil.TimeoutFailed, ctx.Err())
+		default:
+			if err := service.DeleteNATRule(natRule); err != nil {
+				log.Error(err, "Delete NAT rule failed", "NAT rule id", *natRule.Id)
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package natrule
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/mock"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+type fakeNATRuleClient struct {
+	natRules map[string]model.PolicyVpcNatRule
+	patched  int
+}
+
+func (c *fakeNATRuleClient) Delete(orgIdParam string, projectIdParam string, vpcIdParam string, natIdParam string, natRuleIdParam string) error {
+	delete(c.natRules, natRuleIdParam)
+	return nil
+}
+
+func (c *fakeNATRuleClient) Get(orgIdParam string, projectIdParam string, vpcIdParam string, natIdParam string, natRuleIdParam string) (model.PolicyVpcNatRule, error) {
+	return c.natRules[natRuleIdParam], nil
+}
+
+func (c *fakeNATRuleClient) List(orgIdParam string, projectIdParam string, vpcIdParam string, natIdParam string, cursorParam *string, includeMarkForDeleteObjectsParam *bool, includedFieldsParam *string, pageSizeParam *int64, sortAscendingParam *bool, sortByParam *string) (model.PolicyVpcNatRuleListResult, error) {
+	return model.PolicyVpcNatRuleListResult{}, nil
+}
+
+func (c *fakeNATRuleClient) Patch(orgIdParam string, projectIdParam string, vpcIdParam string, natIdParam string, natRuleIdParam string, policyVpcNatRuleParam model.PolicyVpcNatRule) error {
+	c.patched++
+	policyVpcNatRuleParam.Path = String("/orgs/" + orgIdParam + "/projects/" + projectIdParam + "/vpcs/" + vpcIdParam + "/nat/" + natIdParam + "/nat-rules/" + natRuleIdParam)
+	policyVpcNatRuleParam.SequenceNumber = common.Int64(100)
+	c.natRules[natRuleIdParam] = policyVpcNatRuleParam
+	return nil
+}
+
+func (c *fakeNATRuleClient) Update(orgIdParam string, projectIdParam string, vpcIdParam string, natIdParam string, natRuleIdParam string, policyVpcNatRuleParam model.PolicyVpcNatRule) (model.PolicyVpcNatRule, error) {
+	return policyVpcNatRuleParam, nil
+}
+
+func createService() *NATRuleService {
+	natRuleStore := &NATRuleStore{ResourceStore: common.ResourceStore{
+		BindingType: model.PolicyVpcNatRuleBindingType(),
+	}}
+	natRuleStore.Indexer = cache.NewIndexer(keyFunc, cache.Indexers{
+		common.TagScopeNATRuleCRUID: indexFunc,
+		common.TagScopeNamespace:    indexNATRuleNamespace,
+	})
+	return &NATRuleService{
+		Service: common.Service{
+			NSXClient: &nsx.Client{
+				NATRuleClient: &fakeNATRuleClient{natRules: map[string]model.PolicyVpcNatRule{}},
+			},
+			NSXConfig: &config.NSXOperatorConfig{
+				CoeConfig: &config.CoeConfig{
+					Cluster: "k8scl-one:test",
+				},
+			},
+		},
+		NATRuleStore: natRuleStore,
+	}
+}
+
+func TestNATRuleService_CreateOrUpdateNATRule(t *testing.T) {
+	service := createService()
+	natRuleClient := service.NSXClient.NATRuleClient.(*fakeNATRuleClient)
+	vpcService := &mock.MockVPCServiceProvider{}
+	service.VPCService = vpcService
+	obj := &v1alpha1.NATRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "dnat-1", Namespace: "ns-1", UID: "uid-1"},
+		Spec: v1alpha1.NATRuleSpec{
+			Action:             v1alpha1.NATRuleActionDNAT,
+			DestinationNetwork: &v1alpha1.NATRuleNetwork{CIDRs: []string{"192.168.0.10"}},
+			TranslatedNetwork:  &v1alpha1.NATRuleTranslatedNetwork{IPAddress: "10.0.0.10"},
+		},
+	}
+	networks := &NATRuleNetworks{DestinationNetwork: []string{"192.168.0.10"}, TranslatedNetwork: "10.0.0.10"}
+
+	// No VPC is found for the Namespace.
+	vpcService.On("ListVPCInfo", "ns-1").Return([]common.VPCResourceInfo{}).Once()
+	_, err := service.CreateOrUpdateNATRule(obj, networks)
+	assert.ErrorContains(t, err, "no vpc found for ns ns-1")
+
+	vpcService.On("ListVPCInfo", "ns-1").Return([]common.VPCResourceInfo{{OrgID: "default", ProjectID: "project-1", ID: "vpc-1"}})
+	natRule, err := service.CreateOrUpdateNATRule(obj, networks)
+	assert.Nil(t, err)
+	assert.Equal(t, "/orgs/default/projects/project-1/vpcs/vpc-1/nat/USER/nat-rules/dnat-1_uid-1", *natRule.Path)
+	assert.Equal(t, 1, natRuleClient.patched)
+	assert.Equal(t, 1, len(service.ListNATRule()))
+
+	// The NAT rule is not patched again if it is not changed.
+	_, err = service.CreateOrUpdateNATRule(obj, networks)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, natRuleClient.patched)
+
+	networks.TranslatedNetwork = "10.0.0.11"
+	natRule, err = service.CreateOrUpdateNATRule(obj, networks)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, natRuleClient.patched)
+	assert.Equal(t, "10.0.0.11", *natRule.TranslatedNetwork)
+	assert.Equal(t, "dnat-1_uid-1", *service.ListNATRuleByName("ns-1", "dnat-1")[0].Id)
+	assert.Empty(t, service.ListNATRuleByName("ns-1", "dnat-2"))
+	assert.Equal(t, "uid-1", *service.GetUID(natRule))
+
+	assert.Nil(t, service.DeleteNATRuleByCR(obj))
+	assert.Empty(t, natRuleClient.natRules)
+	assert.Empty(t, service.ListNATRule())
+	// Deleting a NATRule without NSX NAT rule is a no-op.
+	assert.Nil(t, service.DeleteNATRuleByCR(obj))
+}
+
+func TestNATRuleService_Cleanup(t *testing.T) {
+	service := createService()
+	natRuleClient := service.NSXClient.NATRuleClient.(*fakeNATRuleClient)
+	for _, id := range []string{"rule-1", "rule-2"} {
+		natRule := model.PolicyVpcNatRule{
+			Id:   String(id),
+			Path: String("/orgs/default/projects/project-1/vpcs/vpc-1/nat/USER/nat-rules/" + id),
+			Tags: []model.Tag{{Scope: String(common.TagScopeNATRuleCRUID), Tag: String(id)}},
+		}
+		natRuleClient.natRules[id] = natRule
+		assert.Nil(t, service.NATRuleStore.Add(&natRule))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := service.Cleanup(ctx)
+	assert.True(t, errors.Is(err, nsxutil.TimeoutFailed))
+
+	assert.Nil(t, service.Cleanup(context.Background()))
+	assert.Empty(t, natRuleClient.natRules)
+	assert.Empty(t, service.ListNATRule())
+}
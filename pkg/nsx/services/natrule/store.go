@@ -0,0 +1,64 @@
+package natrule
+
+import (
+	"errors"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+// NATRuleStore is a store for NAT rules
+type NATRuleStore struct {
+	common.ResourceStore
+}
+
+// keyFunc is used to get the key of a resource, usually, which is the ID of the resource
+func keyFunc(obj interface{}) (string, error) {
+	switch v := obj.(type) {
+	case *model.PolicyVpcNatRule:
+		return *v.Id, nil
+	default:
+		return "", errors.New("keyFunc doesn't support unknown type")
+	}
+}
+
+// indexFunc is used to get index of a resource, which is the UID of the NATRule CR
+func indexFunc(obj interface{}) ([]string, error) {
+	switch v := obj.(type) {
+	case *model.PolicyVpcNatRule:
+		return filterTag(v.Tags, common.TagScopeNATRuleCRUID), nil
+	default:
+		return nil, errors.New("indexFunc doesn't support unknown type")
+	}
+}
+
+func indexNATRuleNamespace(obj interface{}) ([]string, error) {
+	switch o := obj.(type) {
+	case *model.PolicyVpcNatRule:
+		return filterTag(o.Tags, common.TagScopeNamespace), nil
+	default:
+		return nil, errors.New("indexNATRuleNamespace doesn't support unknown type")
+	}
+}
+
+func filterTag(tags []model.Tag, tagScope string) []string {
+	if value := nsxutil.FindTag(tags, tagScope); value != "" {
+		return []string{value}
+	}
+	return []string{}
+}
+
+func (natRuleStore *NATRuleStore) Apply(i interface{}) error {
+	// not used by NAT rule since NAT rule doesn't use hierarchy API
+	return nil
+}
+
+func (natRuleStore *NATRuleStore) GetByKey(key string) *model.PolicyVpcNatRule {
+	obj := natRuleStore.ResourceStore.GetByKey(key)
+	if obj != nil {
+		return obj.(*model.PolicyVpcNatRule)
+	}
+	return nil
+}
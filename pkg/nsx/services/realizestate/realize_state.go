@@ -5,6 +5,7 @@ package realizestate
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
@@ -77,6 +78,33 @@ func (service *RealizeStateService) CheckRealizeState(backoff wait.Backoff, inte
 }
 
 func (service *RealizeStateService) GetPolicyTier1UplinkPortIP(intentPath string) (string, error) {
+	ip, err := service.GetRouterPortIP(intentPath)
+	if err != nil {
+		return "", err
+	}
+	if ip == "" {
+		return "", fmt.Errorf("%s tier1 uplink port IP not found", intentPath)
+	}
+	return ip, nil
+}
+
+// GetRouterPortIP returns the IP address of the logical router port realized for intentPath, or an empty
+// string if no router port with an IP address is realized yet.
+func (service *RealizeStateService) GetRouterPortIP(intentPath string) (string, error) {
+	return service.getRouterPortIP(intentPath, "")
+}
+
+// GetGatewayRouterPortIP returns the IP address of the logical router port realized for intentPath on the
+// gateway gatewayPath, or an empty string if no such router port with an IP address is realized yet. An
+// intent connecting two gateways, e.g. a VPC attachment connecting the VPC to the transit gateway, is
+// realized with a router port on each gateway.
+func (service *RealizeStateService) GetGatewayRouterPortIP(intentPath string, gatewayPath string) (string, error) {
+	return service.getRouterPortIP(intentPath, gatewayPath)
+}
+
+// getRouterPortIP returns the IP address of the first logical router port realized for intentPath, the router
+// port must be on the gateway gatewayPath if it is not empty.
+func (service *RealizeStateService) getRouterPortIP(intentPath string, gatewayPath string) (string, error) {
 	results, err := service.NSXClient.RealizedEntitiesClient.List(intentPath, nil)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
@@ -88,6 +116,9 @@ func (service *RealizeStateService) GetPolicyTier1UplinkPortIP(intentPath string
 		if len(extendAttributes) == 0 || len(result.IntentPaths) != 1 || (result.EntityType != nil && *result.EntityType != "RealizedLogicalRouterPort") {
 			continue
 		}
+		if gatewayPath != "" && !hasAttributeValue(extendAttributes, "LogicalRouterPath", gatewayPath) {
+			continue
+		}
 		for i := range extendAttributes {
 			if extendAttributes[i].Key != nil && *extendAttributes[i].Key == "IpAddresses" {
 				for _, ip := range extendAttributes[i].Values {
@@ -101,5 +132,14 @@ func (service *RealizeStateService) GetPolicyTier1UplinkPortIP(intentPath string
 		}
 	}
 
-	return "", fmt.Errorf("%s tier1 uplink port IP not found", intentPath)
+	return "", nil
+}
+
+func hasAttributeValue(attributes []model.AttributeVal, key string, value string) bool {
+	for i := range attributes {
+		if attributes[i].Key != nil && *attributes[i].Key == key && slices.Contains(attributes[i].Values, value) {
+			return true
+		}
+	}
+	return false
 }
@@ -381,3 +381,45 @@ func TestRealizeStateService_GetPolicyTier1UplinkPortIP(t *testing.T) {
 		})
 	}
 }
+
+func TestRealizeStateService_GetGatewayRouterPortIP(t *testing.T) {
+	s := InitializeRealizeState(common.Service{
+		NSXClient: &nsx.Client{
+			RealizedEntitiesClient: &fakeRealizedEntitiesClient{},
+		},
+	})
+	intentPath := "/orgs/default/projects/project-quality/vpcs/vpc-1/attachments/default"
+	vpcPath := "/orgs/default/projects/project-quality/vpcs/vpc-1"
+	tgwPath := "/orgs/default/projects/project-quality/transit-gateways/default"
+	routerPort := func(gatewayPath string, ip string) model.GenericPolicyRealizedResource {
+		return model.GenericPolicyRealizedResource{
+			State: common.String(model.GenericPolicyRealizedResource_STATE_REALIZED),
+			ExtendedAttributes: []model.AttributeVal{
+				{Key: common.String("LogicalRouterPath"), Values: []string{gatewayPath}},
+				{Key: common.String("IpAddresses"), Values: []string{ip}},
+			},
+			EntityType:  common.String("RealizedLogicalRouterPort"),
+			IntentPaths: []string{intentPath},
+		}
+	}
+	patches := gomonkey.ApplyFunc((*fakeRealizedEntitiesClient).List, func(c *fakeRealizedEntitiesClient, intentPathParam string, sitePathParam *string) (model.GenericPolicyRealizedResourceListResult, error) {
+		return model.GenericPolicyRealizedResourceListResult{
+			Results: []model.GenericPolicyRealizedResource{routerPort(vpcPath, "100.64.0.2/31"), routerPort(tgwPath, "100.64.0.3/31")},
+		}, nil
+	})
+	defer patches.Reset()
+
+	ip, err := s.GetGatewayRouterPortIP(intentPath, tgwPath)
+	require.NoError(t, err)
+	assert.Equal(t, "100.64.0.3", ip)
+	ip, err = s.GetGatewayRouterPortIP(intentPath, vpcPath)
+	require.NoError(t, err)
+	assert.Equal(t, "100.64.0.2", ip)
+	ip, err = s.GetGatewayRouterPortIP(intentPath, "/orgs/default/projects/project-quality/transit-gateways/other")
+	require.NoError(t, err)
+	assert.Empty(t, ip)
+	// The first realized router port is returned without the gateway.
+	ip, err = s.GetRouterPortIP(intentPath)
+	require.NoError(t, err)
+	assert.Equal(t, "100.64.0.2", ip)
+}
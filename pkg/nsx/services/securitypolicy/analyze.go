@@ -0,0 +1,270 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+const (
+	// RuleConflictShadowed means a rule is fully covered by a higher-priority rule, so it never matches.
+	RuleConflictShadowed = "RuleShadowed"
+	// RuleConflictOverlap means an allow rule and a drop/reject rule match identical traffic.
+	RuleConflictOverlap = "RuleOverlap"
+	// RuleConflictIDCollision means two rules generate the same NSX rule ID, only one of them is realized.
+	RuleConflictIDCollision = "RuleIDCollision"
+)
+
+// RuleConflict describes a finding of AnalyzeRuleConflicts.
+type RuleConflict struct {
+	Reason  string
+	Message string
+}
+
+// ruleMatch is the normalized traffic match of a SecurityPolicy rule. The empty appliedTo, peers
+// or ports means the rule matches any.
+type ruleMatch struct {
+	policy    *v1alpha1.SecurityPolicy
+	ruleIdx   int
+	action    string
+	direction string
+	appliedTo string
+	peers     string
+	ports     sets.Set[string]
+}
+
+// AnalyzeRuleConflicts analyzes the rules of the SecurityPolicy against its own rules and the
+// existing SecurityPolicies in the same Namespace. It finds the rules which are fully shadowed by
+// a higher-priority rule, the allow and drop rules matching identical traffic, and the rules whose
+// generated NSX rule IDs collide.
+// The analysis is conservative, a rule covers another one only if its selectors and ports are
+// identical after normalization, or it matches any.
+func (service *SecurityPolicyService) AnalyzeRuleConflicts(obj *v1alpha1.SecurityPolicy, existingPolicies []v1alpha1.SecurityPolicy) ([]RuleConflict, error) {
+	newMatches, err := service.buildRuleMatches(obj)
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []RuleConflict
+	conflicts = append(conflicts, service.findRuleIDCollisions(obj)...)
+
+	var existingMatches []*ruleMatch
+	for i := range existingPolicies {
+		policy := &existingPolicies[i]
+		if policy.Namespace != obj.Namespace || policy.Name == obj.Name {
+			continue
+		}
+		matches, err := service.buildRuleMatches(policy)
+		if err != nil {
+			log.V(1).Info("Skip analyzing the invalid SecurityPolicy", "namespace", policy.Namespace, "name", policy.Name, "error", err)
+			continue
+		}
+		existingMatches = append(existingMatches, matches...)
+	}
+
+	for i, m := range newMatches {
+		// The rules in the same policy are enforced by the rule order.
+		for _, prior := range newMatches[:i] {
+			if conflict := compareRuleMatch(prior, m); conflict != nil {
+				conflicts = append(conflicts, *conflict)
+			}
+		}
+		for _, e := range existingMatches {
+			order := compareSecurityPolicyOrder(e.policy, obj)
+			if order < 0 {
+				if conflict := compareRuleMatch(e, m); conflict != nil {
+					conflicts = append(conflicts, *conflict)
+				}
+			} else if order > 0 {
+				if conflict := compareRuleMatch(m, e); conflict != nil {
+					conflicts = append(conflicts, *conflict)
+				}
+			} else if isRuleMatchEqual(e, m) && isAllowAction(e.action) != isAllowAction(m.action) {
+				// The order between the policies with the same priority is not determined.
+				conflicts = append(conflicts, RuleConflict{
+					Reason: RuleConflictOverlap,
+					Message: fmt.Sprintf("%s and %s match identical traffic with conflicting actions and the same tier and priority, the effective action is undetermined",
+						ruleRef(m), ruleRef(e)),
+				})
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// compareRuleMatch checks whether the lower-priority rule is affected by the higher-priority one.
+func compareRuleMatch(higher, lower *ruleMatch) *RuleConflict {
+	if !isRuleMatchCovered(higher, lower) {
+		return nil
+	}
+	if isRuleMatchEqual(higher, lower) && isAllowAction(higher.action) != isAllowAction(lower.action) {
+		return &RuleConflict{
+			Reason: RuleConflictOverlap,
+			Message: fmt.Sprintf("%s and %s match identical traffic with conflicting actions, %s never matches",
+				ruleRef(higher), ruleRef(lower), ruleRef(lower)),
+		}
+	}
+	return &RuleConflict{
+		Reason:  RuleConflictShadowed,
+		Message: fmt.Sprintf("%s is fully shadowed by higher-priority %s and never matches", ruleRef(lower), ruleRef(higher)),
+	}
+}
+
+func isRuleMatchCovered(higher, lower *ruleMatch) bool {
+	if higher.direction != lower.direction {
+		return false
+	}
+	if higher.appliedTo != "" && higher.appliedTo != lower.appliedTo {
+		return false
+	}
+	if higher.peers != "" && higher.peers != lower.peers {
+		return false
+	}
+	if higher.ports.Len() == 0 {
+		return true
+	}
+	return lower.ports.Len() > 0 && higher.ports.IsSuperset(lower.ports)
+}
+
+func isRuleMatchEqual(a, b *ruleMatch) bool {
+	return a.direction == b.direction && a.appliedTo == b.appliedTo && a.peers == b.peers && a.ports.Equal(b.ports)
+}
+
+func isAllowAction(action string) bool {
+	return action == util.ToUpper(v1alpha1.RuleActionAllow)
+}
+
+func ruleRef(m *ruleMatch) string {
+	rule := m.policy.Spec.Rules[m.ruleIdx]
+	if rule.Name != "" {
+		return fmt.Sprintf("rule %q (rules[%d]) of SecurityPolicy %s/%s", rule.Name, m.ruleIdx, m.policy.Namespace, m.policy.Name)
+	}
+	return fmt.Sprintf("rules[%d] of SecurityPolicy %s/%s", m.ruleIdx, m.policy.Namespace, m.policy.Name)
+}
+
+// findRuleIDCollisions finds the rules of the policy generating the same NSX rule ID by buildRuleID
+// and buildExpandedRuleID. The builder only keeps the first rule with the same ID.
+func (service *SecurityPolicyService) findRuleIDCollisions(obj *v1alpha1.SecurityPolicy) []RuleConflict {
+	var conflicts []RuleConflict
+	ruleIDs := map[string]int{}
+	for ruleIdx := range obj.Spec.Rules {
+		ids := []string{service.buildRuleID(obj, ruleIdx)}
+		if !service.hasNamedPort(&obj.Spec.Rules[ruleIdx]) {
+			ids = append(ids, service.buildExpandedRuleID(obj, ruleIdx, common.ResourceTypeSecurityPolicy, nil))
+		}
+		for _, id := range ids {
+			if prevIdx, ok := ruleIDs[id]; ok && prevIdx != ruleIdx {
+				conflicts = append(conflicts, RuleConflict{
+					Reason: RuleConflictIDCollision,
+					Message: fmt.Sprintf("rules[%d] and rules[%d] of SecurityPolicy %s/%s generate the same NSX rule ID %s, only rules[%d] is realized",
+						prevIdx, ruleIdx, obj.Namespace, obj.Name, id, prevIdx),
+				})
+				break
+			}
+			ruleIDs[id] = ruleIdx
+		}
+	}
+	return conflicts
+}
+
+func (service *SecurityPolicyService) buildRuleMatches(obj *v1alpha1.SecurityPolicy) ([]*ruleMatch, error) {
+	var matches []*ruleMatch
+	for ruleIdx := range obj.Spec.Rules {
+		rule := &obj.Spec.Rules[ruleIdx]
+		if rule.Action == nil || rule.Direction == nil {
+			return nil, fmt.Errorf("rules[%d] of SecurityPolicy %s/%s has no action or direction", ruleIdx, obj.Namespace, obj.Name)
+		}
+		action, err := getRuleAction(rule)
+		if err != nil {
+			return nil, err
+		}
+		direction, err := getRuleDirection(rule)
+		if err != nil {
+			return nil, err
+		}
+		// Policy level 'Applied To' takes precedence over rule level.
+		appliedTo := obj.Spec.AppliedTo
+		if len(appliedTo) == 0 {
+			appliedTo = rule.AppliedTo
+		}
+		peers := rule.Sources
+		if direction == "OUT" {
+			peers = rule.Destinations
+		}
+		m := &ruleMatch{
+			policy:    obj,
+			ruleIdx:   ruleIdx,
+			action:    action,
+			direction: direction,
+			appliedTo: service.normalizeTargets(appliedTo),
+			peers:     service.normalizePeers(peers),
+			ports:     sets.New[string](),
+		}
+		for _, port := range rule.Ports {
+			if port.Protocol == "" {
+				port.Protocol = corev1.ProtocolTCP
+			}
+			m.ports.Insert(service.buildRulePortString(port))
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+func (service *SecurityPolicyService) normalizeTargets(targets []v1alpha1.SecurityPolicyTarget) string {
+	items := make([]string, 0, len(targets))
+	for _, target := range targets {
+		items = append(items, fmt.Sprintf("vm:%s|pod:%s",
+			service.normalizeSelector(target.VMSelector), service.normalizeSelector(target.PodSelector)))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ";")
+}
+
+func (service *SecurityPolicyService) normalizePeers(peers []v1alpha1.SecurityPolicyPeer) string {
+	items := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		cidrs := make([]string, 0, len(peer.IPBlocks))
+		for _, ipBlock := range peer.IPBlocks {
+			cidrs = append(cidrs, ipBlock.CIDR)
+		}
+		sort.Strings(cidrs)
+		items = append(items, fmt.Sprintf("vm:%s|pod:%s|ns:%s|ip:%s",
+			service.normalizeSelector(peer.VMSelector), service.normalizeSelector(peer.PodSelector),
+			service.normalizeSelector(peer.NamespaceSelector), strings.Join(cidrs, ",")))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ";")
+}
+
+// normalizeSelector folds the match labels into 'In' match expressions, merges the match expressions
+// in the same way as the builder, and formats the selector in the canonical order. A nil selector is
+// formatted as "-" to distinguish it from the empty selector which selects all.
+func (service *SecurityPolicyService) normalizeSelector(selector *v1.LabelSelector) string {
+	if selector == nil {
+		return "-"
+	}
+	matchExpressions := make([]v1.LabelSelectorRequirement, 0, len(selector.MatchLabels)+len(selector.MatchExpressions))
+	for key, value := range selector.MatchLabels {
+		matchExpressions = append(matchExpressions, v1.LabelSelectorRequirement{Key: key, Operator: v1.LabelSelectorOpIn, Values: []string{value}})
+	}
+	matchExpressions = append(matchExpressions, selector.MatchExpressions...)
+	merged := &v1.LabelSelector{
+		MatchExpressions: *service.mergeSelectorMatchExpression(matchExpressions),
+	}
+	labelSelector, err := v1.LabelSelectorAsSelector(merged)
+	if err != nil {
+		return fmt.Sprintf("%v", selector)
+	}
+	return labelSelector.String()
+}
@@ -0,0 +1,149 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+func TestSecurityPolicyService_AnalyzeRuleConflicts(t *testing.T) {
+	dropAction := v1alpha1.RuleActionDrop
+	webSelector := &v1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	// The same selector as webSelector expressed with matchExpressions.
+	webSelector2 := &v1.LabelSelector{
+		MatchExpressions: []v1.LabelSelectorRequirement{
+			{Key: "app", Operator: v1.LabelSelectorOpIn, Values: []string{"web"}},
+		},
+	}
+	tcpPort := func(port int) v1alpha1.SecurityPolicyPort {
+		return v1alpha1.SecurityPolicyPort{Protocol: "TCP", Port: intstr.FromInt(port)}
+	}
+	newPolicy := func(name string, priority int, rules ...v1alpha1.SecurityPolicyRule) v1alpha1.SecurityPolicy {
+		return v1alpha1.SecurityPolicy{
+			ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: name, UID: types.UID("uid-" + name)},
+			Spec: v1alpha1.SecurityPolicySpec{
+				Priority:  priority,
+				AppliedTo: []v1alpha1.SecurityPolicyTarget{{PodSelector: webSelector}},
+				Rules:     rules,
+			},
+		}
+	}
+	allowAll := v1alpha1.SecurityPolicyRule{Action: &allowAction, Direction: &directionIn}
+	allow80 := v1alpha1.SecurityPolicyRule{Action: &allowAction, Direction: &directionIn, Ports: []v1alpha1.SecurityPolicyPort{tcpPort(80)}}
+	drop80 := v1alpha1.SecurityPolicyRule{Action: &dropAction, Direction: &directionIn, Ports: []v1alpha1.SecurityPolicyPort{tcpPort(80)}}
+	allow80Out := v1alpha1.SecurityPolicyRule{Action: &allowAction, Direction: &directionOut, Ports: []v1alpha1.SecurityPolicyPort{tcpPort(80)}}
+	ruleFrom := func(rule v1alpha1.SecurityPolicyRule, selector *v1.LabelSelector) v1alpha1.SecurityPolicyRule {
+		rule.Sources = []v1alpha1.SecurityPolicyPeer{{PodSelector: selector}}
+		return rule
+	}
+	inTier := func(policy v1alpha1.SecurityPolicy, tier v1alpha1.SecurityPolicyTier) v1alpha1.SecurityPolicy {
+		policy.Spec.Tier = tier
+		return policy
+	}
+
+	for _, tc := range []struct {
+		name        string
+		vpcEnabled  bool
+		obj         v1alpha1.SecurityPolicy
+		existing    []v1alpha1.SecurityPolicy
+		expReasons  []string
+		expErrorStr string
+	}{
+		{
+			name: "no conflict between different directions",
+			obj:  newPolicy("sp1", 10, allow80, allow80Out),
+		},
+		{
+			name:       "rule shadowed by a prior rule matching any port",
+			obj:        newPolicy("sp1", 10, allowAll, allow80),
+			expReasons: []string{RuleConflictShadowed},
+		},
+		{
+			name: "no conflict if the prior rule matches fewer peers",
+			obj:  newPolicy("sp1", 10, ruleFrom(allow80, webSelector), drop80),
+		},
+		{
+			name:       "allow and drop rules with normalized identical selectors",
+			obj:        newPolicy("sp1", 10, ruleFrom(allow80, webSelector), ruleFrom(drop80, webSelector2)),
+			expReasons: []string{RuleConflictOverlap},
+		},
+		{
+			name:       "rule overlaps with a higher-priority policy, the old version of itself is skipped",
+			obj:        newPolicy("sp1", 10, allow80),
+			existing:   []v1alpha1.SecurityPolicy{newPolicy("sp0", 5, drop80), newPolicy("sp1", 1, allowAll)},
+			expReasons: []string{RuleConflictOverlap},
+		},
+		{
+			name:       "rule shadows a lower-priority policy",
+			obj:        newPolicy("sp1", 10, allowAll),
+			existing:   []v1alpha1.SecurityPolicy{newPolicy("sp2", 20, allow80)},
+			expReasons: []string{RuleConflictShadowed},
+		},
+		{
+			name:       "conflicting actions with the same priority",
+			obj:        newPolicy("sp1", 10, allow80),
+			existing:   []v1alpha1.SecurityPolicy{newPolicy("sp2", 10, drop80)},
+			expReasons: []string{RuleConflictOverlap},
+		},
+		{
+			name:       "rule shadowed by a policy in a higher tier with lower priority",
+			obj:        newPolicy("sp1", 10, allow80),
+			existing:   []v1alpha1.SecurityPolicy{inTier(newPolicy("sp2", 20, allowAll), v1alpha1.SecurityPolicyTierEnvironment)},
+			expReasons: []string{RuleConflictShadowed},
+		},
+		{
+			name:       "rule in a higher tier shadows a policy with higher priority",
+			obj:        inTier(newPolicy("sp1", 20, allowAll), v1alpha1.SecurityPolicyTierEmergency),
+			existing:   []v1alpha1.SecurityPolicy{inTier(newPolicy("sp2", 10, allow80), v1alpha1.SecurityPolicyTierApplication)},
+			expReasons: []string{RuleConflictShadowed},
+		},
+		{
+			name:     "no undetermined order between different tiers with the same priority",
+			obj:      inTier(newPolicy("sp1", 10, drop80), v1alpha1.SecurityPolicyTierInfrastructure),
+			existing: []v1alpha1.SecurityPolicy{inTier(newPolicy("sp2", 10, allowAll), v1alpha1.SecurityPolicyTierEnvironment)},
+		},
+		{
+			name:       "VPC: identical rules collide on rule ID",
+			vpcEnabled: true,
+			obj:        newPolicy("sp1", 10, allow80, allow80),
+			expReasons: []string{RuleConflictIDCollision, RuleConflictShadowed},
+		},
+		{
+			name:        "invalid rule",
+			obj:         newPolicy("sp1", 10, v1alpha1.SecurityPolicyRule{Action: &allowAction}),
+			expErrorStr: "rules[0] of SecurityPolicy ns1/sp1 has no action or direction",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &SecurityPolicyService{
+				Service: common.Service{
+					NSXConfig: &config.NSXOperatorConfig{
+						CoeConfig: &config.CoeConfig{EnableVPCNetwork: tc.vpcEnabled},
+					},
+				},
+			}
+			conflicts, err := svc.AnalyzeRuleConflicts(&tc.obj, tc.existing)
+			if tc.expErrorStr != "" {
+				require.EqualError(t, err, tc.expErrorStr)
+				return
+			}
+			require.NoError(t, err)
+			var reasons []string
+			for _, conflict := range conflicts {
+				reasons = append(reasons, conflict.Reason)
+			}
+			assert.ElementsMatch(t, tc.expReasons, reasons)
+		})
+	}
+}
@@ -72,6 +72,10 @@ func (service *SecurityPolicyService) buildSecurityPolicy(obj *v1alpha1.Security
 
 	nsxSecurityPolicy.Id = String(service.buildSecurityPolicyID(obj, createdFor))
 	nsxSecurityPolicy.DisplayName = String(service.buildSecurityPolicyName(obj))
+	// NSX creates the policy in the Application category by default, the priority orders the policies within the category.
+	if category := getSecurityPolicyCategory(obj); category != categoryApplication {
+		nsxSecurityPolicy.Category = String(category)
+	}
 	// TODO: confirm the sequence number: offset
 	nsxSecurityPolicy.SequenceNumber = Int64(int64(obj.Spec.Priority))
 
@@ -143,7 +147,10 @@ func (service *SecurityPolicyService) buildPolicyGroup(obj *v1alpha1.SecurityPol
 	policyAppliedGroup.DisplayName = String(service.buildAppliedGroupName(obj, -1))
 
 	appliedTo := obj.Spec.AppliedTo
-	targetTags := service.buildTargetTags(obj, &appliedTo, nil, -1, createdFor)
+	targetTags, err := service.buildTargetTags(obj, &appliedTo, nil, -1, createdFor)
+	if err != nil {
+		return nil, "", err
+	}
 	policyAppliedGroup.Tags = targetTags
 	if len(appliedTo) == 0 {
 		return nil, "ANY", nil
@@ -151,7 +158,6 @@ func (service *SecurityPolicyService) buildPolicyGroup(obj *v1alpha1.SecurityPol
 
 	targetGroupCriteriaCount, targetGroupTotalExprCount := 0, 0
 	criteriaCount, totalExprCount := 0, 0
-	var err error
 	errorMsg := ""
 	for i := range appliedTo {
 		criteriaCount, totalExprCount, err = service.updateTargetExpressions(
@@ -197,7 +203,7 @@ func (service *SecurityPolicyService) buildPolicyGroup(obj *v1alpha1.SecurityPol
 
 func (service *SecurityPolicyService) buildTargetTags(obj *v1alpha1.SecurityPolicy, targets *[]v1alpha1.SecurityPolicyTarget,
 	rule *v1alpha1.SecurityPolicyRule, ruleIdx int, createdFor string,
-) []model.Tag {
+) ([]model.Tag, error) {
 	basicTags := service.buildBasicTags(obj, createdFor)
 	serializedBytes, _ := json.Marshal(*targets)
 	targetTags := []model.Tag{
@@ -236,7 +242,11 @@ func (service *SecurityPolicyService) buildTargetTags(obj *v1alpha1.SecurityPoli
 			},
 		)
 	}
-	return targetTags
+	labelTags, err := service.buildLabelTags(obj)
+	if err != nil {
+		return nil, err
+	}
+	return util.AppendLabelTags(targetTags, labelTags), nil
 }
 
 func (service *SecurityPolicyService) buildBasicTags(obj *v1alpha1.SecurityPolicy, createdFor string) []model.Tag {
@@ -704,7 +714,10 @@ func (service *SecurityPolicyService) buildRuleAppliedGroupByRule(obj *v1alpha1.
 	ruleAppliedGroupID := service.buildAppliedGroupID(obj, ruleIdx)
 	ruleAppliedGroupName = service.buildAppliedGroupName(obj, ruleIdx)
 
-	targetTags := service.buildTargetTags(obj, &appliedTo, rule, ruleIdx, createdFor)
+	targetTags, err := service.buildTargetTags(obj, &appliedTo, rule, ruleIdx, createdFor)
+	if err != nil {
+		return nil, "", err
+	}
 	ruleAppliedGroupPath, err := service.buildAppliedGroupPath(obj, ruleIdx)
 	if err != nil {
 		return nil, "", err
@@ -841,7 +854,10 @@ func (service *SecurityPolicyService) buildRulePeerGroup(obj *v1alpha1.SecurityP
 		return nil, "", nil, err
 	}
 
-	peerTags := service.buildPeerTags(obj, rule, ruleIdx, isSource, infraGroupShared, projectGroupShared, createdFor)
+	peerTags, err := service.buildPeerTags(obj, rule, ruleIdx, isSource, infraGroupShared, projectGroupShared, createdFor)
+	if err != nil {
+		return nil, "", nil, err
+	}
 	rulePeerGroup := model.Group{
 		Id:          &rulePeerGroupID,
 		DisplayName: &rulePeerGroupName,
@@ -943,6 +959,10 @@ func (service *SecurityPolicyService) buildRuleBasicInfo(obj *v1alpha1.SecurityP
 	if err != nil {
 		log.Error(err, "Failed to build rule's display name", "securityPolicyUID", obj.UID, "rule", rule, "createdFor", createdFor)
 	}
+	ruleIPProtocol, err := getRuleIPProtocol(rule)
+	if err != nil {
+		return nil, err
+	}
 
 	nsxRule := model.Rule{
 		Id:             String(service.buildExpandedRuleID(obj, ruleIdx, createdFor, namedPortInfo)),
@@ -953,11 +973,15 @@ func (service *SecurityPolicyService) buildRuleBasicInfo(obj *v1alpha1.SecurityP
 		Services:       []string{"ANY"},
 		Tags:           service.buildBasicTags(obj, createdFor),
 	}
+	// Leave the IP protocol unset for the NSX default IPV4_IPV6.
+	if ruleIPProtocol != model.Rule_IP_PROTOCOL_IPV4_IPV6 {
+		nsxRule.IpProtocol = &ruleIPProtocol
+	}
 	log.V(1).Info("Built rule basic info", "nsxRule", nsxRule)
 	return &nsxRule, nil
 }
 
-func (service *SecurityPolicyService) buildPeerTags(obj *v1alpha1.SecurityPolicy, rule *v1alpha1.SecurityPolicyRule, ruleIdx int, isSource, infraGroupShared, projectGroupShared bool, createdFor string) []model.Tag {
+func (service *SecurityPolicyService) buildPeerTags(obj *v1alpha1.SecurityPolicy, rule *v1alpha1.SecurityPolicyRule, ruleIdx int, isSource, infraGroupShared, projectGroupShared bool, createdFor string) ([]model.Tag, error) {
 	basicTags := service.buildBasicTags(obj, createdFor)
 	groupTypeTag := String(common.TagValueGroupDestination)
 	peers := &rule.Destinations
@@ -1011,7 +1035,11 @@ func (service *SecurityPolicyService) buildPeerTags(obj *v1alpha1.SecurityPolicy
 		}
 	}
 
-	return peerTags
+	labelTags, err := service.buildLabelTags(obj)
+	if err != nil {
+		return nil, err
+	}
+	return util.AppendLabelTags(peerTags, labelTags), nil
 }
 
 func (service *SecurityPolicyService) updateTargetExpressions(obj *v1alpha1.SecurityPolicy, target *v1alpha1.SecurityPolicyTarget, group *model.Group, ruleIdx int) (int, int, error) {
@@ -1849,6 +1877,21 @@ func (service *SecurityPolicyService) getNamespaceUID(ns string) (nsUid types.UI
 	return namespace_uid
 }
 
+// buildLabelTags builds the tags mirrored from the allowed labels of the SecurityPolicy and its Namespace, which
+// are appended to the tags of the groups.
+func (service *SecurityPolicyService) buildLabelTags(obj *v1alpha1.SecurityPolicy) ([]model.Tag, error) {
+	labelKeys := service.NSXConfig.GetLabelTagKeys()
+	if len(labelKeys) == 0 {
+		return nil, nil
+	}
+	namespace := &corev1.Namespace{}
+	if err := service.Client.Get(context.Background(), types.NamespacedName{Name: obj.Namespace}, namespace); err != nil {
+		log.Error(err, "Failed to get Namespace labels", "namespace", obj.Namespace)
+		return nil, err
+	}
+	return util.BuildLabelTags(labelKeys, obj.Labels, namespace.Labels), nil
+}
+
 func (service *SecurityPolicyService) buildRulePortString(port v1alpha1.SecurityPolicyPort) string {
 	return fmt.Sprintf("%s.%s", port.Protocol, service.buildRulePortNumberString(port))
 }
@@ -13,9 +13,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
@@ -141,6 +144,7 @@ func Test_BuildSecurityPolicyForT1(t *testing.T) {
 						Services:          []string{"ANY"},
 						SourceGroups:      []string{"ANY"},
 						Action:            &nsxRuleActionDrop,
+						IpProtocol:        common.String(model.Rule_IP_PROTOCOL_IPV4),
 						Tags:              basicTagsForSpWithVMSelector,
 					},
 				},
@@ -306,6 +310,7 @@ func Test_BuildSecurityPolicyForVPC(t *testing.T) {
 						Services:          []string{"ANY"},
 						SourceGroups:      []string{"ANY"},
 						Action:            &nsxRuleActionDrop,
+						IpProtocol:        common.String(model.Rule_IP_PROTOCOL_IPV4),
 						Tags:              vpcBasicTagsForSpWithVMSelector,
 					},
 				},
@@ -435,7 +440,9 @@ func Test_BuildTargetTags(t *testing.T) {
 	defer patches.Reset()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.ElementsMatch(t, tt.expectedTags, service.buildTargetTags(tt.inputPolicy, tt.inputTargets, &tt.inputPolicy.Spec.Rules[0], tt.inputIndex, common.ResourceTypeSecurityPolicy))
+			tags, err := service.buildTargetTags(tt.inputPolicy, tt.inputTargets, &tt.inputPolicy.Spec.Rules[0], tt.inputIndex, common.ResourceTypeSecurityPolicy)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tt.expectedTags, tags)
 		})
 	}
 }
@@ -500,7 +507,9 @@ func Test_BuildPeerTags(t *testing.T) {
 	defer patches.Reset()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.ElementsMatch(t, tt.expectedTags, service.buildPeerTags(tt.inputPolicy, &tt.inputPolicy.Spec.Rules[0], tt.inputIndex, true, false, false, common.ResourceTypeSecurityPolicy))
+			tags, err := service.buildPeerTags(tt.inputPolicy, &tt.inputPolicy.Spec.Rules[0], tt.inputIndex, true, false, false, common.ResourceTypeSecurityPolicy)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tt.expectedTags, tags)
 		})
 	}
 }
@@ -1440,3 +1449,27 @@ func Test_BuildGroupName(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildLabelTags(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "ns1", Labels: map[string]string{"team": "net", "env": "prod"}}}
+	service := &SecurityPolicyService{
+		Service: common.Service{
+			Client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(ns).Build(),
+			NSXConfig: &config.NSXOperatorConfig{
+				K8sConfig: &config.K8sConfig{LabelTagKeys: []string{"team", "env"}},
+			},
+		},
+	}
+	sp := &v1alpha1.SecurityPolicy{ObjectMeta: v1.ObjectMeta{Name: "sp1", Namespace: "ns1", Labels: map[string]string{"team": "dev"}}}
+	tags, err := service.buildLabelTags(sp)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Tag{
+		{Scope: common.String("env"), Tag: common.String("prod")},
+		{Scope: common.String("team"), Tag: common.String("dev")},
+	}, tags)
+
+	// The error is returned if the Namespace labels can't be read.
+	sp.Namespace = "ns2"
+	_, err = service.buildLabelTags(sp)
+	assert.Error(t, err)
+}
@@ -36,9 +36,15 @@ func (share *Share) Key() string {
 }
 
 func (sp *SecurityPolicy) Value() data.DataValue {
+	// NSX sets the category to Application by default if it's not specified.
+	category := sp.Category
+	if category == nil || *category == categoryApplication {
+		category = nil
+	}
 	s := &SecurityPolicy{
 		Id:             sp.Id,
 		DisplayName:    sp.DisplayName,
+		Category:       category,
 		SequenceNumber: sp.SequenceNumber,
 		Scope:          sp.Scope,
 		Tags:           sp.Tags,
@@ -48,6 +54,11 @@ func (sp *SecurityPolicy) Value() data.DataValue {
 }
 
 func (rule *Rule) Value() data.DataValue {
+	// NSX sets the IP protocol to IPV4_IPV6 by default if it's not specified.
+	ipProtocol := rule.IpProtocol
+	if ipProtocol == nil || *ipProtocol == model.Rule_IP_PROTOCOL_IPV4_IPV6 {
+		ipProtocol = nil
+	}
 	r := &Rule{
 		DisplayName:       rule.DisplayName,
 		Id:                rule.Id,
@@ -60,6 +71,7 @@ func (rule *Rule) Value() data.DataValue {
 		ServiceEntries:    rule.ServiceEntries,
 		DestinationGroups: rule.DestinationGroups,
 		SourceGroups:      rule.SourceGroups,
+		IpProtocol:        ipProtocol,
 	}
 	dataValue, _ := ComparableToRule(r).GetDataValue__()
 	return dataValue
@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	vmv1alpha1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 
@@ -140,7 +141,10 @@ func (service *SecurityPolicyService) expandRuleByService(obj *v1alpha1.Security
 
 	// If portAddress contains a list of IPs, we should build an ip set group for the rule.
 	if len(namedPort.ips) > 0 {
-		ruleIPSetGroup := service.buildRuleIPSetGroup(obj, rule, nsxRule, namedPort.ips, ruleIdx, createdFor)
+		ruleIPSetGroup, err := service.buildRuleIPSetGroup(obj, rule, nsxRule, namedPort.ips, ruleIdx, createdFor)
+		if err != nil {
+			return nil, nil, err
+		}
 
 		// In VPC network, NSGroup with IPAddressExpression type can be supported in VPC level as well.
 		IPSetGroupPath, err := service.buildRuleIPSetGroupPath(obj, nsxRule)
@@ -163,9 +167,22 @@ func (service *SecurityPolicyService) resolveNamedPort(obj *v1alpha1.SecurityPol
 	var portAddress []nsxutil.PortAddress
 
 	podSelectors, err := service.getPodSelectors(obj, rule)
-	if err != nil {
+	if err != nil && !errors.As(err, &nsxutil.NoEffectiveOption{}) {
 		return nil, err
 	}
+	// VirtualMachine CRs are only watched in VPC network, so VM named ports are resolved in VPC mode only.
+	var vmSelectors []client.ListOptions
+	if IsVPCEnabled(service) {
+		vmSelectors, err = service.getVMSelectors(obj, rule)
+		if err != nil && !errors.As(err, &nsxutil.NoEffectiveOption{}) {
+			return nil, err
+		}
+	}
+	if len(podSelectors) == 0 && len(vmSelectors) == 0 {
+		return nil, nsxutil.NoEffectiveOption{
+			Desc: "no effective options filtered by the rule and security policy",
+		}
+	}
 
 	for _, selector := range podSelectors {
 		podSelector := selector
@@ -181,8 +198,31 @@ func (service *SecurityPolicyService) resolveNamedPort(obj *v1alpha1.SecurityPol
 		}
 	}
 
+	// VirtualMachineServices are cached per namespace since several selectors may hit the same namespace.
+	vmServices := make(map[string][]vmv1alpha1.VirtualMachineService)
+	for _, selector := range vmSelectors {
+		vmSelector := selector
+		vmList := &vmv1alpha1.VirtualMachineList{}
+		log.V(2).Info("Port", "vmSelector", vmSelector)
+		err := service.Client.List(context.Background(), vmList, &vmSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, vm := range vmList.Items {
+			if _, ok := vmServices[vm.Namespace]; !ok {
+				vmServiceList := &vmv1alpha1.VirtualMachineServiceList{}
+				if err := service.Client.List(context.Background(), vmServiceList, client.InNamespace(vm.Namespace)); err != nil {
+					return nil, err
+				}
+				vmServices[vm.Namespace] = vmServiceList.Items
+			}
+			addr := service.resolveVMPort(vm, vmServices[vm.Namespace], &spPort)
+			portAddress = append(portAddress, addr...)
+		}
+	}
+
 	if len(portAddress) == 0 {
-		log.Info("No pod has the corresponding named port", "port", spPort)
+		log.Info("No pod or VM has the corresponding named port", "port", spPort)
 	}
 	return nsxutil.MergeAddressByPort(portAddress), nil
 }
@@ -195,19 +235,68 @@ func (service *SecurityPolicyService) resolvePodPort(pod v1.Pod, spPort *v1alpha
 		for _, port := range container.Ports {
 			log.V(2).Info("ResolvePodPort", "nameSpace", pod.Namespace, "podName", pod.Name,
 				"portName", port.Name, "containerPort", port.ContainerPort,
-				"protocol", port.Protocol, "podIP", pod.Status.PodIP)
+				"protocol", port.Protocol, "podIPs", pod.Status.PodIPs)
 			if port.Name == spPort.Port.String() && port.Protocol == spPort.Protocol {
 				if pod.Status.Phase != "Running" {
 					log.Info("POD with named port is not running", "pod.Namespace", pod.Namespace, "pod.Name", pod.Name)
 					return addr
 				}
-				if pod.Status.PodIP == "" {
+				podIPs := getPodIPs(&pod)
+				if len(podIPs) == 0 {
 					log.Info("POD with named port doesn't have initialized IP", "pod.Namespace", pod.Namespace, "pod.Name", pod.Name)
 					return addr
 				}
 				addr = append(
 					addr,
-					nsxutil.PortAddress{Port: int(port.ContainerPort), IPs: []string{pod.Status.PodIP}},
+					nsxutil.PortAddress{Port: int(port.ContainerPort), IPs: podIPs},
+				)
+			}
+		}
+	}
+	return addr
+}
+
+// getPodIPs returns the IPs of both IP families for a dual-stack Pod.
+func getPodIPs(pod *v1.Pod) []string {
+	var podIPs []string
+	for _, podIP := range pod.Status.PodIPs {
+		if podIP.IP != "" {
+			podIPs = append(podIPs, podIP.IP)
+		}
+	}
+	if len(podIPs) == 0 && pod.Status.PodIP != "" {
+		podIPs = append(podIPs, pod.Status.PodIP)
+	}
+	return podIPs
+}
+
+// Check port name and protocol against the VirtualMachineServices selecting the VM, the target port
+// of a matched VirtualMachineService port is taken as the VM port number. Only a powered-on VM which
+// has effective ip is resolved.
+func (service *SecurityPolicyService) resolveVMPort(vm vmv1alpha1.VirtualMachine, vmServices []vmv1alpha1.VirtualMachineService,
+	spPort *v1alpha1.SecurityPolicyPort,
+) []nsxutil.PortAddress {
+	var addr []nsxutil.PortAddress
+	for _, vmService := range vmServices {
+		if len(vmService.Spec.Selector) == 0 || !labels.SelectorFromSet(vmService.Spec.Selector).Matches(labels.Set(vm.Labels)) {
+			continue
+		}
+		for _, port := range vmService.Spec.Ports {
+			log.V(2).Info("ResolveVMPort", "nameSpace", vm.Namespace, "vmName", vm.Name, "vmServiceName", vmService.Name,
+				"portName", port.Name, "targetPort", port.TargetPort,
+				"protocol", port.Protocol, "vmIP", vm.Status.VmIp)
+			if port.Name == spPort.Port.String() && port.Protocol == string(spPort.Protocol) {
+				if vm.Status.PowerState != vmv1alpha1.VirtualMachinePoweredOn {
+					log.Info("VM with named port is not powered on", "vm.Namespace", vm.Namespace, "vm.Name", vm.Name)
+					return addr
+				}
+				if vm.Status.VmIp == "" {
+					log.Info("VM with named port doesn't have initialized IP", "vm.Namespace", vm.Namespace, "vm.Name", vm.Name)
+					return addr
+				}
+				addr = append(
+					addr,
+					nsxutil.PortAddress{Port: int(port.TargetPort), IPs: []string{vm.Status.VmIp}},
 				)
 			}
 		}
@@ -243,7 +332,7 @@ func (service *SecurityPolicyService) buildRuleIPSetGroupPath(obj *v1alpha1.Secu
 // Build an ip set group for NSX.
 func (service *SecurityPolicyService) buildRuleIPSetGroup(obj *v1alpha1.SecurityPolicy, rule *v1alpha1.SecurityPolicyRule, ruleModel *model.Rule,
 	ips []string, ruleIdx int, createdFor string,
-) *model.Group {
+) (*model.Group, error) {
 	ipSetGroup := model.Group{}
 
 	ipSetGroupID := service.buildRuleIPSetGroupID(ruleModel)
@@ -252,7 +341,10 @@ func (service *SecurityPolicyService) buildRuleIPSetGroup(obj *v1alpha1.Security
 	ipSetGroup.DisplayName = &ipSetGroupName
 
 	// IPSetGroup is always destination group for named port
-	peerTags := service.buildPeerTags(obj, rule, ruleIdx, false, false, false, createdFor)
+	peerTags, err := service.buildPeerTags(obj, rule, ruleIdx, false, false, false, createdFor)
+	if err != nil {
+		return nil, err
+	}
 	ipSetGroup.Tags = peerTags
 
 	addresses := data.NewListValue()
@@ -268,7 +360,7 @@ func (service *SecurityPolicyService) buildRuleIPSetGroup(obj *v1alpha1.Security
 		},
 	)
 	ipSetGroup.Expression = append(ipSetGroup.Expression, blockExpression)
-	return &ipSetGroup
+	return &ipSetGroup, nil
 }
 
 // Different direction rule decides different target of the traffic, we should carefully get
@@ -365,6 +457,74 @@ func (service *SecurityPolicyService) getPodSelectors(obj *v1alpha1.SecurityPoli
 	return finalSelectors, nil
 }
 
+// getVMSelectors gets the destination VM selectors of a rule which has named port, it follows the
+// same direction logic as getPodSelectors but uses VMSelector instead of PodSelector.
+// For "OUT" direction, a peer with only NamespaceSelector selects all the VMs in the namespaces,
+// while a peer with only PodSelector or IPBlocks selects no VM.
+func (service *SecurityPolicyService) getVMSelectors(obj *v1alpha1.SecurityPolicy, rule *v1alpha1.SecurityPolicyRule) ([]client.ListOptions, error) {
+	var finalSelectors []client.ListOptions
+	ruleDirection, err := getRuleDirection(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	if ruleDirection == "IN" {
+		targets := obj.Spec.AppliedTo
+		if len(targets) == 0 {
+			targets = rule.AppliedTo
+		}
+		for _, target := range targets {
+			if target.VMSelector == nil {
+				continue
+			}
+			label, err := meta1.LabelSelectorAsSelector(target.VMSelector)
+			if err != nil {
+				return nil, err
+			}
+			finalSelectors = append(finalSelectors, client.ListOptions{
+				LabelSelector: label,
+				Namespace:     obj.Namespace,
+			})
+		}
+	} else if ruleDirection == "OUT" {
+		for _, target := range rule.Destinations {
+			if target.VMSelector == nil && (target.PodSelector != nil || target.NamespaceSelector == nil) {
+				continue
+			}
+			var label labels.Selector
+			if target.VMSelector != nil {
+				label, err = meta1.LabelSelectorAsSelector(target.VMSelector)
+				if err != nil {
+					return nil, err
+				}
+			}
+			namespaces := []string{obj.Namespace}
+			if target.NamespaceSelector != nil {
+				ns, err := service.ResolveNamespace(target.NamespaceSelector)
+				if err != nil {
+					return nil, err
+				}
+				namespaces = nil
+				for _, nsItem := range ns.Items {
+					namespaces = append(namespaces, nsItem.Name)
+				}
+			}
+			for _, namespace := range namespaces {
+				finalSelectors = append(finalSelectors, client.ListOptions{
+					LabelSelector: label,
+					Namespace:     namespace,
+				})
+			}
+		}
+	}
+	if len(finalSelectors) == 0 {
+		return nil, nsxutil.NoEffectiveOption{
+			Desc: "no effective VM options filtered by the rule and security policy",
+		}
+	}
+	return finalSelectors, nil
+}
+
 func (service *SecurityPolicyService) hasNamedPort(rule *v1alpha1.SecurityPolicyRule) bool {
 	hasNamedPort := false
 	for _, port := range rule.Ports {
@@ -14,15 +14,18 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	vmv1alpha1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	core_v1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
@@ -30,6 +33,7 @@ import (
 	mock_client "github.com/vmware-tanzu/nsx-operator/pkg/mock/controller-runtime/client"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 )
 
 func TestSecurityPolicyService_buildRuleIPGroup(t *testing.T) {
@@ -86,12 +90,14 @@ func TestSecurityPolicyService_buildRuleIPGroup(t *testing.T) {
 			return types.UID(tagValueNSUID)
 		})
 	defer patches.Reset()
+	peerTags, err := service.buildPeerTags(sp, &sp.Spec.Rules[0], 0, false, false, false, common.ResourceTypeSecurityPolicy)
+	assert.NoError(t, err)
 	ipGroup := model.Group{
 		Id:          &policyGroupID,
 		DisplayName: &policyGroupName,
 		Expression:  []*data.StructValue{blockExpression},
 		// build ipset group tags from input securitypolicy and securitypolicy rule
-		Tags: service.buildPeerTags(sp, &sp.Spec.Rules[0], 0, false, false, false, common.ResourceTypeSecurityPolicy),
+		Tags: peerTags,
 	}
 
 	type args struct {
@@ -107,8 +113,9 @@ func TestSecurityPolicyService_buildRuleIPGroup(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equalf(t, tt.want, service.buildRuleIPSetGroup(sp, &sp.Spec.Rules[0], tt.args.obj, tt.args.ips, 0, common.ResourceTypeSecurityPolicy), "buildRuleIPSetGroup(%v, %v)",
-				tt.args.obj, tt.args.ips)
+			ipSetGroup, err := service.buildRuleIPSetGroup(sp, &sp.Spec.Rules[0], tt.args.obj, tt.args.ips, 0, common.ResourceTypeSecurityPolicy)
+			assert.NoError(t, err)
+			assert.Equalf(t, tt.want, ipSetGroup, "buildRuleIPSetGroup(%v, %v)", tt.args.obj, tt.args.ips)
 		})
 	}
 }
@@ -612,6 +619,108 @@ func Test_ResolveNamespace(t *testing.T) {
 	assert.Equal(t, expectedNamespaceList, nsList)
 }
 
+func TestSecurityPolicyService_resolveNamedPortForVM(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	require.NoError(t, core_v1.AddToScheme(newScheme))
+	require.NoError(t, vmv1alpha1.AddToScheme(newScheme))
+
+	vmWithIP := func(name, ip string, powerState vmv1alpha1.VirtualMachinePowerState) *vmv1alpha1.VirtualMachine {
+		return &vmv1alpha1.VirtualMachine{
+			ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: name, Labels: map[string]string{"app": "web"}},
+			Status:     vmv1alpha1.VirtualMachineStatus{VmIp: ip, PowerState: powerState},
+		}
+	}
+	vmService := &vmv1alpha1.VirtualMachineService{
+		ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: "web-svc"},
+		Spec: vmv1alpha1.VirtualMachineServiceSpec{
+			Selector: map[string]string{"app": "web"},
+			Ports: []vmv1alpha1.VirtualMachineServicePort{
+				{Name: "http", Protocol: "TCP", Port: 80, TargetPort: 8080},
+				{Name: "dns", Protocol: "UDP", Port: 53, TargetPort: 5353},
+			},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects(
+		vmWithIP("vm1", "1.1.1.1", vmv1alpha1.VirtualMachinePoweredOn),
+		vmWithIP("vm2", "1.1.1.2", vmv1alpha1.VirtualMachinePoweredOn),
+		vmWithIP("vm3", "1.1.1.3", vmv1alpha1.VirtualMachinePoweredOff),
+		vmWithIP("vm4", "", vmv1alpha1.VirtualMachinePoweredOn),
+		vmService,
+	).Build()
+
+	svc := &SecurityPolicyService{
+		Service: common.Service{
+			Client: k8sClient,
+			NSXConfig: &config.NSXOperatorConfig{
+				CoeConfig: &config.CoeConfig{EnableVPCNetwork: true},
+			},
+		},
+	}
+	sp := &v1alpha1.SecurityPolicy{
+		ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: "sp1", UID: "uid1"},
+		Spec: v1alpha1.SecurityPolicySpec{
+			AppliedTo: []v1alpha1.SecurityPolicyTarget{
+				{VMSelector: &v1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+			},
+		},
+	}
+	rule := &v1alpha1.SecurityPolicyRule{Action: &allowAction, Direction: &directionIn}
+
+	portAddress, err := svc.resolveNamedPort(sp, rule, v1alpha1.SecurityPolicyPort{
+		Protocol: "TCP",
+		Port:     intstr.FromString("http"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(portAddress))
+	assert.Equal(t, 8080, portAddress[0].Port)
+	assert.ElementsMatch(t, []string{"1.1.1.1", "1.1.1.2"}, portAddress[0].IPs)
+
+	// Protocol mismatch resolves nothing.
+	portAddress, err = svc.resolveNamedPort(sp, rule, v1alpha1.SecurityPolicyPort{
+		Protocol: "TCP",
+		Port:     intstr.FromString("dns"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(portAddress))
+
+	// VM named port is not resolved in T1 network.
+	svc.NSXConfig.CoeConfig.EnableVPCNetwork = false
+	_, err = svc.resolveNamedPort(sp, rule, v1alpha1.SecurityPolicyPort{
+		Protocol: "TCP",
+		Port:     intstr.FromString("http"),
+	})
+	assert.ErrorAs(t, err, &nsxutil.NoEffectiveOption{})
+}
+
+func TestSecurityPolicyService_getVMSelectors(t *testing.T) {
+	vmSelector := &v1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}
+	vmLabel, _ := v1.LabelSelectorAsSelector(vmSelector)
+	svc := &SecurityPolicyService{}
+	sp := &v1alpha1.SecurityPolicy{ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: "sp1"}}
+
+	// IN direction with rule level AppliedTo
+	rule := &v1alpha1.SecurityPolicyRule{
+		Direction: &directionIn,
+		AppliedTo: []v1alpha1.SecurityPolicyTarget{{VMSelector: vmSelector}, {PodSelector: vmSelector}},
+	}
+	got, err := svc.getVMSelectors(sp, rule)
+	require.NoError(t, err)
+	assert.Equal(t, []client.ListOptions{{LabelSelector: vmLabel, Namespace: "ns1"}}, got)
+
+	// OUT direction, a peer with only PodSelector selects no VM
+	rule = &v1alpha1.SecurityPolicyRule{
+		Direction:    &directionOut,
+		Destinations: []v1alpha1.SecurityPolicyPeer{{PodSelector: vmSelector}},
+	}
+	_, err = svc.getVMSelectors(sp, rule)
+	assert.ErrorAs(t, err, &nsxutil.NoEffectiveOption{})
+
+	rule.Destinations = append(rule.Destinations, v1alpha1.SecurityPolicyPeer{VMSelector: vmSelector})
+	got, err = svc.getVMSelectors(sp, rule)
+	require.NoError(t, err)
+	assert.Equal(t, []client.ListOptions{{LabelSelector: vmLabel, Namespace: "ns1"}}, got)
+}
+
 func getRuleServiceEntries(portStart, portEnd int, protocol string) *data.StructValue {
 	return buildRuleServiceEntries(v1alpha1.SecurityPolicyPort{
 		Protocol: core_v1.Protocol(protocol),
@@ -619,3 +728,13 @@ func getRuleServiceEntries(portStart, portEnd int, protocol string) *data.Struct
 		EndPort:  portEnd,
 	})
 }
+
+func Test_getPodIPs(t *testing.T) {
+	pod := &core_v1.Pod{Status: core_v1.PodStatus{PodIP: "1.1.1.1"}}
+	assert.Equal(t, []string{"1.1.1.1"}, getPodIPs(pod))
+
+	pod.Status.PodIPs = []core_v1.PodIP{{IP: "1.1.1.1"}, {IP: "2001:db8::1"}}
+	assert.Equal(t, []string{"1.1.1.1", "2001:db8::1"}, getPodIPs(pod))
+
+	assert.Empty(t, getPodIPs(&core_v1.Pod{}))
+}
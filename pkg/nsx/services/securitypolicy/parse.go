@@ -5,6 +5,11 @@ package securitypolicy
 
 import (
 	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
@@ -44,6 +49,58 @@ func getRuleDirection(rule *v1alpha1.SecurityPolicyRule) (string, error) {
 	return "", errors.New("invalid rule direction")
 }
 
+// getRuleIPProtocol returns the IP protocol of the rule by the IP families of the rule peers. The rule
+// only matches the IP family of the IP blocks if the peers are all IP blocks in the same family,
+// otherwise it matches both IPv4 and IPv6 traffic.
+func getRuleIPProtocol(rule *v1alpha1.SecurityPolicyRule) (string, error) {
+	ruleDirection, err := getRuleDirection(rule)
+	if err != nil {
+		return "", err
+	}
+	peers := rule.Sources
+	if ruleDirection == "OUT" {
+		peers = rule.Destinations
+	}
+	if len(peers) == 0 {
+		return model.Rule_IP_PROTOCOL_IPV4_IPV6, nil
+	}
+	hasIPv4, hasIPv6 := false, false
+	for _, peer := range peers {
+		if peer.PodSelector != nil || peer.VMSelector != nil || peer.NamespaceSelector != nil {
+			return model.Rule_IP_PROTOCOL_IPV4_IPV6, nil
+		}
+		for _, ipBlock := range peer.IPBlocks {
+			isIPv4, err := isIPv4Block(ipBlock.CIDR)
+			if err != nil {
+				return "", err
+			}
+			if isIPv4 {
+				hasIPv4 = true
+			} else {
+				hasIPv6 = true
+			}
+		}
+	}
+	if hasIPv4 && !hasIPv6 {
+		return model.Rule_IP_PROTOCOL_IPV4, nil
+	} else if hasIPv6 && !hasIPv4 {
+		return model.Rule_IP_PROTOCOL_IPV6, nil
+	}
+	return model.Rule_IP_PROTOCOL_IPV4_IPV6, nil
+}
+
+// isIPv4Block checks the IP family of the IP block, which is an IP, a CIDR or an IP range
+// converted from the CIDR with excepts, e.g. "172.17.0.0-172.17.0.255".
+func isIPv4Block(block string) (bool, error) {
+	ipStr, _, _ := strings.Cut(block, "-")
+	ipStr, _, _ = strings.Cut(ipStr, "/")
+	ip := net.ParseIP(strings.TrimSpace(ipStr))
+	if ip == nil {
+		return false, fmt.Errorf("invalid IP block %s", block)
+	}
+	return ip.To4() != nil, nil
+}
+
 func getCluster(service *SecurityPolicyService) string {
 	return service.NSXConfig.Cluster
 }
@@ -7,8 +7,85 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 )
 
 func Test_GetCluster(t *testing.T) {
 	assert.Equal(t, "k8scl-one", getCluster(service))
 }
+
+func Test_getRuleIPProtocol(t *testing.T) {
+	ipBlocksPeer := func(cidrs ...string) v1alpha1.SecurityPolicyPeer {
+		peer := v1alpha1.SecurityPolicyPeer{}
+		for _, cidr := range cidrs {
+			peer.IPBlocks = append(peer.IPBlocks, v1alpha1.IPBlock{CIDR: cidr})
+		}
+		return peer
+	}
+	for _, tc := range []struct {
+		name          string
+		direction     v1alpha1.RuleDirection
+		sources       []v1alpha1.SecurityPolicyPeer
+		destinations  []v1alpha1.SecurityPolicyPeer
+		expIPProtocol string
+		expErrStr     string
+	}{
+		{
+			name:          "no peers",
+			direction:     directionIn,
+			expIPProtocol: model.Rule_IP_PROTOCOL_IPV4_IPV6,
+		},
+		{
+			name:          "IPv4 sources",
+			direction:     directionIn,
+			sources:       []v1alpha1.SecurityPolicyPeer{ipBlocksPeer("172.17.0.0/16", "172.18.0.0-172.18.0.255")},
+			expIPProtocol: model.Rule_IP_PROTOCOL_IPV4,
+		},
+		{
+			name:          "IPv6 destinations",
+			direction:     directionOut,
+			sources:       []v1alpha1.SecurityPolicyPeer{ipBlocksPeer("172.17.0.0/16")},
+			destinations:  []v1alpha1.SecurityPolicyPeer{ipBlocksPeer("2001:db8::/64", "2001:db9::-2001:db9::ff")},
+			expIPProtocol: model.Rule_IP_PROTOCOL_IPV6,
+		},
+		{
+			name:          "dual-stack sources",
+			direction:     directionIn,
+			sources:       []v1alpha1.SecurityPolicyPeer{ipBlocksPeer("172.17.0.0/16"), ipBlocksPeer("2001:db8::/64")},
+			expIPProtocol: model.Rule_IP_PROTOCOL_IPV4_IPV6,
+		},
+		{
+			name:      "IPv4 sources with selector",
+			direction: directionIn,
+			sources: []v1alpha1.SecurityPolicyPeer{
+				ipBlocksPeer("172.17.0.0/16"),
+				{PodSelector: &metav1.LabelSelector{}},
+			},
+			expIPProtocol: model.Rule_IP_PROTOCOL_IPV4_IPV6,
+		},
+		{
+			name:      "invalid IP block",
+			direction: directionIn,
+			sources:   []v1alpha1.SecurityPolicyPeer{ipBlocksPeer("invalid")},
+			expErrStr: "invalid IP block invalid",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := &v1alpha1.SecurityPolicyRule{
+				Direction:    &tc.direction,
+				Sources:      tc.sources,
+				Destinations: tc.destinations,
+			}
+			ipProtocol, err := getRuleIPProtocol(rule)
+			if tc.expErrStr != "" {
+				assert.EqualError(t, err, tc.expErrStr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expIPProtocol, ipProtocol)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+// RuleStatistics is the statistics of an NSX rule realized for a SecurityPolicy or NetworkPolicy.
+type RuleStatistics struct {
+	Namespace  string
+	PolicyName string
+	PolicyUID  string
+	// PolicyType is common.ResourceTypeSecurityPolicy or common.ResourceTypeNetworkPolicy.
+	PolicyType   string
+	RuleID       string
+	RuleName     string
+	HitCount     int64
+	PacketCount  int64
+	ByteCount    int64
+	SessionCount int64
+}
+
+// CollectRuleStatistics reads the statistics of the NSX rules of the NSX SecurityPolicies in store.
+// The statistics of an NSX SecurityPolicy failed to be read are skipped, and the errors are joined.
+func (service *SecurityPolicyService) CollectRuleStatistics() ([]RuleStatistics, error) {
+	var ruleStatistics []RuleStatistics
+	var errs []error
+	for _, obj := range service.securityPolicyStore.List() {
+		nsxSecurityPolicy := obj.(*model.SecurityPolicy)
+		if nsxSecurityPolicy.Id == nil {
+			continue
+		}
+		statistics, err := service.listSecurityPolicyStatistics(nsxSecurityPolicy)
+		if err != nil {
+			log.Error(err, "Failed to list NSX SecurityPolicy statistics", "nsxSecurityPolicyId", *nsxSecurityPolicy.Id)
+			errs = append(errs, err)
+			continue
+		}
+		ruleStatistics = append(ruleStatistics, service.buildRuleStatistics(nsxSecurityPolicy, statistics)...)
+	}
+	return ruleStatistics, errors.Join(errs...)
+}
+
+func (service *SecurityPolicyService) listSecurityPolicyStatistics(nsxSecurityPolicy *model.SecurityPolicy) (model.SecurityPolicyStatisticsListResult, error) {
+	var statistics model.SecurityPolicyStatisticsListResult
+	var err error
+	if IsVPCEnabled(service) {
+		if nsxSecurityPolicy.Path == nil {
+			return statistics, fmt.Errorf("path of NSX SecurityPolicy %s is empty", *nsxSecurityPolicy.Id)
+		}
+		vpcInfo, err := common.ParseVPCResourcePath(*nsxSecurityPolicy.Path)
+		if err != nil {
+			return statistics, err
+		}
+		statistics, err = service.NSXClient.VPCSecurityPolicyStatisticsClient.List(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID,
+			*nsxSecurityPolicy.Id, nil, nil)
+		return statistics, nsxutil.TransNSXApiError(err)
+	}
+	statistics, err = service.NSXClient.SecurityPolicyStatisticsClient.List(getDomain(service), *nsxSecurityPolicy.Id, nil, nil)
+	return statistics, nsxutil.TransNSXApiError(err)
+}
+
+// buildRuleStatistics aggregates the rule statistics of all enforcement points by the rule path,
+// and attaches the owner SecurityPolicy or NetworkPolicy from the tags.
+func (service *SecurityPolicyService) buildRuleStatistics(nsxSecurityPolicy *model.SecurityPolicy, statistics model.SecurityPolicyStatisticsListResult) []RuleStatistics {
+	owner := RuleStatistics{
+		Namespace:  nsxutil.FindTag(nsxSecurityPolicy.Tags, common.TagScopeNamespace),
+		PolicyType: common.ResourceTypeSecurityPolicy,
+		PolicyName: nsxutil.FindTag(nsxSecurityPolicy.Tags, common.TagValueScopeSecurityPolicyName),
+		PolicyUID:  nsxutil.FindTag(nsxSecurityPolicy.Tags, common.TagValueScopeSecurityPolicyUID),
+	}
+	if npUID := nsxutil.FindTag(nsxSecurityPolicy.Tags, common.TagScopeNetworkPolicyUID); npUID != "" {
+		owner.PolicyType = common.ResourceTypeNetworkPolicy
+		owner.PolicyName = nsxutil.FindTag(nsxSecurityPolicy.Tags, common.TagScopeNetworkPolicyName)
+		owner.PolicyUID = npUID
+	}
+
+	var ruleIDs []string
+	ruleStatistics := map[string]*RuleStatistics{}
+	for _, result := range statistics.Results {
+		if result.Statistics == nil {
+			continue
+		}
+		for _, stat := range result.Statistics.Results {
+			if stat.Rule == nil {
+				continue
+			}
+			ruleID := (*stat.Rule)[strings.LastIndex(*stat.Rule, "/")+1:]
+			rs, ok := ruleStatistics[ruleID]
+			if !ok {
+				rs = &RuleStatistics{}
+				*rs = owner
+				rs.RuleID = ruleID
+				rs.RuleName = ruleID
+				if nsxRule, ok := service.ruleStore.GetByKey(ruleID).(*model.Rule); ok && nsxRule.DisplayName != nil {
+					rs.RuleName = *nsxRule.DisplayName
+				}
+				ruleStatistics[ruleID] = rs
+				ruleIDs = append(ruleIDs, ruleID)
+			}
+			rs.HitCount += int64Value(stat.HitCount)
+			rs.PacketCount += int64Value(stat.PacketCount)
+			rs.ByteCount += int64Value(stat.ByteCount)
+			rs.SessionCount += int64Value(stat.SessionCount)
+		}
+	}
+
+	results := make([]RuleStatistics, 0, len(ruleIDs))
+	for _, ruleID := range ruleIDs {
+		results = append(results, *ruleStatistics[ruleID])
+	}
+	return results
+}
+
+func int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,105 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+type fakeVPCSecurityPolicyStatisticsClient struct {
+	statistics map[string][]model.RuleStatistics
+}
+
+func (f *fakeVPCSecurityPolicyStatisticsClient) List(_ string, _ string, _ string, securityPolicyIdParam string, _ *string, _ *string) (model.SecurityPolicyStatisticsListResult, error) {
+	stats, ok := f.statistics[securityPolicyIdParam]
+	if !ok {
+		return model.SecurityPolicyStatisticsListResult{}, errors.New("not found")
+	}
+	// Each enforcement point reports a part of the statistics.
+	result := model.SecurityPolicyStatisticsListResult{}
+	for i := range stats {
+		result.Results = append(result.Results, model.SecurityPolicyStatisticsForEnforcementPoint{
+			Statistics: &model.SecurityPolicyStatistics{Results: stats[i : i+1]},
+		})
+	}
+	return result, nil
+}
+
+func TestSecurityPolicyService_CollectRuleStatistics(t *testing.T) {
+	service := &SecurityPolicyService{
+		Service: common.Service{
+			NSXClient: &nsx.Client{
+				VPCSecurityPolicyStatisticsClient: &fakeVPCSecurityPolicyStatisticsClient{
+					statistics: map[string][]model.RuleStatistics{
+						"sp1": {
+							{Rule: String("/orgs/default/projects/p1/vpcs/v1/security-policies/sp1/rules/r1"), HitCount: Int64(3), PacketCount: Int64(10), ByteCount: Int64(100), SessionCount: Int64(1)},
+							{Rule: String("/orgs/default/projects/p1/vpcs/v1/security-policies/sp1/rules/r1"), HitCount: Int64(2), PacketCount: Int64(5), ByteCount: Int64(50), SessionCount: Int64(1)},
+							{Rule: String("/orgs/default/projects/p1/vpcs/v1/security-policies/sp1/rules/r2")},
+						},
+						"np1": {
+							{Rule: String("/orgs/default/projects/p1/vpcs/v1/security-policies/np1/rules/r3"), HitCount: Int64(1)},
+						},
+					},
+				},
+			},
+			NSXConfig: &config.NSXOperatorConfig{
+				CoeConfig: &config.CoeConfig{EnableVPCNetwork: true},
+			},
+		},
+	}
+	service.setUpStore(common.TagValueScopeSecurityPolicyUID)
+	nsxPolicies := []*model.SecurityPolicy{
+		{
+			Id:   String("sp1"),
+			Path: String("/orgs/default/projects/p1/vpcs/v1/security-policies/sp1"),
+			Tags: []model.Tag{
+				{Scope: String(common.TagScopeNamespace), Tag: String("ns1")},
+				{Scope: String(common.TagValueScopeSecurityPolicyName), Tag: String("sp")},
+				{Scope: String(common.TagValueScopeSecurityPolicyUID), Tag: String("sp-uid")},
+			},
+		},
+		{
+			Id:   String("np1"),
+			Path: String("/orgs/default/projects/p1/vpcs/v1/security-policies/np1"),
+			Tags: []model.Tag{
+				{Scope: String(common.TagScopeNamespace), Tag: String("ns1")},
+				{Scope: String(common.TagScopeNetworkPolicyName), Tag: String("np")},
+				{Scope: String(common.TagScopeNetworkPolicyUID), Tag: String("np-uid")},
+			},
+		},
+		{
+			Id:   String("sp-without-statistics"),
+			Path: String("/orgs/default/projects/p1/vpcs/v1/security-policies/sp-without-statistics"),
+		},
+	}
+	for _, p := range nsxPolicies {
+		assert.NoError(t, service.securityPolicyStore.Add(p))
+	}
+	assert.NoError(t, service.ruleStore.Add(&model.Rule{Id: String("r1"), DisplayName: String("rule1")}))
+
+	ruleStatistics, err := service.CollectRuleStatistics()
+	assert.ErrorContains(t, err, "not found")
+	assert.ElementsMatch(t, []RuleStatistics{
+		{
+			Namespace: "ns1", PolicyName: "sp", PolicyUID: "sp-uid", PolicyType: common.ResourceTypeSecurityPolicy,
+			RuleID: "r1", RuleName: "rule1", HitCount: 5, PacketCount: 15, ByteCount: 150, SessionCount: 2,
+		},
+		{
+			Namespace: "ns1", PolicyName: "sp", PolicyUID: "sp-uid", PolicyType: common.ResourceTypeSecurityPolicy,
+			RuleID: "r2", RuleName: "r2",
+		},
+		{
+			Namespace: "ns1", PolicyName: "np", PolicyUID: "np-uid", PolicyType: common.ResourceTypeNetworkPolicy,
+			RuleID: "r3", RuleName: "r3", HitCount: 1,
+		},
+	}, ruleStatistics)
+}
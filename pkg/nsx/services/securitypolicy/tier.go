@@ -0,0 +1,90 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+// The NSX distributed firewall categories, NSX creates the SecurityPolicy in the Application
+// category if the category is not specified.
+const (
+	categoryEmergency      = "Emergency"
+	categoryInfrastructure = "Infrastructure"
+	categoryEnvironment    = "Environment"
+	categoryApplication    = "Application"
+)
+
+// tierCategories maps the SecurityPolicy tiers to the NSX categories, the order is the
+// enforcement order of the categories.
+var tierCategories = []struct {
+	tier     v1alpha1.SecurityPolicyTier
+	category string
+}{
+	{v1alpha1.SecurityPolicyTierEmergency, categoryEmergency},
+	{v1alpha1.SecurityPolicyTierInfrastructure, categoryInfrastructure},
+	{v1alpha1.SecurityPolicyTierEnvironment, categoryEnvironment},
+	{v1alpha1.SecurityPolicyTierApplication, categoryApplication},
+}
+
+// GetSecurityPolicyTier returns the tier of the SecurityPolicy, the SecurityPolicies created
+// before the tier is introduced and the NetworkPolicies are in the Application tier.
+func GetSecurityPolicyTier(obj *v1alpha1.SecurityPolicy) v1alpha1.SecurityPolicyTier {
+	if obj.Spec.Tier == "" {
+		return v1alpha1.SecurityPolicyTierApplication
+	}
+	return obj.Spec.Tier
+}
+
+// IsPrivilegedTier returns true if the tier is enforced before the Application tier.
+func IsPrivilegedTier(tier v1alpha1.SecurityPolicyTier) bool {
+	return tier != "" && tier != v1alpha1.SecurityPolicyTierApplication
+}
+
+func getSecurityPolicyCategory(obj *v1alpha1.SecurityPolicy) string {
+	tier := GetSecurityPolicyTier(obj)
+	for _, tc := range tierCategories {
+		if tc.tier == tier {
+			return tc.category
+		}
+	}
+	return categoryApplication
+}
+
+// IsTierRealized returns true if the NSX SecurityPolicy of the SecurityPolicy is already realized in the
+// category of its tier.
+func (service *SecurityPolicyService) IsTierRealized(obj *v1alpha1.SecurityPolicy) bool {
+	category := getSecurityPolicyCategory(obj)
+	for _, nsxSecurityPolicy := range service.securityPolicyStore.GetByIndex(common.TagValueScopeSecurityPolicyUID, string(obj.UID)) {
+		nsxCategory := categoryApplication
+		if nsxSecurityPolicy.Category != nil {
+			nsxCategory = *nsxSecurityPolicy.Category
+		}
+		if nsxCategory == category {
+			return true
+		}
+	}
+	return false
+}
+
+func getTierRank(obj *v1alpha1.SecurityPolicy) int {
+	tier := GetSecurityPolicyTier(obj)
+	for i, tc := range tierCategories {
+		if tc.tier == tier {
+			return i
+		}
+	}
+	return len(tierCategories) - 1
+}
+
+// compareSecurityPolicyOrder returns a negative number if the SecurityPolicy a is enforced before b,
+// a positive number if a is enforced after b, and 0 if the order is undetermined. The tier is
+// compared first, then the priority within the tier.
+func compareSecurityPolicyOrder(a, b *v1alpha1.SecurityPolicy) int {
+	if rankA, rankB := getTierRank(a), getTierRank(b); rankA != rankB {
+		return rankA - rankB
+	}
+	return a.Spec.Priority - b.Spec.Priority
+}
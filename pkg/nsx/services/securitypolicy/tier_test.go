@@ -0,0 +1,72 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package securitypolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+func TestSecurityPolicyTier(t *testing.T) {
+	newPolicy := func(tier v1alpha1.SecurityPolicyTier, priority int) *v1alpha1.SecurityPolicy {
+		return &v1alpha1.SecurityPolicy{Spec: v1alpha1.SecurityPolicySpec{Tier: tier, Priority: priority}}
+	}
+
+	// The SecurityPolicies without tier are migrated to the Application tier.
+	assert.Equal(t, v1alpha1.SecurityPolicyTierApplication, GetSecurityPolicyTier(newPolicy("", 0)))
+	assert.Equal(t, categoryApplication, getSecurityPolicyCategory(newPolicy("", 0)))
+	assert.Equal(t, categoryEmergency, getSecurityPolicyCategory(newPolicy(v1alpha1.SecurityPolicyTierEmergency, 0)))
+	assert.Equal(t, categoryInfrastructure, getSecurityPolicyCategory(newPolicy(v1alpha1.SecurityPolicyTierInfrastructure, 0)))
+	assert.Equal(t, categoryEnvironment, getSecurityPolicyCategory(newPolicy(v1alpha1.SecurityPolicyTierEnvironment, 0)))
+
+	assert.False(t, IsPrivilegedTier(""))
+	assert.False(t, IsPrivilegedTier(v1alpha1.SecurityPolicyTierApplication))
+	assert.True(t, IsPrivilegedTier(v1alpha1.SecurityPolicyTierEnvironment))
+
+	// The tier takes precedence over the priority.
+	assert.Negative(t, compareSecurityPolicyOrder(newPolicy(v1alpha1.SecurityPolicyTierEmergency, 100), newPolicy(v1alpha1.SecurityPolicyTierInfrastructure, 1)))
+	assert.Positive(t, compareSecurityPolicyOrder(newPolicy("", 1), newPolicy(v1alpha1.SecurityPolicyTierEnvironment, 100)))
+	assert.Negative(t, compareSecurityPolicyOrder(newPolicy("", 1), newPolicy(v1alpha1.SecurityPolicyTierApplication, 2)))
+	assert.Zero(t, compareSecurityPolicyOrder(newPolicy("", 1), newPolicy(v1alpha1.SecurityPolicyTierApplication, 1)))
+}
+
+func TestSecurityPolicyCategoryCompare(t *testing.T) {
+	// The NSX SecurityPolicies created without category are in the Application category.
+	nsxPolicy := &SecurityPolicy{Id: String("sp1")}
+	applicationPolicy := &SecurityPolicy{Id: String("sp1"), Category: String(categoryApplication)}
+	environmentPolicy := &SecurityPolicy{Id: String("sp1"), Category: String(categoryEnvironment)}
+	assert.Equal(t, nsxPolicy.Value(), applicationPolicy.Value())
+	assert.NotEqual(t, applicationPolicy.Value(), environmentPolicy.Value())
+}
+
+func TestIsTierRealized(t *testing.T) {
+	service := &SecurityPolicyService{}
+	service.setUpStore(common.TagValueScopeSecurityPolicyUID)
+	obj := &v1alpha1.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{UID: "sp-uid"},
+		Spec:       v1alpha1.SecurityPolicySpec{Tier: v1alpha1.SecurityPolicyTierEnvironment},
+	}
+	assert.False(t, service.IsTierRealized(obj))
+
+	nsxPolicy := &model.SecurityPolicy{
+		Id:   String("sp1"),
+		Tags: []model.Tag{{Scope: String(common.TagValueScopeSecurityPolicyUID), Tag: String("sp-uid")}},
+	}
+	assert.NoError(t, service.securityPolicyStore.Apply(nsxPolicy))
+	// The NSX SecurityPolicy without category is in the Application tier.
+	assert.False(t, service.IsTierRealized(obj))
+	obj.Spec.Tier = ""
+	assert.True(t, service.IsTierRealized(obj))
+
+	nsxPolicy.Category = String(categoryEnvironment)
+	assert.NoError(t, service.securityPolicyStore.Apply(nsxPolicy))
+	obj.Spec.Tier = v1alpha1.SecurityPolicyTierEnvironment
+	assert.True(t, service.IsTierRealized(obj))
+}
@@ -45,7 +45,10 @@ func InitializeStaticRoute(commonService common.Service, vpcService common.VPCSe
 	staticRouteService.NSXConfig = commonService.NSXConfig
 	staticRouteService.VPCService = vpcService
 
-	go staticRouteService.InitializeResourceStore(&wg, fatalErrors, common.ResourceTypeStaticRoute, nil, staticRouteService.StaticRouteStore)
+	// Only the static routes created for the StaticRoute CRs are synced, the static routes of the VPCPeerings
+	// are synced by the VPCPeering service.
+	tags := []model.Tag{{Scope: String(common.TagScopeStaticRouteCRUID)}}
+	go staticRouteService.InitializeResourceStore(&wg, fatalErrors, common.ResourceTypeStaticRoute, tags, staticRouteService.StaticRouteStore)
 
 	go func() {
 		wg.Wait()
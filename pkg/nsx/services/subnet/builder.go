@@ -2,8 +2,12 @@ package subnet
 
 import (
 	"fmt"
+	"net"
+
+	"github.com/apparentlymart/go-cidr/cidr"
 
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
@@ -12,7 +16,10 @@ import (
 	"github.com/vmware-tanzu/nsx-operator/pkg/util"
 )
 
-const AccessModeProjectInNSX string = "Private_TGW"
+const (
+	AccessModeProjectInNSX string = "Private_TGW"
+	maxIPv6PrefixScan      int    = 65536
+)
 
 var (
 	String = common.String
@@ -53,6 +60,20 @@ func convertAccessMode(accessMode string) string {
 	return accessMode
 }
 
+// IsAccessModeChanged returns true if the access mode of the NSX Subnet is different from the access
+// mode of the Subnet or SubnetSet. NSX doesn't support changing the access mode of a realized Subnet,
+// so the NSX Subnet needs to be re-created for the new access mode. The empty access mode is treated as
+// Private, which is the default access mode of the NSX Subnet.
+func IsAccessModeChanged(nsxSubnet *model.VpcSubnet, accessMode v1alpha1.AccessMode) bool {
+	if nsxSubnet.AccessMode == nil {
+		return false
+	}
+	if accessMode == "" {
+		accessMode = v1alpha1.AccessMode(v1alpha1.AccessModePrivate)
+	}
+	return *nsxSubnet.AccessMode != convertAccessMode(util.Capitalize(string(accessMode)))
+}
+
 func (service *SubnetService) buildSubnet(obj client.Object, tags []model.Tag) (*model.VpcSubnet, error) {
 	tags = append(service.buildBasicTags(obj), tags...)
 	var nsxSubnet *model.VpcSubnet
@@ -66,12 +87,16 @@ func (service *SubnetService) buildSubnet(obj client.Object, tags []model.Tag) (
 			Ipv4SubnetSize: Int64(int64(o.Spec.IPv4SubnetSize)),
 			DisplayName:    String(service.buildSubnetName(o)),
 		}
-		dhcpMode := string(o.Spec.SubnetDHCPConfig.Mode)
-		if dhcpMode == "" {
-			dhcpMode = v1alpha1.DHCPConfigModeDeactivated
+		dhcpTags, err := service.buildSubnetDHCP(nsxSubnet, o.Spec.SubnetDHCPConfig)
+		if err != nil {
+			return nil, err
 		}
-		nsxSubnet.SubnetDhcpConfig = service.buildSubnetDHCPConfig(dhcpMode, nil)
+		tags = append(tags, dhcpTags...)
+		tags = append(tags, buildReservedIPRangesTags(o.Spec.ReservedIPRanges)...)
 		nsxSubnet.IpAddresses = o.Spec.IPAddresses
+		if !util.HasIPFamily(o.Spec.IPFamilies, v1alpha1.IPFamilyIPv4) {
+			nsxSubnet.Ipv4SubnetSize = nil
+		}
 	case *v1alpha1.SubnetSet:
 		// The index is a random string with the length of 8 chars. It is the first 8 chars of the hash
 		// value on a random UUID string.
@@ -83,14 +108,18 @@ func (service *SubnetService) buildSubnet(obj client.Object, tags []model.Tag) (
 			Ipv4SubnetSize: Int64(int64(o.Spec.IPv4SubnetSize)),
 			DisplayName:    String(service.buildSubnetSetName(o, index)),
 		}
-		dhcpMode := string(o.Spec.SubnetDHCPConfig.Mode)
-		if dhcpMode == "" {
-			dhcpMode = v1alpha1.DHCPConfigModeDeactivated
+		dhcpTags, err := service.buildSubnetDHCP(nsxSubnet, o.Spec.SubnetDHCPConfig)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, dhcpTags...)
+		if !util.HasIPFamily(o.Spec.IPFamilies, v1alpha1.IPFamilyIPv4) {
+			nsxSubnet.Ipv4SubnetSize = nil
 		}
-		nsxSubnet.SubnetDhcpConfig = service.buildSubnetDHCPConfig(dhcpMode, nil)
 	default:
 		return nil, SubnetTypeError
 	}
+	tags = util.AppendLabelTags(tags, service.buildLabelTags(obj))
 	// tags cannot exceed maximum size 26
 	if len(tags) > common.MaxTagsCount {
 		errorMsg := fmt.Sprintf("tags cannot exceed maximum size 26, tags length: %d", len(tags))
@@ -105,6 +134,119 @@ func (service *SubnetService) buildSubnet(obj client.Object, tags []model.Tag) (
 	return nsxSubnet, nil
 }
 
+// buildIPv6Prefix allocates an IPv6 prefix for the NSX Subnet if the Subnet or SubnetSet has
+// IPv6 family and the IPv6 CIDR is not specified, NSX carves the IPv4 CIDR of a dual-stack
+// Subnet from the IPv4 blocks of the VPC with Ipv4SubnetSize.
+func (service *SubnetService) buildIPv6Prefix(obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo) error {
+	var ipFamilies []v1alpha1.IPFamily
+	switch o := obj.(type) {
+	case *v1alpha1.Subnet:
+		ipFamilies = o.Spec.IPFamilies
+	case *v1alpha1.SubnetSet:
+		ipFamilies = o.Spec.IPFamilies
+	}
+	if !util.HasIPFamily(ipFamilies, v1alpha1.IPFamilyIPv6) {
+		return nil
+	}
+	for _, ipAddress := range nsxSubnet.IpAddresses {
+		if ipFamily, _ := util.GetIPFamily(ipAddress); ipFamily == v1alpha1.IPFamilyIPv6 {
+			return nil
+		}
+	}
+	prefix, err := service.allocateIPv6Prefix(vpcInfo)
+	if err != nil {
+		return err
+	}
+	nsxSubnet.IpAddresses = append(nsxSubnet.IpAddresses, prefix)
+	return nil
+}
+
+func ipv6PrefixReservationKey(vpcInfo *common.VPCResourceInfo) string {
+	return vpcInfo.ProjectID + "/" + vpcInfo.VPCID
+}
+
+// releaseIPv6Prefixes releases the reservation of the IPv6 prefixes allocated by allocateIPv6Prefix, the
+// addresses which are not reserved are ignored.
+func (service *SubnetService) releaseIPv6Prefixes(vpcInfo *common.VPCResourceInfo, ipAddresses []string) {
+	service.ipv6PrefixLock.Lock()
+	defer service.ipv6PrefixLock.Unlock()
+	key := ipv6PrefixReservationKey(vpcInfo)
+	if reserved, ok := service.reservedIPv6Prefixes[key]; ok {
+		reserved.Delete(ipAddresses...)
+		if reserved.Len() == 0 {
+			delete(service.reservedIPv6Prefixes, key)
+		}
+	}
+}
+
+// allocateIPv6Prefix returns the first IPv6 prefix in the IPv6 CIDRs of the VPC which doesn't
+// overlap with the IPv6 CIDRs of the existing Subnets in the VPC. The prefix is reserved until
+// releaseIPv6Prefixes is called, so that the Subnets created concurrently get different prefixes.
+func (service *SubnetService) allocateIPv6Prefix(vpcInfo *common.VPCResourceInfo) (string, error) {
+	service.ipv6PrefixLock.Lock()
+	defer service.ipv6PrefixLock.Unlock()
+	key := ipv6PrefixReservationKey(vpcInfo)
+	var usedPrefixes []*net.IPNet
+	for prefix := range service.reservedIPv6Prefixes[key] {
+		if _, ipNet, err := net.ParseCIDR(prefix); err == nil {
+			usedPrefixes = append(usedPrefixes, ipNet)
+		}
+	}
+	for _, subnet := range service.SubnetStore.List() {
+		nsxSubnet := subnet.(*model.VpcSubnet)
+		if nsxSubnet.Path == nil {
+			continue
+		}
+		subnetInfo, err := common.ParseVPCResourcePath(*nsxSubnet.Path)
+		if err != nil || subnetInfo.ProjectID != vpcInfo.ProjectID || subnetInfo.VPCID != vpcInfo.VPCID {
+			continue
+		}
+		for _, ipAddress := range nsxSubnet.IpAddresses {
+			if _, ipNet, err := net.ParseCIDR(ipAddress); err == nil && ipNet.IP.To4() == nil {
+				usedPrefixes = append(usedPrefixes, ipNet)
+			}
+		}
+	}
+	for _, privateIP := range vpcInfo.PrivateIPs {
+		_, block, err := net.ParseCIDR(privateIP)
+		if err != nil || block.IP.To4() != nil {
+			continue
+		}
+		blockSize, _ := block.Mask.Size()
+		if blockSize > common.IPv6SubnetSize {
+			continue
+		}
+		newBits := common.IPv6SubnetSize - blockSize
+		// Only scan a limited number of prefixes in a large IPv6 block.
+		for num := 0; num < maxIPv6PrefixScan && (newBits >= 31 || num < 1<<newBits); num++ {
+			prefix, err := cidr.Subnet(block, newBits, num)
+			if err != nil {
+				break
+			}
+			if !overlapsWith(prefix, usedPrefixes) {
+				if service.reservedIPv6Prefixes == nil {
+					service.reservedIPv6Prefixes = make(map[string]sets.Set[string])
+				}
+				if _, ok := service.reservedIPv6Prefixes[key]; !ok {
+					service.reservedIPv6Prefixes[key] = sets.New[string]()
+				}
+				service.reservedIPv6Prefixes[key].Insert(prefix.String())
+				return prefix.String(), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no available IPv6 prefix in VPC %s", vpcInfo.VPCID)
+}
+
+func overlapsWith(prefix *net.IPNet, ipNets []*net.IPNet) bool {
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(prefix.IP) || prefix.Contains(ipNet.IP) {
+			return true
+		}
+	}
+	return false
+}
+
 func (service *SubnetService) buildSubnetDHCPConfig(mode string, dhcpServerAdditionalConfig *model.DhcpServerAdditionalConfig) *model.SubnetDhcpConfig {
 	nsxMode := nsxutil.ParseDHCPMode(mode)
 	subnetDhcpConfig := &model.SubnetDhcpConfig{
@@ -117,3 +259,9 @@ func (service *SubnetService) buildSubnetDHCPConfig(mode string, dhcpServerAddit
 func (service *SubnetService) buildBasicTags(obj client.Object) []model.Tag {
 	return util.BuildBasicTags(getCluster(service), obj, "")
 }
+
+// buildLabelTags builds the tags mirrored from the allowed labels of the Subnet or SubnetSet, the labels of
+// the Namespace are already in the tags generated by GenerateSubnetNSTags.
+func (service *SubnetService) buildLabelTags(obj client.Object) []model.Tag {
+	return util.BuildLabelTags(service.NSXConfig.GetLabelTagKeys(), obj.GetLabels())
+}
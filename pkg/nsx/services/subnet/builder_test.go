@@ -1,6 +1,7 @@
 package subnet
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -13,6 +14,7 @@ import (
 	"github.com/vmware-tanzu/nsx-operator/pkg/config"
 	mock_client "github.com/vmware-tanzu/nsx-operator/pkg/mock/controller-runtime/client"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
 )
 
 func TestBuildSubnetName(t *testing.T) {
@@ -108,3 +110,131 @@ func TestBuildSubnetForSubnetSet(t *testing.T) {
 	assert.Equal(t, "DHCP_DEACTIVATED", *subnet.SubnetDhcpConfig.Mode)
 	assert.Equal(t, true, *subnet.AdvancedConfig.StaticIpAllocation.Enabled)
 }
+
+func TestBuildSubnetLabelTags(t *testing.T) {
+	service := &SubnetService{
+		Service: common.Service{
+			NSXConfig: &config.NSXOperatorConfig{
+				CoeConfig: &config.CoeConfig{Cluster: "k8scl-one:test"},
+				K8sConfig: &config.K8sConfig{LabelTagKeys: []string{"team", "owner"}},
+			},
+		},
+	}
+	subnetSet := &v1alpha1.SubnetSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "subnetset-1",
+			Namespace: "ns-1",
+			Labels:    map[string]string{"team": "net", "env": "prod"},
+		},
+	}
+	subnet, err := service.buildSubnet(subnetSet, []model.Tag{{Scope: common.String("owner"), Tag: common.String("ns-owner")}})
+	assert.Nil(t, err)
+	assert.Equal(t, model.Tag{Scope: common.String("team"), Tag: common.String("net")}, subnet.Tags[len(subnet.Tags)-1])
+	assert.Equal(t, "ns-owner", nsxutil.FindTag(subnet.Tags, "owner"))
+	assert.Equal(t, "", nsxutil.FindTag(subnet.Tags, "env"))
+
+	// The label tags exceeding the limit are dropped.
+	var tags []model.Tag
+	for i := len(subnet.Tags) - 2; i < common.MaxTagsCount; i++ {
+		tags = append(tags, model.Tag{Scope: common.String(fmt.Sprintf("scope-%d", i)), Tag: common.String("value")})
+	}
+	subnet, err = service.buildSubnet(subnetSet, tags)
+	assert.Nil(t, err)
+	assert.Equal(t, common.MaxTagsCount, len(subnet.Tags))
+	assert.Equal(t, "", nsxutil.FindTag(subnet.Tags, "team"))
+}
+
+func TestBuildIPv6Prefix(t *testing.T) {
+	service := &SubnetService{
+		SubnetStore: &SubnetStore{
+			ResourceStore: common.ResourceStore{
+				Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
+					common.TagScopeSubnetCRUID:    subnetIndexFunc,
+					common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
+					common.TagScopeVMNamespace:    subnetIndexVMNamespaceFunc,
+					common.TagScopeNamespace:      subnetIndexNamespaceFunc,
+				}),
+				BindingType: model.VpcSubnetBindingType(),
+			},
+		},
+	}
+	vpcInfo := &common.VPCResourceInfo{
+		OrgID:      "default",
+		ProjectID:  "project-1",
+		VPCID:      "vpc-1",
+		PrivateIPs: []string{"10.0.0.0/16", "2001:db8::/62"},
+	}
+	// The first prefix is taken by a Subnet in the same VPC, the Subnet in another VPC is ignored.
+	assert.Nil(t, service.SubnetStore.Add(&model.VpcSubnet{
+		Id:          common.String("subnet-1"),
+		Path:        common.String("/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1"),
+		IpAddresses: []string{"10.0.0.0/28", "2001:db8::/64"},
+	}))
+	assert.Nil(t, service.SubnetStore.Add(&model.VpcSubnet{
+		Id:          common.String("subnet-2"),
+		Path:        common.String("/orgs/default/projects/project-1/vpcs/vpc-2/subnets/subnet-2"),
+		IpAddresses: []string{"2001:db8:0:1::/64"},
+	}))
+
+	ipv4Subnet := &v1alpha1.Subnet{}
+	nsxSubnet := &model.VpcSubnet{}
+	assert.Nil(t, service.buildIPv6Prefix(ipv4Subnet, nsxSubnet, vpcInfo))
+	assert.Nil(t, nsxSubnet.IpAddresses)
+
+	dualStackSubnetSet := &v1alpha1.SubnetSet{Spec: v1alpha1.SubnetSetSpec{IPFamilies: []v1alpha1.IPFamily{v1alpha1.IPFamilyIPv4, v1alpha1.IPFamilyIPv6}}}
+	assert.Nil(t, service.buildIPv6Prefix(dualStackSubnetSet, nsxSubnet, vpcInfo))
+	assert.Equal(t, []string{"2001:db8:0:1::/64"}, nsxSubnet.IpAddresses)
+
+	// The reserved prefix is not allocated to another Subnet until it is released.
+	concurrentSubnet := &model.VpcSubnet{}
+	assert.Nil(t, service.buildIPv6Prefix(dualStackSubnetSet, concurrentSubnet, vpcInfo))
+	assert.Equal(t, []string{"2001:db8:0:2::/64"}, concurrentSubnet.IpAddresses)
+	service.releaseIPv6Prefixes(vpcInfo, concurrentSubnet.IpAddresses)
+	service.releaseIPv6Prefixes(vpcInfo, nsxSubnet.IpAddresses)
+	releasedSubnet := &model.VpcSubnet{}
+	assert.Nil(t, service.buildIPv6Prefix(dualStackSubnetSet, releasedSubnet, vpcInfo))
+	assert.Equal(t, []string{"2001:db8:0:1::/64"}, releasedSubnet.IpAddresses)
+
+	// The specified IPv6 CIDR is used.
+	ipv6Subnet := &v1alpha1.Subnet{Spec: v1alpha1.SubnetSpec{IPFamilies: []v1alpha1.IPFamily{v1alpha1.IPFamilyIPv6}, IPAddresses: []string{"2001:db8:0:3::/64"}}}
+	nsxSubnet = &model.VpcSubnet{IpAddresses: ipv6Subnet.Spec.IPAddresses}
+	assert.Nil(t, service.buildIPv6Prefix(ipv6Subnet, nsxSubnet, vpcInfo))
+	assert.Equal(t, []string{"2001:db8:0:3::/64"}, nsxSubnet.IpAddresses)
+
+	// No IPv6 CIDR in the VPC.
+	vpcInfo.PrivateIPs = []string{"10.0.0.0/16"}
+	err := service.buildIPv6Prefix(dualStackSubnetSet, &model.VpcSubnet{}, vpcInfo)
+	assert.ErrorContains(t, err, "no available IPv6 prefix in VPC vpc-1")
+}
+
+func TestBuildSubnetIPv6Only(t *testing.T) {
+	service := &SubnetService{
+		Service: common.Service{
+			NSXConfig: &config.NSXOperatorConfig{
+				CoeConfig: &config.CoeConfig{
+					Cluster: "cluster1",
+				},
+			},
+		},
+	}
+	subnet := &v1alpha1.Subnet{
+		ObjectMeta: v1.ObjectMeta{
+			UID:  "uuid1",
+			Name: "subnet1",
+		},
+		Spec: v1alpha1.SubnetSpec{IPFamilies: []v1alpha1.IPFamily{v1alpha1.IPFamilyIPv6}},
+	}
+	nsxSubnet, err := service.buildSubnet(subnet, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, nsxSubnet.Ipv4SubnetSize)
+}
+
+func TestIsAccessModeChanged(t *testing.T) {
+	nsxSubnet := &model.VpcSubnet{AccessMode: String("Private_TGW")}
+	assert.False(t, IsAccessModeChanged(nsxSubnet, v1alpha1.AccessMode(v1alpha1.AccessModeProject)))
+	assert.True(t, IsAccessModeChanged(nsxSubnet, v1alpha1.AccessMode(v1alpha1.AccessModePublic)))
+	// The empty access mode is treated as Private.
+	assert.True(t, IsAccessModeChanged(nsxSubnet, ""))
+	assert.False(t, IsAccessModeChanged(&model.VpcSubnet{AccessMode: String("Private")}, ""))
+	assert.False(t, IsAccessModeChanged(&model.VpcSubnet{}, v1alpha1.AccessMode(v1alpha1.AccessModePublic)))
+}
@@ -18,13 +18,17 @@ func (subnet *Subnet) Key() string {
 }
 
 func (subnet *Subnet) Value() data.DataValue {
-	// IPv4SubnetSize/AccessMode/IPAddresses are immutable field,
-	// Changes of tags and subnetDHCPConfig are considered as changed.
-	// TODO AccessMode may also need to be compared in future.
+	// IPv4SubnetSize/IPAddresses are immutable field, the change of AccessMode is handled by
+	// re-creating the NSX Subnet, see IsAccessModeChanged.
+	// Changes of tags, subnetDHCPConfig and DHCP relay servers are considered as changed.
 	s := &Subnet{
 		Tags:             subnet.Tags,
 		SubnetDhcpConfig: subnet.SubnetDhcpConfig,
 	}
+	// Only the DHCP relay servers are configured in the DhcpConfig, the other fields are rendered by NSX.
+	if subnet.DhcpConfig != nil && subnet.DhcpConfig.DhcpRelayConfig != nil {
+		s.DhcpConfig = &model.VpcSubnetDhcpConfig{DhcpRelayConfig: subnet.DhcpConfig.DhcpRelayConfig}
+	}
 	dataValue, _ := (*model.VpcSubnet)(s).GetDataValue__()
 	return dataValue
 }
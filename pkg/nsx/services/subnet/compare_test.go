@@ -49,6 +49,18 @@ func TestSubnetToComparable(t *testing.T) {
 			nsxSubnet:      &model.VpcSubnet{Tags: []model.Tag{tag2}},
 			existingSubnet: &model.VpcSubnet{Tags: []model.Tag{tag1}},
 		},
+		{
+			name:           "Subnet with diff DHCP relay servers",
+			expectChanged:  true,
+			nsxSubnet:      &model.VpcSubnet{DhcpConfig: &model.VpcSubnetDhcpConfig{DhcpRelayConfig: &model.VpcDhcpRelayConfig{ServerAddresses: []string{"10.0.0.3"}}}},
+			existingSubnet: &model.VpcSubnet{DhcpConfig: &model.VpcSubnetDhcpConfig{DhcpRelayConfig: &model.VpcDhcpRelayConfig{ServerAddresses: []string{"10.0.0.2"}}}},
+		},
+		{
+			name:           "Subnet with DhcpConfig rendered by NSX",
+			expectChanged:  false,
+			nsxSubnet:      &model.VpcSubnet{},
+			existingSubnet: &model.VpcSubnet{DhcpConfig: &model.VpcSubnetDhcpConfig{EnableDhcp: common.Bool(false)}},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,188 @@
+package subnet
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+// The DHCP option codes of the DHCP server options configured in the generic format.
+const (
+	dhcpOptionDNSServers   int64 = 6
+	dhcpOptionNTPServers   int64 = 42
+	dhcpOptionLeaseTime    int64 = 51
+	dhcpOptionDomainSearch int64 = 119
+)
+
+// buildSubnetDHCP sets the DHCP mode, the DHCP server options, the DHCP relay servers and the DHCP
+// static bindings of the NSX Subnet. The static bindings are realized as the children of the NSX
+// Subnet, it returns the tag with the hash of the static bindings to detect the changes of them as
+// the children are not returned by NSX.
+func (service *SubnetService) buildSubnetDHCP(nsxSubnet *model.VpcSubnet, dhcpConfig v1alpha1.SubnetDHCPConfig) ([]model.Tag, error) {
+	dhcpMode := string(dhcpConfig.Mode)
+	if dhcpMode == "" {
+		dhcpMode = v1alpha1.DHCPConfigModeDeactivated
+	}
+	nsxSubnet.SubnetDhcpConfig = service.buildSubnetDHCPConfig(dhcpMode, buildDHCPServerAdditionalConfig(dhcpConfig.DHCPServerAdditionalConfig))
+	if dhcpConfig.DHCPRelayConfig != nil {
+		nsxSubnet.DhcpConfig = &model.VpcSubnetDhcpConfig{
+			DhcpRelayConfig: &model.VpcDhcpRelayConfig{ServerAddresses: dhcpConfig.DHCPRelayConfig.ServerAddresses},
+		}
+	}
+	if dhcpConfig.DHCPServerAdditionalConfig == nil || len(dhcpConfig.DHCPServerAdditionalConfig.StaticBindings) == 0 {
+		return nil, nil
+	}
+	staticBindings := dhcpConfig.DHCPServerAdditionalConfig.StaticBindings
+	children := make([]*data.StructValue, 0, len(staticBindings))
+	bindingKeys := make([]string, 0, len(staticBindings))
+	for _, binding := range staticBindings {
+		child, err := wrapDHCPStaticBinding(&model.DhcpV4StaticBindingConfig{
+			Id:           String(buildDHCPStaticBindingID(binding.MACAddress)),
+			ResourceType: "DhcpV4StaticBindingConfig",
+			MacAddress:   String(strings.ToLower(binding.MACAddress)),
+			IpAddress:    String(binding.IPAddress),
+			HostName:     optionalString(binding.HostName),
+		})
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+		bindingKeys = append(bindingKeys, strings.Join([]string{strings.ToLower(binding.MACAddress), binding.IPAddress, binding.HostName}, "/"))
+	}
+	nsxSubnet.Children = children
+	sort.Strings(bindingKeys)
+	return []model.Tag{{Scope: String(common.TagScopeDHCPStaticBindingHash), Tag: String(util.Sha1(strings.Join(bindingKeys, ",")))}}, nil
+}
+
+func buildDHCPServerAdditionalConfig(serverConfig *v1alpha1.DHCPServerAdditionalConfig) *model.DhcpServerAdditionalConfig {
+	if serverConfig == nil {
+		return nil
+	}
+	var options []model.GenericDhcpOption
+	if len(serverConfig.DNSServers) > 0 {
+		options = append(options, model.GenericDhcpOption{Code: Int64(dhcpOptionDNSServers), Values: serverConfig.DNSServers})
+	}
+	if len(serverConfig.NTPServers) > 0 {
+		options = append(options, model.GenericDhcpOption{Code: Int64(dhcpOptionNTPServers), Values: serverConfig.NTPServers})
+	}
+	if serverConfig.LeaseTime > 0 {
+		options = append(options, model.GenericDhcpOption{Code: Int64(dhcpOptionLeaseTime), Values: []string{strconv.FormatInt(serverConfig.LeaseTime, 10)}})
+	}
+	if len(serverConfig.SearchDomains) > 0 {
+		options = append(options, model.GenericDhcpOption{Code: Int64(dhcpOptionDomainSearch), Values: serverConfig.SearchDomains})
+	}
+	additionalConfig := &model.DhcpServerAdditionalConfig{ReservedIpRanges: serverConfig.ExcludedRanges}
+	if len(options) > 0 {
+		additionalConfig.Options = &model.DhcpV4Options{Others: options}
+	}
+	return additionalConfig
+}
+
+// buildDHCPStaticBindingID uses the MAC address without colons as the ID of the static binding,
+// so that a MAC address is bound to at most one IP address.
+func buildDHCPStaticBindingID(macAddress string) string {
+	return strings.ReplaceAll(strings.ToLower(macAddress), ":", "")
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return String(s)
+}
+
+func wrapDHCPStaticBinding(binding *model.DhcpV4StaticBindingConfig) (*data.StructValue, error) {
+	bindingValue, errs := NewConverter().ConvertToVapi(binding, model.DhcpV4StaticBindingConfigBindingType())
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	childBinding := model.ChildDhcpStaticBindingConfig{
+		Id:                      binding.Id,
+		MarkedForDelete:         binding.MarkedForDelete,
+		ResourceType:            "ChildDhcpStaticBindingConfig",
+		DhcpStaticBindingConfig: bindingValue.(*data.StructValue),
+	}
+	dataValue, errs := NewConverter().ConvertToVapi(childBinding, model.ChildDhcpStaticBindingConfigBindingType())
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return dataValue.(*data.StructValue), nil
+}
+
+// updateDHCPRelayConfig returns the DhcpConfig of the NSX Subnet with the desired DHCP relay servers,
+// the other fields rendered by NSX are inherited from the existing DhcpConfig.
+func updateDHCPRelayConfig(existing, desired *model.VpcSubnetDhcpConfig) *model.VpcSubnetDhcpConfig {
+	if existing == nil {
+		return desired
+	}
+	dhcpConfig := *existing
+	dhcpConfig.DhcpRelayConfig = nil
+	if desired != nil {
+		dhcpConfig.DhcpRelayConfig = desired.DhcpRelayConfig
+	}
+	return &dhcpConfig
+}
+
+// deleteStaleDHCPStaticBindings appends the children to delete the static bindings which are realized
+// on the existing NSX Subnet but removed from the SubnetDHCPConfig.
+func (service *SubnetService) deleteStaleDHCPStaticBindings(existingSubnet, nsxSubnet *model.VpcSubnet) error {
+	existingHash := nsxutil.FindTag(existingSubnet.Tags, common.TagScopeDHCPStaticBindingHash)
+	if existingHash == "" || existingHash == nsxutil.FindTag(nsxSubnet.Tags, common.TagScopeDHCPStaticBindingHash) || existingSubnet.Path == nil {
+		return nil
+	}
+	vpcInfo, err := common.ParseVPCResourcePath(*existingSubnet.Path)
+	if err != nil {
+		return err
+	}
+	desiredIDs := make(map[string]struct{}, len(nsxSubnet.Children))
+	for _, child := range nsxSubnet.Children {
+		obj, errs := NewConverter().ConvertToGolang(child, model.ChildDhcpStaticBindingConfigBindingType())
+		if len(errs) > 0 {
+			return errs[0]
+		}
+		if childBinding := obj.(model.ChildDhcpStaticBindingConfig); childBinding.Id != nil {
+			desiredIDs[*childBinding.Id] = struct{}{}
+		}
+	}
+	var cursor *string
+	for {
+		result, err := service.NSXClient.DhcpStaticBindingConfigsClient.List(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *existingSubnet.Id, cursor, nil, nil, nil, nil, nil)
+		if err != nil {
+			err = nsxutil.TransNSXApiError(err)
+			return fmt.Errorf("failed to list DHCP static bindings of Subnet %s: %w", *existingSubnet.Id, err)
+		}
+		for _, bindingValue := range result.Results {
+			obj, errs := NewConverter().ConvertToGolang(bindingValue, model.DhcpV4StaticBindingConfigBindingType())
+			if len(errs) > 0 {
+				return errs[0]
+			}
+			binding := obj.(model.DhcpV4StaticBindingConfig)
+			if binding.Id == nil {
+				continue
+			}
+			if _, ok := desiredIDs[*binding.Id]; ok {
+				continue
+			}
+			binding.MarkedForDelete = &MarkedForDelete
+			child, err := wrapDHCPStaticBinding(&binding)
+			if err != nil {
+				return err
+			}
+			nsxSubnet.Children = append(nsxSubnet.Children, child)
+		}
+		if result.Cursor == nil || *result.Cursor == "" {
+			break
+		}
+		cursor = result.Cursor
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package subnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/orgs/projects/vpcs/subnets"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+type fakeDhcpStaticBindingConfigsClient struct {
+	subnets.DhcpStaticBindingConfigsClient
+	bindings []*data.StructValue
+}
+
+func (f fakeDhcpStaticBindingConfigsClient) List(orgIdParam string, projectIdParam string, vpcIdParam string, subnetIdParam string, cursorParam *string, includeMarkForDeleteObjectsParam *bool, includedFieldsParam *string, pageSizeParam *int64, sortAscendingParam *bool, sortByParam *string) (model.DhcpStaticBindingConfigListResult, error) {
+	return model.DhcpStaticBindingConfigListResult{Results: f.bindings}, nil
+}
+
+func convertChildDHCPStaticBinding(t *testing.T, child *data.StructValue) (model.ChildDhcpStaticBindingConfig, model.DhcpV4StaticBindingConfig) {
+	obj, errs := NewConverter().ConvertToGolang(child, model.ChildDhcpStaticBindingConfigBindingType())
+	assert.Empty(t, errs)
+	childBinding := obj.(model.ChildDhcpStaticBindingConfig)
+	obj, errs = NewConverter().ConvertToGolang(childBinding.DhcpStaticBindingConfig, model.DhcpV4StaticBindingConfigBindingType())
+	assert.Empty(t, errs)
+	return childBinding, obj.(model.DhcpV4StaticBindingConfig)
+}
+
+func TestBuildSubnetDHCP(t *testing.T) {
+	service := &SubnetService{}
+
+	// DHCP server options and static bindings.
+	nsxSubnet := &model.VpcSubnet{}
+	tags, err := service.buildSubnetDHCP(nsxSubnet, v1alpha1.SubnetDHCPConfig{
+		Mode: v1alpha1.DHCPConfigMode(v1alpha1.DHCPConfigModeServer),
+		DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{
+			DNSServers:     []string{"10.0.0.2"},
+			SearchDomains:  []string{"example.com"},
+			NTPServers:     []string{"10.0.0.3"},
+			LeaseTime:      3600,
+			StaticBindings: []v1alpha1.DHCPStaticBinding{{MACAddress: "00:50:56:AA:BB:01", IPAddress: "10.0.0.10", HostName: "vm-1"}},
+			ExcludedRanges: []string{"10.0.0.20-10.0.0.30"},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "DHCP_SERVER", *nsxSubnet.SubnetDhcpConfig.Mode)
+	assert.Equal(t, []string{"10.0.0.20-10.0.0.30"}, nsxSubnet.SubnetDhcpConfig.DhcpServerAdditionalConfig.ReservedIpRanges)
+	assert.Equal(t, []model.GenericDhcpOption{
+		{Code: Int64(dhcpOptionDNSServers), Values: []string{"10.0.0.2"}},
+		{Code: Int64(dhcpOptionNTPServers), Values: []string{"10.0.0.3"}},
+		{Code: Int64(dhcpOptionLeaseTime), Values: []string{"3600"}},
+		{Code: Int64(dhcpOptionDomainSearch), Values: []string{"example.com"}},
+	}, nsxSubnet.SubnetDhcpConfig.DhcpServerAdditionalConfig.Options.Others)
+	assert.Nil(t, nsxSubnet.DhcpConfig)
+	assert.Equal(t, 1, len(nsxSubnet.Children))
+	childBinding, binding := convertChildDHCPStaticBinding(t, nsxSubnet.Children[0])
+	assert.Equal(t, "005056aabb01", *childBinding.Id)
+	assert.Equal(t, "00:50:56:aa:bb:01", *binding.MacAddress)
+	assert.Equal(t, "10.0.0.10", *binding.IpAddress)
+	assert.Equal(t, "vm-1", *binding.HostName)
+	assert.Equal(t, 1, len(tags))
+	assert.Equal(t, common.TagScopeDHCPStaticBindingHash, *tags[0].Scope)
+
+	// The hash doesn't depend on the order of the static bindings.
+	bindings := []v1alpha1.DHCPStaticBinding{{MACAddress: "00:50:56:00:00:01", IPAddress: "10.0.0.10"}, {MACAddress: "00:50:56:00:00:02", IPAddress: "10.0.0.11"}}
+	tags1, _ := service.buildSubnetDHCP(&model.VpcSubnet{}, v1alpha1.SubnetDHCPConfig{
+		Mode:                       v1alpha1.DHCPConfigMode(v1alpha1.DHCPConfigModeServer),
+		DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{StaticBindings: bindings},
+	})
+	tags2, _ := service.buildSubnetDHCP(&model.VpcSubnet{}, v1alpha1.SubnetDHCPConfig{
+		Mode:                       v1alpha1.DHCPConfigMode(v1alpha1.DHCPConfigModeServer),
+		DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{StaticBindings: []v1alpha1.DHCPStaticBinding{bindings[1], bindings[0]}},
+	})
+	assert.Equal(t, tags1, tags2)
+
+	// DHCP relay servers.
+	nsxSubnet = &model.VpcSubnet{}
+	tags, err = service.buildSubnetDHCP(nsxSubnet, v1alpha1.SubnetDHCPConfig{
+		Mode:            v1alpha1.DHCPConfigMode(v1alpha1.DHCPConfigModeRelay),
+		DHCPRelayConfig: &v1alpha1.DHCPRelayConfig{ServerAddresses: []string{"10.0.0.2"}},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, tags)
+	assert.Equal(t, "DHCP_RELAY", *nsxSubnet.SubnetDhcpConfig.Mode)
+	assert.Nil(t, nsxSubnet.SubnetDhcpConfig.DhcpServerAdditionalConfig)
+	assert.Equal(t, []string{"10.0.0.2"}, nsxSubnet.DhcpConfig.DhcpRelayConfig.ServerAddresses)
+
+	// DHCP is deactivated by default.
+	nsxSubnet = &model.VpcSubnet{}
+	_, err = service.buildSubnetDHCP(nsxSubnet, v1alpha1.SubnetDHCPConfig{})
+	assert.Nil(t, err)
+	assert.Equal(t, "DHCP_DEACTIVATED", *nsxSubnet.SubnetDhcpConfig.Mode)
+	assert.Nil(t, nsxSubnet.DhcpConfig)
+	assert.Nil(t, nsxSubnet.Children)
+}
+
+func TestUpdateDHCPRelayConfig(t *testing.T) {
+	existing := &model.VpcSubnetDhcpConfig{
+		EnableDhcp:      Bool(true),
+		DhcpRelayConfig: &model.VpcDhcpRelayConfig{ServerAddresses: []string{"10.0.0.2"}},
+	}
+	desired := &model.VpcSubnetDhcpConfig{DhcpRelayConfig: &model.VpcDhcpRelayConfig{ServerAddresses: []string{"10.0.0.3"}}}
+	assert.Equal(t, desired, updateDHCPRelayConfig(nil, desired))
+	assert.Equal(t, &model.VpcSubnetDhcpConfig{EnableDhcp: Bool(true), DhcpRelayConfig: desired.DhcpRelayConfig}, updateDHCPRelayConfig(existing, desired))
+	assert.Equal(t, &model.VpcSubnetDhcpConfig{EnableDhcp: Bool(true)}, updateDHCPRelayConfig(existing, nil))
+	// The existing DhcpConfig is not changed.
+	assert.Equal(t, []string{"10.0.0.2"}, existing.DhcpRelayConfig.ServerAddresses)
+}
+
+func TestDeleteStaleDHCPStaticBindings(t *testing.T) {
+	service := &SubnetService{}
+	dhcpConfig := v1alpha1.SubnetDHCPConfig{
+		Mode: v1alpha1.DHCPConfigMode(v1alpha1.DHCPConfigModeServer),
+		DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{
+			StaticBindings: []v1alpha1.DHCPStaticBinding{{MACAddress: "00:50:56:00:00:01", IPAddress: "10.0.0.10"}},
+		},
+	}
+	nsxSubnet := &model.VpcSubnet{}
+	tags, err := service.buildSubnetDHCP(nsxSubnet, dhcpConfig)
+	assert.Nil(t, err)
+	nsxSubnet.Tags = tags
+
+	var existingBindings []*data.StructValue
+	for _, id := range []string{"005056000001", "005056000002"} {
+		bindingValue, errs := NewConverter().ConvertToVapi(&model.DhcpV4StaticBindingConfig{Id: String(id), ResourceType: "DhcpV4StaticBindingConfig"}, model.DhcpV4StaticBindingConfigBindingType())
+		assert.Empty(t, errs)
+		existingBindings = append(existingBindings, bindingValue.(*data.StructValue))
+	}
+	service.NSXClient = &nsx.Client{DhcpStaticBindingConfigsClient: fakeDhcpStaticBindingConfigsClient{bindings: existingBindings}}
+	existingSubnet := &model.VpcSubnet{
+		Id:   String("subnet-1"),
+		Path: String("/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1"),
+		Tags: []model.Tag{{Scope: String(common.TagScopeDHCPStaticBindingHash), Tag: String("stale-hash")}},
+	}
+
+	// The static binding removed from the SubnetDHCPConfig is deleted.
+	assert.Nil(t, service.deleteStaleDHCPStaticBindings(existingSubnet, nsxSubnet))
+	assert.Equal(t, 2, len(nsxSubnet.Children))
+	childBinding, _ := convertChildDHCPStaticBinding(t, nsxSubnet.Children[1])
+	assert.Equal(t, "005056000002", *childBinding.Id)
+	assert.True(t, *childBinding.MarkedForDelete)
+
+	// The static bindings are not listed if the hash is not changed.
+	existingSubnet.Tags = tags
+	nsxSubnet.Children = nsxSubnet.Children[:1]
+	assert.Nil(t, service.deleteStaleDHCPStaticBindings(existingSubnet, nsxSubnet))
+	assert.Equal(t, 1, len(nsxSubnet.Children))
+	assert.Equal(t, nsxutil.FindTag(tags, common.TagScopeDHCPStaticBindingHash), nsxutil.FindTag(existingSubnet.Tags, common.TagScopeDHCPStaticBindingHash))
+}
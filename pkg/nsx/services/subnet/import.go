@@ -0,0 +1,107 @@
+package subnet
+
+import (
+	"fmt"
+	"strings"
+
+	apierrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+// nsxOperatorTagPrefix is the prefix of the tag scopes set by nsx-operator, the other tags on an
+// imported NSX Subnet are kept as they are owned by the administrator.
+const nsxOperatorTagPrefix = "nsx-op/"
+
+// IsImportedSubnet returns true if the NSX Subnet is imported from a pre-created VPC instead of
+// being created by nsx-operator.
+func IsImportedSubnet(nsxSubnet *model.VpcSubnet) bool {
+	return nsxutil.FindTag(nsxSubnet.Tags, common.TagScopeSubnetImported) == "true"
+}
+
+func removeNSXOperatorTags(tags []model.Tag) []model.Tag {
+	var res []model.Tag
+	for _, tag := range tags {
+		if tag.Scope != nil && strings.HasPrefix(*tag.Scope, nsxOperatorTagPrefix) {
+			continue
+		}
+		res = append(res, tag)
+	}
+	return res
+}
+
+// ImportSubnet adopts the existing NSX Subnet specified by the nsxSubnetPath of the Subnet CR. The NSX
+// Subnet is not re-created, only the tags of nsx-operator are added to it, so that it is tracked in the
+// SubnetStore and released instead of deleted when the Subnet CR is deleted.
+func (service *SubnetService) ImportSubnet(subnet *v1alpha1.Subnet, tags []model.Tag) (*model.VpcSubnet, error) {
+	vpcInfo, err := common.ParseVPCResourcePath(subnet.Spec.NSXSubnetPath)
+	if err != nil {
+		return nil, err
+	}
+	nsxSubnet, err := service.NSXClient.SubnetsClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, vpcInfo.ID)
+	if err != nil {
+		err = nsxutil.TransNSXApiError(err)
+		return nil, fmt.Errorf("failed to get NSX Subnet %s: %w", subnet.Spec.NSXSubnetPath, err)
+	}
+	if uid := nsxutil.FindTag(nsxSubnet.Tags, common.TagScopeSubnetCRUID); uid != "" && uid != string(subnet.UID) {
+		return nil, fmt.Errorf("NSX Subnet %s is already used by Subnet %s", subnet.Spec.NSXSubnetPath, nsxutil.FindTag(nsxSubnet.Tags, common.TagScopeSubnetCRName))
+	}
+	if nsxutil.FindTag(nsxSubnet.Tags, common.TagScopeSubnetSetCRUID) != "" {
+		return nil, fmt.Errorf("NSX Subnet %s is already used by SubnetSet %s", subnet.Spec.NSXSubnetPath, nsxutil.FindTag(nsxSubnet.Tags, common.TagScopeSubnetSetCRName))
+	}
+
+	// The Namespace labels are not added to the imported NSX Subnet as they can't be told apart from
+	// the tags of the administrator when releasing the NSX Subnet.
+	desiredTags := append(removeNSXOperatorTags(nsxSubnet.Tags), service.buildBasicTags(subnet)...)
+	for _, tag := range tags {
+		if tag.Scope != nil && strings.HasPrefix(*tag.Scope, nsxOperatorTagPrefix) {
+			desiredTags = append(desiredTags, tag)
+		}
+	}
+	desiredTags = append(desiredTags, model.Tag{Scope: String(common.TagScopeSubnetImported), Tag: String("true")})
+	if !util.TagsEqual(nsxSubnet.Tags, desiredTags) {
+		nsxSubnet.Tags = desiredTags
+		nsxSubnet, err = service.NSXClient.SubnetsClient.Update(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, vpcInfo.ID, nsxSubnet)
+		if err != nil {
+			err = nsxutil.TransNSXApiError(err)
+			return nil, fmt.Errorf("failed to import NSX Subnet %s: %w", subnet.Spec.NSXSubnetPath, err)
+		}
+		log.Info("Imported NSX Subnet", "Subnet", subnet.Namespace+"/"+subnet.Name, "nsxSubnetPath", subnet.Spec.NSXSubnetPath)
+	}
+	if err = service.SubnetStore.Apply(&nsxSubnet); err != nil {
+		return nil, err
+	}
+	return &nsxSubnet, nil
+}
+
+// ReleaseImportedSubnet removes the tags of nsx-operator from the imported NSX Subnet and removes it
+// from the SubnetStore, the NSX Subnet itself is kept in the pre-created VPC.
+func (service *SubnetService) ReleaseImportedSubnet(nsxSubnet model.VpcSubnet) error {
+	vpcInfo, err := common.ParseVPCResourcePath(*nsxSubnet.Path)
+	if err != nil {
+		return err
+	}
+	existingSubnet, err := service.NSXClient.SubnetsClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSubnet.Id)
+	if _, notFound := err.(apierrors.NotFound); err != nil && !notFound {
+		err = nsxutil.TransNSXApiError(err)
+		return fmt.Errorf("failed to get NSX Subnet %s: %w", *nsxSubnet.Path, err)
+	}
+	// The tags are not removed if the NSX Subnet has been deleted by the administrator.
+	if err == nil {
+		existingSubnet.Tags = removeNSXOperatorTags(existingSubnet.Tags)
+		if _, err = service.NSXClient.SubnetsClient.Update(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSubnet.Id, existingSubnet); err != nil {
+			err = nsxutil.TransNSXApiError(err)
+			return fmt.Errorf("failed to release NSX Subnet %s: %w", *nsxSubnet.Path, err)
+		}
+	}
+	nsxSubnet.MarkedForDelete = &MarkedForDelete
+	if err = service.SubnetStore.Apply(&nsxSubnet); err != nil {
+		return err
+	}
+	log.Info("Released imported NSX Subnet", "nsxSubnetPath", *nsxSubnet.Path)
+	return nil
+}
@@ -0,0 +1,113 @@
+package subnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/orgs/projects/vpcs"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+type fakeImportSubnetsClient struct {
+	vpcs.SubnetsClient
+	subnets map[string]model.VpcSubnet
+	updated int
+}
+
+func (f *fakeImportSubnetsClient) Get(orgIdParam string, projectIdParam string, vpcIdParam string, subnetIdParam string) (model.VpcSubnet, error) {
+	nsxSubnet, ok := f.subnets[subnetIdParam]
+	if !ok {
+		return model.VpcSubnet{}, apierrors.NotFound{}
+	}
+	return nsxSubnet, nil
+}
+
+func (f *fakeImportSubnetsClient) Update(orgIdParam string, projectIdParam string, vpcIdParam string, subnetIdParam string, vpcSubnetParam model.VpcSubnet) (model.VpcSubnet, error) {
+	f.updated++
+	f.subnets[subnetIdParam] = vpcSubnetParam
+	return vpcSubnetParam, nil
+}
+
+func TestImportSubnet(t *testing.T) {
+	subnetPath := "/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1"
+	fakeClient := &fakeImportSubnetsClient{subnets: map[string]model.VpcSubnet{
+		"subnet-1": {
+			Id:   String("subnet-1"),
+			Path: String(subnetPath),
+			Tags: []model.Tag{{Scope: String("owner"), Tag: String("admin")}},
+		},
+	}}
+	service := &SubnetService{
+		Service: common.Service{
+			NSXClient: &nsx.Client{SubnetsClient: fakeClient},
+			NSXConfig: &config.NSXOperatorConfig{CoeConfig: &config.CoeConfig{Cluster: "k8scl-one"}},
+		},
+		SubnetStore: &SubnetStore{
+			ResourceStore: common.ResourceStore{
+				Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
+					common.TagScopeSubnetCRUID:    subnetIndexFunc,
+					common.TagScopeSubnetSetCRUID: subnetSetIndexFunc,
+					common.TagScopeVMNamespace:    subnetIndexVMNamespaceFunc,
+					common.TagScopeNamespace:      subnetIndexNamespaceFunc,
+				}),
+				BindingType: model.VpcSubnetBindingType(),
+			},
+		},
+	}
+	subnet := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet-a", Namespace: "ns-1", UID: "uid-1"},
+		Spec:       v1alpha1.SubnetSpec{NSXSubnetPath: subnetPath},
+	}
+	nsTags := []model.Tag{
+		{Scope: String(common.TagScopeVMNamespaceUID), Tag: String("ns-uid")},
+		{Scope: String(common.TagScopeVMNamespace), Tag: String("ns-1")},
+		{Scope: String("env"), Tag: String("dev")},
+	}
+
+	// The NSX Subnet is tagged without the Namespace labels and added to the store.
+	nsxSubnet, err := service.ImportSubnet(subnet, nsTags)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, fakeClient.updated)
+	assert.True(t, IsImportedSubnet(nsxSubnet))
+	assert.Equal(t, "admin", nsxutil.FindTag(nsxSubnet.Tags, "owner"))
+	assert.Equal(t, "", nsxutil.FindTag(nsxSubnet.Tags, "env"))
+	assert.Equal(t, "ns-uid", nsxutil.FindTag(nsxSubnet.Tags, common.TagScopeVMNamespaceUID))
+	assert.Equal(t, 1, len(service.ListSubnetCreatedBySubnet("uid-1")))
+
+	// The NSX Subnet is not updated again if the tags are not changed.
+	_, err = service.ImportSubnet(subnet, nsTags)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, fakeClient.updated)
+
+	// The NSX Subnet can't be imported by another Subnet.
+	otherSubnet := subnet.DeepCopy()
+	otherSubnet.Name = "subnet-b"
+	otherSubnet.UID = "uid-2"
+	_, err = service.ImportSubnet(otherSubnet, nsTags)
+	assert.ErrorContains(t, err, "is already used by Subnet subnet-a")
+
+	// The NSX Subnet is released instead of deleted.
+	assert.Nil(t, service.DeleteSubnet(*nsxSubnet))
+	assert.Equal(t, 2, fakeClient.updated)
+	assert.Equal(t, []model.Tag{{Scope: String("owner"), Tag: String("admin")}}, fakeClient.subnets["subnet-1"].Tags)
+	assert.Equal(t, 0, len(service.ListSubnetCreatedBySubnet("uid-1")))
+
+	// The store is cleaned up even if the NSX Subnet has been deleted.
+	nsxSubnet, err = service.ImportSubnet(subnet, nsTags)
+	assert.Nil(t, err)
+	delete(fakeClient.subnets, "subnet-1")
+	assert.Nil(t, service.DeleteSubnet(*nsxSubnet))
+	assert.Equal(t, 0, len(service.ListSubnetCreatedBySubnet("uid-1")))
+
+	_, err = service.ImportSubnet(subnet, nsTags)
+	assert.ErrorContains(t, err, "failed to get NSX Subnet")
+}
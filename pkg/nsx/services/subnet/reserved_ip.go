@@ -0,0 +1,94 @@
+package subnet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+// reservedIPAllocationPrefix is the prefix of the IDs of the IP allocations which reserve the IPs
+// in the static IPv4 pool of the NSX Subnet.
+const reservedIPAllocationPrefix = "nsx-op-reserved-"
+
+func buildReservedIPAllocationID(ip string) string {
+	return reservedIPAllocationPrefix + strings.ReplaceAll(ip, ".", "_")
+}
+
+func buildReservedIPRangesTags(reservedIPRanges []string) []model.Tag {
+	if len(reservedIPRanges) == 0 {
+		return nil
+	}
+	return []model.Tag{{Scope: String(common.TagScopeReservedIPRangesHash), Tag: String(util.Sha1(strings.Join(reservedIPRanges, ",")))}}
+}
+
+// updateReservedIPRanges reserves the IPs in the reserved IP ranges of the Subnet by creating an IP
+// allocation for each IP in the static IPv4 pool of the NSX Subnet, so that the IPs are skipped by the
+// static IP allocation of the SubnetPorts. The IP allocations of the IPs removed from the reserved IP
+// ranges are deleted.
+func (service *SubnetService) updateReservedIPRanges(nsxSubnet *model.VpcSubnet, reservedIPRanges []string) error {
+	// Skip listing the IP allocations if no IP is reserved in the Subnet.
+	if len(reservedIPRanges) == 0 && nsxutil.FindTag(nsxSubnet.Tags, common.TagScopeReservedIPRangesHash) == "" {
+		return nil
+	}
+	if nsxSubnet.Path == nil {
+		return fmt.Errorf("NSX Subnet %s is not realized", *nsxSubnet.Id)
+	}
+	vpcInfo, err := common.ParseVPCResourcePath(*nsxSubnet.Path)
+	if err != nil {
+		return err
+	}
+	reservedIPs, err := util.ExpandIPv4Ranges(reservedIPRanges)
+	if err != nil {
+		return err
+	}
+	existingIDs := sets.New[string]()
+	var cursor *string
+	for {
+		result, err := service.NSXClient.IPAllocationClient.List(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSubnet.Id, common.StaticIPv4PoolID, cursor, nil, nil, nil, nil, nil)
+		if err != nil {
+			return nsxutil.TransNSXApiError(err)
+		}
+		for _, allocation := range result.Results {
+			if allocation.Id != nil && strings.HasPrefix(*allocation.Id, reservedIPAllocationPrefix) {
+				existingIDs.Insert(*allocation.Id)
+			}
+		}
+		if result.Cursor == nil || *result.Cursor == "" {
+			break
+		}
+		cursor = result.Cursor
+	}
+
+	desiredIDs := sets.New[string]()
+	for _, ip := range reservedIPs {
+		id := buildReservedIPAllocationID(ip)
+		desiredIDs.Insert(id)
+		if existingIDs.Has(id) {
+			continue
+		}
+		allocation := model.IpAddressAllocation{
+			Id:           String(id),
+			DisplayName:  String(id),
+			AllocationIp: String(ip),
+			Tags:         []model.Tag{{Scope: String(common.TagScopeCluster), Tag: String(getCluster(service))}},
+		}
+		if err := service.NSXClient.IPAllocationClient.Patch(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSubnet.Id, common.StaticIPv4PoolID, id, allocation); err != nil {
+			err = nsxutil.TransNSXApiError(err)
+			return fmt.Errorf("failed to reserve IP %s in Subnet %s: %w", ip, *nsxSubnet.Id, err)
+		}
+	}
+	for id := range existingIDs.Difference(desiredIDs) {
+		if err := service.NSXClient.IPAllocationClient.Delete(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxSubnet.Id, common.StaticIPv4PoolID, id); err != nil {
+			err = nsxutil.TransNSXApiError(err)
+			return fmt.Errorf("failed to release reserved IP allocation %s in Subnet %s: %w", id, *nsxSubnet.Id, err)
+		}
+	}
+	log.Info("Updated reserved IPs of Subnet", "Subnet", *nsxSubnet.Id, "reservedIPs", len(reservedIPs))
+	return nil
+}
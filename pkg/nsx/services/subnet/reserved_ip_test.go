@@ -0,0 +1,67 @@
+package subnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/orgs/projects/vpcs/subnets/ip_pools"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+type fakeIPAllocationsClient struct {
+	ip_pools.IpAllocationsClient
+	allocations []model.IpAddressAllocation
+	patched     []string
+	deleted     []string
+}
+
+func (f *fakeIPAllocationsClient) List(orgIdParam string, projectIdParam string, vpcIdParam string, subnetIdParam string, ipPoolIdParam string, cursorParam *string, includeMarkForDeleteObjectsParam *bool, includedFieldsParam *string, pageSizeParam *int64, sortAscendingParam *bool, sortByParam *string) (model.IpAddressAllocationListResult, error) {
+	return model.IpAddressAllocationListResult{Results: f.allocations}, nil
+}
+
+func (f *fakeIPAllocationsClient) Patch(orgIdParam string, projectIdParam string, vpcIdParam string, subnetIdParam string, ipPoolIdParam string, ipAllocationIdParam string, ipAddressAllocationParam model.IpAddressAllocation) error {
+	f.patched = append(f.patched, ipAllocationIdParam)
+	return nil
+}
+
+func (f *fakeIPAllocationsClient) Delete(orgIdParam string, projectIdParam string, vpcIdParam string, subnetIdParam string, ipPoolIdParam string, ipAllocationIdParam string) error {
+	f.deleted = append(f.deleted, ipAllocationIdParam)
+	return nil
+}
+
+func TestUpdateReservedIPRanges(t *testing.T) {
+	fakeClient := &fakeIPAllocationsClient{
+		allocations: []model.IpAddressAllocation{
+			{Id: String(buildReservedIPAllocationID("10.0.0.10"))},
+			{Id: String(buildReservedIPAllocationID("10.0.0.20"))},
+			// The IP allocations of the SubnetPorts are not touched.
+			{Id: String("port-1")},
+		},
+	}
+	service := &SubnetService{
+		Service: common.Service{
+			NSXClient: &nsx.Client{IPAllocationClient: fakeClient},
+			NSXConfig: &config.NSXOperatorConfig{CoeConfig: &config.CoeConfig{Cluster: "k8scl-one"}},
+		},
+	}
+	nsxSubnet := &model.VpcSubnet{
+		Id:   String("subnet-1"),
+		Path: String("/orgs/default/projects/project-1/vpcs/vpc-1/subnets/subnet-1"),
+	}
+
+	// No IP allocation is listed if no IP is reserved.
+	assert.Nil(t, service.updateReservedIPRanges(nsxSubnet, nil))
+	assert.Nil(t, fakeClient.patched)
+	assert.Nil(t, fakeClient.deleted)
+
+	nsxSubnet.Tags = buildReservedIPRangesTags([]string{"10.0.0.10-10.0.0.11"})
+	assert.Nil(t, service.updateReservedIPRanges(nsxSubnet, []string{"10.0.0.10-10.0.0.11"}))
+	assert.Equal(t, []string{"nsx-op-reserved-10_0_0_11"}, fakeClient.patched)
+	assert.Equal(t, []string{"nsx-op-reserved-10_0_0_20"}, fakeClient.deleted)
+
+	assert.ErrorContains(t, service.updateReservedIPRanges(&model.VpcSubnet{Id: String("subnet-2")}, []string{"10.0.0.10"}), "not realized")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
@@ -36,6 +37,13 @@ var (
 type SubnetService struct {
 	common.Service
 	SubnetStore *SubnetStore
+	// SubnetPortService is used to report the IP utilization of the Subnets of the SubnetSets,
+	// the IP utilization is not reported if it is not set.
+	SubnetPortService common.SubnetPortServiceProvider
+	// ipv6PrefixLock serializes the IPv6 prefix allocation, reservedIPv6Prefixes holds the prefixes allocated
+	// to the NSX Subnets being created which are not in the SubnetStore yet, indexed by the VPC.
+	ipv6PrefixLock       sync.Mutex
+	reservedIPv6Prefixes map[string]sets.Set[string]
 }
 
 // SubnetParameters stores parameters to CRUD Subnet object
@@ -95,6 +103,11 @@ func (service *SubnetService) CreateOrUpdateSubnet(obj client.Object, vpcInfo co
 		if existingSubnet == nil {
 			changed = true
 		} else {
+			// Reserve the IPs before updating the tag of the reserved IP ranges on the NSX Subnet.
+			if err := service.updateReservedIPRanges(existingSubnet, subnet.Spec.ReservedIPRanges); err != nil {
+				log.Error(err, "Failed to update reserved IP ranges", "SubnetId", uid)
+				return nil, err
+			}
 			changed = common.CompareResource(SubnetToComparable(existingSubnet), SubnetToComparable(nsxSubnet))
 			if changed {
 				// Only tags and dhcp are expected to be updated
@@ -104,6 +117,12 @@ func (service *SubnetService) CreateOrUpdateSubnet(obj client.Object, vpcInfo co
 				updatedSubnet := *existingSubnet
 				updatedSubnet.Tags = nsxSubnet.Tags
 				updatedSubnet.SubnetDhcpConfig = nsxSubnet.SubnetDhcpConfig
+				updatedSubnet.DhcpConfig = updateDHCPRelayConfig(existingSubnet.DhcpConfig, nsxSubnet.DhcpConfig)
+				updatedSubnet.Children = nsxSubnet.Children
+				if err := service.deleteStaleDHCPStaticBindings(existingSubnet, &updatedSubnet); err != nil {
+					log.Error(err, "Failed to delete stale DHCP static bindings", "SubnetId", uid)
+					return nil, err
+				}
 				nsxSubnet = &updatedSubnet
 			}
 		}
@@ -112,7 +131,23 @@ func (service *SubnetService) CreateOrUpdateSubnet(obj client.Object, vpcInfo co
 			return existingSubnet, nil
 		}
 	}
-	return service.createOrUpdateSubnet(obj, nsxSubnet, &vpcInfo)
+	if err := service.buildIPv6Prefix(obj, nsxSubnet, &vpcInfo); err != nil {
+		log.Error(err, "Failed to allocate IPv6 prefix for Subnet")
+		return nil, err
+	}
+	// The allocated IPv6 prefix is either in the SubnetStore or free again after the NSX Subnet is created.
+	defer service.releaseIPv6Prefixes(&vpcInfo, nsxSubnet.IpAddresses)
+	createdSubnet, err := service.createOrUpdateSubnet(obj, nsxSubnet, &vpcInfo)
+	if err != nil {
+		return nil, err
+	}
+	if subnet, ok := obj.(*v1alpha1.Subnet); ok {
+		if err := service.updateReservedIPRanges(createdSubnet, subnet.Spec.ReservedIPRanges); err != nil {
+			log.Error(err, "Failed to update reserved IP ranges", "SubnetId", uid)
+			return nil, err
+		}
+	}
+	return createdSubnet, nil
 }
 
 func (service *SubnetService) createOrUpdateSubnet(obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo) (*model.VpcSubnet, error) {
@@ -159,6 +194,10 @@ func (service *SubnetService) createOrUpdateSubnet(obj client.Object, nsxSubnet
 }
 
 func (service *SubnetService) DeleteSubnet(nsxSubnet model.VpcSubnet) error {
+	// The imported NSX Subnet is owned by the pre-created VPC, only release it.
+	if IsImportedSubnet(&nsxSubnet) {
+		return service.ReleaseImportedSubnet(nsxSubnet)
+	}
 	vpcInfo, _ := common.ParseVPCResourcePath(*nsxSubnet.Path)
 	nsxSubnet.MarkedForDelete = &MarkedForDelete
 	// WrapHighLevelSubnet will modify the input subnet, make a copy for the following store update.
@@ -241,6 +280,7 @@ func (service *SubnetService) GetSubnetStatus(subnet *model.VpcSubnet) ([]model.
 
 func (service *SubnetService) UpdateSubnetSetStatus(obj *v1alpha1.SubnetSet) error {
 	var subnetInfoList []v1alpha1.SubnetInfo
+	var ipUtilization *v1alpha1.IPUtilization
 	nsxSubnets := service.SubnetStore.GetByIndex(common.TagScopeSubnetSetCRUID, string(obj.GetUID()))
 	for _, subnet := range nsxSubnets {
 		subnet := subnet
@@ -257,9 +297,15 @@ func (service *SubnetService) UpdateSubnetSetStatus(obj *v1alpha1.SubnetSet) err
 				subnetInfo.DHCPServerAddresses = append(subnetInfo.DHCPServerAddresses, *status.DhcpServerAddress)
 			}
 		}
+		if service.SubnetPortService != nil {
+			subnetIPUtilization := service.SubnetPortService.GetIPUtilization(subnet)
+			subnetInfo.IPUtilization = &subnetIPUtilization
+			ipUtilization = AddIPUtilization(ipUtilization, subnetIPUtilization)
+		}
 		subnetInfoList = append(subnetInfoList, subnetInfo)
 	}
 	obj.Status.Subnets = subnetInfoList
+	obj.Status.IPUtilization = ipUtilization
 	if err := service.Client.Status().Update(context.Background(), obj); err != nil {
 		log.Error(err, "Failed to update SubnetSet status")
 		return err
@@ -267,6 +313,18 @@ func (service *SubnetService) UpdateSubnetSetStatus(obj *v1alpha1.SubnetSet) err
 	return nil
 }
 
+// AddIPUtilization adds the IP utilization of a Subnet to the aggregated IP utilization, a new one
+// is returned if the aggregated IP utilization is nil.
+func AddIPUtilization(aggregated *v1alpha1.IPUtilization, subnetIPUtilization v1alpha1.IPUtilization) *v1alpha1.IPUtilization {
+	if aggregated == nil {
+		aggregated = &v1alpha1.IPUtilization{}
+	}
+	aggregated.Total += subnetIPUtilization.Total
+	aggregated.Allocated += subnetIPUtilization.Allocated
+	aggregated.Available += subnetIPUtilization.Available
+	return aggregated
+}
+
 func (service *SubnetService) GetSubnetByKey(key string) (*model.VpcSubnet, error) {
 	nsxSubnet := service.SubnetStore.GetByKey(key)
 	if nsxSubnet == nil {
@@ -367,16 +425,18 @@ func (service *SubnetService) GenerateSubnetNSTags(obj client.Object) []model.Ta
 		}
 	}
 	// Append Namespace labels as tags
+	labelKeys := service.NSXConfig.GetLabelTagKeys()
 	for k, v := range namespace.Labels {
+		// The allowed label of the Subnet or SubnetSet takes precedence over the Namespace label with the same key.
+		if _, ok := obj.GetLabels()[k]; ok && slices.Contains(labelKeys, k) {
+			continue
+		}
 		tags = append(tags, model.Tag{Scope: common.String(k), Tag: common.String(v)})
 	}
 	return tags
 }
 
-func (service *SubnetService) UpdateSubnetSet(ns string, vpcSubnets []*model.VpcSubnet, tags []model.Tag, dhcpMode string) error {
-	if dhcpMode == "" {
-		dhcpMode = v1alpha1.DHCPConfigModeDeactivated
-	}
+func (service *SubnetService) UpdateSubnetSet(ns string, vpcSubnets []*model.VpcSubnet, tags []model.Tag, dhcpConfig v1alpha1.SubnetDHCPConfig) error {
 	for i, vpcSubnet := range vpcSubnets {
 		subnetSet := &v1alpha1.SubnetSet{}
 		var name string
@@ -409,12 +469,20 @@ func (service *SubnetService) UpdateSubnetSet(ns string, vpcSubnets []*model.Vpc
 		// Avoid updating vpcSubnets[i] to ensure Subnet store
 		// is only updated after the updating succeeds.
 		updatedSubnet := *vpcSubnets[i]
-		updatedSubnet.Tags = newTags
-		// Update the SubnetSet DHCP Config
-		if updatedSubnet.SubnetDhcpConfig != nil {
-			// Generate a new SubnetDhcpConfig for updatedSubnet to
-			// avoid changing vpcSubnets[i].SubnetDhcpConfig
-			updatedSubnet.SubnetDhcpConfig = service.buildSubnetDHCPConfig(dhcpMode, updatedSubnet.SubnetDhcpConfig.DhcpServerAdditionalConfig)
+		// Update the SubnetSet DHCP Config, a new SubnetDhcpConfig is generated
+		// for updatedSubnet to avoid changing vpcSubnets[i].SubnetDhcpConfig
+		desiredSubnet := &model.VpcSubnet{}
+		dhcpTags, err := service.buildSubnetDHCP(desiredSubnet, dhcpConfig)
+		if err != nil {
+			return fmt.Errorf("failed to build DHCP config for Subnet %s: %w", *vpcSubnet.Id, err)
+		}
+		updatedSubnet.Tags = util.AppendLabelTags(append(newTags, dhcpTags...), service.buildLabelTags(subnetSet))
+		// The DHCP server additional config removed from the SubnetSet is cleared on the NSX Subnet as well.
+		updatedSubnet.SubnetDhcpConfig = desiredSubnet.SubnetDhcpConfig
+		updatedSubnet.DhcpConfig = updateDHCPRelayConfig(vpcSubnets[i].DhcpConfig, desiredSubnet.DhcpConfig)
+		updatedSubnet.Children = desiredSubnet.Children
+		if err := service.deleteStaleDHCPStaticBindings(vpcSubnets[i], &updatedSubnet); err != nil {
+			return fmt.Errorf("failed to delete stale DHCP static bindings of Subnet %s: %w", *vpcSubnet.Id, err)
 		}
 		changed := common.CompareResource(SubnetToComparable(vpcSubnets[i]), SubnetToComparable(&updatedSubnet))
 		if !changed {
@@ -406,7 +406,8 @@ func TestSubnetService_UpdateSubnetSet(t *testing.T) {
 			Id:   common.String("subnet-1"),
 			Tags: tags,
 			SubnetDhcpConfig: &model.SubnetDhcpConfig{
-				Mode: common.String("DHCP_SERVER"),
+				Mode:                       common.String("DHCP_SERVER"),
+				DhcpServerAdditionalConfig: &model.DhcpServerAdditionalConfig{ReservedIpRanges: []string{"10.0.0.20-10.0.0.30"}},
 			},
 			AdvancedConfig: &model.SubnetAdvancedConfig{
 				StaticIpAllocation: &model.StaticIpAllocation{
@@ -423,14 +424,20 @@ func TestSubnetService_UpdateSubnetSet(t *testing.T) {
 		return nil
 	})
 
+	var updatedSubnet *model.VpcSubnet
 	patchesCreateOrUpdateSubnet := gomonkey.ApplyFunc((*SubnetService).createOrUpdateSubnet,
 		func(r *SubnetService, obj client.Object, nsxSubnet *model.VpcSubnet, vpcInfo *common.VPCResourceInfo) (*model.VpcSubnet, error) {
+			updatedSubnet = nsxSubnet
 			return &model.VpcSubnet{Path: &fakeSubnetPath}, nil
 		})
 	defer patchesCreateOrUpdateSubnet.Reset()
 
-	err := service.UpdateSubnetSet("ns-1", vpcSubnets, tags, "")
+	// The DHCP server additional config removed from the SubnetSet is cleared on the NSX Subnet.
+	err := service.UpdateSubnetSet("ns-1", vpcSubnets, tags, v1alpha1.SubnetDHCPConfig{Mode: v1alpha1.DHCPConfigMode(v1alpha1.DHCPConfigModeServer)})
 	assert.Nil(t, err)
+	assert.NotNil(t, updatedSubnet)
+	assert.Nil(t, updatedSubnet.SubnetDhcpConfig.DhcpServerAdditionalConfig)
+	assert.NotNil(t, vpcSubnets[0].SubnetDhcpConfig.DhcpServerAdditionalConfig)
 }
 
 func TestSubnetService_createOrUpdateSubnet(t *testing.T) {
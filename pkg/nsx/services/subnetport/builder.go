@@ -32,7 +32,8 @@ func (service *SubnetPortService) buildSubnetPort(obj interface{}, nsxSubnet *mo
 		return nil, fmt.Errorf("unsupported object: %v", obj)
 	}
 	objNamespace = objMeta.Namespace
-	if _, ok := obj.(*corev1.Pod); ok {
+	switch obj.(type) {
+	case *corev1.Pod, *PodInterface:
 		appId = string(objMeta.UID)
 	}
 	var externalAddressBinding *model.ExternalAddressBinding
@@ -47,13 +48,27 @@ func (service *SubnetPortService) buildSubnetPort(obj interface{}, nsxSubnet *mo
 		allocateAddresses = "BOTH"
 	}
 
+	var addressBindings []model.PortAddressBindingEntry
+	switch o := obj.(type) {
+	case *v1alpha1.SubnetPort:
+		allocateAddresses, addressBindings = buildStaticAddressBindings(o.Spec.IPAddress, o.Spec.MACAddress, allocateAddresses)
+	case *PodInterface:
+		allocateAddresses, addressBindings = buildStaticAddressBindings(o.IPAddress, "", allocateAddresses)
+	}
+
 	nsxSubnetPortName := service.BuildSubnetPortName(objMeta)
-	nsxSubnetPortID := service.BuildSubnetPortId(objMeta)
+	nsxSubnetPortID := service.getSubnetPortID(obj)
 	// use the subnetPort CR UID as the attachment uid generation to ensure the latter stable
 	nsxCIFID, err := uuid.NewRandomFromReader(bytes.NewReader([]byte(string(objMeta.UID))))
 	if err != nil {
 		return nil, err
 	}
+	if podInterface, ok := obj.(*PodInterface); ok {
+		nsxSubnetPortName = util.GenerateTruncName(common.MaxNameLength, objMeta.Name, "", podInterface.Name, "", "")
+		// The secondary interfaces share the Pod UID, hash the Pod UID with the interface name to
+		// generate a stable and distinct attachment uid for each of them.
+		nsxCIFID = uuid.NewSHA1(uuid.NameSpaceOID, []byte(string(objMeta.UID)+"/"+podInterface.Name))
+	}
 	nsxSubnetPortPath := fmt.Sprintf("%s/ports/%s", *nsxSubnet.Path, nsxSubnetPortID)
 	namespace := &corev1.Namespace{}
 	namespacedName := types.NamespacedName{
@@ -63,12 +78,21 @@ func (service *SubnetPortService) buildSubnetPort(obj interface{}, nsxSubnet *mo
 		return nil, err
 	}
 	namespace_uid := namespace.UID
-	tags := util.BuildBasicTags(getCluster(service), obj, namespace_uid)
+	var tags []model.Tag
+	if podInterface, ok := obj.(*PodInterface); ok {
+		tags = util.BuildBasicTags(getCluster(service), podInterface.Pod, namespace_uid)
+		tags = append(tags, model.Tag{Scope: String(common.TagScopePodInterface), Tag: String(podInterface.Name)})
+	} else {
+		tags = util.BuildBasicTags(getCluster(service), obj, namespace_uid)
+	}
 	if labelTags != nil {
 		for k, v := range *labelTags {
 			tags = append(tags, model.Tag{Scope: String(k), Tag: String(v)})
 		}
 	}
+	// The labels of the Pod or VM are mirrored as they are for the security policies, the allowed labels of the
+	// SubnetPort CR and the Namespace are mirrored within the NSX tag limit.
+	tags = util.AppendLabelTags(tags, util.BuildLabelTags(service.NSXConfig.GetLabelTagKeys(), objMeta.Labels, namespace.Labels))
 	nsxSubnetPort := &model.VpcSubnetPort{
 		DisplayName: String(nsxSubnetPortName),
 		Id:          String(nsxSubnetPortID),
@@ -82,6 +106,7 @@ func (service *SubnetPortService) buildSubnetPort(obj interface{}, nsxSubnet *mo
 		Path:                   &nsxSubnetPortPath,
 		ParentPath:             nsxSubnet.Path,
 		ExternalAddressBinding: externalAddressBinding,
+		AddressBindings:        addressBindings,
 	}
 	if appId != "" {
 		nsxSubnetPort.Attachment.AppId = &appId
@@ -104,10 +129,27 @@ func getObjectMeta(obj interface{}) *metav1.ObjectMeta {
 		return &o.ObjectMeta
 	case *corev1.Pod:
 		return &o.ObjectMeta
+	case *PodInterface:
+		return &o.Pod.ObjectMeta
 	}
 	return nil
 }
 
+// getSubnetPortID returns the ID of the NSX SubnetPort for the SubnetPort CR, the Pod or the secondary
+// network interface of the Pod. The NSX SubnetPort of the Pod may be taken from a SubnetPort pool.
+func (service *SubnetPortService) getSubnetPortID(obj interface{}) string {
+	switch o := obj.(type) {
+	case *PodInterface:
+		return service.BuildPodInterfacePortID(&o.Pod.ObjectMeta, o.Name)
+	case *corev1.Pod:
+		return service.GetSubnetPortIDForPod(o)
+	}
+	if objMeta := getObjectMeta(obj); objMeta != nil {
+		return service.BuildSubnetPortId(objMeta)
+	}
+	return ""
+}
+
 func getCluster(service *SubnetPortService) string {
 	return service.NSXConfig.Cluster
 }
@@ -36,6 +36,7 @@ func TestBuildSubnetPort(t *testing.T) {
 				},
 			},
 		},
+		SubnetPortStore: createSubnetPortService().SubnetPortStore,
 	}
 	ctx := context.Background()
 	namespace := &corev1.Namespace{}
@@ -391,3 +392,28 @@ func TestGetAddressBindingBySubnetPort(t *testing.T) {
 	}
 
 }
+
+func TestBuildStaticAddressBindings(t *testing.T) {
+	sp := &v1alpha1.SubnetPort{}
+	allocateAddresses, bindings := buildStaticAddressBindings(sp.Spec.IPAddress, sp.Spec.MACAddress, model.PortAttachment_ALLOCATE_ADDRESSES_BOTH)
+	assert.Equal(t, model.PortAttachment_ALLOCATE_ADDRESSES_BOTH, allocateAddresses)
+	assert.Nil(t, bindings)
+
+	sp.Spec.IPAddress = "10.0.0.10"
+	allocateAddresses, bindings = buildStaticAddressBindings(sp.Spec.IPAddress, sp.Spec.MACAddress, model.PortAttachment_ALLOCATE_ADDRESSES_BOTH)
+	assert.Equal(t, model.PortAttachment_ALLOCATE_ADDRESSES_MAC_POOL, allocateAddresses)
+	assert.Equal(t, []model.PortAddressBindingEntry{{IpAddress: String("10.0.0.10")}}, bindings)
+
+	sp.Spec.MACAddress = "00:50:56:00:00:01"
+	allocateAddresses, bindings = buildStaticAddressBindings(sp.Spec.IPAddress, sp.Spec.MACAddress, model.PortAttachment_ALLOCATE_ADDRESSES_BOTH)
+	assert.Equal(t, model.PortAttachment_ALLOCATE_ADDRESSES_NONE, allocateAddresses)
+	assert.Equal(t, []model.PortAddressBindingEntry{{IpAddress: String("10.0.0.10"), MacAddress: String("00:50:56:00:00:01")}}, bindings)
+
+	sp.Spec.IPAddress = ""
+	allocateAddresses, _ = buildStaticAddressBindings(sp.Spec.IPAddress, sp.Spec.MACAddress, model.PortAttachment_ALLOCATE_ADDRESSES_BOTH)
+	assert.Equal(t, model.PortAttachment_ALLOCATE_ADDRESSES_IP_POOL, allocateAddresses)
+
+	// The allocation mode of the DHCP Subnet is not changed.
+	allocateAddresses, _ = buildStaticAddressBindings(sp.Spec.IPAddress, sp.Spec.MACAddress, model.PortAttachment_ALLOCATE_ADDRESSES_DHCP)
+	assert.Equal(t, model.PortAttachment_ALLOCATE_ADDRESSES_DHCP, allocateAddresses)
+}
@@ -0,0 +1,36 @@
+package subnetport
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+// PodInterface is a secondary network interface of a Pod requested in the Pod annotation, which is
+// realized as an extra NSX SubnetPort tagged with the Pod UID and the interface name.
+type PodInterface struct {
+	Pod       *corev1.Pod
+	Name      string
+	IPAddress string
+}
+
+func (service *SubnetPortService) BuildPodInterfacePortID(obj *metav1.ObjectMeta, interfaceName string) string {
+	return util.GenerateIDByObjectWithSuffix(obj, interfaceName)
+}
+
+// ListPodInterfacePorts returns the NSX SubnetPorts of the secondary network interfaces of the Pod,
+// keyed by the interface name. The NSX SubnetPort of the primary interface is not included.
+func (service *SubnetPortService) ListPodInterfacePorts(podUID string) map[string]*model.VpcSubnetPort {
+	ports := make(map[string]*model.VpcSubnetPort)
+	for _, nsxSubnetPort := range service.SubnetPortStore.GetByIndex(servicecommon.TagScopePodUID, podUID) {
+		if interfaceName := nsxutil.FindTag(nsxSubnetPort.Tags, servicecommon.TagScopePodInterface); interfaceName != "" {
+			ports[interfaceName] = nsxSubnetPort
+		}
+	}
+	return ports
+}
@@ -0,0 +1,96 @@
+package subnetport
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	mock_client "github.com/vmware-tanzu/nsx-operator/pkg/mock/controller-runtime/client"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+func TestBuildSubnetPortForPodInterface(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	k8sClient := mock_client.NewMockClient(mockCtl)
+	k8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	service := &SubnetPortService{
+		Service: common.Service{
+			Client: k8sClient,
+			NSXConfig: &config.NSXOperatorConfig{
+				CoeConfig: &config.CoeConfig{Cluster: "fake_cluster"},
+			},
+		},
+		SubnetPortStore: createSubnetPortService().SubnetPortStore,
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       "c5db1800-ce4c-11de-a935-8105ba7ace78",
+			Name:      "fake_pod",
+			Namespace: "fake_ns",
+		},
+	}
+	nsxSubnet := &model.VpcSubnet{Path: common.String("fake_path")}
+
+	primaryPort, err := service.buildSubnetPort(pod, nsxSubnet, "fake_context_id", nil)
+	assert.Nil(t, err)
+	nsxSubnetPort, err := service.buildSubnetPort(&PodInterface{Pod: pod, Name: "net1", IPAddress: "10.0.0.10"}, nsxSubnet, "fake_context_id", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "fake_pod_c5db1800-ce4c-11de-a935-8105ba7ace78_net1", *nsxSubnetPort.Id)
+	assert.Equal(t, "fake_pod_net1", *nsxSubnetPort.DisplayName)
+	assert.Equal(t, "fake_path/ports/fake_pod_c5db1800-ce4c-11de-a935-8105ba7ace78_net1", *nsxSubnetPort.Path)
+	assert.Equal(t, string(pod.UID), nsxutil.FindTag(nsxSubnetPort.Tags, common.TagScopePodUID))
+	assert.Equal(t, "fake_pod", nsxutil.FindTag(nsxSubnetPort.Tags, common.TagScopePodName))
+	assert.Equal(t, "net1", nsxutil.FindTag(nsxSubnetPort.Tags, common.TagScopePodInterface))
+	assert.Equal(t, string(pod.UID), *nsxSubnetPort.Attachment.AppId)
+	assert.Equal(t, "fake_context_id", *nsxSubnetPort.Attachment.ContextId)
+	assert.NotEqual(t, *primaryPort.Attachment.Id, *nsxSubnetPort.Attachment.Id)
+	assert.Equal(t, model.PortAttachment_ALLOCATE_ADDRESSES_MAC_POOL, *nsxSubnetPort.Attachment.AllocateAddresses)
+	assert.Equal(t, "10.0.0.10", GetStaticIPAddress(nsxSubnetPort))
+	assert.Equal(t, *nsxSubnetPort.Id, service.getSubnetPortID(&PodInterface{Pod: pod, Name: "net1"}))
+	assert.Equal(t, *primaryPort.Id, service.getSubnetPortID(pod))
+
+	// The attachment uid is stable for the same interface, and distinct for the Pods sharing a long
+	// interface name.
+	longName := "secondary-network-interface"
+	port1, err := service.buildSubnetPort(&PodInterface{Pod: pod, Name: longName}, nsxSubnet, "fake_context_id", nil)
+	assert.Nil(t, err)
+	port1Again, err := service.buildSubnetPort(&PodInterface{Pod: pod, Name: longName}, nsxSubnet, "fake_context_id", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, *port1.Attachment.Id, *port1Again.Attachment.Id)
+	pod2 := pod.DeepCopy()
+	pod2.UID = "d6ec2911-df5d-22ef-b046-9216cb8bdf89"
+	pod2.Name = "fake_pod_2"
+	port2, err := service.buildSubnetPort(&PodInterface{Pod: pod2, Name: longName}, nsxSubnet, "fake_context_id", nil)
+	assert.Nil(t, err)
+	assert.NotEqual(t, *port1.Attachment.Id, *port2.Attachment.Id)
+}
+
+func TestListPodInterfacePorts(t *testing.T) {
+	service := &SubnetPortService{
+		SubnetPortStore: &SubnetPortStore{ResourceStore: common.ResourceStore{
+			Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
+				common.TagScopePodUID: subnetPortIndexByPodUID,
+			}),
+			BindingType: model.VpcSubnetPortBindingType(),
+		}},
+	}
+	podTag := model.Tag{Scope: common.String(common.TagScopePodUID), Tag: common.String("pod-uid")}
+	for _, port := range []*model.VpcSubnetPort{
+		{Id: common.String("port-0"), Tags: []model.Tag{podTag}},
+		{Id: common.String("port-1"), Tags: []model.Tag{podTag, {Scope: common.String(common.TagScopePodInterface), Tag: common.String("net1")}}},
+		{Id: common.String("port-2"), Tags: []model.Tag{{Scope: common.String(common.TagScopePodUID), Tag: common.String("other-uid")}, {Scope: common.String(common.TagScopePodInterface), Tag: common.String("net1")}}},
+	} {
+		assert.Nil(t, service.SubnetPortStore.Add(port))
+	}
+	ports := service.ListPodInterfacePorts("pod-uid")
+	assert.Equal(t, 1, len(ports))
+	assert.Equal(t, "port-1", *ports["net1"].Id)
+	assert.Empty(t, service.ListPodInterfacePorts("unknown-uid"))
+}
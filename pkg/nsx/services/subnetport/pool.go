@@ -0,0 +1,191 @@
+package subnetport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/realizestate"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+// SubnetPortPool is the set of the realized NSX SubnetPorts pre-created on a node for the Pods allocated
+// to a SubnetSet. The NSX SubnetPorts are not attached to any Pod until they are handed to a new Pod.
+type SubnetPortPool struct {
+	SubnetSetUID       string
+	SubnetSetNamespace string
+	SubnetSetName      string
+	NodeName           string
+	Ports              []*model.VpcSubnetPort
+}
+
+func poolKey(subnetSetUID, nodeName string) string {
+	return subnetSetUID + "/" + nodeName
+}
+
+// IsPoolPort returns true if the NSX SubnetPort is in a SubnetPort pool and not handed to a Pod yet.
+func IsPoolPort(nsxSubnetPort *model.VpcSubnetPort) bool {
+	return nsxutil.FindTag(nsxSubnetPort.Tags, servicecommon.TagScopeSubnetPortPoolNode) != ""
+}
+
+func (service *SubnetPortService) buildPoolSubnetPort(subnetSet *v1alpha1.SubnetSet, nodeName string, nsxSubnet *model.VpcSubnet, contextID string) *model.VpcSubnetPort {
+	allocateAddresses := "BOTH"
+	if nsxSubnet.SubnetDhcpConfig != nil && nsxSubnet.SubnetDhcpConfig.Mode != nil && *nsxSubnet.SubnetDhcpConfig.Mode != nsxutil.ParseDHCPMode(v1alpha1.DHCPConfigModeDeactivated) {
+		allocateAddresses = "DHCP"
+	}
+	nsxSubnetPortID := util.GenerateTruncName(servicecommon.MaxIdLength, subnetSet.Name, "pool", util.GetRandomIndexString(), "", "")
+	tags := []model.Tag{
+		{Scope: String(servicecommon.TagScopeCluster), Tag: String(getCluster(service))},
+		{Scope: String(servicecommon.TagScopeVersion), Tag: String(strings.Join(servicecommon.TagValueVersion, "."))},
+		{Scope: String(servicecommon.TagScopeNamespace), Tag: String(subnetSet.Namespace)},
+		{Scope: String(servicecommon.TagScopeSubnetSetCRName), Tag: String(subnetSet.Name)},
+		{Scope: String(servicecommon.TagScopeSubnetSetCRUID), Tag: String(string(subnetSet.UID))},
+		{Scope: String(servicecommon.TagScopeSubnetPortPoolNode), Tag: String(nodeName)},
+	}
+	return &model.VpcSubnetPort{
+		DisplayName: String(nsxSubnetPortID),
+		Id:          String(nsxSubnetPortID),
+		Attachment: &model.PortAttachment{
+			AllocateAddresses: &allocateAddresses,
+			Id:                String(uuid.NewString()),
+			TrafficTag:        servicecommon.Int64(0),
+			Type_:             String("STATIC"),
+			ContextId:         String(contextID),
+		},
+		Tags:       tags,
+		Path:       String(fmt.Sprintf("%s/ports/%s", *nsxSubnet.Path, nsxSubnetPortID)),
+		ParentPath: nsxSubnet.Path,
+	}
+}
+
+// CreatePoolPort creates a NSX SubnetPort on the node for the SubnetPort pool of the SubnetSet, and waits
+// for it to be realized.
+func (service *SubnetPortService) CreatePoolPort(subnetSet *v1alpha1.SubnetSet, nodeName string, nsxSubnet *model.VpcSubnet, contextID string) error {
+	nsxSubnetPort := service.buildPoolSubnetPort(subnetSet, nodeName, nsxSubnet, contextID)
+	subnetInfo, err := servicecommon.ParseVPCResourcePath(*nsxSubnet.Path)
+	if err != nil {
+		return err
+	}
+	err = service.NSXClient.PortClient.Patch(subnetInfo.OrgID, subnetInfo.ProjectID, subnetInfo.VPCID, subnetInfo.ID, *nsxSubnetPort.Id, *nsxSubnetPort)
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		log.Error(err, "failed to create pool subnet port", "nsxSubnetPort.Id", *nsxSubnetPort.Id, "nsxSubnetPath", *nsxSubnet.Path)
+		return err
+	}
+	realizeService := realizestate.InitializeRealizeState(service.Service)
+	if err := realizeService.CheckRealizeState(util.NSXTRealizeRetry, *nsxSubnetPort.Path, []string{}); err != nil {
+		log.Error(err, "failed to get realized status of pool subnet port, cleaning the resource", "nsxSubnetPort.Path", *nsxSubnetPort.Path)
+		if deleteErr := service.NSXClient.PortClient.Delete(subnetInfo.OrgID, subnetInfo.ProjectID, subnetInfo.VPCID, subnetInfo.ID, *nsxSubnetPort.Id); deleteErr != nil {
+			log.Error(deleteErr, "failed to delete pool subnet port", "nsxSubnetPort.Path", *nsxSubnetPort.Path)
+		}
+		return err
+	}
+	createdNSXSubnetPort, err := service.NSXClient.PortClient.Get(subnetInfo.OrgID, subnetInfo.ProjectID, subnetInfo.VPCID, subnetInfo.ID, *nsxSubnetPort.Id)
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		return err
+	}
+	if err = service.SubnetPortStore.Apply(&createdNSXSubnetPort); err != nil {
+		return err
+	}
+	log.Info("created pool subnet port", "nsxSubnetPort.Path", *nsxSubnetPort.Path, "node", nodeName)
+	return nil
+}
+
+// ListPoolPorts returns the NSX SubnetPorts in the SubnetPort pool of the SubnetSet on the node.
+func (service *SubnetPortService) ListPoolPorts(subnetSetUID, nodeName string) []*model.VpcSubnetPort {
+	return service.SubnetPortStore.GetByIndex(servicecommon.IndexKeySubnetPortPool, poolKey(subnetSetUID, nodeName))
+}
+
+// ListSubnetPortPools returns all the SubnetPort pools which have NSX SubnetPorts. The NSX SubnetPorts
+// of a pool are sorted by ID so that the result is stable.
+func (service *SubnetPortService) ListSubnetPortPools() []SubnetPortPool {
+	var pools []SubnetPortPool
+	for _, key := range service.SubnetPortStore.ListIndexFuncValues(servicecommon.IndexKeySubnetPortPool).UnsortedList() {
+		ports := service.SubnetPortStore.GetByIndex(servicecommon.IndexKeySubnetPortPool, key)
+		if len(ports) == 0 {
+			continue
+		}
+		sort.Slice(ports, func(i, j int) bool {
+			return *ports[i].Id < *ports[j].Id
+		})
+		pools = append(pools, SubnetPortPool{
+			SubnetSetUID:       nsxutil.FindTag(ports[0].Tags, servicecommon.TagScopeSubnetSetCRUID),
+			SubnetSetNamespace: nsxutil.FindTag(ports[0].Tags, servicecommon.TagScopeNamespace),
+			SubnetSetName:      nsxutil.FindTag(ports[0].Tags, servicecommon.TagScopeSubnetSetCRName),
+			NodeName:           nsxutil.FindTag(ports[0].Tags, servicecommon.TagScopeSubnetPortPoolNode),
+			Ports:              ports,
+		})
+	}
+	return pools
+}
+
+// AcquirePoolPort takes a NSX SubnetPort from the SubnetPort pool of the SubnetSet on the node for the Pod,
+// nil is returned if the pool is empty. The NSX SubnetPort is tagged with the Pod in NSX and in the store,
+// so that it is not handed to another Pod or collected as a pool port, and CreateOrUpdateSubnetPort
+// updates its attachment in NSX.
+func (service *SubnetPortService) AcquirePoolPort(pod *corev1.Pod, subnetSetUID, nodeName string) *model.VpcSubnetPort {
+	service.poolLock.Lock()
+	defer service.poolLock.Unlock()
+	for _, poolPort := range service.ListPoolPorts(subnetSetUID, nodeName) {
+		if poolPort.ParentPath == nil {
+			continue
+		}
+		namespace := &corev1.Namespace{}
+		if err := service.Client.Get(context.TODO(), types.NamespacedName{Name: pod.Namespace}, namespace); err != nil {
+			log.Error(err, "failed to get Namespace for pool subnet port", "namespace", pod.Namespace, "pod.UID", pod.UID)
+			return nil
+		}
+		nsxSubnetPort := *poolPort
+		nsxSubnetPort.Tags = util.BuildBasicTags(getCluster(service), pod, namespace.UID)
+		subnetInfo, err := servicecommon.ParseVPCResourcePath(*nsxSubnetPort.ParentPath)
+		if err != nil {
+			log.Error(err, "failed to parse the Subnet path of pool subnet port", "nsxSubnetPort.Id", *nsxSubnetPort.Id)
+			return nil
+		}
+		err = service.NSXClient.PortClient.Patch(subnetInfo.OrgID, subnetInfo.ProjectID, subnetInfo.VPCID, subnetInfo.ID, *nsxSubnetPort.Id, nsxSubnetPort)
+		if err = nsxutil.TransNSXApiError(err); err != nil {
+			log.Error(err, "failed to tag pool subnet port with pod", "nsxSubnetPort.Id", *nsxSubnetPort.Id, "pod.UID", pod.UID)
+			return nil
+		}
+		if err := service.SubnetPortStore.Apply(&nsxSubnetPort); err != nil {
+			log.Error(err, "failed to acquire pool subnet port", "nsxSubnetPort.Id", *nsxSubnetPort.Id, "pod.UID", pod.UID)
+			return nil
+		}
+		log.Info("acquired pool subnet port for pod", "nsxSubnetPort.Id", *nsxSubnetPort.Id, "pod.Name", pod.Name, "pod.UID", pod.UID)
+		return &nsxSubnetPort
+	}
+	return nil
+}
+
+// DeletePoolPort deletes the NSX SubnetPort from the SubnetPort pool, it is skipped if the NSX SubnetPort
+// has been handed to a Pod.
+func (service *SubnetPortService) DeletePoolPort(nsxSubnetPort *model.VpcSubnetPort) error {
+	service.poolLock.Lock()
+	defer service.poolLock.Unlock()
+	existingSubnetPort := service.SubnetPortStore.GetByKey(*nsxSubnetPort.Id)
+	if existingSubnetPort == nil || !IsPoolPort(existingSubnetPort) {
+		return nil
+	}
+	return service.DeleteSubnetPort(existingSubnetPort)
+}
+
+// GetSubnetPortIDForPod returns the ID of the NSX SubnetPort of the primary network interface of the Pod,
+// which is generated from the Pod unless the NSX SubnetPort is taken from a SubnetPort pool.
+func (service *SubnetPortService) GetSubnetPortIDForPod(pod *corev1.Pod) string {
+	for _, nsxSubnetPort := range service.SubnetPortStore.GetByIndex(servicecommon.TagScopePodUID, string(pod.UID)) {
+		if nsxutil.FindTag(nsxSubnetPort.Tags, servicecommon.TagScopePodInterface) == "" {
+			return *nsxSubnetPort.Id
+		}
+	}
+	return service.BuildSubnetPortId(&pod.ObjectMeta)
+}
@@ -0,0 +1,118 @@
+package subnetport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	mock_client "github.com/vmware-tanzu/nsx-operator/pkg/mock/controller-runtime/client"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+type fakePoolPortClient struct {
+	fakePortClient
+	ports   map[string]model.VpcSubnetPort
+	deleted []string
+}
+
+func (c *fakePoolPortClient) Patch(orgIdParam string, projectIdParam string, vpcIdParam string, subnetIdParam string, portIdParam string, vpcSubnetPortParam model.VpcSubnetPort) error {
+	c.ports[portIdParam] = vpcSubnetPortParam
+	return nil
+}
+
+func (c *fakePoolPortClient) Get(orgIdParam string, projectIdParam string, vpcIdParam string, subnetIdParam string, portIdParam string) (model.VpcSubnetPort, error) {
+	return c.ports[portIdParam], nil
+}
+
+func (c *fakePoolPortClient) Delete(orgIdParam string, projectIdParam string, vpcIdParam string, subnetIdParam string, portIdParam string) error {
+	c.deleted = append(c.deleted, portIdParam)
+	delete(c.ports, portIdParam)
+	return nil
+}
+
+func TestSubnetPortService_SubnetPortPool(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	k8sClient := mock_client.NewMockClient(mockCtl)
+	k8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+		if namespace, ok := obj.(*corev1.Namespace); ok {
+			namespace.UID = types.UID(key.Name + "-uid")
+		}
+		return nil
+	}).AnyTimes()
+	portClient := &fakePoolPortClient{ports: map[string]model.VpcSubnetPort{}}
+	service := createSubnetPortService()
+	service.Service = common.Service{
+		Client: k8sClient,
+		NSXClient: &nsx.Client{
+			PortClient:             portClient,
+			RealizedEntitiesClient: &fakeRealizedEntitiesClient{},
+		},
+		NSXConfig: &config.NSXOperatorConfig{CoeConfig: &config.CoeConfig{Cluster: "k8scl-one"}},
+	}
+	subnetSet := &v1alpha1.SubnetSet{ObjectMeta: metav1.ObjectMeta{Name: "pod-default", Namespace: "ns-1", UID: "subnetset-uid"}}
+	nsxSubnet := &model.VpcSubnet{Id: common.String(subnetId), Path: common.String(subnetPath)}
+
+	// The pool ports are realized and tagged with the SubnetSet and the node, without being attached to any Pod.
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, service.CreatePoolPort(subnetSet, "node-1", nsxSubnet, "node-uid"))
+	}
+	poolPorts := service.ListPoolPorts("subnetset-uid", "node-1")
+	assert.Equal(t, 2, len(poolPorts))
+	for _, poolPort := range poolPorts {
+		assert.True(t, IsPoolPort(poolPort))
+		assert.Equal(t, "node-1", nsxutil.FindTag(poolPort.Tags, common.TagScopeSubnetPortPoolNode))
+		assert.Equal(t, "node-uid", *poolPort.Attachment.ContextId)
+		assert.Nil(t, poolPort.Attachment.AppId)
+	}
+	pools := service.ListSubnetPortPools()
+	assert.Equal(t, 1, len(pools))
+	assert.Equal(t, "subnetset-uid", pools[0].SubnetSetUID)
+	assert.Equal(t, "ns-1", pools[0].SubnetSetNamespace)
+	assert.Equal(t, "pod-default", pools[0].SubnetSetName)
+	assert.Equal(t, "node-1", pools[0].NodeName)
+	assert.True(t, *pools[0].Ports[0].Id < *pools[0].Ports[1].Id)
+
+	// The pool port is handed to the Pod, and the NSX SubnetPort of the Pod is built on it.
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "ns-1", UID: "c5db1800-ce4c-11de-a935-8105ba7ace78"}}
+	assert.Equal(t, service.BuildSubnetPortId(&pod.ObjectMeta), service.GetSubnetPortIDForPod(pod))
+	acquiredPort := service.AcquirePoolPort(pod, "subnetset-uid", "node-1")
+	assert.NotNil(t, acquiredPort)
+	assert.False(t, IsPoolPort(acquiredPort))
+	assert.Equal(t, 1, len(service.ListPoolPorts("subnetset-uid", "node-1")))
+	assert.Equal(t, *acquiredPort.Id, service.GetSubnetPortIDForPod(pod))
+	// The NSX SubnetPort is tagged with the Pod and its Namespace in NSX as well.
+	assert.Equal(t, "ns-1-uid", nsxutil.FindTag(acquiredPort.Tags, common.TagScopeNamespaceUID))
+	assert.Equal(t, acquiredPort.Tags, portClient.ports[*acquiredPort.Id].Tags)
+	nsxSubnetPort, err := service.buildSubnetPort(pod, nsxSubnet, "node-uid", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, *acquiredPort.Id, *nsxSubnetPort.Id)
+	assert.Equal(t, *acquiredPort.Path, *nsxSubnetPort.Path)
+	assert.Equal(t, "c5db1800-ce4c-11de-a935-8105ba7ace78", *nsxSubnetPort.Attachment.AppId)
+
+	// The acquired port is not deleted as a pool port.
+	assert.Nil(t, service.DeletePoolPort(acquiredPort))
+	assert.Empty(t, portClient.deleted)
+
+	// The pool is empty after the last pool port is handed to another Pod.
+	otherPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-2", Namespace: "ns-1", UID: "other-pod-uid"}}
+	assert.NotNil(t, service.AcquirePoolPort(otherPod, "subnetset-uid", "node-1"))
+	assert.Nil(t, service.AcquirePoolPort(otherPod, "subnetset-uid", "node-1"))
+	assert.Empty(t, service.ListSubnetPortPools())
+
+	assert.Nil(t, service.CreatePoolPort(subnetSet, "node-2", nsxSubnet, "node-uid-2"))
+	poolPort := service.ListPoolPorts("subnetset-uid", "node-2")[0]
+	assert.Nil(t, service.DeletePoolPort(poolPort))
+	assert.Equal(t, []string{*poolPort.Id}, portClient.deleted)
+	assert.Empty(t, service.ListPoolPorts("subnetset-uid", "node-2"))
+}
@@ -0,0 +1,121 @@
+package subnetport
+
+import (
+	"fmt"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	servicecommon "github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+// The reasons of the SubnetPort conditions when NSX rejects the requested static addresses.
+const (
+	ReasonStaticIPAddressUnavailable = "StaticIPAddressUnavailable"
+	ReasonStaticAddressRejected      = "StaticAddressRejected"
+)
+
+// StaticAddressError is returned when NSX rejects the static IP address or MAC address requested
+// by the SubnetPort.
+type StaticAddressError struct {
+	Reason string
+	Err    error
+}
+
+func (e *StaticAddressError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StaticAddressError) Unwrap() error {
+	return e.Err
+}
+
+// buildStaticAddressBindings returns the address allocation mode and the address bindings of the
+// SubnetPort, NSX only allocates the addresses which are not requested by the SubnetPort.
+func buildStaticAddressBindings(ipAddress, macAddress string, allocateAddresses string) (string, []model.PortAddressBindingEntry) {
+	if ipAddress == "" && macAddress == "" {
+		return allocateAddresses, nil
+	}
+	binding := model.PortAddressBindingEntry{}
+	if ipAddress != "" {
+		binding.IpAddress = String(ipAddress)
+	}
+	if macAddress != "" {
+		binding.MacAddress = String(macAddress)
+	}
+	if allocateAddresses == model.PortAttachment_ALLOCATE_ADDRESSES_BOTH {
+		switch {
+		case ipAddress != "" && macAddress != "":
+			allocateAddresses = model.PortAttachment_ALLOCATE_ADDRESSES_NONE
+		case ipAddress != "":
+			allocateAddresses = model.PortAttachment_ALLOCATE_ADDRESSES_MAC_POOL
+		default:
+			allocateAddresses = model.PortAttachment_ALLOCATE_ADDRESSES_IP_POOL
+		}
+	}
+	return allocateAddresses, []model.PortAddressBindingEntry{binding}
+}
+
+// GetStaticIPAddress returns the static IP address requested by the NSX SubnetPort.
+func GetStaticIPAddress(nsxSubnetPort *model.VpcSubnetPort) string {
+	for _, binding := range nsxSubnetPort.AddressBindings {
+		if binding.IpAddress != nil {
+			return *binding.IpAddress
+		}
+	}
+	return ""
+}
+
+// allocateStaticIPAddress allocates the static IP address requested by the SubnetPort from the static
+// IPv4 pool of the NSX Subnet, so that NSX doesn't allocate it to the other SubnetPorts.
+func (service *SubnetPortService) allocateStaticIPAddress(nsxSubnetPort *model.VpcSubnetPort, subnetInfo *servicecommon.VPCResourceInfo) error {
+	ipAddress := GetStaticIPAddress(nsxSubnetPort)
+	if ipAddress == "" {
+		return nil
+	}
+	allocation := model.IpAddressAllocation{
+		Id:           nsxSubnetPort.Id,
+		DisplayName:  nsxSubnetPort.DisplayName,
+		AllocationIp: String(ipAddress),
+		Tags:         nsxSubnetPort.Tags,
+	}
+	if err := service.NSXClient.IPAllocationClient.Patch(subnetInfo.OrgID, subnetInfo.ProjectID, subnetInfo.VPCID, subnetInfo.ID, servicecommon.StaticIPv4PoolID, *nsxSubnetPort.Id, allocation); err != nil {
+		err = nsxutil.TransNSXApiError(err)
+		return &StaticAddressError{
+			Reason: ReasonStaticIPAddressUnavailable,
+			Err:    fmt.Errorf("failed to allocate IP address %s: %w", ipAddress, err),
+		}
+	}
+	return nil
+}
+
+// rollbackStaticIPAddress restores the static IP allocation of the existing NSX SubnetPort after the
+// NSX SubnetPort fails to be created or updated, so that the newly allocated IP address is not leaked.
+func (service *SubnetPortService) rollbackStaticIPAddress(nsxSubnetPort, existingSubnetPort *model.VpcSubnetPort, subnetInfo *servicecommon.VPCResourceInfo) {
+	ipAddress := GetStaticIPAddress(nsxSubnetPort)
+	if ipAddress == "" {
+		return
+	}
+	var err error
+	if existingSubnetPort != nil && GetStaticIPAddress(existingSubnetPort) != "" {
+		if GetStaticIPAddress(existingSubnetPort) == ipAddress {
+			return
+		}
+		err = service.allocateStaticIPAddress(existingSubnetPort, subnetInfo)
+	} else {
+		err = service.NSXClient.IPAllocationClient.Delete(subnetInfo.OrgID, subnetInfo.ProjectID, subnetInfo.VPCID, subnetInfo.ID, servicecommon.StaticIPv4PoolID, *nsxSubnetPort.Id)
+		err = nsxutil.TransNSXApiError(err)
+	}
+	if err != nil {
+		log.Error(err, "Failed to roll back the static IP address allocation", "nsxSubnetPort.Id", *nsxSubnetPort.Id, "ipAddress", ipAddress)
+	}
+}
+
+func (service *SubnetPortService) releaseStaticIPAddress(nsxSubnetPort *model.VpcSubnetPort) error {
+	if GetStaticIPAddress(nsxSubnetPort) == "" {
+		return nil
+	}
+	nsxOrgID, nsxProjectID, nsxVPCID, nsxSubnetID := nsxutil.ParseVPCPath(*nsxSubnetPort.Path)
+	err := service.NSXClient.IPAllocationClient.Delete(nsxOrgID, nsxProjectID, nsxVPCID, nsxSubnetID, servicecommon.StaticIPv4PoolID, *nsxSubnetPort.Id)
+	return nsxutil.TransNSXApiError(err)
+}
@@ -0,0 +1,82 @@
+package subnetport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/orgs/projects/vpcs/subnets/ip_pools"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+type fakeIPAllocationsClient struct {
+	ip_pools.IpAllocationsClient
+	patched []string
+	deleted []string
+}
+
+func (f *fakeIPAllocationsClient) Patch(orgIdParam string, projectIdParam string, vpcIdParam string, subnetIdParam string, ipPoolIdParam string, ipAllocationIdParam string, ipAddressAllocationParam model.IpAddressAllocation) error {
+	f.patched = append(f.patched, *ipAddressAllocationParam.AllocationIp)
+	return nil
+}
+
+func (f *fakeIPAllocationsClient) Delete(orgIdParam string, projectIdParam string, vpcIdParam string, subnetIdParam string, ipPoolIdParam string, ipAllocationIdParam string) error {
+	f.deleted = append(f.deleted, ipAllocationIdParam)
+	return nil
+}
+
+func TestRollbackStaticIPAddress(t *testing.T) {
+	subnetInfo := &common.VPCResourceInfo{OrgID: "default", ProjectID: "project-1", VPCID: "vpc-1", ID: "subnet-1"}
+	newPort := func(ipAddress string) *model.VpcSubnetPort {
+		port := &model.VpcSubnetPort{Id: String("port-1")}
+		if ipAddress != "" {
+			port.AddressBindings = []model.PortAddressBindingEntry{{IpAddress: String(ipAddress)}}
+		}
+		return port
+	}
+	tests := []struct {
+		name         string
+		nsxPort      *model.VpcSubnetPort
+		existingPort *model.VpcSubnetPort
+		wantPatched  []string
+		wantDeleted  []string
+	}{
+		{
+			name:    "NoStaticIPAddress",
+			nsxPort: newPort(""),
+		},
+		{
+			name:        "ReleaseNewAllocation",
+			nsxPort:     newPort("10.0.0.10"),
+			wantDeleted: []string{"port-1"},
+		},
+		{
+			name:         "ReleaseAllocationOfPortWithoutStaticIP",
+			nsxPort:      newPort("10.0.0.10"),
+			existingPort: newPort(""),
+			wantDeleted:  []string{"port-1"},
+		},
+		{
+			name:         "SameStaticIPAddress",
+			nsxPort:      newPort("10.0.0.10"),
+			existingPort: newPort("10.0.0.10"),
+		},
+		{
+			name:         "RestoreExistingAllocation",
+			nsxPort:      newPort("10.0.0.10"),
+			existingPort: newPort("10.0.0.11"),
+			wantPatched:  []string{"10.0.0.11"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakeIPAllocationsClient{}
+			service := &SubnetPortService{Service: common.Service{NSXClient: &nsx.Client{IPAllocationClient: fakeClient}}}
+			service.rollbackStaticIPAddress(tt.nsxPort, tt.existingPort, subnetInfo)
+			assert.Equal(t, tt.wantPatched, fakeClient.patched)
+			assert.Equal(t, tt.wantDeleted, fakeClient.deleted)
+		})
+	}
+}
@@ -86,6 +86,22 @@ func subnetPortIndexPodNamespace(obj interface{}) ([]string, error) {
 	}
 }
 
+// subnetPortIndexByPool indexes the NSX SubnetPorts in the SubnetPort pool by the SubnetSet UID and the
+// node name, the NSX SubnetPorts handed to the Pods are not indexed.
+func subnetPortIndexByPool(obj interface{}) ([]string, error) {
+	switch o := obj.(type) {
+	case *model.VpcSubnetPort:
+		nodeNames := filterTag(o.Tags, common.TagScopeSubnetPortPoolNode)
+		subnetSetUIDs := filterTag(o.Tags, common.TagScopeSubnetSetCRUID)
+		if len(nodeNames) == 0 || len(subnetSetUIDs) == 0 {
+			return nil, nil
+		}
+		return []string{poolKey(subnetSetUIDs[0], nodeNames[0])}, nil
+	default:
+		return nil, errors.New("subnetPortIndexByPool doesn't support unknown type")
+	}
+}
+
 // SubnetPortStore is a store for SubnetPorts
 type SubnetPortStore struct {
 	common.ResourceStore
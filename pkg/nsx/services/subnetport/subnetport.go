@@ -11,7 +11,6 @@ import (
 
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 
@@ -32,6 +31,14 @@ var (
 type SubnetPortService struct {
 	servicecommon.Service
 	SubnetPortStore *SubnetPortStore
+	// poolLock serializes handing the NSX SubnetPorts in the SubnetPort pools to the Pods.
+	poolLock sync.Mutex
+}
+
+// SubnetGateway is the gateway address and the prefix length of a Subnet in an IP family.
+type SubnetGateway struct {
+	Gateway string
+	Prefix  int
 }
 
 // InitializeSubnetPort sync NSX resources.
@@ -54,6 +61,7 @@ func InitializeSubnetPort(service servicecommon.Service) (*SubnetPortService, er
 					servicecommon.TagScopeVMNamespace:     subnetPortIndexNamespace,
 					servicecommon.TagScopeNamespace:       subnetPortIndexPodNamespace,
 					servicecommon.IndexKeySubnetID:        subnetPortIndexBySubnetID,
+					servicecommon.IndexKeySubnetPortPool:  subnetPortIndexByPool,
 				}),
 			BindingType: model.VpcSubnetPortBindingType(),
 		},
@@ -84,6 +92,8 @@ func (service *SubnetPortService) CreateOrUpdateSubnetPort(obj interface{}, nsxS
 		uid = string(o.UID)
 	case *v1.Pod:
 		uid = string(o.UID)
+	case *PodInterface:
+		uid = string(o.Pod.UID) + "/" + o.Name
 	}
 	log.Info("creating or updating subnetport", "nsxSubnetPort.Id", uid, "nsxSubnetPath", *nsxSubnet.Path)
 	nsxSubnetPort, err := service.buildSubnetPort(obj, nsxSubnet, contextID, tags)
@@ -109,11 +119,19 @@ func (service *SubnetPortService) CreateOrUpdateSubnetPort(obj interface{}, nsxS
 		// We don't need to update it but still need to check realized state.
 	} else {
 		nsxSubnetPort = buildSubnetPortExternalAddressBindingFromExisting(nsxSubnetPort, existingSubnetPort)
+		if err = service.allocateStaticIPAddress(nsxSubnetPort, &subnetInfo); err != nil {
+			log.Error(err, "failed to allocate static IP address for subnet port", "nsxSubnetPort.Id", *nsxSubnetPort.Id, "nsxSubnetPath", *nsxSubnet.Path)
+			return nil, err
+		}
 		log.Info("updating the NSX subnet port", "existingSubnetPort", existingSubnetPort, "desiredSubnetPort", nsxSubnetPort)
 		err = service.NSXClient.PortClient.Patch(subnetInfo.OrgID, subnetInfo.ProjectID, subnetInfo.VPCID, subnetInfo.ID, *nsxSubnetPort.Id, *nsxSubnetPort)
 		err = nsxutil.TransNSXApiError(err)
 		if err != nil {
 			log.Error(err, "failed to create or update subnet port", "nsxSubnetPort.Id", *nsxSubnetPort.Id, "nsxSubnetPath", *nsxSubnet.Path)
+			service.rollbackStaticIPAddress(nsxSubnetPort, existingSubnetPort, &subnetInfo)
+			if len(nsxSubnetPort.AddressBindings) > 0 {
+				return nil, &StaticAddressError{Reason: ReasonStaticAddressRejected, Err: err}
+			}
 			return nil, err
 		}
 		err = service.SubnetPortStore.Apply(nsxSubnetPort)
@@ -154,14 +172,7 @@ func (service *SubnetPortService) CreateOrUpdateSubnetPort(obj interface{}, nsxS
 
 // CheckSubnetPortState will check the port realized status then get the port state to prepare the CR status.
 func (service *SubnetPortService) CheckSubnetPortState(obj interface{}, nsxSubnetPath string, enableDHCP bool) (*model.SegmentPortState, error) {
-	var objMeta metav1.ObjectMeta
-	switch o := obj.(type) {
-	case *v1alpha1.SubnetPort:
-		objMeta = o.ObjectMeta
-	case *v1.Pod:
-		objMeta = o.ObjectMeta
-	}
-	portID := util.GenerateIDByObject(&objMeta)
+	portID := service.getSubnetPortID(obj)
 	nsxSubnetPort := service.SubnetPortStore.GetByKey(portID)
 	if nsxSubnetPort == nil {
 		return nil, errors.New("failed to get subnet port from store")
@@ -193,13 +204,7 @@ func (service *SubnetPortService) CheckSubnetPortState(obj interface{}, nsxSubne
 }
 
 func (service *SubnetPortService) GetSubnetPortState(obj interface{}, nsxSubnetPath string) (*model.SegmentPortState, error) {
-	var nsxSubnetPortID string
-	switch o := obj.(type) {
-	case *v1alpha1.SubnetPort:
-		nsxSubnetPortID = service.BuildSubnetPortId(&o.ObjectMeta)
-	case *v1.Pod:
-		nsxSubnetPortID = service.BuildSubnetPortId(&o.ObjectMeta)
-	}
+	nsxSubnetPortID := service.getSubnetPortID(obj)
 	nsxOrgID, nsxProjectID, nsxVPCID, nsxSubnetID := nsxutil.ParseVPCPath(nsxSubnetPath)
 	nsxSubnetPortState, err := service.NSXClient.PortStateClient.Get(nsxOrgID, nsxProjectID, nsxVPCID, nsxSubnetID, nsxSubnetPortID, nil, nil)
 	err = nsxutil.TransNSXApiError(err)
@@ -218,6 +223,10 @@ func (service *SubnetPortService) DeleteSubnetPort(nsxSubnetPort *model.VpcSubne
 		log.Error(err, "failed to delete subnetport", "nsxSubnetPort.Path", *nsxSubnetPort.Path)
 		return err
 	}
+	if err = service.releaseStaticIPAddress(nsxSubnetPort); err != nil {
+		log.Error(err, "failed to release static IP address of subnetport", "nsxSubnetPort.Path", *nsxSubnetPort.Path)
+		return err
+	}
 	if err = service.SubnetPortStore.Delete(*nsxSubnetPort.Id); err != nil {
 		return err
 	}
@@ -255,38 +264,44 @@ func (service *SubnetPortService) ListNSXSubnetPortIDForPod() sets.Set[string] {
 }
 
 // TODO: merge the logic to subnet service when subnet implementation is done.
-func (service *SubnetPortService) GetGatewayPrefixForSubnetPort(obj *v1alpha1.SubnetPort, nsxSubnetPath string) (string, int, error) {
+// GetGatewayPrefixesForSubnetPort returns the gateway address and the prefix length of the Subnet in each
+// IP family, a dual-stack Subnet has a gateway for both IPv4 and IPv6.
+func (service *SubnetPortService) GetGatewayPrefixesForSubnetPort(obj *v1alpha1.SubnetPort, nsxSubnetPath string) (map[v1alpha1.IPFamily]SubnetGateway, error) {
 	subnetInfo, err := servicecommon.ParseVPCResourcePath(nsxSubnetPath)
 	if err != nil {
-		return "", -1, err
+		return nil, err
 	}
 	// TODO: if the port is not the first on the same subnet, try to get the info from existing realized subnetport CR to avoid query NSX API again.
 	statusList, err := service.NSXClient.SubnetStatusClient.List(subnetInfo.OrgID, subnetInfo.ProjectID, subnetInfo.VPCID, subnetInfo.ID)
 	err = nsxutil.TransNSXApiError(err)
 	if err != nil {
 		log.Error(err, "failed to get subnet status")
-		return "", -1, err
+		return nil, err
 	}
 	if len(statusList.Results) == 0 {
 		err := errors.New("empty status result")
 		log.Error(err, "no subnet status found")
-		return "", -1, err
-	}
-	status := statusList.Results[0]
-	if status.GatewayAddress == nil {
-		err := fmt.Errorf("invalid status result: %+v", status)
-		log.Error(err, "subnet status does not have gateway address", "nsxSubnetPath", nsxSubnetPath)
-		return "", -1, err
-	}
-	gateway, err := util.RemoveIPPrefix(*status.GatewayAddress)
-	if err != nil {
-		return "", -1, err
+		return nil, err
 	}
-	prefix, err := util.GetIPPrefix(*status.GatewayAddress)
-	if err != nil {
-		return "", -1, err
+	gateways := make(map[v1alpha1.IPFamily]SubnetGateway)
+	for _, status := range statusList.Results {
+		if status.GatewayAddress == nil {
+			err := fmt.Errorf("invalid status result: %+v", status)
+			log.Error(err, "subnet status does not have gateway address", "nsxSubnetPath", nsxSubnetPath)
+			return nil, err
+		}
+		gateway, err := util.RemoveIPPrefix(*status.GatewayAddress)
+		if err != nil {
+			return nil, err
+		}
+		prefix, err := util.GetIPPrefix(*status.GatewayAddress)
+		if err != nil {
+			return nil, err
+		}
+		ipFamily, _ := util.GetIPFamily(gateway)
+		gateways[ipFamily] = SubnetGateway{Gateway: gateway, Prefix: prefix}
 	}
-	return gateway, prefix, nil
+	return gateways, nil
 }
 
 func (service *SubnetPortService) GetSubnetPathForSubnetPortFromStore(nsxSubnetPortID string) string {
@@ -366,6 +381,35 @@ func (service *SubnetPortService) Cleanup(ctx context.Context) error {
 	return nil
 }
 
+// calculateSubnetCapacity returns the count of the IP addresses in the Subnet. A SubnetPort on
+// a dual-stack Subnet takes an IP address from each IP family, so the IP family with fewer IP
+// addresses decides the capacity.
+func calculateSubnetCapacity(subnet *model.VpcSubnet) int {
+	cidrs := map[v1alpha1.IPFamily][]string{}
+	for _, ipAddress := range subnet.IpAddresses {
+		ipFamily, err := util.GetIPFamily(ipAddress)
+		if err != nil {
+			continue
+		}
+		cidrs[ipFamily] = append(cidrs[ipFamily], ipAddress)
+	}
+	totalIP := -1
+	if _, ok := cidrs[v1alpha1.IPFamilyIPv4]; !ok && subnet.Ipv4SubnetSize != nil {
+		// The IPv4 CIDR in IpAddresses overrides Ipv4SubnetSize.
+		totalIP = int(*subnet.Ipv4SubnetSize)
+	}
+	for _, familyCIDRs := range cidrs {
+		count, err := util.CalculateIPFromCIDRs(familyCIDRs)
+		if err != nil {
+			continue
+		}
+		if totalIP < 0 || count < totalIP {
+			totalIP = count
+		}
+	}
+	return totalIP
+}
+
 // AllocatePortFromSubnet checks the number of SubnetPorts on the Subnet.
 // If the Subnet has capacity for the new SubnetPorts, it will increase
 // the number of SubnetPort under creation and return true.
@@ -377,14 +421,9 @@ func (service *SubnetPortService) AllocatePortFromSubnet(subnet *model.VpcSubnet
 	info.lock.Lock()
 	defer info.lock.Unlock()
 	if !ok {
-		totalIP := int(*subnet.Ipv4SubnetSize)
-		if len(subnet.IpAddresses) > 0 {
-			// totalIP will be overrided if IpAddresses are specified.
-			totalIP, _ = util.CalculateIPFromCIDRs(subnet.IpAddresses)
-		}
 		// NSX reserves 4 ip addresses in each subnet for network address, gateway address,
 		// dhcp server address and broadcast address.
-		info.totalIp = totalIP - 4
+		info.totalIp = calculateSubnetCapacity(subnet) - 4
 	}
 
 	// Number of SubnetPorts on the Subnet includes the SubnetPorts under creation
@@ -398,6 +437,31 @@ func (service *SubnetPortService) AllocatePortFromSubnet(subnet *model.VpcSubnet
 	return false
 }
 
+// GetIPUtilization returns the number of the IPs in the Subnet which can be allocated to the SubnetPorts,
+// and the number of the IPs allocated to the SubnetPorts, including the SubnetPorts under creation.
+func (service *SubnetPortService) GetIPUtilization(subnet *model.VpcSubnet) v1alpha1.IPUtilization {
+	// NSX reserves 4 ip addresses in each subnet.
+	total := max(calculateSubnetCapacity(subnet)-4, 0)
+	allocated := len(service.GetPortsOfSubnet(*subnet.Id))
+	if obj, ok := service.SubnetPortStore.PortCountInfo.Load(*subnet.Path); ok {
+		info := obj.(*CountInfo)
+		info.lock.Lock()
+		allocated += info.dirtyCount
+		info.lock.Unlock()
+	}
+	return v1alpha1.IPUtilization{
+		Total:     total,
+		Allocated: allocated,
+		Available: max(total-allocated, 0),
+	}
+}
+
+// GetFreeIPCount returns the number of the IPs in the Subnet which are not allocated to the SubnetPorts,
+// including the SubnetPorts under creation.
+func (service *SubnetPortService) GetFreeIPCount(subnet *model.VpcSubnet) int {
+	return service.GetIPUtilization(subnet).Available
+}
+
 // ReleasePortInSubnet decreases the number of SubnetPort under creation.
 func (service *SubnetPortService) ReleasePortInSubnet(path string) {
 	obj, ok := service.SubnetPortStore.PortCountInfo.Load(path)
@@ -3,6 +3,7 @@ package subnetport
 import (
 	"context"
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 
@@ -380,8 +381,9 @@ func TestSubnetPortService_DeleteSubnetPort(t *testing.T) {
 	}
 }
 
-func TestSubnetPortService_GetGatewayPrefixForSubnetPort(t *testing.T) {
+func TestSubnetPortService_GetGatewayPrefixesForSubnetPort(t *testing.T) {
 	gatewayAddress := "10.0.0.1/26"
+	ipv6GatewayAddress := "2001:db8::1/64"
 	invalidGatewayAddress1 := "10.0.0.256"
 	invalidGatewayAddress2 := "10.0.0.1/a"
 	tests := []struct {
@@ -396,6 +398,7 @@ func TestSubnetPortService_GetGatewayPrefixForSubnetPort(t *testing.T) {
 					Values: gomonkey.Params{model.VpcSubnetStatusListResult{
 						Results: []model.VpcSubnetStatus{
 							{GatewayAddress: &gatewayAddress},
+							{GatewayAddress: &ipv6GatewayAddress},
 						},
 					}, nil},
 					Times: 1,
@@ -489,13 +492,15 @@ func TestSubnetPortService_GetGatewayPrefixForSubnetPort(t *testing.T) {
 			if patches != nil {
 				defer patches.Reset()
 			}
-			gateway, prefix, err := service.GetGatewayPrefixForSubnetPort(nil, subnetPath)
+			gateways, err := service.GetGatewayPrefixesForSubnetPort(nil, subnetPath)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("DeleteSubnetPort() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if err == nil {
-				assert.Equal(t, "10.0.0.1", gateway)
-				assert.Equal(t, 26, prefix)
+				assert.Equal(t, map[v1alpha1.IPFamily]SubnetGateway{
+					v1alpha1.IPFamilyIPv4: {Gateway: "10.0.0.1", Prefix: 26},
+					v1alpha1.IPFamilyIPv6: {Gateway: "2001:db8::1", Prefix: 64},
+				}, gateways)
 			}
 		})
 	}
@@ -826,9 +831,22 @@ func TestSubnetPortService_AllocatePortFromSubnet(t *testing.T) {
 	assert.True(t, ok)
 	empty := subnetPortService.IsEmptySubnet(subnetId, subnetPath)
 	assert.False(t, empty)
+	nsxSubnet := &model.VpcSubnet{IpAddresses: []string{"10.0.0.1/28"}, Path: &subnetPath, Id: &subnetId}
+	assert.Equal(t, 11, subnetPortService.GetFreeIPCount(nsxSubnet))
+	assert.Equal(t, v1alpha1.IPUtilization{Total: 12, Allocated: 1, Available: 11}, subnetPortService.GetIPUtilization(nsxSubnet))
 	subnetPortService.ReleasePortInSubnet(subnetPath)
 	empty = subnetPortService.IsEmptySubnet(subnetId, subnetPath)
 	assert.True(t, empty)
+	assert.Equal(t, 12, subnetPortService.GetFreeIPCount(nsxSubnet))
+}
+
+func TestCalculateSubnetCapacity(t *testing.T) {
+	assert.Equal(t, 16, calculateSubnetCapacity(&model.VpcSubnet{Ipv4SubnetSize: common.Int64(16)}))
+	assert.Equal(t, 32, calculateSubnetCapacity(&model.VpcSubnet{Ipv4SubnetSize: common.Int64(16), IpAddresses: []string{"10.0.0.0/27"}}))
+	// The IPv4 family decides the capacity of a dual-stack Subnet.
+	assert.Equal(t, 64, calculateSubnetCapacity(&model.VpcSubnet{Ipv4SubnetSize: common.Int64(64), IpAddresses: []string{"2001:db8::/64"}}))
+	assert.Equal(t, 16, calculateSubnetCapacity(&model.VpcSubnet{IpAddresses: []string{"2001:db8::/64", "10.0.0.0/28"}}))
+	assert.Equal(t, math.MaxInt32, calculateSubnetCapacity(&model.VpcSubnet{IpAddresses: []string{"2001:db8::/64"}}))
 }
 
 func createSubnetPortService() *SubnetPortService {
@@ -842,6 +860,7 @@ func createSubnetPortService() *SubnetPortService {
 					common.TagScopeVMNamespace:     subnetPortIndexNamespace,
 					common.TagScopeNamespace:       subnetPortIndexPodNamespace,
 					common.IndexKeySubnetID:        subnetPortIndexBySubnetID,
+					common.IndexKeySubnetPortPool:  subnetPortIndexByPool,
 				}),
 			BindingType: model.VpcSubnetPortBindingType(),
 		}},
@@ -2,6 +2,7 @@ package vpc
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
@@ -51,11 +52,15 @@ func combineVPCIDAndLBSID(vpcID, lbsID string) string {
 }
 
 func buildNSXVPC(obj *v1alpha1.NetworkInfo, nsObj *v1.Namespace, nc common.VPCNetworkConfigInfo, cluster string,
-	nsxVPC *model.Vpc, useAVILB bool, lbProviderChanged bool) (*model.Vpc, error) {
+	nsxVPC *model.Vpc, useAVILB bool, lbProviderChanged bool, labelKeys []string) (*model.Vpc, error) {
 	vpc := &model.Vpc{}
+	labelTags := util.BuildLabelTags(labelKeys, nsObj.Labels)
 	if nsxVPC != nil {
-		// for upgrade case, only check public/private ip block size changing
-		if !IsVPCChanged(nc, nsxVPC) && !lbProviderChanged {
+		// the label tags are updated when the allowed labels of the Namespace are changed
+		tags := util.ReplaceLabelTags(nsxVPC.Tags, labelKeys, labelTags)
+		tagsChanged := len(labelKeys) > 0 && !util.TagsEqual(nsxVPC.Tags, tags)
+		// for upgrade case, only check new private ip blocks
+		if !IsVPCChanged(nc, nsxVPC) && !lbProviderChanged && !tagsChanged {
 			log.Info("no changes on current NSX VPC, skip updating", "VPC", nsxVPC.Id)
 			return nil, nil
 		}
@@ -65,6 +70,9 @@ func buildNSXVPC(obj *v1alpha1.NetworkInfo, nsObj *v1.Namespace, nc common.VPCNe
 			nsxVPC.LoadBalancerVpcEndpoint = &model.LoadBalancerVPCEndpoint{Enabled: &loadBalancerVPCEndpointEnabled}
 		}
 		*vpc = *nsxVPC
+		if tagsChanged {
+			vpc.Tags = tags
+		}
 	} else {
 		// for creating vpc case, fill in vpc properties based on networkconfig
 		vpcName := util.GenerateIDByObjectByLimit(obj, common.MaxSubnetNameLength)
@@ -79,9 +87,19 @@ func buildNSXVPC(obj *v1alpha1.NetworkInfo, nsObj *v1.Namespace, nc common.VPCNe
 		vpc.Tags = util.BuildBasicTags(cluster, obj, nsObj.UID)
 		vpc.Tags = append(vpc.Tags, model.Tag{
 			Scope: common.String(common.TagScopeVPCManagedBy), Tag: common.String(common.AutoCreatedVPCTagValue)})
+		vpc.Tags = util.AppendLabelTags(vpc.Tags, labelTags)
 	}
 
-	vpc.PrivateIps = nc.PrivateIPs
+	if nsxVPC != nil {
+		// Keep the order of the existing private cidrs, drop the ones removed from the VPCNetworkConfig
+		// and append the new ones. The webhook rejects removing the private cidrs in use.
+		vpc.PrivateIps = slices.DeleteFunc(slices.Clone(nsxVPC.PrivateIps), func(cidr string) bool {
+			return !slices.Contains(nc.PrivateIPs, cidr)
+		})
+		vpc.PrivateIps = append(vpc.PrivateIps, newPrivateIPs(nc, nsxVPC)...)
+	} else {
+		vpc.PrivateIps = nc.PrivateIPs
+	}
 	return vpc, nil
 }
 
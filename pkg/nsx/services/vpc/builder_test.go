@@ -87,7 +87,7 @@ func TestBuildNSXVPC(t *testing.T) {
 		VPCs:       nil,
 	}
 	nsObj := &v1.Namespace{
-		ObjectMeta: metav1.ObjectMeta{Name: "ns1", UID: "nsuid1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "ns1", UID: "nsuid1", Labels: map[string]string{"team": "net", "env": "prod"}},
 	}
 	clusterStr := "cluster1"
 
@@ -99,6 +99,7 @@ func TestBuildNSXVPC(t *testing.T) {
 		netInfoObj        *v1alpha1.NetworkInfo
 		expVPC            *model.Vpc
 		lbProviderChanged bool
+		labelKeys         []string
 	}{
 		{
 			name:         "existing VPC not change",
@@ -121,6 +122,18 @@ func TestBuildNSXVPC(t *testing.T) {
 			},
 			lbProviderChanged: false,
 		},
+		{
+			name: "existing VPC appends and removes private IPv4 blocks",
+			existingVPC: &model.Vpc{
+				PrivateIps: []string{"192.168.1.0/24", "192.168.2.0/24"},
+			},
+			ncPrivateIps: []string{"192.168.2.0/24", "192.168.3.0/24"},
+			useAVILB:     false,
+			expVPC: &model.Vpc{
+				PrivateIps: []string{"192.168.2.0/24", "192.168.3.0/24"},
+			},
+			lbProviderChanged: false,
+		},
 		{
 			name:              "create new VPC with AVI load balancer enabled",
 			ncPrivateIps:      []string{"192.168.3.0/24"},
@@ -220,13 +233,65 @@ func TestBuildNSXVPC(t *testing.T) {
 				IpAddressType: common.String("IPV4"),
 			},
 		},
+		{
+			name:         "create new VPC with label tags",
+			ncPrivateIps: []string{"192.168.3.0/24"},
+			labelKeys:    []string{"team", "owner"},
+			netInfoObj: &v1alpha1.NetworkInfo{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "netinfo1", UID: "netinfouid1"},
+			},
+			expVPC: &model.Vpc{
+				Id:            common.String("netinfo1_netinfouid1"),
+				DisplayName:   common.String("netinfo1_netinfouid1"),
+				PrivateIps:    []string{"192.168.3.0/24"},
+				IpAddressType: common.String("IPV4"),
+				Tags: []model.Tag{
+					{Scope: common.String("nsx-op/cluster"), Tag: common.String("cluster1")},
+					{Scope: common.String("nsx-op/version"), Tag: common.String("1.0.0")},
+					{Scope: common.String("nsx-op/namespace"), Tag: common.String("ns1")},
+					{Scope: common.String("nsx-op/namespace_uid"), Tag: common.String("nsuid1")},
+					{Scope: common.String("nsx/managed-by"), Tag: common.String("nsx-op")},
+					{Scope: common.String("team"), Tag: common.String("net")},
+				},
+			},
+		},
+		{
+			name:         "existing VPC label tags not change",
+			ncPrivateIps: []string{"192.168.1.0/24"},
+			existingVPC: &model.Vpc{
+				PrivateIps: []string{"192.168.1.0/24"},
+				Tags:       []model.Tag{{Scope: common.String("team"), Tag: common.String("net")}},
+			},
+			labelKeys: []string{"team"},
+		},
+		{
+			name:         "existing VPC updates label tags",
+			ncPrivateIps: []string{"192.168.1.0/24"},
+			existingVPC: &model.Vpc{
+				PrivateIps: []string{"192.168.1.0/24"},
+				Tags: []model.Tag{
+					{Scope: common.String("nsx-op/cluster"), Tag: common.String("cluster1")},
+					{Scope: common.String("owner"), Tag: common.String("alice")},
+					{Scope: common.String("team"), Tag: common.String("dev")},
+				},
+			},
+			labelKeys: []string{"team", "owner", "env"},
+			expVPC: &model.Vpc{
+				PrivateIps: []string{"192.168.1.0/24"},
+				Tags: []model.Tag{
+					{Scope: common.String("nsx-op/cluster"), Tag: common.String("cluster1")},
+					{Scope: common.String("env"), Tag: common.String("prod")},
+					{Scope: common.String("team"), Tag: common.String("net")},
+				},
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			nc.PrivateIPs = tc.ncPrivateIps
 			if tc.netInfoObj != nil {
 				netInfoObj = tc.netInfoObj
 			}
-			got, err := buildNSXVPC(netInfoObj, nsObj, nc, clusterStr, tc.existingVPC, tc.useAVILB, tc.lbProviderChanged)
+			got, err := buildNSXVPC(netInfoObj, nsObj, nc, clusterStr, tc.existingVPC, tc.useAVILB, tc.lbProviderChanged, tc.labelKeys)
 			assert.Nil(t, err)
 			assert.Equal(t, tc.expVPC, got)
 		})
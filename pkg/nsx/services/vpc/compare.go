@@ -2,15 +2,25 @@ package vpc
 
 import (
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 )
 
-// IsVPCChanged checks if the VPCNetworkConfig is changed, currently we only support appending public/private cidrs
-// so only comparing list size is enough to identify if VPC changed
+// IsVPCChanged checks if the private cidrs of the VPCNetworkConfig are changed, i.e. any private cidr is
+// appended to or removed from the VPCNetworkConfig. The webhook rejects removing the private cidrs in use.
 func IsVPCChanged(nc common.VPCNetworkConfigInfo, vpc *model.Vpc) bool {
-	if len(nc.PrivateIPs) != len(vpc.PrivateIps) {
-		return true
+	return !sets.New(nc.PrivateIPs...).Equal(sets.New(vpc.PrivateIps...))
+}
+
+// newPrivateIPs returns the private cidrs in the VPCNetworkConfig which are not in the VPC yet.
+func newPrivateIPs(nc common.VPCNetworkConfigInfo, vpc *model.Vpc) []string {
+	existing := sets.New(vpc.PrivateIps...)
+	var added []string
+	for _, cidr := range nc.PrivateIPs {
+		if !existing.Has(cidr) {
+			added = append(added, cidr)
+		}
 	}
-	return false
+	return added
 }
@@ -34,6 +34,22 @@ func TestIsVPCChanged(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "removed private IP",
+			args: args{
+				nc:  common.VPCNetworkConfigInfo{PrivateIPs: []string{"2.2.2.2"}},
+				vpc: &model.Vpc{PrivateIps: []string{"1.1.1.1", "2.2.2.2"}},
+			},
+			want: true,
+		},
+		{
+			name: "replaced private IP",
+			args: args{
+				nc:  common.VPCNetworkConfigInfo{PrivateIPs: []string{"2.2.2.2"}},
+				vpc: &model.Vpc{PrivateIps: []string{"1.1.1.1"}},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -10,6 +10,8 @@ import (
 	stderrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
@@ -194,6 +196,10 @@ func (s *VPCService) GetCurrentVPCsByNamespace(ctx context.Context, namespace st
 	if sharedNamespace == nil {
 		return s.VpcStore.GetVPCsByNamespaceIDFromStore(string(namespaceObj.UID))
 	}
+	// The owner Namespace of the shared VPC has been deleted.
+	if sharedNamespace.UID == "" {
+		return s.VpcStore.GetVPCsByNamespaceFromStore(sharedNamespace.Name)
+	}
 	return s.VpcStore.GetVPCsByNamespaceIDFromStore(string(sharedNamespace.UID))
 }
 
@@ -201,6 +207,59 @@ func (s *VPCService) GetVPCsByNamespace(namespace string) []*model.Vpc {
 	return s.VpcStore.GetVPCsByNamespaceFromStore(namespace)
 }
 
+// ListVPCAddressesInUse returns the CIDRs of the NSX Subnets and the private IP address allocations in the VPCs
+// of the Namespace, including the ones not created by nsx-operator, e.g. the Subnets imported or created on NSX
+// directly. The IP address allocation may be a single IP address.
+func (s *VPCService) ListVPCAddressesInUse(namespace string) ([]string, error) {
+	var addresses []string
+	for _, nsxVPC := range s.GetVPCsByNamespace(namespace) {
+		if nsxVPC.Path == nil {
+			continue
+		}
+		vpcInfo, err := common.ParseVPCResourcePath(*nsxVPC.Path)
+		if err != nil {
+			return nil, err
+		}
+		var cursor *string
+		for {
+			result, err := s.NSXClient.SubnetsClient.List(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, cursor, nil, nil, nil, nil, nil)
+			if err != nil {
+				err = nsxutil.TransNSXApiError(err)
+				log.Error(err, "Failed to list NSX Subnets", "VPC", vpcInfo.VPCID)
+				return nil, err
+			}
+			for _, nsxSubnet := range result.Results {
+				addresses = append(addresses, nsxSubnet.IpAddresses...)
+			}
+			if result.Cursor == nil || *result.Cursor == "" {
+				break
+			}
+			cursor = result.Cursor
+		}
+		cursor = nil
+		for {
+			result, err := s.NSXClient.IPAddressAllocationClient.List(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, cursor, nil, nil, nil, nil, nil)
+			if err != nil {
+				err = nsxutil.TransNSXApiError(err)
+				log.Error(err, "Failed to list NSX IP address allocations", "VPC", vpcInfo.VPCID)
+				return nil, err
+			}
+			for _, allocation := range result.Results {
+				if allocation.AllocationIps == nil || (allocation.IpAddressBlockVisibility != nil &&
+					*allocation.IpAddressBlockVisibility != model.VpcIpAddressAllocation_IP_ADDRESS_BLOCK_VISIBILITY_PRIVATE) {
+					continue
+				}
+				addresses = append(addresses, *allocation.AllocationIps)
+			}
+			if result.Cursor == nil || *result.Cursor == "" {
+				break
+			}
+			cursor = result.Cursor
+		}
+	}
+	return addresses, nil
+}
+
 func (s *VPCService) ListVPC() []model.Vpc {
 	vpcs := s.VpcStore.List()
 	var vpcSet []model.Vpc
@@ -210,6 +269,51 @@ func (s *VPCService) ListVPC() []model.Vpc {
 	return vpcSet
 }
 
+// deleteStaleVPCs deletes the VPCs created for the Namespace which are not in the NSX project of the
+// VPCNetworkConfiguration, they are left behind after the Namespace is moved to another VPCNetworkConfiguration.
+func (s *VPCService) deleteStaleVPCs(nsObj *v1.Namespace, nc *common.VPCNetworkConfigInfo) error {
+	for _, nsxVPC := range s.VpcStore.GetVPCsByNamespaceIDFromStore(string(nsObj.UID)) {
+		if !isStaleVPC(nsxVPC, nc) {
+			continue
+		}
+		log.Info("Deleting stale VPC of Namespace", "Namespace", nsObj.Name, "VPC", *nsxVPC.Path)
+		if err := s.DeleteVPC(*nsxVPC.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isMigratingVPC checks if the Namespace is being moved to another network config, i.e. the migration target is
+// recorded or the VPC is being created under the new network config.
+func isMigratingVPC(nsObj *v1.Namespace) bool {
+	if nsObj.Annotations[common.AnnotationVPCMigrationTarget] != "" {
+		return true
+	}
+	for _, cond := range nsObj.Status.Conditions {
+		if string(cond.Type) == common.NamespaceConditionVPCMigration {
+			return cond.Reason == common.VPCMigrationReasonCreatingVPC
+		}
+	}
+	return false
+}
+
+// isStaleVPC checks if the VPC is not created under the VPCNetworkConfiguration, all the created VPCs are
+// stale if the VPCNetworkConfiguration uses a pre-created VPC.
+func isStaleVPC(nsxVPC *model.Vpc, nc *common.VPCNetworkConfigInfo) bool {
+	if nsxVPC.Path == nil {
+		return false
+	}
+	if IsPreCreatedVPC(*nc) {
+		return true
+	}
+	vpcInfo, err := common.ParseVPCResourcePath(*nsxVPC.Path)
+	if err != nil {
+		return false
+	}
+	return vpcInfo.OrgID != nc.Org || vpcInfo.ProjectID != nc.NSXProject
+}
+
 // DeleteVPC will try to delete VPC resource from NSX.
 func (s *VPCService) DeleteVPC(path string) error {
 	pathInfo, err := common.ParseVPCResourcePath(path)
@@ -551,22 +655,21 @@ func (s *VPCService) getNamespace(ctx context.Context, name string) (*v1.Namespa
 }
 
 // resolveSharedVPCNamespace will resolve the Namespace relationship based on VPC sharing,
-// whether a shared VPC Namespace exists.
+// whether a shared VPC Namespace exists. The sharing is declared by a SharedVPC CR or by the
+// annotation "nsx.vmware.com/shared_vpc_namespace" on the Namespace.
 func (s *VPCService) resolveSharedVPCNamespace(ctx context.Context, ns string) (*v1.Namespace, *v1.Namespace, error) {
 	obj, err := s.getNamespace(ctx, ns)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	annos := obj.Annotations
-	// If no annotation on ns, then this is not a shared VPC ns
-	if len(annos) == 0 {
-		return obj, nil, nil
+	nsForSharedVPCs, err := util.GetSharedVPCNamespace(s.Client, ctx, obj)
+	if err != nil {
+		log.Error(err, "Failed to resolve shared VPC Namespace", "Namespace", ns)
+		return nil, nil, err
 	}
-
-	// If no annotation nsx.vmware.com/shared_vpc_namespace on ns, this is not a shared vpc
-	nsForSharedVPCs, exist := annos[common.AnnotationSharedVPCNamespace]
-	if !exist {
+	// If the Namespace is neither the owner nor a consumer of a shared VPC, this is not a shared VPC ns
+	if nsForSharedVPCs == "" {
 		return obj, nil, nil
 	}
 	if nsForSharedVPCs == ns {
@@ -574,8 +677,12 @@ func (s *VPCService) resolveSharedVPCNamespace(ctx context.Context, ns string) (
 	}
 	sharedNamespace, err := s.getNamespace(ctx, nsForSharedVPCs)
 	if err != nil {
-		// if sharedNamespace does not exist, add this case for security,
-		// It shouldn't happen that a shared Namespace doesn't exist but is used as an annotation on another Namespace.
+		// The VPC of the deleted owner Namespace is kept until all the consumer Namespaces are deleted,
+		// return the owner Namespace by name so that the VPC can still be found by the consumers.
+		if apierrors.IsNotFound(err) {
+			log.Info("Owner Namespace of the shared VPC is deleted", "Namespace", ns, "OwnerNamespace", nsForSharedVPCs)
+			return nil, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nsForSharedVPCs}}, nil
+		}
 		return nil, nil, err
 	}
 	return nil, sharedNamespace, nil
@@ -588,6 +695,11 @@ func (s *VPCService) GetNetworkconfigNameFromNS(ctx context.Context, ns string)
 	}
 
 	annos := obj.Annotations
+	// The applied network config is kept until the Namespace has been moved to the
+	// network config in annotation "nsx.vmware.com/vpc_network_config".
+	if ncName, exist := annos[common.AnnotationAppliedVPCNetworkConfig]; exist {
+		return ncName, nil
+	}
 	useDefault := false
 	// use default network config
 	if len(annos) == 0 {
@@ -708,6 +820,40 @@ func (s *VPCService) GetVpcConnectivityProfile(nc *common.VPCNetworkConfigInfo,
 	return &vpcConnectivityProfile, nil
 }
 
+// ValidateAccessModeIPBlocks checks the VPC has the IP blocks to allocate the CIDRs of the Subnets
+// with the access mode. The IP blocks of the pre-created VPC are not checked as they are managed
+// outside of nsx-operator.
+func (s *VPCService) ValidateAccessModeIPBlocks(ns string, vpcInfo common.VPCResourceInfo, accessMode string) error {
+	nc := s.GetVPCNetworkConfigByNamespace(ns)
+	if nc == nil {
+		return fmt.Errorf("failed to find VPCNetworkConfig for Namespace %s", ns)
+	}
+	if nc.VPCPath != "" {
+		return nil
+	}
+	if accessMode == v1alpha1.AccessModePrivate {
+		if len(vpcInfo.PrivateIPs) == 0 {
+			return fmt.Errorf("VPC %s has no private IPs for the Subnets with access mode %s", vpcInfo.VPCID, accessMode)
+		}
+		return nil
+	}
+	vpcConnectivityProfile, err := s.GetVpcConnectivityProfile(nc, nc.VPCConnectivityProfile)
+	if err != nil {
+		return err
+	}
+	switch accessMode {
+	case v1alpha1.AccessModePublic:
+		if len(vpcConnectivityProfile.ExternalIpBlocks) == 0 {
+			return fmt.Errorf("VPCConnectivityProfile %s has no external IP blocks for the Subnets with access mode %s", nc.VPCConnectivityProfile, accessMode)
+		}
+	case v1alpha1.AccessModeProject:
+		if len(vpcConnectivityProfile.PrivateTgwIpBlocks) == 0 {
+			return fmt.Errorf("VPCConnectivityProfile %s has no private TGW IP blocks for the Subnets with access mode %s", nc.VPCConnectivityProfile, accessMode)
+		}
+	}
+	return nil
+}
+
 /*
 IsLBProviderChanged is used to judge if the lb provider is changed from day0 to day2
 
@@ -743,6 +889,15 @@ func (s *VPCService) CreateOrUpdateVPC(ctx context.Context, obj *v1alpha1.Networ
 		return nil, err
 	}
 
+	// The VPCs out of the network config are only deleted when the Namespace is moved to another network config,
+	// a change of the network config in use must not delete the VPC of the running workloads.
+	if nc != nil && isMigratingVPC(nsObj) {
+		if err := s.deleteStaleVPCs(nsObj, nc); err != nil {
+			log.Error(err, "Failed to delete stale VPC", "Namespace", obj.Namespace)
+			return nil, err
+		}
+	}
+
 	// Return pre-created VPC resource if it is used in the VPCNetworkConfiguration
 	if nc != nil && IsPreCreatedVPC(*nc) {
 		preVPC, err := s.GetVPCFromNSXByPath(nc.VPCPath)
@@ -780,7 +935,7 @@ func (s *VPCService) CreateOrUpdateVPC(ctx context.Context, obj *v1alpha1.Networ
 	}
 
 	lbProviderChanged := s.IsLBProviderChanged(nsxVPC, lbProvider)
-	createdVpc, err := buildNSXVPC(obj, nsObj, *nc, s.NSXConfig.Cluster, nsxVPC, lbProvider == AVILB, lbProviderChanged)
+	createdVpc, err := buildNSXVPC(obj, nsObj, *nc, s.NSXConfig.Cluster, nsxVPC, lbProvider == AVILB, lbProviderChanged, s.NSXConfig.GetLabelTagKeys())
 	if err != nil {
 		log.Error(err, "Failed to build NSX VPC object")
 		return nil, err
@@ -940,6 +1095,40 @@ func (s *VPCService) checkVpcAttachmentRealization(createdAttachment *model.VpcA
 	return nil
 }
 
+// UpdateVPCConnectivityProfile switches the default attachment of the VPC to the connectivity profile
+// in the VPCNetworkConfig if it is changed.
+func (s *VPCService) UpdateVPCConnectivityProfile(nc *common.VPCNetworkConfigInfo, nsxVPC *model.Vpc) error {
+	vpcInfo, err := common.ParseVPCResourcePath(*nsxVPC.Path)
+	if err != nil {
+		return err
+	}
+	attachment, err := s.NSXClient.VpcAttachmentClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, common.DefaultVpcAttachmentId)
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		log.Error(err, "Failed to get VPC attachment", "VPC", vpcInfo.VPCID)
+		return err
+	}
+	if attachment.VpcConnectivityProfile != nil && *attachment.VpcConnectivityProfile == nc.VPCConnectivityProfile {
+		return nil
+	}
+	log.Info("Switching VPC connectivity profile", "VPC", vpcInfo.VPCID, "old", attachment.VpcConnectivityProfile, "new", nc.VPCConnectivityProfile)
+	attachment.VpcConnectivityProfile = common.String(nc.VPCConnectivityProfile)
+	err = s.NSXClient.VpcAttachmentClient.Patch(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, common.DefaultVpcAttachmentId, attachment)
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		log.Error(err, "Failed to update VPC attachment", "VPC", vpcInfo.VPCID)
+		return err
+	}
+	// Unlike a new VPC, the VPC in use is not deleted if the attachment is in error realization state.
+	realizeService := realizestate.InitializeRealizeState(s.Service)
+	attachmentPath := fmt.Sprintf("%s/attachments/%s", *nsxVPC.Path, common.DefaultVpcAttachmentId)
+	if err = realizeService.CheckRealizeState(util.NSXTRealizeRetry, attachmentPath, []string{}); err != nil {
+		log.Error(err, "Failed to check VPC attachment realization state", "VPC", vpcInfo.VPCID)
+		return err
+	}
+	return nil
+}
+
 func (s *VPCService) GetGatewayConnectionTypeFromConnectionPath(connectionPath string) (string, error) {
 	/* examples of connection_path:
 	   /infra/distributed-gateway-connections/gateway-101
@@ -1141,11 +1330,16 @@ func (s *VPCService) ListVPCInfo(ns string) []common.VPCResourceInfo {
 	vpcs := s.GetCurrentVPCsByNamespace(context.Background(), ns)
 	log.Info("Got VPCs by Namespace from store", "Namespace", ns, "VPCCount", len(vpcs))
 	for _, v := range vpcs {
+		// Skip the VPC which is deleted after the Namespace is moved to another VPCNetworkConfiguration.
+		if nc != nil && isStaleVPC(v, nc) {
+			continue
+		}
 		vpcResourceInfo, err := common.ParseVPCResourcePath(*v.Path)
 		if err != nil {
 			log.Error(err, "Failed to get VPC info from VPC path", "VPCPath", *v.Path)
 		}
 		vpcResourceInfo.PrivateIpv4Blocks = v.PrivateIpv4Blocks
+		vpcResourceInfo.PrivateIPs = v.PrivateIps
 		VPCInfoList = append(VPCInfoList, vpcResourceInfo)
 	}
 	return VPCInfoList
@@ -24,6 +24,7 @@ import (
 	apierrors "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/data"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/orgs/projects/vpcs"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
@@ -233,6 +234,18 @@ func TestGetNetworkConfigFromNS(t *testing.T) {
 	ns, err = service.GetNetworkconfigNameFromNS(ctx, "test")
 	assert.Nil(t, err)
 	assert.Equal(t, "test-nc", ns)
+
+	// The applied network config is used until the Namespace is moved to the new one.
+	k8sClient.EXPECT().Get(ctx, gomock.Any(), mockNs).Return(nil).Do(func(_ context.Context, k client.ObjectKey, obj client.Object, option ...client.GetOption) error {
+		obj.SetAnnotations(map[string]string{
+			"nsx.vmware.com/vpc_network_config":         "test-nc-new",
+			"nsx.vmware.com/applied_vpc_network_config": "test-nc",
+		})
+		return nil
+	})
+	ns, err = service.GetNetworkconfigNameFromNS(ctx, "test")
+	assert.Nil(t, err)
+	assert.Equal(t, "test-nc", ns)
 }
 
 func TestGetSharedVPCNamespaceFromNS(t *testing.T) {
@@ -240,6 +253,7 @@ func TestGetSharedVPCNamespaceFromNS(t *testing.T) {
 		name                    string
 		ns                      string
 		existingNames           []*v1.Namespace
+		existingSharedVPCs      []*v1alpha1.SharedVPC
 		expectedNS              string
 		expectedSharedNamespace string
 		expectedErrStr          string
@@ -291,12 +305,56 @@ func TestGetSharedVPCNamespaceFromNS(t *testing.T) {
 			},
 			expectedSharedNamespace: "test-ns-2",
 		},
+		{
+			name: "Got the shared Namespace from SharedVPC",
+			ns:   "test-ns-1",
+			existingNames: []*v1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: "test-ns-1"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "test-ns-2"}},
+			},
+			existingSharedVPCs: []*v1alpha1.SharedVPC{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "shared-vpc"},
+					Spec:       v1alpha1.SharedVPCSpec{OwnerNamespace: "test-ns-2", ConsumerNamespaces: []string{"test-ns-1"}},
+				},
+			},
+			expectedSharedNamespace: "test-ns-2",
+		},
+		{
+			name: "Owner Namespace of SharedVPC",
+			ns:   "test-ns-2",
+			existingNames: []*v1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: "test-ns-2"}},
+			},
+			existingSharedVPCs: []*v1alpha1.SharedVPC{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "shared-vpc"},
+					Spec:       v1alpha1.SharedVPCSpec{OwnerNamespace: "test-ns-2", ConsumerNamespaces: []string{"test-ns-1"}},
+				},
+			},
+			expectedSharedNamespace: "test-ns-2",
+		},
+		{
+			name: "Owner Namespace of SharedVPC is deleted",
+			ns:   "test-ns-1",
+			existingNames: []*v1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: "test-ns-1"}},
+			},
+			existingSharedVPCs: []*v1alpha1.SharedVPC{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "shared-vpc"},
+					Spec:       v1alpha1.SharedVPCSpec{OwnerNamespace: "test-ns-2", ConsumerNamespaces: []string{"test-ns-1"}},
+				},
+			},
+			expectedSharedNamespace: "test-ns-2",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			service, _, _ := createService(t)
 			newScheme := runtime.NewScheme()
 			utilruntime.Must(clientgoscheme.AddToScheme(newScheme))
+			utilruntime.Must(v1alpha1.AddToScheme(newScheme))
 			fakeClient := fake.NewClientBuilder().WithScheme(newScheme).WithObjects().Build()
 			service.Client = fakeClient
 
@@ -304,6 +362,10 @@ func TestGetSharedVPCNamespaceFromNS(t *testing.T) {
 				err := service.Client.Create(context.TODO(), ns)
 				assert.NoError(t, err)
 			}
+			for _, sharedVPC := range tt.existingSharedVPCs {
+				err := service.Client.Create(context.TODO(), sharedVPC)
+				assert.NoError(t, err)
+			}
 
 			ns, sharedNS, _err := service.resolveSharedVPCNamespace(context.Background(), tt.ns)
 
@@ -1333,6 +1395,38 @@ func TestVPCService_GetVPCNetworkConfigByNamespace(t *testing.T) {
 	reflect.DeepEqual(info, got)
 }
 
+func TestVPCService_ValidateAccessModeIPBlocks(t *testing.T) {
+	service, _, _ := createService(t)
+	service.NSXClient.VPCConnectivityProfilesClient = &fakeVPCConnectivityProfilesClient{}
+	vpcInfo := common.VPCResourceInfo{VPCID: "vpc-1", PrivateIPs: []string{"172.26.0.0/16"}}
+
+	err := service.ValidateAccessModeIPBlocks("fake-ns", vpcInfo, v1alpha1.AccessModePrivate)
+	assert.ErrorContains(t, err, "failed to find VPCNetworkConfig for Namespace fake-ns")
+
+	service.RegisterNamespaceNetworkconfigBinding("fake-ns", "fake-name")
+	service.RegisterVPCNetworkConfig("fake-name", common.VPCNetworkConfigInfo{
+		Name:                   "fake-name",
+		Org:                    "default",
+		NSXProject:             "project-1",
+		VPCConnectivityProfile: "/orgs/default/projects/project-1/vpc-connectivity-profiles/default",
+	})
+	assert.NoError(t, service.ValidateAccessModeIPBlocks("fake-ns", vpcInfo, v1alpha1.AccessModePrivate))
+	err = service.ValidateAccessModeIPBlocks("fake-ns", common.VPCResourceInfo{VPCID: "vpc-1"}, v1alpha1.AccessModePrivate)
+	assert.ErrorContains(t, err, "VPC vpc-1 has no private IPs")
+
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(&fakeVPCConnectivityProfilesClient{}), "Get", func(_ *fakeVPCConnectivityProfilesClient, _ string, _ string, _ string) (model.VpcConnectivityProfile, error) {
+		return model.VpcConnectivityProfile{ExternalIpBlocks: []string{"/infra/ip-blocks/external"}}, nil
+	})
+	defer patches.Reset()
+	assert.NoError(t, service.ValidateAccessModeIPBlocks("fake-ns", vpcInfo, v1alpha1.AccessModePublic))
+	err = service.ValidateAccessModeIPBlocks("fake-ns", vpcInfo, v1alpha1.AccessModeProject)
+	assert.ErrorContains(t, err, "has no private TGW IP blocks")
+
+	// The IP blocks of the pre-created VPC are not checked.
+	service.RegisterVPCNetworkConfig("fake-name", common.VPCNetworkConfigInfo{Name: "fake-name", VPCPath: "/orgs/default/projects/project-1/vpcs/vpc-1"})
+	assert.NoError(t, service.ValidateAccessModeIPBlocks("fake-ns", vpcInfo, v1alpha1.AccessModeProject))
+}
+
 func TestVPCService_ValidateNetworkConfig(t *testing.T) {
 	service, _, _ := createService(t)
 
@@ -2210,3 +2304,194 @@ func TestGetNSXLBSNATIP(t *testing.T) {
 		})
 	}
 }
+
+type fakeVpcAttachmentClient struct {
+	attachment model.VpcAttachment
+	patched    *model.VpcAttachment
+}
+
+func (c *fakeVpcAttachmentClient) Delete(_ string, _ string, _ string, _ string) error {
+	return nil
+}
+
+func (c *fakeVpcAttachmentClient) Get(_ string, _ string, _ string, _ string) (model.VpcAttachment, error) {
+	return c.attachment, nil
+}
+
+func (c *fakeVpcAttachmentClient) List(_ string, _ string, _ string, _ *string, _ *bool, _ *string, _ *int64, _ *bool, _ *string) (model.VpcAttachmentListResult, error) {
+	return model.VpcAttachmentListResult{}, nil
+}
+
+func (c *fakeVpcAttachmentClient) Patch(_ string, _ string, _ string, _ string, attachment model.VpcAttachment) error {
+	c.patched = &attachment
+	return nil
+}
+
+func (c *fakeVpcAttachmentClient) Update(_ string, _ string, _ string, _ string, attachment model.VpcAttachment) (model.VpcAttachment, error) {
+	return attachment, nil
+}
+
+func TestUpdateVPCConnectivityProfile(t *testing.T) {
+	oldProfile := "/orgs/default/projects/p1/vpc-connectivity-profiles/old"
+	newProfile := "/orgs/default/projects/p1/vpc-connectivity-profiles/new"
+	nsxVPC := &model.Vpc{Id: common.String("vpc1"), Path: common.String("/orgs/default/projects/p1/vpcs/vpc1")}
+	nc := &common.VPCNetworkConfigInfo{Name: "nc1", Org: "default", NSXProject: "p1", VPCConnectivityProfile: newProfile}
+
+	for _, tc := range []struct {
+		name            string
+		existingProfile string
+		expPatched      bool
+	}{
+		{name: "profile not changed", existingProfile: newProfile, expPatched: false},
+		{name: "profile changed", existingProfile: oldProfile, expPatched: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			attachmentClient := &fakeVpcAttachmentClient{attachment: model.VpcAttachment{
+				Id:                     common.String(common.DefaultVpcAttachmentId),
+				VpcConnectivityProfile: common.String(tc.existingProfile),
+			}}
+			service := &VPCService{Service: common.Service{
+				NSXClient: &nsx.Client{
+					VpcAttachmentClient:    attachmentClient,
+					RealizedEntitiesClient: &fakeRealizedEntitiesClient{},
+				},
+			}}
+			assert.NoError(t, service.UpdateVPCConnectivityProfile(nc, nsxVPC))
+			if !tc.expPatched {
+				assert.Nil(t, attachmentClient.patched)
+				return
+			}
+			assert.Equal(t, newProfile, *attachmentClient.patched.VpcConnectivityProfile)
+		})
+	}
+}
+
+func TestIsStaleVPC(t *testing.T) {
+	nsxVPC := &model.Vpc{Id: common.String("vpc1"), Path: common.String("/orgs/default/projects/p1/vpcs/vpc1")}
+	for _, tc := range []struct {
+		name string
+		nc   common.VPCNetworkConfigInfo
+		exp  bool
+	}{
+		{name: "same project", nc: common.VPCNetworkConfigInfo{Org: "default", NSXProject: "p1"}, exp: false},
+		{name: "other project", nc: common.VPCNetworkConfigInfo{Org: "default", NSXProject: "p2"}, exp: true},
+		{name: "pre-created VPC", nc: common.VPCNetworkConfigInfo{Org: "default", NSXProject: "p1", VPCPath: "/orgs/default/projects/p1/vpcs/pre-vpc"}, exp: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.exp, isStaleVPC(nsxVPC, &tc.nc))
+		})
+	}
+}
+
+func TestIsMigratingVPC(t *testing.T) {
+	migrationCond := func(reason string) []v1.NamespaceCondition {
+		return []v1.NamespaceCondition{{Type: v1.NamespaceConditionType(common.NamespaceConditionVPCMigration), Reason: reason}}
+	}
+	for _, tc := range []struct {
+		name  string
+		nsObj *v1.Namespace
+		exp   bool
+	}{
+		{name: "no migration", nsObj: &v1.Namespace{}, exp: false},
+		{name: "migration target", nsObj: &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{common.AnnotationVPCMigrationTarget: "nc2"}}}, exp: true},
+		{name: "creating VPC", nsObj: &v1.Namespace{Status: v1.NamespaceStatus{Conditions: migrationCond("CreatingVPC")}}, exp: true},
+		{name: "moving Subnets", nsObj: &v1.Namespace{Status: v1.NamespaceStatus{Conditions: migrationCond("MovingSubnets")}}, exp: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.exp, isMigratingVPC(tc.nsObj))
+		})
+	}
+}
+
+func TestDeleteStaleVPCs(t *testing.T) {
+	service, _, _ := createService(t)
+	service.VpcStore = &VPCStore{ResourceStore: common.ResourceStore{
+		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
+			common.TagScopeNamespaceUID: vpcIndexNamespaceIDFunc,
+			common.TagScopeNamespace:    vpcIndexNamespaceNameFunc,
+		}),
+		BindingType: model.VpcBindingType(),
+	}}
+	nsObj := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1", UID: "ns1-uid"}}
+	tags := []model.Tag{
+		{Scope: common.String(common.TagScopeNamespace), Tag: common.String("ns1")},
+		{Scope: common.String(common.TagScopeNamespaceUID), Tag: common.String("ns1-uid")},
+	}
+	oldVPC := &model.Vpc{Id: common.String("vpc1"), Path: common.String("/orgs/default/projects/p1/vpcs/vpc1"), Tags: tags}
+	assert.NoError(t, service.VpcStore.Apply(oldVPC))
+
+	var deleted []string
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(service.NSXClient.VPCClient), "Delete", func(_ *mocks.MockVpcsClient, orgId string, projectId string, vpcId string, isRecursive *bool) error {
+		deleted = append(deleted, vpcId)
+		return nil
+	})
+	defer patches.Reset()
+
+	// The VPC is kept if it is in the project of the network config.
+	assert.NoError(t, service.deleteStaleVPCs(nsObj, &common.VPCNetworkConfigInfo{Org: "default", NSXProject: "p1"}))
+	assert.Empty(t, deleted)
+	assert.Len(t, service.GetVPCsByNamespace("ns1"), 1)
+
+	// The VPC is deleted after the Namespace is moved to a network config in another project.
+	nc := &common.VPCNetworkConfigInfo{Org: "default", NSXProject: "p2"}
+	assert.NoError(t, service.deleteStaleVPCs(nsObj, nc))
+	assert.Equal(t, []string{"vpc1"}, deleted)
+	assert.Empty(t, service.GetVPCsByNamespace("ns1"))
+}
+
+type fakeSubnetsClient struct {
+	vpcs.SubnetsClient
+}
+
+func (c *fakeSubnetsClient) List(_ string, _ string, _ string, cursor *string, _ *bool, _ *string, _ *int64, _ *bool, _ *string) (model.VpcSubnetListResult, error) {
+	if cursor == nil {
+		return model.VpcSubnetListResult{
+			Results: []model.VpcSubnet{{IpAddresses: []string{"10.0.0.0/28"}}},
+			Cursor:  common.String("1"),
+		}, nil
+	}
+	return model.VpcSubnetListResult{Results: []model.VpcSubnet{{IpAddresses: []string{"10.0.1.0/28"}}}}, nil
+}
+
+type fakeIPAddressAllocationClient struct {
+	vpcs.IpAddressAllocationsClient
+	err error
+}
+
+func (c *fakeIPAddressAllocationClient) List(_ string, _ string, _ string, _ *string, _ *bool, _ *string, _ *int64, _ *bool, _ *string) (model.VpcIpAddressAllocationListResult, error) {
+	return model.VpcIpAddressAllocationListResult{Results: []model.VpcIpAddressAllocation{
+		{AllocationIps: common.String("10.0.2.5"), IpAddressBlockVisibility: common.String(model.VpcIpAddressAllocation_IP_ADDRESS_BLOCK_VISIBILITY_PRIVATE)},
+		{AllocationIps: common.String("192.168.0.5"), IpAddressBlockVisibility: common.String(model.VpcIpAddressAllocation_IP_ADDRESS_BLOCK_VISIBILITY_EXTERNAL)},
+	}}, c.err
+}
+
+func TestListVPCAddressesInUse(t *testing.T) {
+	service, _, _ := createService(t)
+	service.VpcStore = &VPCStore{ResourceStore: common.ResourceStore{
+		Indexer: cache.NewIndexer(keyFunc, cache.Indexers{
+			common.TagScopeNamespaceUID: vpcIndexNamespaceIDFunc,
+			common.TagScopeNamespace:    vpcIndexNamespaceNameFunc,
+		}),
+		BindingType: model.VpcBindingType(),
+	}}
+	assert.NoError(t, service.VpcStore.Apply(&model.Vpc{
+		Id:   common.String("vpc1"),
+		Path: common.String("/orgs/default/projects/p1/vpcs/vpc1"),
+		Tags: []model.Tag{{Scope: common.String(common.TagScopeNamespace), Tag: common.String("ns1")}},
+	}))
+	allocationClient := &fakeIPAddressAllocationClient{}
+	service.NSXClient.SubnetsClient = &fakeSubnetsClient{}
+	service.NSXClient.IPAddressAllocationClient = allocationClient
+
+	addresses, err := service.ListVPCAddressesInUse("ns1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/28", "10.0.1.0/28", "10.0.2.5"}, addresses)
+
+	addresses, err = service.ListVPCAddressesInUse("ns2")
+	require.NoError(t, err)
+	assert.Empty(t, addresses)
+
+	allocationClient.err = errors.New("NSX is not available")
+	_, err = service.ListVPCAddressesInUse("ns1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package vpcpeering
+
+import (
+	"fmt"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/realizestate"
+	"github.com/vmware-tanzu/nsx-operator/pkg/util"
+)
+
+// buildStaticRoute builds the static route in the VPC of the peer Namespace to reach the network of the other
+// peer through the transit gateway. The ID is unique per peer Namespace and network, so that the routes of
+// both peers can be kept in the same store.
+func (service *VPCPeeringService) buildStaticRoute(obj *v1alpha1.VPCPeering, namespace string, network string, nextHop string) *model.StaticRoutes {
+	return service.buildRoute(obj, namespace+"/"+network, namespace, network, nextHop)
+}
+
+// buildTransitGatewayStaticRoute builds the static route in the transit gateway to reach the network of the
+// peer Namespace through the VPC attachment of the peer.
+func (service *VPCPeeringService) buildTransitGatewayStaticRoute(obj *v1alpha1.VPCPeering, namespace string, network string, nextHop string) *model.StaticRoutes {
+	return service.buildRoute(obj, "transit-gateway/"+namespace+"/"+network, namespace, network, nextHop)
+}
+
+func (service *VPCPeeringService) buildRoute(obj *v1alpha1.VPCPeering, key string, namespace string, network string, nextHop string) *model.StaticRoutes {
+	suffix := util.Sha1(key)[:common.HashLength]
+	tags := service.buildBasicTags(obj)
+	tags = append(tags, model.Tag{Scope: String(common.TagScopeNamespace), Tag: String(namespace)})
+	return &model.StaticRoutes{
+		Id:          String(util.GenerateIDByObjectWithSuffix(obj, suffix)),
+		DisplayName: String(util.GenerateTruncName(common.MaxNameLength, obj.Name, "", suffix, "", "")),
+		Network:     String(network),
+		NextHops: []model.RouterNexthop{{
+			AdminDistance: common.Int64(1),
+			IpAddress:     String(nextHop),
+		}},
+		Tags: tags,
+	}
+}
+
+func (service *VPCPeeringService) buildBasicTags(obj *v1alpha1.VPCPeering) []model.Tag {
+	return util.BuildBasicTags(service.Service.NSXConfig.Cluster, obj, "")
+}
+
+// getTransitNextHop returns the IP address of the transit gateway on the VPC attachment, which is the next hop
+// of the VPC to reach the other VPCs attached to the transit gateway. The IP address is allocated by NSX, so it
+// is read from the router port realized for the attachment on the transit gateway.
+func (service *VPCPeeringService) getTransitNextHop(attachment *model.VpcAttachment, transitGatewayPath string) (string, error) {
+	return service.getAttachmentIP(attachment, transitGatewayPath, "transit gateway")
+}
+
+// getVPCNextHop returns the IP address of the VPC on the VPC attachment, which is the next hop of the transit
+// gateway to reach the networks of the VPC. It is read from the router port realized for the attachment on
+// the VPC.
+func (service *VPCPeeringService) getVPCNextHop(attachment *model.VpcAttachment, vpcPath string) (string, error) {
+	return service.getAttachmentIP(attachment, vpcPath, "VPC")
+}
+
+func (service *VPCPeeringService) getAttachmentIP(attachment *model.VpcAttachment, gatewayPath string, side string) (string, error) {
+	if attachment.Path == nil {
+		return "", fmt.Errorf("VPC attachment %s has no path", *attachment.Id)
+	}
+	ip, err := realizestate.InitializeRealizeState(service.Service).GetGatewayRouterPortIP(*attachment.Path, gatewayPath)
+	if err != nil {
+		return "", err
+	}
+	if ip == "" {
+		return "", fmt.Errorf("%s IP of VPC attachment %s is not realized", side, *attachment.Path)
+	}
+	return ip, nil
+}
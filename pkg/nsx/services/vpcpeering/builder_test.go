@@ -0,0 +1,68 @@
+package vpcpeering
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+func TestBuildStaticRoute(t *testing.T) {
+	service := createService()
+	obj := &v1alpha1.VPCPeering{ObjectMeta: metav1.ObjectMeta{Name: "peering-1", UID: "uid-1"}}
+
+	staticRoute := service.buildStaticRoute(obj, "ns-1", "10.0.2.0/24", "100.64.0.1")
+	assert.Equal(t, "peering-1_uid-1_e409b200", *staticRoute.Id)
+	assert.Equal(t, "peering-1_e409b200", *staticRoute.DisplayName)
+	assert.Equal(t, "10.0.2.0/24", *staticRoute.Network)
+	assert.Equal(t, 1, len(staticRoute.NextHops))
+	assert.Equal(t, "100.64.0.1", *staticRoute.NextHops[0].IpAddress)
+	assert.Equal(t, int64(1), *staticRoute.NextHops[0].AdminDistance)
+	assert.Equal(t, "ns-1", nsxutil.FindTag(staticRoute.Tags, common.TagScopeNamespace))
+	assert.Equal(t, "peering-1", nsxutil.FindTag(staticRoute.Tags, common.TagScopeVPCPeeringCRName))
+	assert.Equal(t, "uid-1", nsxutil.FindTag(staticRoute.Tags, common.TagScopeVPCPeeringCRUID))
+
+	// The same network routed in the VPC of the other peer has a different ID.
+	assert.NotEqual(t, *staticRoute.Id, *service.buildStaticRoute(obj, "ns-2", "10.0.2.0/24", "100.64.0.1").Id)
+}
+
+func TestBuildTransitGatewayStaticRoute(t *testing.T) {
+	service := createService()
+	obj := &v1alpha1.VPCPeering{ObjectMeta: metav1.ObjectMeta{Name: "peering-1", UID: "uid-1"}}
+
+	staticRoute := service.buildTransitGatewayStaticRoute(obj, "ns-1", "10.0.2.0/24", "100.64.0.0")
+	assert.Equal(t, "10.0.2.0/24", *staticRoute.Network)
+	assert.Equal(t, "100.64.0.0", *staticRoute.NextHops[0].IpAddress)
+	assert.Equal(t, "ns-1", nsxutil.FindTag(staticRoute.Tags, common.TagScopeNamespace))
+	assert.Equal(t, "uid-1", nsxutil.FindTag(staticRoute.Tags, common.TagScopeVPCPeeringCRUID))
+
+	// The route in the transit gateway doesn't conflict with the route of the same network in the VPC.
+	assert.NotEqual(t, *staticRoute.Id, *service.buildStaticRoute(obj, "ns-1", "10.0.2.0/24", "100.64.0.1").Id)
+}
+
+func TestGetTransitNextHop(t *testing.T) {
+	service := createService()
+	tgwPath := "/orgs/default/projects/project-1/transit-gateways/tgw-default"
+	// Both router ports of the attachment are realized, the VPC side is listed first.
+	attachment := &model.VpcAttachment{Id: String("default"), Path: String("/orgs/default/projects/project-1/vpcs/vpc-2/attachments/default")}
+	nextHop, err := service.getTransitNextHop(attachment, tgwPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "100.64.0.3", nextHop)
+	nextHop, err = service.getVPCNextHop(attachment, "/orgs/default/projects/project-1/vpcs/vpc-2")
+	assert.Nil(t, err)
+	assert.Equal(t, "100.64.0.2", nextHop)
+
+	_, err = service.getTransitNextHop(&model.VpcAttachment{Id: String("default"), Path: String("/orgs/default/projects/project-1/vpcs/vpc-6/attachments/default")}, tgwPath)
+	assert.ErrorContains(t, err, "transit gateway IP of VPC attachment /orgs/default/projects/project-1/vpcs/vpc-6/attachments/default is not realized")
+
+	_, err = service.getVPCNextHop(&model.VpcAttachment{Id: String("default"), Path: String("/orgs/default/projects/project-1/vpcs/vpc-7/attachments/default")}, "/orgs/default/projects/project-1/vpcs/vpc-7")
+	assert.ErrorContains(t, err, "VPC IP of VPC attachment /orgs/default/projects/project-1/vpcs/vpc-7/attachments/default is not realized")
+
+	_, err = service.getTransitNextHop(&model.VpcAttachment{Id: String("default")}, tgwPath)
+	assert.ErrorContains(t, err, "VPC attachment default has no path")
+}
@@ -0,0 +1,26 @@
+package vpcpeering
+
+import (
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func stringEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// compareStaticRoute returns true if the static route built from the CR doesn't change the existing NSX static route
+func (service *VPCPeeringService) compareStaticRoute(existingStaticRoute *model.StaticRoutes, nsxStaticRoute *model.StaticRoutes) bool {
+	if !stringEqual(existingStaticRoute.Network, nsxStaticRoute.Network) ||
+		len(existingStaticRoute.NextHops) != len(nsxStaticRoute.NextHops) {
+		return false
+	}
+	for i := range nsxStaticRoute.NextHops {
+		if !stringEqual(existingStaticRoute.NextHops[i].IpAddress, nsxStaticRoute.NextHops[i].IpAddress) {
+			return false
+		}
+	}
+	return true
+}
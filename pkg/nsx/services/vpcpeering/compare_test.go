@@ -0,0 +1,34 @@
+package vpcpeering
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+)
+
+func TestCompareStaticRoute(t *testing.T) {
+	service := createService()
+	existingStaticRoute := &model.StaticRoutes{
+		Network:  String("10.0.2.0/24"),
+		NextHops: []model.RouterNexthop{{AdminDistance: common.Int64(1), IpAddress: String("100.64.0.1")}},
+		Path:     String("/orgs/default/projects/project-1/vpcs/vpc-1/static-routes/route-1"),
+	}
+	nsxStaticRoute := &model.StaticRoutes{
+		Network:  String("10.0.2.0/24"),
+		NextHops: []model.RouterNexthop{{AdminDistance: common.Int64(1), IpAddress: String("100.64.0.1")}},
+	}
+	assert.True(t, service.compareStaticRoute(existingStaticRoute, nsxStaticRoute))
+
+	nsxStaticRoute.NextHops[0].IpAddress = String("100.64.0.2")
+	assert.False(t, service.compareStaticRoute(existingStaticRoute, nsxStaticRoute))
+
+	nsxStaticRoute.NextHops = nil
+	assert.False(t, service.compareStaticRoute(existingStaticRoute, nsxStaticRoute))
+
+	nsxStaticRoute.NextHops = existingStaticRoute.NextHops
+	nsxStaticRoute.Network = String("10.0.3.0/24")
+	assert.False(t, service.compareStaticRoute(existingStaticRoute, nsxStaticRoute))
+}
@@ -0,0 +1,72 @@
+package vpcpeering
+
+import (
+	"errors"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+// VPCPeeringStore is a store for the static routes realizing the VPCPeerings
+type VPCPeeringStore struct {
+	common.ResourceStore
+}
+
+// keyFunc is used to get the key of a resource, usually, which is the ID of the resource
+func keyFunc(obj interface{}) (string, error) {
+	switch v := obj.(type) {
+	case *model.StaticRoutes:
+		return *v.Id, nil
+	default:
+		return "", errors.New("keyFunc doesn't support unknown type")
+	}
+}
+
+// indexFunc is used to get index of a resource, which is the UID of the VPCPeering CR
+func indexFunc(obj interface{}) ([]string, error) {
+	switch v := obj.(type) {
+	case *model.StaticRoutes:
+		return filterTag(v.Tags, common.TagScopeVPCPeeringCRUID), nil
+	default:
+		return nil, errors.New("indexFunc doesn't support unknown type")
+	}
+}
+
+func indexVPCPeeringName(obj interface{}) ([]string, error) {
+	switch v := obj.(type) {
+	case *model.StaticRoutes:
+		return filterTag(v.Tags, common.TagScopeVPCPeeringCRName), nil
+	default:
+		return nil, errors.New("indexVPCPeeringName doesn't support unknown type")
+	}
+}
+
+func filterTag(tags []model.Tag, tagScope string) []string {
+	if value := nsxutil.FindTag(tags, tagScope); value != "" {
+		return []string{value}
+	}
+	return []string{}
+}
+
+func (vpcPeeringStore *VPCPeeringStore) Apply(i interface{}) error {
+	// not used by VPC peering since static route doesn't use hierarchy API
+	return nil
+}
+
+func (vpcPeeringStore *VPCPeeringStore) GetByKey(key string) *model.StaticRoutes {
+	obj := vpcPeeringStore.ResourceStore.GetByKey(key)
+	if obj != nil {
+		return obj.(*model.StaticRoutes)
+	}
+	return nil
+}
+
+func (vpcPeeringStore *VPCPeeringStore) GetByIndex(index string, value string) []*model.StaticRoutes {
+	var staticRoutes []*model.StaticRoutes
+	for _, obj := range vpcPeeringStore.ResourceStore.GetByIndex(index, value) {
+		staticRoutes = append(staticRoutes, obj.(*model.StaticRoutes))
+	}
+	return staticRoutes
+}
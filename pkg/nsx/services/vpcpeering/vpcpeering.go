@@ -0,0 +1,412 @@
+package vpcpeering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/logger"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+// VPCPeeringService realizes the VPCPeering CRs with the NSX static routes in the VPCs of both peers, which
+// route the exchanged networks of the other peer through the transit gateway both VPCs are attached to, and
+// the NSX static routes in the transit gateway, which route the exchanged networks of each peer through the
+// VPC attachment of the peer.
+type VPCPeeringService struct {
+	common.Service
+	VPCPeeringStore *VPCPeeringStore
+	VPCService      common.VPCServiceProvider
+}
+
+var (
+	log    = &logger.Log
+	String = common.String
+
+	errNoVPC = errors.New("no vpc found")
+
+	transitGatewayPathRegex = regexp.MustCompile(`^/orgs/([^/]+)/projects/([^/]+)/transit-gateways/([^/]+)`)
+)
+
+// vpcPeer is a peer of the VPCPeering resolved from the NSX VPC of the Namespace.
+type vpcPeer struct {
+	namespace      string
+	vpcInfo        common.VPCResourceInfo
+	vpcPath        string
+	transitGateway *model.TransitGateway
+	// nextHop is the IP address of the transit gateway on the VPC attachment.
+	nextHop string
+	// vpcNextHop is the IP address of the VPC on the VPC attachment.
+	vpcNextHop string
+}
+
+// InitializeVPCPeering sync NSX resources
+func InitializeVPCPeering(commonService common.Service, vpcService common.VPCServiceProvider) (*VPCPeeringService, error) {
+	wg := sync.WaitGroup{}
+	wgDone := make(chan bool)
+	fatalErrors := make(chan error)
+
+	wg.Add(1)
+	vpcPeeringService := &VPCPeeringService{Service: commonService}
+	vpcPeeringStore := &VPCPeeringStore{}
+	vpcPeeringStore.Indexer = cache.NewIndexer(keyFunc, cache.Indexers{
+		common.TagScopeVPCPeeringCRUID:  indexFunc,
+		common.TagScopeVPCPeeringCRName: indexVPCPeeringName,
+	})
+	vpcPeeringStore.BindingType = model.StaticRoutesBindingType()
+	vpcPeeringService.VPCPeeringStore = vpcPeeringStore
+	vpcPeeringService.NSXConfig = commonService.NSXConfig
+	vpcPeeringService.VPCService = vpcService
+
+	// Only the static routes created for the VPCPeering CRs are synced.
+	tags := []model.Tag{{Scope: String(common.TagScopeVPCPeeringCRUID)}}
+	go vpcPeeringService.InitializeResourceStore(&wg, fatalErrors, common.ResourceTypeStaticRoute, tags, vpcPeeringService.VPCPeeringStore)
+
+	go func() {
+		wg.Wait()
+		close(wgDone)
+	}()
+
+	select {
+	case <-wgDone:
+		break
+	case err := <-fatalErrors:
+		close(fatalErrors)
+		return vpcPeeringService, err
+	}
+
+	return vpcPeeringService, nil
+}
+
+// CreateOrUpdateVPCPeering creates or updates the NSX static routes of the VPCPeering CR. networks holds the
+// exchanged networks of each peer in the order of the peers in the spec, which are resolved from the CR by
+// the caller. The VPC of each peer gets a static route for every network of the other peer, the transit
+// gateway gets a static route for every network of both peers, and the stale static routes of the CR are
+// deleted. The realized state of both peers is returned.
+func (service *VPCPeeringService) CreateOrUpdateVPCPeering(obj *v1alpha1.VPCPeering, networks [][]string) ([]v1alpha1.VPCPeeringPeerStatus, error) {
+	if len(obj.Spec.Peers) != 2 || len(networks) != 2 {
+		return nil, fmt.Errorf("VPCPeering %s must have exactly two peers", obj.Name)
+	}
+	peers := make([]*vpcPeer, 2)
+	for i := range obj.Spec.Peers {
+		peer, err := service.resolvePeer(obj.Spec.Peers[i].Namespace)
+		if err != nil {
+			// The peering is broken once the VPC of a peer is gone, e.g. the Namespace is deleted, so the
+			// routes of the CR in the VPC of the remaining peer are withdrawn.
+			if errors.Is(err, errNoVPC) {
+				if deleteErr := service.deleteStaticRoutesOutOfNamespace(obj, obj.Spec.Peers[i].Namespace); deleteErr != nil {
+					return nil, deleteErr
+				}
+			}
+			return nil, err
+		}
+		peers[i] = peer
+	}
+	if peers[0].vpcPath == peers[1].vpcPath {
+		return nil, fmt.Errorf("Namespaces %s and %s use the same VPC %s", peers[0].namespace, peers[1].namespace, peers[0].vpcPath)
+	}
+	if *peers[0].transitGateway.Path != *peers[1].transitGateway.Path {
+		return nil, fmt.Errorf("VPC %s and VPC %s are not attached to the same transit gateway", peers[0].vpcPath, peers[1].vpcPath)
+	}
+
+	statuses := make([]v1alpha1.VPCPeeringPeerStatus, 2)
+	desiredIDs := sets.New[string]()
+	for i, peer := range peers {
+		statuses[i] = v1alpha1.VPCPeeringPeerStatus{
+			Namespace:          peer.namespace,
+			VPCPath:            peer.vpcPath,
+			TransitGatewayPath: *peer.transitGateway.Path,
+		}
+		// The VPC of the peer routes the networks of the other peer.
+		for _, network := range networks[1-i] {
+			nsxStaticRoute := service.buildStaticRoute(obj, peer.namespace, network, peer.nextHop)
+			desiredIDs.Insert(*nsxStaticRoute.Id)
+			staticRoute, err := service.createOrUpdateStaticRoute(peer, nsxStaticRoute)
+			if err != nil {
+				return nil, err
+			}
+			statuses[i].Routes = append(statuses[i].Routes, v1alpha1.VPCPeeringRoute{
+				Network: *staticRoute.Network,
+				NextHop: peer.nextHop,
+				Path:    *staticRoute.Path,
+			})
+		}
+		// The transit gateway routes the networks of the peer to the VPC attachment of the peer.
+		for _, network := range networks[i] {
+			nsxStaticRoute := service.buildTransitGatewayStaticRoute(obj, peer.namespace, network, peer.vpcNextHop)
+			desiredIDs.Insert(*nsxStaticRoute.Id)
+			staticRoute, err := service.createOrUpdateTransitGatewayStaticRoute(peer.transitGateway, nsxStaticRoute)
+			if err != nil {
+				return nil, err
+			}
+			statuses[i].TransitGatewayRoutes = append(statuses[i].TransitGatewayRoutes, v1alpha1.VPCPeeringRoute{
+				Network: *staticRoute.Network,
+				NextHop: peer.vpcNextHop,
+				Path:    *staticRoute.Path,
+			})
+		}
+	}
+
+	for _, staticRoute := range service.VPCPeeringStore.GetByIndex(common.TagScopeVPCPeeringCRUID, string(obj.UID)) {
+		if desiredIDs.Has(*staticRoute.Id) {
+			continue
+		}
+		if err := service.DeleteStaticRoute(staticRoute); err != nil {
+			return nil, err
+		}
+	}
+	return statuses, nil
+}
+
+func (service *VPCPeeringService) createOrUpdateStaticRoute(peer *vpcPeer, nsxStaticRoute *model.StaticRoutes) (*model.StaticRoutes, error) {
+	existingStaticRoute := service.VPCPeeringStore.GetByKey(*nsxStaticRoute.Id)
+	if existingStaticRoute != nil && service.compareStaticRoute(existingStaticRoute, nsxStaticRoute) {
+		return existingStaticRoute, nil
+	}
+	vpcInfo := peer.vpcInfo
+	err := service.NSXClient.StaticRouteClient.Patch(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.ID, *nsxStaticRoute.Id, *nsxStaticRoute)
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		return nil, err
+	}
+	staticRoute, err := service.NSXClient.StaticRouteClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.ID, *nsxStaticRoute.Id)
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		return nil, err
+	}
+	if err = service.VPCPeeringStore.Add(&staticRoute); err != nil {
+		return nil, err
+	}
+	log.Info("Successfully created or updated NSX static route for VPC peering", "nsxStaticRoute", *staticRoute.Path)
+	return &staticRoute, nil
+}
+
+func (service *VPCPeeringService) createOrUpdateTransitGatewayStaticRoute(transitGateway *model.TransitGateway, nsxStaticRoute *model.StaticRoutes) (*model.StaticRoutes, error) {
+	existingStaticRoute := service.VPCPeeringStore.GetByKey(*nsxStaticRoute.Id)
+	if existingStaticRoute != nil && service.compareStaticRoute(existingStaticRoute, nsxStaticRoute) {
+		return existingStaticRoute, nil
+	}
+	orgID, projectID, transitGatewayID, err := parseTransitGatewayPath(*transitGateway.Path)
+	if err != nil {
+		return nil, err
+	}
+	err = service.NSXClient.TransitGatewayStaticRouteClient.Patch(orgID, projectID, transitGatewayID, *nsxStaticRoute.Id, *nsxStaticRoute)
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		return nil, err
+	}
+	// The path is not returned by the transit gateway static route client, it is generated as NSX does.
+	nsxStaticRoute.Path = String(fmt.Sprintf("%s/static-routes/%s", *transitGateway.Path, *nsxStaticRoute.Id))
+	if err = service.VPCPeeringStore.Add(nsxStaticRoute); err != nil {
+		return nil, err
+	}
+	log.Info("Successfully created or updated NSX transit gateway static route for VPC peering", "nsxStaticRoute", *nsxStaticRoute.Path)
+	return nsxStaticRoute, nil
+}
+
+// resolvePeer resolves the VPC of the Namespace and the transit gateway the VPC is attached to.
+func (service *VPCPeeringService) resolvePeer(namespace string) (*vpcPeer, error) {
+	vpc := service.VPCService.ListVPCInfo(namespace)
+	if len(vpc) == 0 {
+		return nil, fmt.Errorf("%w for ns %s", errNoVPC, namespace)
+	}
+	attachment, err := service.getVPCAttachment(vpc[0])
+	if err != nil {
+		return nil, err
+	}
+	transitGateway, err := service.getAttachedTransitGateway(vpc[0], attachment)
+	if err != nil {
+		return nil, err
+	}
+	nextHop, err := service.getTransitNextHop(attachment, *transitGateway.Path)
+	if err != nil {
+		return nil, err
+	}
+	vpcPath := fmt.Sprintf("/orgs/%s/projects/%s/vpcs/%s", vpc[0].OrgID, vpc[0].ProjectID, vpc[0].ID)
+	vpcNextHop, err := service.getVPCNextHop(attachment, vpcPath)
+	if err != nil {
+		return nil, err
+	}
+	return &vpcPeer{
+		namespace:      namespace,
+		vpcInfo:        vpc[0],
+		vpcPath:        vpcPath,
+		transitGateway: transitGateway,
+		nextHop:        nextHop,
+		vpcNextHop:     vpcNextHop,
+	}, nil
+}
+
+// GetTransitGateway returns the transit gateway of the VPC connectivity profile used by the VPC attachment.
+func (service *VPCPeeringService) GetTransitGateway(vpcInfo common.VPCResourceInfo) (*model.TransitGateway, error) {
+	attachment, err := service.getVPCAttachment(vpcInfo)
+	if err != nil {
+		return nil, err
+	}
+	return service.getAttachedTransitGateway(vpcInfo, attachment)
+}
+
+func (service *VPCPeeringService) getVPCAttachment(vpcInfo common.VPCResourceInfo) (*model.VpcAttachment, error) {
+	// pre created VPC may have more than one attachment, list all the attachment and select the first one
+	attachments, err := service.NSXClient.VpcAttachmentClient.List(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.ID, nil, nil, nil, nil, nil, nil)
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments.Results) == 0 || attachments.Results[0].VpcConnectivityProfile == nil {
+		return nil, fmt.Errorf("no VPC attachment found for VPC %s", vpcInfo.ID)
+	}
+	return &attachments.Results[0], nil
+}
+
+func (service *VPCPeeringService) getAttachedTransitGateway(vpcInfo common.VPCResourceInfo, attachment *model.VpcAttachment) (*model.TransitGateway, error) {
+	profile, err := service.NSXClient.VPCConnectivityProfilesClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, lastPathSegment(*attachment.VpcConnectivityProfile))
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		return nil, err
+	}
+	if profile.TransitGatewayPath == nil {
+		return nil, fmt.Errorf("VPC connectivity profile %s has no transit gateway", *attachment.VpcConnectivityProfile)
+	}
+	transitGateway, err := service.NSXClient.TransitGatewayClient.Get(vpcInfo.OrgID, vpcInfo.ProjectID, lastPathSegment(*profile.TransitGatewayPath))
+	err = nsxutil.TransNSXApiError(err)
+	if err != nil {
+		return nil, err
+	}
+	if transitGateway.Path == nil {
+		transitGateway.Path = profile.TransitGatewayPath
+	}
+	return &transitGateway, nil
+}
+
+func lastPathSegment(path string) string {
+	parts := strings.Split(path, "/")
+	return parts[len(parts)-1]
+}
+
+// parseTransitGatewayPath returns the org, project and transit gateway ID of the transit gateway path or the
+// path of a transit gateway child resource.
+func parseTransitGatewayPath(path string) (string, string, string, error) {
+	matches := transitGatewayPathRegex.FindStringSubmatch(path)
+	if len(matches) != 4 {
+		return "", "", "", fmt.Errorf("invalid transit gateway path '%s'", path)
+	}
+	return matches[1], matches[2], matches[3], nil
+}
+
+func isTransitGatewayStaticRoute(nsxStaticRoute *model.StaticRoutes) bool {
+	return nsxStaticRoute.Path != nil && transitGatewayPathRegex.MatchString(*nsxStaticRoute.Path)
+}
+
+func (service *VPCPeeringService) DeleteStaticRoute(nsxStaticRoute *model.StaticRoutes) error {
+	if isTransitGatewayStaticRoute(nsxStaticRoute) {
+		return service.deleteTransitGatewayStaticRoute(nsxStaticRoute)
+	}
+	vpcInfo, err := common.ParseVPCResourcePath(*nsxStaticRoute.Path)
+	if err != nil {
+		log.Error(err, "Failed to parse NSX VPC path for static route", "path", *nsxStaticRoute.Path)
+		return err
+	}
+	if err := service.NSXClient.StaticRouteClient.Delete(vpcInfo.OrgID, vpcInfo.ProjectID, vpcInfo.VPCID, *nsxStaticRoute.Id); err != nil {
+		err = nsxutil.TransNSXApiError(err)
+		return err
+	}
+	if err := service.VPCPeeringStore.Delete(nsxStaticRoute); err != nil {
+		return err
+	}
+
+	log.Info("Successfully deleted NSX static route for VPC peering", "nsxStaticRoute", *nsxStaticRoute.Id)
+	return nil
+}
+
+func (service *VPCPeeringService) deleteTransitGatewayStaticRoute(nsxStaticRoute *model.StaticRoutes) error {
+	orgID, projectID, transitGatewayID, err := parseTransitGatewayPath(*nsxStaticRoute.Path)
+	if err != nil {
+		log.Error(err, "Failed to parse NSX transit gateway path for static route", "path", *nsxStaticRoute.Path)
+		return err
+	}
+	if err := service.NSXClient.TransitGatewayStaticRouteClient.Delete(orgID, projectID, transitGatewayID, *nsxStaticRoute.Id); err != nil {
+		err = nsxutil.TransNSXApiError(err)
+		return err
+	}
+	if err := service.VPCPeeringStore.Delete(nsxStaticRoute); err != nil {
+		return err
+	}
+
+	log.Info("Successfully deleted NSX transit gateway static route for VPC peering", "nsxStaticRoute", *nsxStaticRoute.Id)
+	return nil
+}
+
+// deleteStaticRoutesOutOfNamespace deletes the static routes of the CR in the VPCs of the peers other than the
+// Namespace, and all the static routes of the CR in the transit gateway.
+func (service *VPCPeeringService) deleteStaticRoutesOutOfNamespace(obj *v1alpha1.VPCPeering, namespace string) error {
+	for _, staticRoute := range service.VPCPeeringStore.GetByIndex(common.TagScopeVPCPeeringCRUID, string(obj.UID)) {
+		if !isTransitGatewayStaticRoute(staticRoute) && nsxutil.FindTag(staticRoute.Tags, common.TagScopeNamespace) == namespace {
+			continue
+		}
+		if err := service.DeleteStaticRoute(staticRoute); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (service *VPCPeeringService) DeleteVPCPeeringByCR(obj *v1alpha1.VPCPeering) error {
+	for _, staticRoute := range service.VPCPeeringStore.GetByIndex(common.TagScopeVPCPeeringCRUID, string(obj.UID)) {
+		if err := service.DeleteStaticRoute(staticRoute); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (service *VPCPeeringService) GetUID(staticRoute *model.StaticRoutes) *string {
+	if staticRoute == nil {
+		return nil
+	}
+	for _, tag := range staticRoute.Tags {
+		if *tag.Scope == common.TagScopeVPCPeeringCRUID {
+			return tag.Tag
+		}
+	}
+	return nil
+}
+
+func (service *VPCPeeringService) ListStaticRouteByName(name string) []*model.StaticRoutes {
+	return service.VPCPeeringStore.GetByIndex(common.TagScopeVPCPeeringCRName, name)
+}
+
+func (service *VPCPeeringService) ListStaticRoute() []*model.StaticRoutes {
+	staticRouteSet := []*model.StaticRoutes{}
+	for _, staticRoute := range service.VPCPeeringStore.List() {
+		staticRouteSet = append(staticRouteSet, staticRoute.(*model.StaticRoutes))
+	}
+	return staticRouteSet
+}
+
+func (service *VPCPeeringService) Cleanup(ctx context.Context) error {
+	staticRouteSet := service.ListStaticRoute()
+	log.Info("Cleanup VPC peering static route", "count", len(staticRouteSet))
+	for _, staticRoute := range staticRouteSet {
+		log.Info("Deleting VPC peering static route", "static route path", *staticRoute.Path)
+		select {
+		case <-ctx.Done():
+			return errors.Join(nsxutil.TimeoutFailed, ctx.Err())
+		default:
+			if err := service.DeleteStaticRoute(staticRoute); err != nil {
+				log.Error(err, "Delete VPC peering static route failed", "static route id", *staticRoute.Id)
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,337 @@
+package vpcpeering
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/orgs/projects"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/orgs/projects/vpcs"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	"github.com/vmware-tanzu/nsx-operator/pkg/config"
+	"github.com/vmware-tanzu/nsx-operator/pkg/mock"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx"
+	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
+	nsxutil "github.com/vmware-tanzu/nsx-operator/pkg/nsx/util"
+)
+
+type fakeStaticRoutesClient struct {
+	vpcs.StaticRoutesClient
+	// staticRoutes is keyed by the static route path.
+	staticRoutes map[string]model.StaticRoutes
+	patched      int
+}
+
+func staticRoutePath(orgIdParam string, projectIdParam string, vpcIdParam string, routeIdParam string) string {
+	return "/orgs/" + orgIdParam + "/projects/" + projectIdParam + "/vpcs/" + vpcIdParam + "/static-routes/" + routeIdParam
+}
+
+func (c *fakeStaticRoutesClient) Delete(orgIdParam string, projectIdParam string, vpcIdParam string, routeIdParam string) error {
+	delete(c.staticRoutes, staticRoutePath(orgIdParam, projectIdParam, vpcIdParam, routeIdParam))
+	return nil
+}
+
+func (c *fakeStaticRoutesClient) Get(orgIdParam string, projectIdParam string, vpcIdParam string, routeIdParam string) (model.StaticRoutes, error) {
+	return c.staticRoutes[staticRoutePath(orgIdParam, projectIdParam, vpcIdParam, routeIdParam)], nil
+}
+
+func (c *fakeStaticRoutesClient) Patch(orgIdParam string, projectIdParam string, vpcIdParam string, routeIdParam string, staticRoutesParam model.StaticRoutes) error {
+	c.patched++
+	staticRoutesParam.Path = String(staticRoutePath(orgIdParam, projectIdParam, vpcIdParam, routeIdParam))
+	c.staticRoutes[*staticRoutesParam.Path] = staticRoutesParam
+	return nil
+}
+
+type fakeTransitGatewayStaticRoutesClient struct {
+	// staticRoutes is keyed by the static route path.
+	staticRoutes map[string]model.StaticRoutes
+	patched      int
+}
+
+func transitGatewayStaticRoutePath(orgIdParam string, projectIdParam string, transitGatewayIdParam string, routeIdParam string) string {
+	return "/orgs/" + orgIdParam + "/projects/" + projectIdParam + "/transit-gateways/" + transitGatewayIdParam + "/static-routes/" + routeIdParam
+}
+
+func (c *fakeTransitGatewayStaticRoutesClient) Delete(orgIdParam string, projectIdParam string, transitGatewayIdParam string, routeIdParam string) error {
+	delete(c.staticRoutes, transitGatewayStaticRoutePath(orgIdParam, projectIdParam, transitGatewayIdParam, routeIdParam))
+	return nil
+}
+
+func (c *fakeTransitGatewayStaticRoutesClient) Patch(orgIdParam string, projectIdParam string, transitGatewayIdParam string, routeIdParam string, staticRoutesParam model.StaticRoutes) error {
+	c.patched++
+	c.staticRoutes[transitGatewayStaticRoutePath(orgIdParam, projectIdParam, transitGatewayIdParam, routeIdParam)] = staticRoutesParam
+	return nil
+}
+
+type fakeVpcAttachmentClient struct {
+	vpcs.AttachmentsClient
+	// profiles is the connectivity profile path keyed by the VPC ID.
+	profiles map[string]string
+}
+
+func (c *fakeVpcAttachmentClient) List(_ string, _ string, vpcIdParam string, _ *string, _ *bool, _ *string, _ *int64, _ *bool, _ *string) (model.VpcAttachmentListResult, error) {
+	profile, ok := c.profiles[vpcIdParam]
+	if !ok {
+		return model.VpcAttachmentListResult{}, nil
+	}
+	return model.VpcAttachmentListResult{Results: []model.VpcAttachment{{
+		Id:                     String("default"),
+		Path:                   String("/orgs/default/projects/project-1/vpcs/" + vpcIdParam + "/attachments/default"),
+		VpcConnectivityProfile: String(profile),
+	}}}, nil
+}
+
+// fakeRouterPort is a router port realized on the gateway.
+type fakeRouterPort struct {
+	gatewayPath string
+	ip          string
+}
+
+type fakeRealizedEntitiesClient struct {
+	// ports is the realized router ports keyed by the intent path.
+	ports map[string][]fakeRouterPort
+}
+
+func (c *fakeRealizedEntitiesClient) List(intentPathParam string, _ *string) (model.GenericPolicyRealizedResourceListResult, error) {
+	result := model.GenericPolicyRealizedResourceListResult{}
+	for _, port := range c.ports[intentPathParam] {
+		result.Results = append(result.Results, model.GenericPolicyRealizedResource{
+			EntityType:  String("RealizedLogicalRouterPort"),
+			IntentPaths: []string{intentPathParam},
+			ExtendedAttributes: []model.AttributeVal{
+				{Key: String("LogicalRouterPath"), Values: []string{port.gatewayPath}},
+				{Key: String("IpAddresses"), Values: []string{port.ip}},
+			},
+		})
+	}
+	return result, nil
+}
+
+type fakeVPCConnectivityProfilesClient struct {
+	projects.VpcConnectivityProfilesClient
+}
+
+func (c *fakeVPCConnectivityProfilesClient) Get(orgIdParam string, projectIdParam string, profileIdParam string) (model.VpcConnectivityProfile, error) {
+	return model.VpcConnectivityProfile{
+		TransitGatewayPath: String("/orgs/" + orgIdParam + "/projects/" + projectIdParam + "/transit-gateways/tgw-" + profileIdParam),
+	}, nil
+}
+
+type fakeTransitGatewaysClient struct {
+	projects.TransitGatewaysClient
+}
+
+func (c *fakeTransitGatewaysClient) Get(orgIdParam string, projectIdParam string, transitGatewayIdParam string) (model.TransitGateway, error) {
+	if transitGatewayIdParam == "tgw-missing" {
+		return model.TransitGateway{}, errors.New("transit gateway not found")
+	}
+	return model.TransitGateway{
+		Path:           String("/orgs/" + orgIdParam + "/projects/" + projectIdParam + "/transit-gateways/" + transitGatewayIdParam),
+		TransitSubnets: []string{"100.64.0.0/16"},
+	}, nil
+}
+
+func createService() *VPCPeeringService {
+	vpcPeeringStore := &VPCPeeringStore{ResourceStore: common.ResourceStore{
+		BindingType: model.StaticRoutesBindingType(),
+	}}
+	vpcPeeringStore.Indexer = cache.NewIndexer(keyFunc, cache.Indexers{
+		common.TagScopeVPCPeeringCRUID:  indexFunc,
+		common.TagScopeVPCPeeringCRName: indexVPCPeeringName,
+	})
+	return &VPCPeeringService{
+		Service: common.Service{
+			NSXClient: &nsx.Client{
+				StaticRouteClient: &fakeStaticRoutesClient{staticRoutes: map[string]model.StaticRoutes{}},
+				VpcAttachmentClient: &fakeVpcAttachmentClient{profiles: map[string]string{
+					"vpc-1": "/orgs/default/projects/project-1/vpc-connectivity-profiles/default",
+					"vpc-2": "/orgs/default/projects/project-1/vpc-connectivity-profiles/default",
+					"vpc-3": "/orgs/default/projects/project-1/vpc-connectivity-profiles/other",
+					"vpc-4": "/orgs/default/projects/project-1/vpc-connectivity-profiles/missing",
+					"vpc-6": "/orgs/default/projects/project-1/vpc-connectivity-profiles/default",
+					"vpc-7": "/orgs/default/projects/project-1/vpc-connectivity-profiles/default",
+				}},
+				RealizedEntitiesClient: &fakeRealizedEntitiesClient{ports: map[string][]fakeRouterPort{
+					"/orgs/default/projects/project-1/vpcs/vpc-1/attachments/default": {
+						{gatewayPath: "/orgs/default/projects/project-1/vpcs/vpc-1", ip: "100.64.0.0/31"},
+						{gatewayPath: "/orgs/default/projects/project-1/transit-gateways/tgw-default", ip: "100.64.0.1/31"},
+					},
+					"/orgs/default/projects/project-1/vpcs/vpc-2/attachments/default": {
+						{gatewayPath: "/orgs/default/projects/project-1/vpcs/vpc-2", ip: "100.64.0.2/31"},
+						{gatewayPath: "/orgs/default/projects/project-1/transit-gateways/tgw-default", ip: "100.64.0.3/31"},
+					},
+					"/orgs/default/projects/project-1/vpcs/vpc-3/attachments/default": {
+						{gatewayPath: "/orgs/default/projects/project-1/vpcs/vpc-3", ip: "100.64.0.4/31"},
+						{gatewayPath: "/orgs/default/projects/project-1/transit-gateways/tgw-other", ip: "100.64.0.5/31"},
+					},
+					// Only the router port on the transit gateway is realized.
+					"/orgs/default/projects/project-1/vpcs/vpc-7/attachments/default": {
+						{gatewayPath: "/orgs/default/projects/project-1/transit-gateways/tgw-default", ip: "100.64.0.7/31"},
+					},
+				}},
+				VPCConnectivityProfilesClient:   &fakeVPCConnectivityProfilesClient{},
+				TransitGatewayClient:            &fakeTransitGatewaysClient{},
+				TransitGatewayStaticRouteClient: &fakeTransitGatewayStaticRoutesClient{staticRoutes: map[string]model.StaticRoutes{}},
+			},
+			NSXConfig: &config.NSXOperatorConfig{
+				CoeConfig: &config.CoeConfig{
+					Cluster: "k8scl-one:test",
+				},
+			},
+		},
+		VPCPeeringStore: vpcPeeringStore,
+	}
+}
+
+func TestVPCPeeringService_CreateOrUpdateVPCPeering(t *testing.T) {
+	service := createService()
+	staticRouteClient := service.NSXClient.StaticRouteClient.(*fakeStaticRoutesClient)
+	transitGatewayStaticRouteClient := service.NSXClient.TransitGatewayStaticRouteClient.(*fakeTransitGatewayStaticRoutesClient)
+	vpcService := &mock.MockVPCServiceProvider{}
+	service.VPCService = vpcService
+	obj := &v1alpha1.VPCPeering{
+		ObjectMeta: metav1.ObjectMeta{Name: "peering-1", UID: "uid-1"},
+		Spec: v1alpha1.VPCPeeringSpec{Peers: []v1alpha1.VPCPeeringPeer{
+			{Namespace: "ns-1", CIDRs: []string{"10.0.1.0/24"}},
+			{Namespace: "ns-2", CIDRs: []string{"10.0.2.0/24", "10.0.3.0/24"}},
+		}},
+	}
+	networks := [][]string{{"10.0.1.0/24"}, {"10.0.2.0/24", "10.0.3.0/24"}}
+
+	// No VPC is found for the Namespace.
+	vpcService.On("ListVPCInfo", "ns-1").Return([]common.VPCResourceInfo{}).Once()
+	_, err := service.CreateOrUpdateVPCPeering(obj, networks)
+	assert.ErrorContains(t, err, "no vpc found for ns ns-1")
+
+	vpcService.On("ListVPCInfo", "ns-1").Return([]common.VPCResourceInfo{{OrgID: "default", ProjectID: "project-1", ID: "vpc-1"}})
+	vpcService.On("ListVPCInfo", "ns-2").Return([]common.VPCResourceInfo{{OrgID: "default", ProjectID: "project-1", ID: "vpc-2"}})
+	statuses, err := service.CreateOrUpdateVPCPeering(obj, networks)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, staticRouteClient.patched)
+	assert.Equal(t, 3, transitGatewayStaticRouteClient.patched)
+	assert.Equal(t, 6, len(service.ListStaticRoute()))
+	assert.Equal(t, 2, len(statuses))
+	tgwPath := "/orgs/default/projects/project-1/transit-gateways/tgw-default"
+	assert.Equal(t, "ns-1", statuses[0].Namespace)
+	assert.Equal(t, "/orgs/default/projects/project-1/vpcs/vpc-1", statuses[0].VPCPath)
+	assert.Equal(t, tgwPath, statuses[0].TransitGatewayPath)
+	assert.Equal(t, []string{"10.0.2.0/24", "10.0.3.0/24"}, []string{statuses[0].Routes[0].Network, statuses[0].Routes[1].Network})
+	assert.Equal(t, "100.64.0.1", statuses[0].Routes[0].NextHop)
+	assert.Contains(t, statuses[0].Routes[0].Path, "/vpcs/vpc-1/static-routes/peering-1_uid-1_")
+	assert.Equal(t, "ns-2", statuses[1].Namespace)
+	assert.Equal(t, 1, len(statuses[1].Routes))
+	assert.Equal(t, "10.0.1.0/24", statuses[1].Routes[0].Network)
+	assert.Equal(t, "100.64.0.3", statuses[1].Routes[0].NextHop)
+	assert.Contains(t, statuses[1].Routes[0].Path, "/vpcs/vpc-2/static-routes/peering-1_uid-1_")
+	// The transit gateway routes the networks of each peer to the VPC side of the VPC attachment of the peer.
+	assert.Equal(t, 1, len(statuses[0].TransitGatewayRoutes))
+	assert.Equal(t, "10.0.1.0/24", statuses[0].TransitGatewayRoutes[0].Network)
+	assert.Equal(t, "100.64.0.0", statuses[0].TransitGatewayRoutes[0].NextHop)
+	assert.Contains(t, statuses[0].TransitGatewayRoutes[0].Path, tgwPath+"/static-routes/peering-1_uid-1_")
+	assert.Equal(t, []string{"10.0.2.0/24", "10.0.3.0/24"}, []string{statuses[1].TransitGatewayRoutes[0].Network, statuses[1].TransitGatewayRoutes[1].Network})
+	assert.Equal(t, "100.64.0.2", statuses[1].TransitGatewayRoutes[0].NextHop)
+	assert.Equal(t, 3, len(transitGatewayStaticRouteClient.staticRoutes))
+
+	// The static routes are not patched again if they are not changed.
+	_, err = service.CreateOrUpdateVPCPeering(obj, networks)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, staticRouteClient.patched)
+	assert.Equal(t, 3, transitGatewayStaticRouteClient.patched)
+
+	// The static route of the removed network is deleted.
+	networks[1] = []string{"10.0.2.0/24"}
+	statuses, err = service.CreateOrUpdateVPCPeering(obj, networks)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, staticRouteClient.patched)
+	assert.Equal(t, 1, len(statuses[0].Routes))
+	assert.Equal(t, 1, len(statuses[1].TransitGatewayRoutes))
+	assert.Equal(t, 4, len(service.ListStaticRoute()))
+	assert.Equal(t, 2, len(staticRouteClient.staticRoutes))
+	assert.Equal(t, 2, len(transitGatewayStaticRouteClient.staticRoutes))
+	assert.Equal(t, 4, len(service.ListStaticRouteByName("peering-1")))
+	assert.Empty(t, service.ListStaticRouteByName("peering-2"))
+	assert.Equal(t, "uid-1", *service.GetUID(service.ListStaticRoute()[0]))
+
+	// The static routes in the VPC of ns-1 and in the transit gateway are deleted once the VPC of ns-2 is gone.
+	vpcService.On("ListVPCInfo", "ns-2").Unset()
+	vpcService.On("ListVPCInfo", "ns-2").Return([]common.VPCResourceInfo{})
+	_, err = service.CreateOrUpdateVPCPeering(obj, networks)
+	assert.ErrorContains(t, err, "no vpc found for ns ns-2")
+	assert.Equal(t, 1, len(service.ListStaticRoute()))
+	assert.Equal(t, "ns-2", nsxutil.FindTag(service.ListStaticRoute()[0].Tags, common.TagScopeNamespace))
+	assert.Empty(t, transitGatewayStaticRouteClient.staticRoutes)
+
+	assert.Nil(t, service.DeleteVPCPeeringByCR(obj))
+	assert.Empty(t, staticRouteClient.staticRoutes)
+	assert.Empty(t, service.ListStaticRoute())
+	// Deleting a VPCPeering without NSX static route is a no-op.
+	assert.Nil(t, service.DeleteVPCPeeringByCR(obj))
+}
+
+func TestVPCPeeringService_CreateOrUpdateVPCPeeringError(t *testing.T) {
+	vpcService := &mock.MockVPCServiceProvider{}
+	vpcService.On("ListVPCInfo", "ns-1").Return([]common.VPCResourceInfo{{OrgID: "default", ProjectID: "project-1", ID: "vpc-1"}})
+	vpcService.On("ListVPCInfo", "ns-shared").Return([]common.VPCResourceInfo{{OrgID: "default", ProjectID: "project-1", ID: "vpc-1"}})
+	vpcService.On("ListVPCInfo", "ns-3").Return([]common.VPCResourceInfo{{OrgID: "default", ProjectID: "project-1", ID: "vpc-3"}})
+	vpcService.On("ListVPCInfo", "ns-4").Return([]common.VPCResourceInfo{{OrgID: "default", ProjectID: "project-1", ID: "vpc-4"}})
+	vpcService.On("ListVPCInfo", "ns-5").Return([]common.VPCResourceInfo{{OrgID: "default", ProjectID: "project-1", ID: "vpc-5"}})
+	vpcService.On("ListVPCInfo", "ns-6").Return([]common.VPCResourceInfo{{OrgID: "default", ProjectID: "project-1", ID: "vpc-6"}})
+	vpcService.On("ListVPCInfo", "ns-7").Return([]common.VPCResourceInfo{{OrgID: "default", ProjectID: "project-1", ID: "vpc-7"}})
+	tests := []struct {
+		name   string
+		peers  []string
+		expErr string
+	}{
+		{name: "SameVPC", peers: []string{"ns-1", "ns-shared"}, expErr: "Namespaces ns-1 and ns-shared use the same VPC /orgs/default/projects/project-1/vpcs/vpc-1"},
+		{name: "DifferentTransitGateway", peers: []string{"ns-1", "ns-3"}, expErr: "VPC /orgs/default/projects/project-1/vpcs/vpc-1 and VPC /orgs/default/projects/project-1/vpcs/vpc-3 are not attached to the same transit gateway"},
+		{name: "TransitGatewayNotFound", peers: []string{"ns-1", "ns-4"}, expErr: "transit gateway not found"},
+		{name: "NoVPCAttachment", peers: []string{"ns-1", "ns-5"}, expErr: "no VPC attachment found for VPC vpc-5"},
+		{name: "NextHopNotRealized", peers: []string{"ns-1", "ns-6"}, expErr: "transit gateway IP of VPC attachment /orgs/default/projects/project-1/vpcs/vpc-6/attachments/default is not realized"},
+		{name: "VPCNextHopNotRealized", peers: []string{"ns-1", "ns-7"}, expErr: "VPC IP of VPC attachment /orgs/default/projects/project-1/vpcs/vpc-7/attachments/default is not realized"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := createService()
+			service.VPCService = vpcService
+			obj := &v1alpha1.VPCPeering{
+				ObjectMeta: metav1.ObjectMeta{Name: "peering-1", UID: "uid-1"},
+				Spec: v1alpha1.VPCPeeringSpec{Peers: []v1alpha1.VPCPeeringPeer{
+					{Namespace: tt.peers[0], CIDRs: []string{"10.0.1.0/24"}},
+					{Namespace: tt.peers[1], CIDRs: []string{"10.0.2.0/24"}},
+				}},
+			}
+			_, err := service.CreateOrUpdateVPCPeering(obj, [][]string{{"10.0.1.0/24"}, {"10.0.2.0/24"}})
+			assert.ErrorContains(t, err, tt.expErr)
+			assert.Empty(t, service.ListStaticRoute())
+		})
+	}
+}
+
+func TestVPCPeeringService_Cleanup(t *testing.T) {
+	service := createService()
+	staticRouteClient := service.NSXClient.StaticRouteClient.(*fakeStaticRoutesClient)
+	path := staticRoutePath("default", "project-1", "vpc-1", "route-1")
+	staticRoute := model.StaticRoutes{Id: String("route-1"), Path: String(path), Tags: []model.Tag{{Scope: String(common.TagScopeVPCPeeringCRUID), Tag: String("uid-1")}}}
+	staticRouteClient.staticRoutes[path] = staticRoute
+	assert.Nil(t, service.VPCPeeringStore.Add(&staticRoute))
+	transitGatewayStaticRouteClient := service.NSXClient.TransitGatewayStaticRouteClient.(*fakeTransitGatewayStaticRoutesClient)
+	path = transitGatewayStaticRoutePath("default", "project-1", "tgw-default", "route-2")
+	transitGatewayStaticRoute := model.StaticRoutes{Id: String("route-2"), Path: String(path), Tags: []model.Tag{{Scope: String(common.TagScopeVPCPeeringCRUID), Tag: String("uid-1")}}}
+	transitGatewayStaticRouteClient.staticRoutes[path] = transitGatewayStaticRoute
+	assert.Nil(t, service.VPCPeeringStore.Add(&transitGatewayStaticRoute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := service.Cleanup(ctx)
+	assert.ErrorIs(t, err, nsxutil.TimeoutFailed)
+
+	assert.Nil(t, service.Cleanup(context.Background()))
+	assert.Empty(t, service.ListStaticRoute())
+	assert.Empty(t, staticRouteClient.staticRoutes)
+	assert.Empty(t, transitGatewayStaticRouteClient.staticRoutes)
+}
@@ -0,0 +1,47 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	"github.com/vmware/vsphere-automation-sdk-go/runtime/data/serializers/cleanjson"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+const transitGatewayStaticRoutePath = "policy/api/v1/orgs/%s/projects/%s/transit-gateways/%s/static-routes/%s"
+
+// TransitGatewayStaticRoutesClient manages the static routes of a transit gateway. The NSX SDK doesn't provide
+// the client yet, so the requests are sent by the cluster directly. The transit gateway static routes share
+// the schema of the VPC static routes.
+type TransitGatewayStaticRoutesClient interface {
+	Delete(orgIdParam string, projectIdParam string, transitGatewayIdParam string, routeIdParam string) error
+	Patch(orgIdParam string, projectIdParam string, transitGatewayIdParam string, routeIdParam string, staticRoutesParam model.StaticRoutes) error
+}
+
+type transitGatewayStaticRoutesClient struct {
+	cluster *Cluster
+}
+
+func NewTransitGatewayStaticRoutesClient(cluster *Cluster) TransitGatewayStaticRoutesClient {
+	return &transitGatewayStaticRoutesClient{cluster: cluster}
+}
+
+func (c *transitGatewayStaticRoutesClient) Delete(orgIdParam string, projectIdParam string, transitGatewayIdParam string, routeIdParam string) error {
+	return c.cluster.HttpDelete(fmt.Sprintf(transitGatewayStaticRoutePath, orgIdParam, projectIdParam, transitGatewayIdParam, routeIdParam))
+}
+
+func (c *transitGatewayStaticRoutesClient) Patch(orgIdParam string, projectIdParam string, transitGatewayIdParam string, routeIdParam string, staticRoutesParam model.StaticRoutes) error {
+	dataValue, errs := bindings.NewTypeConverter().ConvertToVapi(staticRoutesParam, model.StaticRoutesBindingType())
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	body, err := cleanjson.NewDataValueToJsonEncoder().Encode(dataValue)
+	if err != nil {
+		return err
+	}
+	return c.cluster.HttpPatch(fmt.Sprintf(transitGatewayStaticRoutePath, orgIdParam, projectIdParam, transitGatewayIdParam, routeIdParam), []byte(body))
+}
@@ -0,0 +1,64 @@
+/* Copyright © 2024 Broadcom, Inc. All Rights Reserved.
+   SPDX-License-Identifier: Apache-2.0 */
+
+package nsx
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func TestTransitGatewayStaticRoutesClient(t *testing.T) {
+	cluster := &Cluster{endpoints: []*Endpoint{{
+		provider: &address{host: "1.2.3.4", scheme: "https"},
+	}}}
+	cluster.config = &Config{EnvoyPort: 0}
+	client := NewTransitGatewayStaticRoutesClient(cluster)
+
+	var request *http.Request
+	var body map[string]interface{}
+	statusCode := http.StatusOK
+	patch := gomonkey.ApplyFunc((*http.Client).Do,
+		func(client *http.Client, req *http.Request) (*http.Response, error) {
+			request, body = req, nil
+			if req.Body != nil {
+				data, _ := io.ReadAll(req.Body)
+				assert.NoError(t, json.Unmarshal(data, &body))
+			}
+			return &http.Response{
+				StatusCode: statusCode,
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+				Request:    req,
+			}, nil
+		})
+	defer patch.Reset()
+
+	staticRoute := model.StaticRoutes{
+		Network:  pointy.String("10.0.1.0/24"),
+		NextHops: []model.RouterNexthop{{IpAddress: pointy.String("100.64.0.0"), AdminDistance: pointy.Int64(1)}},
+	}
+	err := client.Patch("default", "project-1", "tgw-1", "route-1", staticRoute)
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPatch, request.Method)
+	assert.Equal(t, "/policy/api/v1/orgs/default/projects/project-1/transit-gateways/tgw-1/static-routes/route-1", request.URL.Path)
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	assert.Equal(t, "10.0.1.0/24", body["network"])
+	assert.Equal(t, "100.64.0.0", body["next_hops"].([]interface{})[0].(map[string]interface{})["ip_address"])
+
+	require.NoError(t, client.Delete("default", "project-1", "tgw-1", "route-1"))
+	assert.Equal(t, http.MethodDelete, request.Method)
+	assert.Equal(t, "/policy/api/v1/orgs/default/projects/project-1/transit-gateways/tgw-1/static-routes/route-1", request.URL.Path)
+
+	statusCode = http.StatusBadRequest
+	err = client.Patch("default", "project-1", "tgw-1", "route-1", staticRoute)
+	assert.Error(t, err)
+}
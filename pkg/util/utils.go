@@ -4,13 +4,16 @@
 package util
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha1" // #nosec G505: not used for security purposes
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -22,6 +25,8 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	t1v1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
@@ -33,6 +38,8 @@ import (
 const (
 	wcpSystemResource = "vmware-system-shared-t1"
 	base62Chars       = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// MaxReservedIPs is the maximum number of the IPs in the reserved IP ranges of a Subnet.
+	MaxReservedIPs = 256
 )
 
 var (
@@ -168,6 +175,26 @@ func IsSystemNamespace(c client.Client, ns string, obj *v1.Namespace) (bool, err
 	return false, nil
 }
 
+// GetSharedVPCNamespace returns the Namespace whose VPC is used by the given Namespace, it is the Namespace itself
+// if it is the owner of a shared VPC, or an empty string if the Namespace does not share a VPC. The sharing is
+// declared either by a SharedVPC CR or by the legacy annotation "nsx.vmware.com/shared_vpc_namespace", and the
+// annotation takes precedence.
+func GetSharedVPCNamespace(c client.Client, ctx context.Context, obj *v1.Namespace) (string, error) {
+	if sharedNS, ok := obj.Annotations[common.AnnotationSharedVPCNamespace]; ok {
+		return sharedNS, nil
+	}
+	sharedVPCList := &v1alpha1.SharedVPCList{}
+	if err := c.List(ctx, sharedVPCList); err != nil {
+		return "", err
+	}
+	for _, sharedVPC := range sharedVPCList.Items {
+		if sharedVPC.Spec.OwnerNamespace == obj.Name || Contains(sharedVPC.Spec.ConsumerNamespaces, obj.Name) {
+			return sharedVPC.Spec.OwnerNamespace, nil
+		}
+	}
+	return "", nil
+}
+
 // CheckPodHasNamedPort checks if the pod has a named port, it filters the pod events
 // we don't want give concern.
 func CheckPodHasNamedPort(pod v1.Pod, reason string) bool {
@@ -225,21 +252,253 @@ func GetSubnetMask(subnetLength int) (string, error) {
 	return subnetMask.String(), nil
 }
 
+// GetIPFamily gets the IP family of an IP address or a CIDR, e.g.
+// "1.2.3.4/24" -> IPv4, "2001:db8::1" -> IPv6
+func GetIPFamily(ipAddress string) (v1alpha1.IPFamily, error) {
+	ip := net.ParseIP(strings.Split(ipAddress, "/")[0])
+	if ip == nil {
+		return "", fmt.Errorf("invalid IP address %s", ipAddress)
+	}
+	if ip.To4() != nil {
+		return v1alpha1.IPFamilyIPv4, nil
+	}
+	return v1alpha1.IPFamilyIPv6, nil
+}
+
+// CalculateIPFromCIDRs returns the total count of the IP addresses in the CIDRs. The count of
+// an IPv6 CIDR is capped to math.MaxInt32, which is far more than the workloads in a Subnet.
 func CalculateIPFromCIDRs(IPAddresses []string) (int, error) {
 	total := 0
 	for _, addr := range IPAddresses {
-		mask, err := strconv.Atoi(strings.Split(addr, "/")[1])
+		_, ipNet, err := net.ParseCIDR(addr)
 		if err != nil {
 			return -1, err
 		}
-		total += int(cidr.AddressCount(&net.IPNet{
-			IP:   net.ParseIP(strings.Split(addr, "/")[0]),
-			Mask: net.CIDRMask(mask, 32),
-		}))
+		// AddressCount overflows to 0 for the CIDRs larger than /64.
+		count := cidr.AddressCount(ipNet)
+		if count == 0 || count > math.MaxInt32 {
+			count = math.MaxInt32
+		}
+		total += int(count)
 	}
 	return total, nil
 }
 
+// HasIPFamily checks if the IP family is in the IP families of a Subnet or SubnetSet, IPv4
+// is the default IP family if the IP families are not specified.
+func HasIPFamily(ipFamilies []v1alpha1.IPFamily, ipFamily v1alpha1.IPFamily) bool {
+	if len(ipFamilies) == 0 {
+		return ipFamily == v1alpha1.IPFamilyIPv4
+	}
+	for _, f := range ipFamilies {
+		if f == ipFamily {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSubnetIPFamilies validates the combination of the IP families, the Subnet sizes and
+// the Subnet CIDRs in the spec of a Subnet or SubnetSet. If the Subnet CIDRs are set, each IP
+// family needs at least one CIDR.
+func ValidateSubnetIPFamilies(ipFamilies []v1alpha1.IPFamily, ipv4SubnetSize, ipv6SubnetSize int, ipAddresses []string) error {
+	if len(ipFamilies) == 2 && ipFamilies[0] == ipFamilies[1] {
+		return fmt.Errorf("duplicated IP family %s", ipFamilies[0])
+	}
+	if ipv4SubnetSize != 0 && !HasIPFamily(ipFamilies, v1alpha1.IPFamilyIPv4) {
+		return errors.New("ipv4SubnetSize can only be set with IPv4 family")
+	}
+	if ipv6SubnetSize != 0 {
+		if !HasIPFamily(ipFamilies, v1alpha1.IPFamilyIPv6) {
+			return errors.New("ipv6SubnetSize can only be set with IPv6 family")
+		}
+		if ipv6SubnetSize != common.IPv6SubnetSize {
+			return fmt.Errorf("ipv6SubnetSize %d is not supported, it must be %d", ipv6SubnetSize, common.IPv6SubnetSize)
+		}
+	}
+	cidrFamilies := sets.New[v1alpha1.IPFamily]()
+	for _, ipAddress := range ipAddresses {
+		if _, _, err := net.ParseCIDR(ipAddress); err != nil {
+			return fmt.Errorf("invalid CIDR %s", ipAddress)
+		}
+		ipFamily, _ := GetIPFamily(ipAddress)
+		if !HasIPFamily(ipFamilies, ipFamily) {
+			return fmt.Errorf("CIDR %s doesn't match the IP families", ipAddress)
+		}
+		if ipFamily == v1alpha1.IPFamilyIPv6 {
+			if prefix, _ := GetIPPrefix(ipAddress); prefix != common.IPv6SubnetSize {
+				return fmt.Errorf("IPv6 CIDR %s must be /%d", ipAddress, common.IPv6SubnetSize)
+			}
+		}
+		cidrFamilies.Insert(ipFamily)
+	}
+	if len(ipAddresses) > 0 {
+		for _, ipFamily := range []v1alpha1.IPFamily{v1alpha1.IPFamilyIPv4, v1alpha1.IPFamilyIPv6} {
+			if HasIPFamily(ipFamilies, ipFamily) && !cidrFamilies.Has(ipFamily) {
+				return fmt.Errorf("at least one %s CIDR is required", ipFamily)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateSubnetDHCPConfig validates the DHCP server and relay configuration of the Subnet or SubnetSet.
+func ValidateSubnetDHCPConfig(dhcpConfig v1alpha1.SubnetDHCPConfig) error {
+	if relayConfig := dhcpConfig.DHCPRelayConfig; relayConfig != nil {
+		if string(dhcpConfig.Mode) != v1alpha1.DHCPConfigModeRelay {
+			return fmt.Errorf("dhcpRelayConfig is only applicable for %s mode", v1alpha1.DHCPConfigModeRelay)
+		}
+		for _, serverAddress := range relayConfig.ServerAddresses {
+			if net.ParseIP(serverAddress) == nil {
+				return fmt.Errorf("invalid DHCP relay server address %s", serverAddress)
+			}
+		}
+	}
+	serverConfig := dhcpConfig.DHCPServerAdditionalConfig
+	if serverConfig == nil {
+		return nil
+	}
+	if string(dhcpConfig.Mode) != v1alpha1.DHCPConfigModeServer {
+		return fmt.Errorf("dhcpServerAdditionalConfig is only applicable for %s mode", v1alpha1.DHCPConfigModeServer)
+	}
+	for _, ip := range append(append([]string{}, serverConfig.DNSServers...), serverConfig.NTPServers...) {
+		if net.ParseIP(ip).To4() == nil {
+			return fmt.Errorf("invalid DNS or NTP server %s, which must be an IPv4 address", ip)
+		}
+	}
+	macAddresses := sets.New[string]()
+	ipAddresses := sets.New[string]()
+	for _, binding := range serverConfig.StaticBindings {
+		mac, err := net.ParseMAC(binding.MACAddress)
+		if err != nil {
+			return fmt.Errorf("invalid MAC address %s", binding.MACAddress)
+		}
+		ip := net.ParseIP(binding.IPAddress)
+		if ip == nil || ip.To4() == nil {
+			return fmt.Errorf("invalid IP address %s of the static binding, which must be an IPv4 address", binding.IPAddress)
+		}
+		if macAddresses.Has(mac.String()) {
+			return fmt.Errorf("duplicated static binding for MAC address %s", binding.MACAddress)
+		}
+		if ipAddresses.Has(ip.String()) {
+			return fmt.Errorf("duplicated static binding for IP address %s", binding.IPAddress)
+		}
+		macAddresses.Insert(mac.String())
+		ipAddresses.Insert(ip.String())
+	}
+	for _, ipRange := range serverConfig.ExcludedRanges {
+		if err := validateIPv4Range(ipRange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseIPv4Range parses the IPv4 range in the format of a single IP or startIP-endIP, and returns
+// the start IP and the end IP of the range.
+func ParseIPv4Range(ipRange string) (startIP, endIP net.IP, err error) {
+	ips := strings.Split(ipRange, "-")
+	if len(ips) > 2 {
+		return nil, nil, fmt.Errorf("invalid IP range %s", ipRange)
+	}
+	var parsedIPs []net.IP
+	for _, ip := range ips {
+		parsedIP := net.ParseIP(strings.TrimSpace(ip)).To4()
+		if parsedIP == nil {
+			return nil, nil, fmt.Errorf("invalid IP range %s, which must be IPv4 addresses", ipRange)
+		}
+		parsedIPs = append(parsedIPs, parsedIP)
+	}
+	startIP, endIP = parsedIPs[0], parsedIPs[len(parsedIPs)-1]
+	if bytes.Compare(startIP, endIP) > 0 {
+		return nil, nil, fmt.Errorf("invalid IP range %s, the start IP is greater than the end IP", ipRange)
+	}
+	return startIP, endIP, nil
+}
+
+func validateIPv4Range(ipRange string) error {
+	_, _, err := ParseIPv4Range(ipRange)
+	return err
+}
+
+// ValidateReservedIPRanges validates the reserved IP ranges of the Subnet, the IPs in the ranges are
+// reserved one by one in NSX, so the total number of the reserved IPs is limited. If the IPv4 CIDRs of
+// the Subnet are known, each range must fall inside one of them.
+func ValidateReservedIPRanges(ipRanges []string, cidrs []string) error {
+	var cidrRanges [][2]net.IP
+	for _, cidr := range cidrs {
+		startIP, endIP, err := parseCIDRRange(cidr)
+		if err != nil || startIP.To4() == nil {
+			continue
+		}
+		cidrRanges = append(cidrRanges, [2]net.IP{startIP.To4(), endIP.To4()})
+	}
+	total := 0
+	for _, ipRange := range ipRanges {
+		startIP, endIP, err := ParseIPv4Range(ipRange)
+		if err != nil {
+			return err
+		}
+		inCIDR := len(cidrRanges) == 0
+		for _, r := range cidrRanges {
+			if bytes.Compare(startIP, r[0]) >= 0 && bytes.Compare(endIP, r[1]) <= 0 {
+				inCIDR = true
+				break
+			}
+		}
+		if !inCIDR {
+			return fmt.Errorf("IP range %s is not in the Subnet CIDRs %s", ipRange, strings.Join(cidrs, ", "))
+		}
+		total += int(binary.BigEndian.Uint32(endIP)-binary.BigEndian.Uint32(startIP)) + 1
+		if total > MaxReservedIPs {
+			return fmt.Errorf("the reserved IP ranges contain more than %d IPs", MaxReservedIPs)
+		}
+	}
+	return nil
+}
+
+// ExpandIPv4Ranges returns all the IPs in the IPv4 ranges without duplication.
+func ExpandIPv4Ranges(ipRanges []string) ([]string, error) {
+	ipSet := sets.New[string]()
+	var ips []string
+	for _, ipRange := range ipRanges {
+		startIP, endIP, err := ParseIPv4Range(ipRange)
+		if err != nil {
+			return nil, err
+		}
+		for i := binary.BigEndian.Uint32(startIP); i <= binary.BigEndian.Uint32(endIP); i++ {
+			ip := make(net.IP, net.IPv4len)
+			binary.BigEndian.PutUint32(ip, i)
+			if !ipSet.Has(ip.String()) {
+				ipSet.Insert(ip.String())
+				ips = append(ips, ip.String())
+			}
+			if i == math.MaxUint32 {
+				break
+			}
+		}
+	}
+	return ips, nil
+}
+
+// IPInRanges returns true if the IP is in any of the IPv4 ranges, the invalid ranges are ignored.
+func IPInRanges(ip net.IP, ipRanges []string) bool {
+	ip = ip.To4()
+	if ip == nil {
+		return false
+	}
+	for _, ipRange := range ipRanges {
+		startIP, endIP, err := ParseIPv4Range(ipRange)
+		if err != nil {
+			continue
+		}
+		if bytes.Compare(ip, startIP) >= 0 && bytes.Compare(ip, endIP) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func parseCIDRRange(cidr string) (startIP, endIP net.IP, err error) {
 	// TODO: confirm whether the error message is enough
 	_, ipnet, err := net.ParseCIDR(cidr)
@@ -255,25 +514,30 @@ func parseCIDRRange(cidr string) (startIP, endIP net.IP, err error) {
 	return startIP, endIP, nil
 }
 
-func calculateOffsetIP(ip net.IP, offset int) (net.IP, error) {
-	ipInt := ipToUint32(ip)
-	ipInt += uint32(offset)
-	return uint32ToIP(ipInt), nil
-}
-
-func ipToUint32(ip net.IP) uint32 {
-	ip = ip.To4()
-	return binary.BigEndian.Uint32(ip)
+// normalizeIP returns the 4-byte representation of an IPv4 address, and the 16-byte representation
+// of an IPv6 address, so that the addresses in the same family are comparable byte by byte.
+func normalizeIP(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip.To16()
 }
 
-func uint32ToIP(ipInt uint32) net.IP {
-	ip := make(net.IP, net.IPv4len)
-	binary.BigEndian.PutUint32(ip, ipInt)
-	return ip
+func calculateOffsetIP(ip net.IP, offset int) (net.IP, error) {
+	ip = normalizeIP(ip)
+	if ip == nil {
+		return nil, errors.New("invalid IP address")
+	}
+	ipInt := new(big.Int).SetBytes(ip)
+	ipInt.Add(ipInt, big.NewInt(int64(offset)))
+	if ipInt.Sign() < 0 || ipInt.BitLen() > len(ip)*8 {
+		return nil, fmt.Errorf("IP %s with offset %d is out of range", ip, offset)
+	}
+	return ipInt.FillBytes(make(net.IP, len(ip))), nil
 }
 
 func compareIP(ip1, ip2 net.IP) bool {
-	return ipToUint32(ip1) < ipToUint32(ip2)
+	return bytes.Compare(normalizeIP(ip1), normalizeIP(ip2)) < 0
 }
 
 func rangesAbstractRange(ranges [][]net.IP, except []net.IP) [][]net.IP {
@@ -281,44 +545,44 @@ func rangesAbstractRange(ranges [][]net.IP, except []net.IP) [][]net.IP {
 	// except: [172.0.100.1 172.0.100.255]
 	// return: [[172.0.0.1 172.0.100.0] [172.0.101.0 172.0.255.255] [172.2.0.1 172.2.255.255]]
 	var results [][]net.IP
-	except[0] = except[0].To4()
-	except[1] = except[1].To4()
+	exceptStart, exceptEnd := normalizeIP(except[0]), normalizeIP(except[1])
 	for _, r := range ranges {
-		rng := r
-		rng[0] = rng[0].To4()
-		rng[1] = rng[1].To4()
-		exceptPrev, _ := calculateOffsetIP(except[0], -1)
-		exceptNext, _ := calculateOffsetIP(except[1], 1)
-		if compareIP(except[0], rng[0]) && compareIP(rng[1], except[1]) {
-		} else if compareIP(rng[0], except[0]) && compareIP(except[1], rng[1]) {
-			results = append(results, []net.IP{rng[0], exceptPrev}, []net.IP{exceptNext, rng[1]})
-		} else if compareIP(rng[0], except[0]) && compareIP(except[0], rng[1]) && compareIP(rng[1], except[1]) {
-			results = append(results, []net.IP{rng[0], exceptPrev})
-		} else if compareIP(except[0], rng[0]) && compareIP(rng[0], except[1]) && compareIP(except[1], rng[1]) {
-			results = append(results, []net.IP{exceptNext, rng[1]})
-		} else if compareIP(except[1], rng[0]) {
-			results = append(results, []net.IP{rng[0], rng[1]})
-		} else if compareIP(rng[1], except[0]) {
-			results = append(results, []net.IP{rng[0], rng[1]})
+		rngStart, rngEnd := normalizeIP(r[0]), normalizeIP(r[1])
+		if compareIP(exceptEnd, rngStart) || compareIP(rngEnd, exceptStart) {
+			results = append(results, []net.IP{rngStart, rngEnd})
+			continue
+		}
+		// The offsets never overflow, as the except start is greater than the range start, and the
+		// except end is less than the range end.
+		if compareIP(rngStart, exceptStart) {
+			exceptPrev, _ := calculateOffsetIP(exceptStart, -1)
+			results = append(results, []net.IP{rngStart, exceptPrev})
+		}
+		if compareIP(exceptEnd, rngEnd) {
+			exceptNext, _ := calculateOffsetIP(exceptEnd, 1)
+			results = append(results, []net.IP{exceptNext, rngEnd})
 		}
 	}
 	return results
 }
 
+// GetCIDRRangesWithExcept returns the IP ranges of the CIDR excluding the except CIDRs, both IPv4
+// and IPv6 CIDRs are supported, but the except CIDRs must be in the same IP family as the CIDR.
 func GetCIDRRangesWithExcept(cidr string, excepts []string) ([]string, error) {
-	var calculatedRanges [][]net.IP
 	var resultRanges []string
 	mainStartIP, mainEndIP, err := parseCIDRRange(cidr)
-	calculatedRanges = append(calculatedRanges, []net.IP{mainStartIP, mainEndIP})
 	if err != nil {
 		return nil, err
 	}
-	for _, ept := range excepts {
-		except := ept
+	calculatedRanges := [][]net.IP{{mainStartIP, mainEndIP}}
+	for _, except := range excepts {
 		exceptStartIP, exceptEndIP, err := parseCIDRRange(except)
 		if err != nil {
 			return nil, err
 		}
+		if len(exceptStartIP) != len(mainStartIP) {
+			return nil, fmt.Errorf("except %s is not in the same IP family as CIDR %s", except, cidr)
+		}
 		calculatedRanges = rangesAbstractRange(calculatedRanges, []net.IP{exceptStartIP, exceptEndIP})
 	}
 	for _, rng := range calculatedRanges {
@@ -449,6 +713,13 @@ func BuildBasicTags(cluster string, obj interface{}, namespaceID types.UID) []mo
 		tags = append(tags, model.Tag{Scope: String(common.TagScopeNamespace), Tag: String(i.ObjectMeta.Namespace)})
 		tags = append(tags, model.Tag{Scope: String(common.TagScopeStaticRouteCRName), Tag: String(i.ObjectMeta.Name)})
 		tags = append(tags, model.Tag{Scope: String(common.TagScopeStaticRouteCRUID), Tag: String(string(i.UID))})
+	case *v1alpha1.NATRule:
+		tags = append(tags, model.Tag{Scope: String(common.TagScopeNamespace), Tag: String(i.ObjectMeta.Namespace)})
+		tags = append(tags, model.Tag{Scope: String(common.TagScopeNATRuleCRName), Tag: String(i.ObjectMeta.Name)})
+		tags = append(tags, model.Tag{Scope: String(common.TagScopeNATRuleCRUID), Tag: String(string(i.UID))})
+	case *v1alpha1.VPCPeering:
+		tags = append(tags, model.Tag{Scope: String(common.TagScopeVPCPeeringCRName), Tag: String(i.ObjectMeta.Name)})
+		tags = append(tags, model.Tag{Scope: String(common.TagScopeVPCPeeringCRUID), Tag: String(string(i.UID))})
 	case *t1v1alpha1.SecurityPolicy:
 		tags = append(tags, model.Tag{Scope: String(common.TagScopeNamespace), Tag: String(i.ObjectMeta.Namespace)})
 	case *networkingv1.NetworkPolicy:
@@ -493,6 +764,121 @@ func BuildBasicTags(cluster string, obj interface{}, namespaceID types.UID) []mo
 	return tags
 }
 
+// reservedLabelTagPrefixes are the prefixes of the tag scopes owned by nsx-operator and NCP, the labels with
+// such keys are never mirrored to NSX tags so that they can't override the tags used to track the resources.
+var reservedLabelTagPrefixes = []string{"nsx-op/", "ncp/", "nsx/"}
+
+// BuildLabelTags returns the NSX tags mirroring the labels whose keys are in labelKeys, sorted by scope. The
+// keys and values are normalized to the NSX tag length limits. If a key is in several label maps, the value of
+// the first map wins, so the labels of an object should be passed before the labels of its Namespace.
+func BuildLabelTags(labelKeys []string, labelMaps ...map[string]string) []model.Tag {
+	if len(labelKeys) == 0 {
+		return nil
+	}
+	labels := make(map[string]string)
+	for i := len(labelMaps) - 1; i >= 0; i-- {
+		for _, key := range labelKeys {
+			if value, ok := labelMaps[i][key]; ok {
+				labels[key] = value
+			}
+		}
+	}
+	for key := range labels {
+		if isReservedLabelKey(key) {
+			delete(labels, key)
+		}
+	}
+	var tags []model.Tag
+	for key, value := range *NormalizeLabels(&labels) {
+		tags = append(tags, model.Tag{Scope: String(key), Tag: String(value)})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return *tags[i].Scope < *tags[j].Scope
+	})
+	return tags
+}
+
+// isReservedLabelKey returns true if the label key is in a tag scope owned by nsx-operator or NCP.
+func isReservedLabelKey(key string) bool {
+	for _, prefix := range reservedLabelTagPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// AppendLabelTags appends the label tags built by BuildLabelTags to tags. The scopes already in tags are skipped,
+// and the label tags exceeding MaxTagsCount are dropped as the NSX resource can't carry more tags.
+func AppendLabelTags(tags []model.Tag, labelTags []model.Tag) []model.Tag {
+	scopes := sets.New[string]()
+	for _, tag := range tags {
+		if tag.Scope != nil {
+			scopes.Insert(*tag.Scope)
+		}
+	}
+	var dropped []string
+	for _, tag := range labelTags {
+		if scopes.Has(*tag.Scope) {
+			continue
+		}
+		if len(tags) >= common.MaxTagsCount {
+			dropped = append(dropped, *tag.Scope)
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	if len(dropped) > 0 {
+		log.Info("Dropped the label tags exceeding the NSX tag limit", "limit", common.MaxTagsCount, "scopes", dropped)
+	}
+	return tags
+}
+
+// ReplaceLabelTags removes the tags mirrored from the labels with keys in labelKeys from tags, then appends the
+// new label tags, so that the labels removed from the Kubernetes object are also removed from the NSX resource.
+// The label keys in the reserved tag scopes are skipped, the tags owned by nsx-operator and NCP are kept.
+func ReplaceLabelTags(tags []model.Tag, labelKeys []string, labelTags []model.Tag) []model.Tag {
+	labelScopes := sets.New[string]()
+	for _, key := range labelKeys {
+		if isReservedLabelKey(key) {
+			continue
+		}
+		labelScopes.Insert(NormalizeLabelKey(key, truncateLabelHash))
+	}
+	var res []model.Tag
+	for _, tag := range tags {
+		if tag.Scope != nil && labelScopes.Has(*tag.Scope) {
+			continue
+		}
+		res = append(res, tag)
+	}
+	return AppendLabelTags(res, labelTags)
+}
+
+// TagsEqual returns true if the two tag lists have the same scopes and values regardless of the order.
+func TagsEqual(tags1, tags2 []model.Tag) bool {
+	toSet := func(tags []model.Tag) sets.Set[string] {
+		s := sets.New[string]()
+		for _, tag := range tags {
+			s.Insert(fmt.Sprintf("%s=%s", ptr.Deref(tag.Scope, ""), ptr.Deref(tag.Tag, "")))
+		}
+		return s
+	}
+	return len(tags1) == len(tags2) && toSet(tags1).Equal(toSet(tags2))
+}
+
+// IsAccessModeChanged returns true if the access mode of the Subnet or SubnetSet is changed, the empty
+// access mode is defaulted to Private by nsx-operator.
+func IsAccessModeChanged(oldAccessMode, accessMode v1alpha1.AccessMode) bool {
+	if oldAccessMode == "" {
+		oldAccessMode = v1alpha1.AccessMode(v1alpha1.AccessModePrivate)
+	}
+	if accessMode == "" {
+		accessMode = v1alpha1.AccessMode(v1alpha1.AccessModePrivate)
+	}
+	return oldAccessMode != accessMode
+}
+
 func Capitalize(s string) string {
 	if s == "" {
 		return ""
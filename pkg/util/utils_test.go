@@ -6,6 +6,7 @@ package util
 import (
 	"context"
 	"fmt"
+	"math"
 	"net"
 	"reflect"
 	"strconv"
@@ -15,12 +16,15 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	"github.com/vmware-tanzu/nsx-operator/pkg/nsx/services/common"
 )
 
@@ -113,6 +117,51 @@ func TestUtil_IsNsInSystemNamespace(t *testing.T) {
 	client.Delete(ctx, sysNs)
 }
 
+func TestGetSharedVPCNamespace(t *testing.T) {
+	scheme := clientgoscheme.Scheme
+	v1alpha1.AddToScheme(scheme)
+	sharedVPC := &v1alpha1.SharedVPC{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+		Spec:       v1alpha1.SharedVPCSpec{OwnerNamespace: "ns-owner", ConsumerNamespaces: []string{"ns-consumer"}},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sharedVPC).Build()
+	ctx := context.TODO()
+
+	tests := []struct {
+		name     string
+		ns       *v1.Namespace
+		expected string
+	}{
+		{
+			name:     "Owner",
+			ns:       &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-owner"}},
+			expected: "ns-owner",
+		},
+		{
+			name:     "Consumer",
+			ns:       &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-consumer"}},
+			expected: "ns-owner",
+		},
+		{
+			name:     "Annotation",
+			ns:       &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-consumer", Annotations: map[string]string{common.AnnotationSharedVPCNamespace: "ns-legacy"}}},
+			expected: "ns-legacy",
+		},
+		{
+			name:     "NotShared",
+			ns:       &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-other"}},
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sharedNS, err := GetSharedVPCNamespace(client, ctx, tt.ns)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sharedNS)
+		})
+	}
+}
+
 func Test_CheckPodHasNamedPort(t *testing.T) {
 	pod := v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -235,6 +284,110 @@ func TestCalculateSubnetSize(t *testing.T) {
 	}
 }
 
+func TestGetIPFamily(t *testing.T) {
+	ipFamily, err := GetIPFamily("10.0.0.1/24")
+	assert.Nil(t, err)
+	assert.Equal(t, v1alpha1.IPFamilyIPv4, ipFamily)
+	ipFamily, err = GetIPFamily("2001:db8::1")
+	assert.Nil(t, err)
+	assert.Equal(t, v1alpha1.IPFamilyIPv6, ipFamily)
+	_, err = GetIPFamily("invalid")
+	assert.NotNil(t, err)
+}
+
+func TestCalculateIPFromCIDRs(t *testing.T) {
+	total, err := CalculateIPFromCIDRs([]string{"10.0.0.0/28", "10.0.1.0/24"})
+	assert.Nil(t, err)
+	assert.Equal(t, 272, total)
+	total, err = CalculateIPFromCIDRs([]string{"2001:db8::/64"})
+	assert.Nil(t, err)
+	assert.Equal(t, math.MaxInt32, total)
+	total, err = CalculateIPFromCIDRs([]string{"2001:db8::/120"})
+	assert.Nil(t, err)
+	assert.Equal(t, 256, total)
+	_, err = CalculateIPFromCIDRs([]string{"10.0.0.0/a"})
+	assert.NotNil(t, err)
+}
+
+func TestValidateSubnetIPFamilies(t *testing.T) {
+	ipv4 := v1alpha1.IPFamilyIPv4
+	ipv6 := v1alpha1.IPFamilyIPv6
+	tests := []struct {
+		name           string
+		ipFamilies     []v1alpha1.IPFamily
+		ipv4SubnetSize int
+		ipv6SubnetSize int
+		ipAddresses    []string
+		wantErr        string
+	}{
+		{name: "Default", ipv4SubnetSize: 32, ipAddresses: []string{"10.0.0.0/28"}},
+		{name: "DualStack", ipFamilies: []v1alpha1.IPFamily{ipv4, ipv6}, ipv4SubnetSize: 32, ipv6SubnetSize: 64, ipAddresses: []string{"10.0.0.0/28", "2001:db8::/64"}},
+		{name: "IPv6Only", ipFamilies: []v1alpha1.IPFamily{ipv6}, ipv6SubnetSize: 64},
+		{name: "DuplicatedFamily", ipFamilies: []v1alpha1.IPFamily{ipv6, ipv6}, wantErr: "duplicated IP family IPv6"},
+		{name: "IPv4SizeWithoutIPv4", ipFamilies: []v1alpha1.IPFamily{ipv6}, ipv4SubnetSize: 32, wantErr: "ipv4SubnetSize can only be set with IPv4 family"},
+		{name: "IPv6SizeWithoutIPv6", ipv6SubnetSize: 64, wantErr: "ipv6SubnetSize can only be set with IPv6 family"},
+		{name: "UnsupportedIPv6Size", ipFamilies: []v1alpha1.IPFamily{ipv6}, ipv6SubnetSize: 80, wantErr: "ipv6SubnetSize 80 is not supported"},
+		{name: "CIDRFamilyMismatch", ipAddresses: []string{"2001:db8::/64"}, wantErr: "doesn't match the IP families"},
+		{name: "MultipleCIDRsInFamily", ipAddresses: []string{"10.0.0.0/28", "10.0.1.0/28"}},
+		{name: "MissingCIDRInFamily", ipFamilies: []v1alpha1.IPFamily{ipv4, ipv6}, ipAddresses: []string{"10.0.0.0/28", "10.0.1.0/28"}, wantErr: "at least one IPv6 CIDR is required"},
+		{name: "InvalidIPv6Prefix", ipFamilies: []v1alpha1.IPFamily{ipv6}, ipAddresses: []string{"2001:db8::/80"}, wantErr: "must be /64"},
+		{name: "InvalidCIDR", ipAddresses: []string{"10.0.0.0"}, wantErr: "invalid CIDR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSubnetIPFamilies(tt.ipFamilies, tt.ipv4SubnetSize, tt.ipv6SubnetSize, tt.ipAddresses)
+			if tt.wantErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSubnetDHCPConfig(t *testing.T) {
+	server := v1alpha1.DHCPConfigMode(v1alpha1.DHCPConfigModeServer)
+	relay := v1alpha1.DHCPConfigMode(v1alpha1.DHCPConfigModeRelay)
+	tests := []struct {
+		name       string
+		dhcpConfig v1alpha1.SubnetDHCPConfig
+		wantErr    string
+	}{
+		{name: "Empty"},
+		{name: "Server", dhcpConfig: v1alpha1.SubnetDHCPConfig{Mode: server, DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{
+			DNSServers:     []string{"10.0.0.2"},
+			NTPServers:     []string{"10.0.0.3"},
+			StaticBindings: []v1alpha1.DHCPStaticBinding{{MACAddress: "00:50:56:00:00:01", IPAddress: "10.0.0.10"}, {MACAddress: "00:50:56:00:00:02", IPAddress: "10.0.0.11"}},
+			ExcludedRanges: []string{"10.0.0.20", "10.0.0.30-10.0.0.40"},
+		}}},
+		{name: "Relay", dhcpConfig: v1alpha1.SubnetDHCPConfig{Mode: relay, DHCPRelayConfig: &v1alpha1.DHCPRelayConfig{ServerAddresses: []string{"10.0.0.2", "2001:db8::2"}}}},
+		{name: "ServerConfigWithRelayMode", dhcpConfig: v1alpha1.SubnetDHCPConfig{Mode: relay, DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{}}, wantErr: "only applicable for DHCPServer mode"},
+		{name: "RelayConfigWithServerMode", dhcpConfig: v1alpha1.SubnetDHCPConfig{Mode: server, DHCPRelayConfig: &v1alpha1.DHCPRelayConfig{}}, wantErr: "only applicable for DHCPRelay mode"},
+		{name: "InvalidRelayServer", dhcpConfig: v1alpha1.SubnetDHCPConfig{Mode: relay, DHCPRelayConfig: &v1alpha1.DHCPRelayConfig{ServerAddresses: []string{"dhcp.example.com"}}}, wantErr: "invalid DHCP relay server address"},
+		{name: "InvalidDNSServer", dhcpConfig: v1alpha1.SubnetDHCPConfig{Mode: server, DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{DNSServers: []string{"2001:db8::2"}}}, wantErr: "invalid DNS or NTP server"},
+		{name: "InvalidMAC", dhcpConfig: v1alpha1.SubnetDHCPConfig{Mode: server, DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{
+			StaticBindings: []v1alpha1.DHCPStaticBinding{{MACAddress: "00:50:56", IPAddress: "10.0.0.10"}},
+		}}, wantErr: "invalid MAC address"},
+		{name: "DuplicatedMAC", dhcpConfig: v1alpha1.SubnetDHCPConfig{Mode: server, DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{
+			StaticBindings: []v1alpha1.DHCPStaticBinding{{MACAddress: "00:50:56:00:00:01", IPAddress: "10.0.0.10"}, {MACAddress: "00:50:56:00:00:01", IPAddress: "10.0.0.11"}},
+		}}, wantErr: "duplicated static binding for MAC address"},
+		{name: "DuplicatedIP", dhcpConfig: v1alpha1.SubnetDHCPConfig{Mode: server, DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{
+			StaticBindings: []v1alpha1.DHCPStaticBinding{{MACAddress: "00:50:56:00:00:01", IPAddress: "10.0.0.10"}, {MACAddress: "00:50:56:00:00:02", IPAddress: "10.0.0.10"}},
+		}}, wantErr: "duplicated static binding for IP address"},
+		{name: "InvalidExcludedRange", dhcpConfig: v1alpha1.SubnetDHCPConfig{Mode: server, DHCPServerAdditionalConfig: &v1alpha1.DHCPServerAdditionalConfig{ExcludedRanges: []string{"10.0.0.40-10.0.0.30"}}}, wantErr: "the start IP is greater than the end IP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSubnetDHCPConfig(tt.dhcpConfig)
+			if tt.wantErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestNormalizeId(t *testing.T) {
 	type args struct {
 		name string
@@ -596,20 +749,40 @@ func TestGetCIDRRangesWithExcept(t *testing.T) {
 	cidr2 := "172.0.0.0/16"
 	excepts2 := []string{"172.0.100.0/24", "172.0.102.0/24"}
 	want2 := []string{"172.0.0.0-172.0.99.255", "172.0.101.0-172.0.101.255", "172.0.103.0-172.0.255.255"}
+	cidr3 := "172.0.0.0/16"
+	excepts3 := []string{"172.0.0.0/24", "172.0.255.0/24"}
+	want3 := []string{"172.0.1.0-172.0.254.255"}
+	cidr4 := "2001:db8::/64"
+	excepts4 := []string{"2001:db8::/96", "2001:db8::1:0:0/96"}
+	want4 := []string{"2001:db8::2:0:0-2001:db8::ffff:ffff:ffff:ffff"}
+	cidr5 := "::/0"
+	excepts5 := []string{"::/1"}
+	want5 := []string{"8000::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"}
 	type args struct {
 		cidr    string
 		excepts []string
 	}
 	tests := []struct {
-		name string
-		args args
-		want []string
+		name   string
+		args   args
+		want   []string
+		expErr string
 	}{
-		{"1", args{cidr1, excepts1}, want1},
-		{"2", args{cidr2, excepts2}, want2},
+		{"1", args{cidr1, excepts1}, want1, ""},
+		{"2", args{cidr2, excepts2}, want2, ""},
+		{"except at the boundaries", args{cidr3, excepts3}, want3, ""},
+		{"IPv6", args{cidr4, excepts4}, want4, ""},
+		{"IPv6 all addresses", args{cidr5, excepts5}, want5, ""},
+		{"mixed IP families", args{cidr1, []string{"2001:db8::/64"}}, nil, "except 2001:db8::/64 is not in the same IP family as CIDR 172.17.0.0/16"},
 	}
 	for _, tt := range tests {
 		got, err := GetCIDRRangesWithExcept(tt.args.cidr, tt.args.excepts)
+		if tt.expErr != "" {
+			if err == nil || err.Error() != tt.expErr {
+				t.Errorf("%s failed: got error %v, want %s", tt.name, err, tt.expErr)
+			}
+			continue
+		}
 		if err != nil {
 			t.Errorf("%s failed: %s", tt.name, err)
 		}
@@ -628,13 +801,25 @@ func Test_calculateOffsetIP(t *testing.T) {
 		offset int
 	}
 	tests := []struct {
-		name string
-		args args
-		want net.IP
-	}{{"1", args{ip, offset1}, want1}}
+		name   string
+		args   args
+		want   net.IP
+		expErr bool
+	}{
+		{"1", args{ip, offset1}, want1, false},
+		{"IPv6", args{net.ParseIP("2001:db8::ffff"), 1}, net.ParseIP("2001:db8::1:0"), false},
+		{"IPv4 underflow", args{net.ParseIP("0.0.0.0"), -1}, nil, true},
+		{"IPv6 overflow", args{net.ParseIP("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"), 1}, nil, true},
+	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := calculateOffsetIP(tt.args.ip, tt.args.offset)
+			if tt.expErr {
+				if err == nil {
+					t.Errorf("%s failed: expected error", tt.name)
+				}
+				return
+			}
 			if err != nil {
 				t.Errorf("%s failed: %s", tt.name, err)
 			}
@@ -753,3 +938,92 @@ func TestNewSha1(t *testing.T) {
 		assert.True(t, allowedChars.Has(c))
 	}
 }
+
+func TestReservedIPRanges(t *testing.T) {
+	startIP, endIP, err := ParseIPv4Range("10.0.0.10-10.0.0.12")
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.10", startIP.String())
+	assert.Equal(t, "10.0.0.12", endIP.String())
+	_, _, err = ParseIPv4Range("10.0.0.12-10.0.0.10")
+	assert.ErrorContains(t, err, "the start IP is greater than the end IP")
+	_, _, err = ParseIPv4Range("2001:db8::1")
+	assert.ErrorContains(t, err, "must be IPv4 addresses")
+
+	assert.Nil(t, ValidateReservedIPRanges([]string{"10.0.0.5", "10.0.0.10-10.0.0.12"}, nil))
+	assert.ErrorContains(t, ValidateReservedIPRanges([]string{"10.0.0.0-10.0.1.0"}, nil), "more than 256 IPs")
+	assert.Nil(t, ValidateReservedIPRanges([]string{"10.0.0.5", "10.0.0.10-10.0.0.12"}, []string{"10.0.0.0/28", "2001:db8::/64"}))
+	assert.ErrorContains(t, ValidateReservedIPRanges([]string{"10.0.0.10-10.0.0.16"}, []string{"10.0.0.0/28"}), "IP range 10.0.0.10-10.0.0.16 is not in the Subnet CIDRs 10.0.0.0/28")
+
+	ips, err := ExpandIPv4Ranges([]string{"10.0.0.255-10.0.1.1", "10.0.1.0"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10.0.0.255", "10.0.1.0", "10.0.1.1"}, ips)
+
+	assert.True(t, IPInRanges(net.ParseIP("10.0.0.11"), []string{"10.0.0.5", "10.0.0.10-10.0.0.12"}))
+	assert.False(t, IPInRanges(net.ParseIP("10.0.0.13"), []string{"10.0.0.5", "10.0.0.10-10.0.0.12"}))
+	assert.False(t, IPInRanges(net.ParseIP("10.0.0.13"), []string{"invalid"}))
+}
+
+func TestIsAccessModeChanged(t *testing.T) {
+	assert.False(t, IsAccessModeChanged("", v1alpha1.AccessMode(v1alpha1.AccessModePrivate)))
+	assert.False(t, IsAccessModeChanged(v1alpha1.AccessMode(v1alpha1.AccessModePublic), v1alpha1.AccessMode(v1alpha1.AccessModePublic)))
+	assert.True(t, IsAccessModeChanged("", v1alpha1.AccessMode(v1alpha1.AccessModeProject)))
+	assert.True(t, IsAccessModeChanged(v1alpha1.AccessMode(v1alpha1.AccessModePublic), ""))
+}
+
+func TestBuildLabelTags(t *testing.T) {
+	assert.Nil(t, BuildLabelTags(nil, map[string]string{"team": "net"}))
+
+	longKey := "example.com/" + strings.Repeat("k", 130)
+	nsLabels := map[string]string{"team": "ns-team", "env": "prod", "nsx-op/cluster": "fake", "ignored": "x"}
+	objLabels := map[string]string{"team": "obj-team", longKey: "v"}
+	tags := BuildLabelTags([]string{"team", "env", "nsx-op/cluster", longKey, "owner"}, objLabels, nsLabels)
+	require.Equal(t, 3, len(tags))
+	assert.Equal(t, "env", *tags[0].Scope)
+	assert.Equal(t, "prod", *tags[0].Tag)
+	assert.Equal(t, NormalizeLabelKey(longKey, truncateLabelHash), *tags[1].Scope)
+	assert.LessOrEqual(t, len(*tags[1].Scope), common.MaxTagScopeLength)
+	assert.Equal(t, "team", *tags[2].Scope)
+	assert.Equal(t, "obj-team", *tags[2].Tag)
+}
+
+func TestAppendLabelTags(t *testing.T) {
+	labelTags := []model.Tag{
+		{Scope: String("env"), Tag: String("prod")},
+		{Scope: String("team"), Tag: String("net")},
+	}
+	tags := AppendLabelTags([]model.Tag{{Scope: String("team"), Tag: String("ns-team")}}, labelTags)
+	assert.Equal(t, []model.Tag{
+		{Scope: String("team"), Tag: String("ns-team")},
+		{Scope: String("env"), Tag: String("prod")},
+	}, tags)
+
+	var fullTags []model.Tag
+	for i := 0; i < common.MaxTagsCount-1; i++ {
+		fullTags = append(fullTags, model.Tag{Scope: String(fmt.Sprintf("scope-%d", i)), Tag: String("value")})
+	}
+	tags = AppendLabelTags(fullTags, labelTags)
+	assert.Equal(t, common.MaxTagsCount, len(tags))
+	assert.Equal(t, "env", *tags[common.MaxTagsCount-1].Scope)
+}
+
+func TestReplaceLabelTags(t *testing.T) {
+	tags := []model.Tag{
+		{Scope: String(common.TagScopeCluster), Tag: String("cluster1")},
+		{Scope: String("owner"), Tag: String("alice")},
+		{Scope: String("team"), Tag: String("dev")},
+	}
+	newTags := ReplaceLabelTags(tags, []string{"team", "owner"}, []model.Tag{{Scope: String("team"), Tag: String("net")}})
+	assert.Equal(t, []model.Tag{
+		{Scope: String(common.TagScopeCluster), Tag: String("cluster1")},
+		{Scope: String("team"), Tag: String("net")},
+	}, newTags)
+	assert.False(t, TagsEqual(tags, newTags))
+	assert.True(t, TagsEqual(newTags, []model.Tag{newTags[1], newTags[0]}))
+
+	// The tags in the reserved scopes are kept even if the label keys are configured.
+	newTags = ReplaceLabelTags(tags, []string{common.TagScopeCluster, "team"}, nil)
+	assert.Equal(t, []model.Tag{
+		{Scope: String(common.TagScopeCluster), Tag: String("cluster1")},
+		{Scope: String("owner"), Tag: String("alice")},
+	}, newTags)
+}